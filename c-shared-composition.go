@@ -0,0 +1,227 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/* Composition state for IMEs. See govarnam.Composition */
+
+/*
+#include "c-shared.h"
+#include "c-shared-varray.h"
+#include "stdlib.h"
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+type compositionHandle struct {
+	varnamHandleID C.int
+	composition    *govarnam.Composition
+}
+
+var compositionHandles = map[C.int]*compositionHandle{}
+var compositionHandlesMutex = sync.Mutex{}
+var lastCompositionID C.int
+
+func getCompositionHandle(id C.int) *compositionHandle {
+	compositionHandlesMutex.Lock()
+	defer compositionHandlesMutex.Unlock()
+	return compositionHandles[id]
+}
+
+//export varnam_composition_new
+func varnam_composition_new(varnamHandleID C.int, pageSize C.int, compositionID *C.int) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	composition := handle.varnam.NewComposition()
+	composition.PageSize = int(pageSize)
+
+	compositionHandlesMutex.Lock()
+	lastCompositionID++
+	compositionHandles[lastCompositionID] = &compositionHandle{varnamHandleID, composition}
+	*compositionID = lastCompositionID
+	compositionHandlesMutex.Unlock()
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_composition_free
+func varnam_composition_free(compositionID C.int) C.int {
+	compositionHandlesMutex.Lock()
+	defer compositionHandlesMutex.Unlock()
+
+	if _, ok := compositionHandles[compositionID]; !ok {
+		return C.VARNAM_MISUSE
+	}
+	delete(compositionHandles, compositionID)
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_composition_append
+func varnam_composition_append(compositionID C.int, chars *C.char) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	ch.composition.Append(backgroundContext, C.GoString(chars))
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_composition_backspace
+func varnam_composition_backspace(compositionID C.int) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	ch.composition.Backspace(backgroundContext)
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_composition_reset
+func varnam_composition_reset(compositionID C.int) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	ch.composition.Reset()
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_composition_is_empty
+func varnam_composition_is_empty(compositionID C.int) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil || ch.composition.IsEmpty() {
+		return C.int(1)
+	}
+	return C.int(0)
+}
+
+//export varnam_composition_get_input
+func varnam_composition_get_input(compositionID C.int, output **C.char) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	*output = C.CString(ch.composition.Input())
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_composition_get_preedit
+func varnam_composition_get_preedit(compositionID C.int, output **C.char) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	*output = C.CString(ch.composition.Preedit())
+	return C.VARNAM_SUCCESS
+}
+
+// Candidates of the current page & index of the highlighted one in it
+//
+//export varnam_composition_get_page
+func varnam_composition_get_page(compositionID C.int, resultPointer **C.varray, cursor *C.int) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	page, pageCursor := ch.composition.Page()
+
+	ptr := C.varray_init()
+	for _, sug := range page {
+		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
+		C.varray_push(ptr, cSug)
+	}
+	*resultPointer = ptr
+	*cursor = C.int(pageCursor)
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_composition_move_cursor
+func varnam_composition_move_cursor(compositionID C.int, by C.int) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	ch.composition.MoveCursor(int(by))
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_composition_next_page
+func varnam_composition_next_page(compositionID C.int) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	ch.composition.NextPage()
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_composition_previous_page
+func varnam_composition_previous_page(compositionID C.int) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	ch.composition.PreviousPage()
+	return C.VARNAM_SUCCESS
+}
+
+// index is of all candidates, not the page
+//
+//export varnam_composition_select
+func varnam_composition_select(compositionID C.int, index C.int) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	ch.composition.Select(int(index))
+	return C.VARNAM_SUCCESS
+}
+
+// output is set even if learning the committed word fails
+//
+//export varnam_composition_commit
+func varnam_composition_commit(compositionID C.int, output **C.char) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	text, err := ch.composition.Commit()
+	*output = C.CString(text)
+
+	handle := getVarnamHandle(ch.varnamHandleID)
+	handle.err = err
+
+	return checkError(err)
+}
+
+//export varnam_composition_commit_input
+func varnam_composition_commit_input(compositionID C.int, output **C.char) C.int {
+	ch := getCompositionHandle(compositionID)
+	if ch == nil {
+		return C.VARNAM_MISUSE
+	}
+
+	*output = C.CString(ch.composition.CommitInput())
+	return C.VARNAM_SUCCESS
+}
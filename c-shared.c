@@ -3,12 +3,13 @@
 #include "stdlib.h"
 #include "c-shared-varray.h"
 
-Suggestion* makeSuggestion(char* word, int weight, int learned_on)
+Suggestion* makeSuggestion(char* word, int weight, int learned_on, float score)
 {
   Suggestion *sug = (Suggestion*) malloc (sizeof(Suggestion));
   sug->Word = word;
   sug->Weight = weight;
   sug->LearnedOn = learned_on;
+  sug->Score = score;
   return sug;
 }
 
@@ -25,6 +26,11 @@ TransliterationResult* makeResult(varray* exact_words, varray* exact_matches, va
   return result;
 }
 
+void destroyString(char* str)
+{
+  free(str);
+}
+
 void destroySuggestions(void* pointer)
 {
   if (pointer != NULL) {
@@ -44,11 +50,13 @@ void destroySuggestionsArray(varray* pointer)
 
 void destroyTransliterationResult(TransliterationResult* result)
 {
+  destroySuggestionsArray(result->ExactWords);
   destroySuggestionsArray(result->ExactMatches);
   destroySuggestionsArray(result->DictionarySuggestions);
   destroySuggestionsArray(result->PatternDictionarySuggestions);
   destroySuggestionsArray(result->TokenizerSuggestions);
   destroySuggestionsArray(result->GreedyTokenized);
+  result->ExactWords = NULL;
   result->ExactMatches = NULL;
   result->DictionarySuggestions = NULL;
   result->PatternDictionarySuggestions = NULL;
@@ -58,7 +66,7 @@ void destroyTransliterationResult(TransliterationResult* result)
   result = NULL;
 }
 
-SchemeDetails* makeSchemeDetails(char* Identifier, char* LangCode, char* DisplayName, char* Author, char* CompiledDate, bool IsStable)
+SchemeDetails* makeSchemeDetails(char* Identifier, char* LangCode, char* DisplayName, char* Author, char* CompiledDate, bool IsStable, char* Path, char* NativeDisplayName, char* Version, char* MinLibraryVersion, char* Base)
 {
   SchemeDetails* sd = (SchemeDetails*) malloc (sizeof(SchemeDetails));
   sd->Identifier = Identifier;
@@ -67,6 +75,11 @@ SchemeDetails* makeSchemeDetails(char* Identifier, char* LangCode, char* Display
   sd->Author = Author;
   sd->CompiledDate = CompiledDate;
   sd->IsStable = IsStable;
+  sd->Path = Path;
+  sd->NativeDisplayName = NativeDisplayName;
+  sd->Version = Version;
+  sd->MinLibraryVersion = MinLibraryVersion;
+  sd->Base = Base;
 
   return sd;
 }
@@ -74,11 +87,19 @@ SchemeDetails* makeSchemeDetails(char* Identifier, char* LangCode, char* Display
 void destroySchemeDetails(void* pointer)
 {
   if (pointer != NULL) {
-    Suggestion* sug = (Suggestion*) pointer;
-    free(sug->Word);
-    sug->Word = NULL;
-    free(sug);
-    sug = NULL;
+    SchemeDetails* sd = (SchemeDetails*) pointer;
+    free(sd->Identifier);
+    free(sd->LangCode);
+    free(sd->DisplayName);
+    free(sd->Author);
+    free(sd->CompiledDate);
+    free(sd->Path);
+    free(sd->NativeDisplayName);
+    free(sd->Version);
+    free(sd->MinLibraryVersion);
+    free(sd->Base);
+    free(sd);
+    sd = NULL;
   }
 }
 
@@ -136,3 +157,31 @@ void destroySymbolArray(void* cSymbols)
 {
   varray_free(cSymbols, &destroySymbol);
 }
+
+SchemeIssue* makeSchemeIssue(int Severity, int Kind, Symbol* Symbol, char* Message)
+{
+  SchemeIssue *issue = (SchemeIssue*) malloc (sizeof(SchemeIssue));
+  issue->Severity = Severity;
+  issue->Kind = Kind;
+  issue->Symbol = Symbol;
+  issue->Message = Message;
+  return issue;
+}
+
+void destroySchemeIssue(void* pointer)
+{
+  if (pointer != NULL) {
+    SchemeIssue* issue = (SchemeIssue*) pointer;
+    destroySymbol(issue->Symbol);
+    free(issue->Message);
+    issue->Symbol = NULL;
+    issue->Message = NULL;
+    free(issue);
+    issue = NULL;
+  }
+}
+
+void destroySchemeIssueArray(void* cSchemeIssues)
+{
+  varray_free(cSchemeIssues, &destroySchemeIssue);
+}
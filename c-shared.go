@@ -17,7 +17,9 @@ import "C"
 import (
 	"context"
 	"log"
+	"strings"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/varnamproject/govarnam/govarnam"
@@ -67,37 +69,37 @@ func makeCTransliterationResult(ctx context.Context, goResult govarnam.Translite
 
 		cExactWords := C.varray_init()
 		for _, sug := range goResult.ExactWords {
-			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 			C.varray_push(cExactWords, cSug)
 		}
 
 		cExactMatches := C.varray_init()
 		for _, sug := range goResult.ExactMatches {
-			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 			C.varray_push(cExactMatches, cSug)
 		}
 
 		cDictionarySuggestions := C.varray_init()
 		for _, sug := range goResult.DictionarySuggestions {
-			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 			C.varray_push(cDictionarySuggestions, cSug)
 		}
 
 		cPatternDictionarySuggestions := C.varray_init()
 		for _, sug := range goResult.PatternDictionarySuggestions {
-			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 			C.varray_push(cPatternDictionarySuggestions, cSug)
 		}
 
 		cTokenizerSuggestions := C.varray_init()
 		for _, sug := range goResult.TokenizerSuggestions {
-			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 			C.varray_push(cTokenizerSuggestions, cSug)
 		}
 
 		cGreedyTokenized := C.varray_init()
 		for _, sug := range goResult.GreedyTokenized {
-			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 			C.varray_push(cGreedyTokenized, cSug)
 		}
 
@@ -117,11 +119,21 @@ func varnam_get_build() *C.char {
 	return C.CString(govarnam.BuildString)
 }
 
+//export varnam_get_abi_version
+func varnam_get_abi_version() C.int {
+	return C.VARNAM_ABI_VERSION
+}
+
 //export varnam_set_vst_lookup_dir
 func varnam_set_vst_lookup_dir(path *C.char) {
 	govarnam.SetVSTLookupDir(C.GoString(path))
 }
 
+//export varnam_add_vst_lookup_dir
+func varnam_add_vst_lookup_dir(path *C.char) {
+	govarnam.AddVSTLookupDir(C.GoString(path))
+}
+
 type varnamHandle struct {
 	varnam *govarnam.Varnam
 	err    error
@@ -204,7 +216,7 @@ func varnam_transliterate(varnamHandleID C.int, id C.int, word *C.char, resultPo
 
 		cResult := C.varray_init()
 		for _, sug := range result {
-			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+			cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 			C.varray_push(cResult, cSug)
 		}
 		*resultPointer = cResult
@@ -238,7 +250,23 @@ func varnam_transliterate_greedy_tokenized(varnamHandleID C.int, word *C.char, r
 
 	ptr := C.varray_init()
 	for _, sug := range result {
-		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
+		C.varray_push(ptr, cSug)
+	}
+	*resultPointer = ptr
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_transliterate_quick
+func varnam_transliterate_quick(varnamHandleID C.int, word *C.char, resultPointer **C.varray) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	result := handle.varnam.TransliterateQuick(context.Background(), C.GoString(word))
+
+	ptr := C.varray_init()
+	for _, sug := range result {
+		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 		C.varray_push(ptr, cSug)
 	}
 	*resultPointer = ptr
@@ -258,7 +286,7 @@ func varnam_reverse_transliterate(varnamHandleID C.int, word *C.char, resultPoin
 
 	cResult := C.varray_init()
 	for _, sug := range sugs {
-		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 		C.varray_push(cResult, cSug)
 	}
 	*resultPointer = cResult
@@ -266,6 +294,115 @@ func varnam_reverse_transliterate(varnamHandleID C.int, word *C.char, resultPoin
 	return C.VARNAM_SUCCESS
 }
 
+//export varnam_romanize_iso15919
+func varnam_romanize_iso15919(varnamHandleID C.int, text *C.char, output **C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	result, err := handle.varnam.RomanizeISO15919(C.GoString(text))
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+	*output = C.CString(result)
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_dump_vst
+func varnam_dump_vst(varnamHandleID C.int, output **C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	var buf strings.Builder
+	err := handle.varnam.DumpVST(&buf)
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+	*output = C.CString(buf.String())
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_validate_scheme
+func varnam_validate_scheme(varnamHandleID C.int, resultPointer **C.varray) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	issues, err := handle.varnam.ValidateScheme()
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+
+	cResult := C.varray_init()
+	for _, issue := range issues {
+		cIssue := C.makeSchemeIssue(C.int(issue.Severity), C.int(issue.Kind), goSymbolToCSymbol(issue.Symbol), C.CString(issue.Message))
+		C.varray_push(cResult, unsafe.Pointer(cIssue))
+	}
+	*resultPointer = cResult
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_dump_tokens
+func varnam_dump_tokens(varnamHandleID C.int, input *C.char, output **C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	var buf strings.Builder
+	err := handle.varnam.DumpTokens(context.Background(), C.GoString(input), &buf)
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+	*output = C.CString(buf.String())
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_run_gold_tests
+func varnam_run_gold_tests(varnamHandleID C.int, filePath *C.char, passed *C.int, failed *C.int, output **C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	report, err := handle.varnam.RunGoldTestsFromFile(context.Background(), C.GoString(filePath))
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+
+	var buf strings.Builder
+	report.Write(&buf)
+
+	*passed = C.int(report.Passed)
+	*failed = C.int(report.Failed)
+	*output = C.CString(buf.String())
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_transliterate_sentence
+func varnam_transliterate_sentence(varnamHandleID C.int, sentence *C.char, output **C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	result, err := handle.varnam.TransliterateSentence(context.Background(), C.GoString(sentence))
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+	*output = C.CString(result)
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_transliterate_between
+func varnam_transliterate_between(fromSchemeID *C.char, toSchemeID *C.char, text *C.char, output **C.char) C.int {
+	result, err := govarnam.TransliterateBetween(C.GoString(fromSchemeID), C.GoString(toSchemeID), C.GoString(text))
+	if err != nil {
+		return C.VARNAM_ERROR
+	}
+	*output = C.CString(result)
+
+	return C.VARNAM_SUCCESS
+}
+
 //export varnam_debug
 func varnam_debug(varnamHandleID C.int, val C.int) {
 	getVarnamHandle(varnamHandleID).varnam.Debug = cintToBool(val)
@@ -312,6 +449,13 @@ func varnam_learn(varnamHandleID C.int, word *C.char, weight C.int) C.int {
 	return checkError(handle.err)
 }
 
+//export varnam_learn_at
+func varnam_learn_at(varnamHandleID C.int, word *C.char, weight C.int, learnedOn C.longlong) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.LearnAt(C.GoString(word), int(weight), time.Unix(int64(learnedOn), 0))
+	return checkError(handle.err)
+}
+
 //export varnam_train
 func varnam_train(varnamHandleID C.int, pattern *C.char, word *C.char) C.int {
 	handle := getVarnamHandle(varnamHandleID)
@@ -319,6 +463,55 @@ func varnam_train(varnamHandleID C.int, pattern *C.char, word *C.char) C.int {
 	return checkError(handle.err)
 }
 
+//export varnam_learn_in_domain
+func varnam_learn_in_domain(varnamHandleID C.int, word *C.char, weight C.int, domain *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.LearnInDomain(C.GoString(word), int(weight), C.GoString(domain))
+	return checkError(handle.err)
+}
+
+//export varnam_train_in_domain
+func varnam_train_in_domain(varnamHandleID C.int, pattern *C.char, word *C.char, domain *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.TrainInDomain(C.GoString(pattern), C.GoString(word), C.GoString(domain))
+	return checkError(handle.err)
+}
+
+//export varnam_remove_from_domain
+func varnam_remove_from_domain(varnamHandleID C.int, word *C.char, domain *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.RemoveFromDomain(C.GoString(word), C.GoString(domain))
+	return checkError(handle.err)
+}
+
+//export varnam_report_selected
+func varnam_report_selected(varnamHandleID C.int, pattern *C.char, word *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.ReportSelected(C.GoString(pattern), C.GoString(word))
+	return checkError(handle.err)
+}
+
+//export varnam_touch
+func varnam_touch(varnamHandleID C.int, word *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.Touch(C.GoString(word))
+	return checkError(handle.err)
+}
+
+//export varnam_can_learn
+func varnam_can_learn(varnamHandleID C.int, word *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.CanLearn(C.GoString(word))
+	return checkError(handle.err)
+}
+
+//export varnam_validate_train
+func varnam_validate_train(varnamHandleID C.int, pattern *C.char, word *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.ValidateTrain(C.GoString(pattern), C.GoString(word))
+	return checkError(handle.err)
+}
+
 //export varnam_unlearn
 func varnam_unlearn(varnamHandleID C.int, word *C.char) C.int {
 	handle := getVarnamHandle(varnamHandleID)
@@ -326,6 +519,76 @@ func varnam_unlearn(varnamHandleID C.int, word *C.char) C.int {
 	return checkError(handle.err)
 }
 
+//export varnam_blacklist
+func varnam_blacklist(varnamHandleID C.int, word *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.Blacklist(C.GoString(word))
+	return checkError(handle.err)
+}
+
+//export varnam_remove_from_blacklist
+func varnam_remove_from_blacklist(varnamHandleID C.int, word *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.RemoveFromBlacklist(C.GoString(word))
+	return checkError(handle.err)
+}
+
+//export varnam_pin
+func varnam_pin(varnamHandleID C.int, word *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.Pin(C.GoString(word))
+	return checkError(handle.err)
+}
+
+//export varnam_unpin
+func varnam_unpin(varnamHandleID C.int, word *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.Unpin(C.GoString(word))
+	return checkError(handle.err)
+}
+
+//export varnam_override_symbol
+func varnam_override_symbol(varnamHandleID C.int, symbol C.struct_Symbol_t) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.OverrideSymbol(cSymbolToGoSymbol(symbol))
+	return checkError(handle.err)
+}
+
+//export varnam_remove_symbol_override
+func varnam_remove_symbol_override(varnamHandleID C.int, pattern *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.RemoveSymbolOverride(C.GoString(pattern))
+	return checkError(handle.err)
+}
+
+//export varnam_set_symbol_weight
+func varnam_set_symbol_weight(varnamHandleID C.int, pattern *C.char, value1 *C.char, weight C.int) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.SetSymbolWeight(C.GoString(pattern), C.GoString(value1), int(weight))
+	return checkError(handle.err)
+}
+
+//export varnam_reset_symbol_weight
+func varnam_reset_symbol_weight(varnamHandleID C.int, pattern *C.char, value1 *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.ResetSymbolWeight(C.GoString(pattern), C.GoString(value1))
+	return checkError(handle.err)
+}
+
+//export varnam_reload_scheme
+func varnam_reload_scheme(varnamHandleID C.int) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.ReloadScheme()
+	return checkError(handle.err)
+}
+
+//export varnam_reload_scheme_if_changed
+func varnam_reload_scheme_if_changed(varnamHandleID C.int) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	_, handle.err = handle.varnam.ReloadSchemeIfChanged()
+	return checkError(handle.err)
+}
+
 //export varnam_learn_from_file
 func varnam_learn_from_file(varnamHandleID C.int, filePath *C.char, resultPointer **C.struct_LearnStatus_t) C.int {
 	handle := getVarnamHandle(varnamHandleID)
@@ -398,6 +661,22 @@ func varnam_export(varnamHandleID C.int, filePath *C.char, wordsPerFile C.int) C
 	return checkError(handle.err)
 }
 
+//export varnam_export_to_hunspell
+func varnam_export_to_hunspell(varnamHandleID C.int, basePath *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.ExportToHunspell(C.GoString(basePath))
+
+	return checkError(handle.err)
+}
+
+//export varnam_write_mapped_vst
+func varnam_write_mapped_vst(varnamHandleID C.int, filePath *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	handle.err = handle.varnam.WriteMappedVST(C.GoString(filePath))
+
+	return checkError(handle.err)
+}
+
 //export varnam_import
 func varnam_import(varnamHandleID C.int, filePath *C.char) C.int {
 	handle := getVarnamHandle(varnamHandleID)
@@ -406,6 +685,57 @@ func varnam_import(varnamHandleID C.int, filePath *C.char) C.int {
 	return checkError(handle.err)
 }
 
+//export varnam_import_from_libvarnam
+func varnam_import_from_libvarnam(varnamHandleID C.int, filePath *C.char, resultPointer **C.struct_LearnStatus_t) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	learnStatus, err := handle.varnam.ImportFromLibvarnam(C.GoString(filePath))
+
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+
+	result := C.makeLearnStatus(C.int(learnStatus.TotalWords), C.int(learnStatus.FailedWords))
+	*resultPointer = &result
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_import_from_hunspell
+func varnam_import_from_hunspell(varnamHandleID C.int, dicPath *C.char, affPath *C.char, resultPointer **C.struct_LearnStatus_t) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	learnStatus, err := handle.varnam.ImportFromHunspell(C.GoString(dicPath), C.GoString(affPath))
+
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+
+	result := C.makeLearnStatus(C.int(learnStatus.TotalWords), C.int(learnStatus.FailedWords))
+	*resultPointer = &result
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_import_from_varnam_web
+func varnam_import_from_varnam_web(varnamHandleID C.int, filePath *C.char, resultPointer **C.struct_LearnStatus_t) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	learnStatus, err := handle.varnam.ImportFromVarnamWeb(C.GoString(filePath))
+
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+
+	result := C.makeLearnStatus(C.int(learnStatus.TotalWords), C.int(learnStatus.FailedWords))
+	*resultPointer = &result
+
+	return C.VARNAM_SUCCESS
+}
+
 //export varnam_get_vst_path
 func varnam_get_vst_path(varnamHandleID C.int) *C.char {
 	handle := getVarnamHandle(varnamHandleID)
@@ -478,7 +808,31 @@ func varnam_get_recently_learned_words(varnamHandleID C.int, id C.int, offset C.
 
 	ptr := C.varray_init()
 	for _, sug := range result {
-		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
+		C.varray_push(ptr, cSug)
+	}
+	*resultPointer = ptr
+
+	return C.VARNAM_SUCCESS
+}
+
+//export varnam_get_random_learned_words
+func varnam_get_random_learned_words(varnamHandleID C.int, id C.int, limit C.int, preferLessUsed C.int, resultPointer **C.varray) C.int {
+	ctx, cancel := makeContext(id)
+	defer cancel()
+
+	handle := getVarnamHandle(varnamHandleID)
+
+	result, err := handle.varnam.GetRandomLearnedWords(ctx, int(limit), preferLessUsed == 1)
+
+	if err != nil {
+		handle.err = err
+		return C.VARNAM_ERROR
+	}
+
+	ptr := C.varray_init()
+	for _, sug := range result {
+		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 		C.varray_push(ptr, cSug)
 	}
 	*resultPointer = ptr
@@ -497,7 +851,7 @@ func varnam_get_suggestions(varnamHandleID C.int, id C.int, word *C.char, result
 
 	ptr := C.varray_init()
 	for _, sug := range result {
-		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn)))
+		cSug := unsafe.Pointer(C.makeSuggestion(C.CString(sug.Word), C.int(sug.Weight), C.int(sug.LearnedOn), C.float(sug.Score)))
 		C.varray_push(ptr, cSug)
 	}
 	*resultPointer = ptr
@@ -513,6 +867,12 @@ func makeGoSchemeDetails(sd *C.struct_SchemeDetails_t) govarnam.SchemeDetails {
 		Author:       C.GoString(sd.Author),
 		CompiledDate: C.GoString(sd.CompiledDate),
 		IsStable:     cintToBool(sd.IsStable),
+		Path:         C.GoString(sd.Path),
+
+		NativeDisplayName: C.GoString(sd.NativeDisplayName),
+		Version:           C.GoString(sd.Version),
+		MinLibraryVersion: C.GoString(sd.MinLibraryVersion),
+		Base:              C.GoString(sd.Base),
 	}
 }
 
@@ -532,6 +892,11 @@ func makeCSchemeDetails(sd govarnam.SchemeDetails) *C.struct_SchemeDetails_t {
 		C.CString(sd.Author),
 		C.CString(sd.CompiledDate),
 		cIsStable,
+		C.CString(sd.Path),
+		C.CString(sd.NativeDisplayName),
+		C.CString(sd.Version),
+		C.CString(sd.MinLibraryVersion),
+		C.CString(sd.Base),
 	)
 }
 
@@ -541,6 +906,15 @@ func varnam_get_scheme_details(varnamHandleID C.int) *C.struct_SchemeDetails_t {
 	return makeCSchemeDetails(handle.varnam.SchemeDetails)
 }
 
+//export varnam_is_rtl
+func varnam_is_rtl(varnamHandleID C.int) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+	if handle.varnam.IsRTL() {
+		return C.int(1)
+	}
+	return C.int(0)
+}
+
 //export varnam_get_vst_dir
 func varnam_get_vst_dir() *C.char {
 	var dir string
@@ -626,6 +1000,22 @@ func vm_delete_token(varnamHandleID C.int, searchCriteria C.struct_Symbol_t) C.i
 	return checkError(handle.err)
 }
 
+//export vm_create_stem_rule
+func vm_create_stem_rule(varnamHandleID C.int, oldEnding *C.char, newEnding *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	handle.err = handle.varnam.VMCreateStemRule(C.GoString(oldEnding), C.GoString(newEnding))
+	return checkError(handle.err)
+}
+
+//export vm_create_stem_exception
+func vm_create_stem_exception(varnamHandleID C.int, oldEnding *C.char, exception *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	handle.err = handle.varnam.VMCreateStemException(C.GoString(oldEnding), C.GoString(exception))
+	return checkError(handle.err)
+}
+
 //export vm_flush_buffer
 func vm_flush_buffer(varnamHandleID C.int) C.int {
 	handle := getVarnamHandle(varnamHandleID)
@@ -634,6 +1024,25 @@ func vm_flush_buffer(varnamHandleID C.int) C.int {
 	return checkError(handle.err)
 }
 
+// All empty to use the scheme's joiner patterns again
+//export varnam_set_control_characters
+func varnam_set_control_characters(varnamHandleID C.int, joiner *C.char, nonJoiner *C.char, escape *C.char) C.int {
+	handle := getVarnamHandle(varnamHandleID)
+
+	controls := &govarnam.ControlCharacters{
+		Joiner:    C.GoString(joiner),
+		NonJoiner: C.GoString(nonJoiner),
+		Escape:    C.GoString(escape),
+	}
+
+	if *controls == (govarnam.ControlCharacters{}) {
+		controls = nil
+	}
+	handle.varnam.ControlCharacters = controls
+
+	return C.VARNAM_SUCCESS
+}
+
 //export varnam_config
 func varnam_config(varnamHandleID C.int, key C.int, value C.int) C.int {
 	handle := getVarnamHandle(varnamHandleID)
@@ -660,6 +1069,55 @@ func varnam_config(varnamHandleID C.int, key C.int, value C.int) C.int {
 	case C.VARNAM_CONFIG_SET_DICTIONARY_MATCH_EXACT:
 		handle.varnam.DictionaryMatchExact = cintToBool(value)
 		break
+	case C.VARNAM_CONFIG_SET_QUERY_TIMEOUT:
+		handle.varnam.QueryTimeout = time.Duration(value) * time.Millisecond
+		break
+	case C.VARNAM_CONFIG_KEEP_DUPLICATE_SUGGESTIONS:
+		handle.varnam.KeepDuplicateSuggestions = cintToBool(value)
+		break
+	case C.VARNAM_CONFIG_SET_SCRIPT_BOUNDARY:
+		handle.varnam.ScriptBoundary = int(value)
+		break
+	case C.VARNAM_CONFIG_SET_JOINER_POLICY:
+		handle.varnam.JoinerPolicy = int(value)
+		break
+	case C.VARNAM_CONFIG_SET_CASE_INSENSITIVE:
+		handle.varnam.CaseInsensitive = cintToBool(value)
+		break
+	case C.VARNAM_CONFIG_SET_FUZZY_MATCHING:
+		handle.varnam.FuzzyMatching = cintToBool(value)
+		break
+	case C.VARNAM_CONFIG_SET_TOKENIZER_SUGGESTIONS_THRESHOLD:
+		handle.varnam.TokenizerSuggestionsThreshold = int(value)
+		break
+	case C.VARNAM_CONFIG_SET_TOKENIZER_SUGGESTIONS_ON_THRESHOLD:
+		handle.varnam.TokenizerSuggestionsOnThreshold = int(value)
+		break
+	case C.VARNAM_CONFIG_SET_DICTIONARY_MIN_INPUT_LENGTH:
+		handle.varnam.DictionaryMinInputLength = int(value)
+		break
+	case C.VARNAM_CONFIG_SET_RANKER:
+		if int(value) == govarnam.VARNAM_RANKER_RECENCY_BLENDED {
+			handle.varnam.Ranker = govarnam.NewRecencyBlendedRanker()
+		} else {
+			handle.varnam.Ranker = govarnam.ConfidenceFirstRanker{}
+		}
+		break
+	case C.VARNAM_CONFIG_SET_SUGGEST_CORRECTIONS:
+		handle.varnam.SuggestCorrections = cintToBool(value)
+		break
+	case C.VARNAM_CONFIG_SET_QUICK_BUDGET:
+		handle.varnam.QuickBudget = time.Duration(value) * time.Millisecond
+		break
+	case C.VARNAM_CONFIG_SET_TOKENIZER_WEIGHT_THRESHOLD:
+		handle.varnam.TokenizerWeightThreshold = float64(value) / 100
+		break
+	case C.VARNAM_CONFIG_SET_NO_GRANTHA:
+		handle.varnam.NoGrantha = cintToBool(value)
+		break
+	case C.VARNAM_CONFIG_SET_INPUT_METHOD:
+		handle.varnam.InputMethod = int(value)
+		break
 	}
 
 	return C.VARNAM_SUCCESS
@@ -8,6 +8,11 @@
 #define VARNAM_ERROR   2
 #define VARNAM_CANCELLED  3
 
+// Bumped when a struct below changes in a way that breaks bindings.
+// New fields are only added at the end of a struct, so bindings made
+// for an older version can still read the fields they know of
+#define VARNAM_ABI_VERSION 1
+
 #define VARNAM_CONFIG_USE_DEAD_CONSONANTS 100
 #define VARNAM_CONFIG_IGNORE_DUPLICATE_TOKEN 101
 // VARNAM_CONFIG_ENABLE_SUGGESTIONS hasn't been implemented yet 
@@ -17,11 +22,37 @@
 #define VARNAM_CONFIG_SET_PATTERN_DICTIONARY_SUGGESTIONS_LIMIT 105
 #define VARNAM_CONFIG_SET_TOKENIZER_SUGGESTIONS_LIMIT 106
 #define VARNAM_CONFIG_SET_DICTIONARY_MATCH_EXACT 107
+// Value is in milliseconds. 0 disables
+#define VARNAM_CONFIG_SET_QUERY_TIMEOUT 108
+#define VARNAM_CONFIG_KEEP_DUPLICATE_SUGGESTIONS 109
+// 0 = VARNAM_SCRIPT_BOUNDARY_JOIN, 1 = VARNAM_SCRIPT_BOUNDARY_SPLIT
+#define VARNAM_CONFIG_SET_SCRIPT_BOUNDARY 110
+// 0 = keep, 1 = atomic, 2 = legacy ZWJ forms, 3 = strip joiners
+#define VARNAM_CONFIG_SET_JOINER_POLICY 111
+#define VARNAM_CONFIG_SET_CASE_INSENSITIVE 112
+#define VARNAM_CONFIG_SET_FUZZY_MATCHING 113
+// 0 disables
+#define VARNAM_CONFIG_SET_TOKENIZER_SUGGESTIONS_THRESHOLD 114
+// 0 = demote, 1 = drop
+#define VARNAM_CONFIG_SET_TOKENIZER_SUGGESTIONS_ON_THRESHOLD 115
+#define VARNAM_CONFIG_SET_DICTIONARY_MIN_INPUT_LENGTH 116
+// 0 = confidence first, 1 = recency blended
+#define VARNAM_CONFIG_SET_RANKER 117
+#define VARNAM_CONFIG_SET_SUGGEST_CORRECTIONS 118
+// Value is in milliseconds. 0 disables
+#define VARNAM_CONFIG_SET_QUICK_BUDGET 119
+// Value is in percent of the best tokenizer suggestion's weight. 0 disables
+#define VARNAM_CONFIG_SET_TOKENIZER_WEIGHT_THRESHOLD 120
+// Tamil only. 1 = leave out Grantha letters
+#define VARNAM_CONFIG_SET_NO_GRANTHA 121
+// 0 = phonetic, 1 = InScript keyboard layout
+#define VARNAM_CONFIG_SET_INPUT_METHOD 122
 
 typedef struct Suggestion_t {
   char* Word;
   int Weight;
   int LearnedOn;
+  float Score;
 } Suggestion;
 
 typedef struct TransliterationResult_t {
@@ -33,10 +64,13 @@ typedef struct TransliterationResult_t {
   varray* GreedyTokenized;
 } TransliterationResult;
 
-Suggestion* makeSuggestion(char* word, int weight, int learned_on);
+Suggestion* makeSuggestion(char* word, int weight, int learned_on, float score);
 
 TransliterationResult* makeResult(varray* exact_words, varray* exact_matches, varray* dictionary_suggestions, varray* pattern_dictionary_suggestions, varray* tokenizer_suggestions, varray* greedy_tokenized);
 
+// For strings given by varnam_* functions
+void destroyString(char* str);
+
 void destroySuggestionsArray(varray* pointer);
 void destroyTransliterationResult(TransliterationResult*);
 
@@ -47,9 +81,14 @@ typedef struct SchemeDetails_t {
   char* Author;
   char* CompiledDate;
   bool IsStable;
+  char* Path;
+  char* NativeDisplayName;
+  char* Version;
+  char* MinLibraryVersion;
+  char* Base;
 } SchemeDetails;
 
-SchemeDetails* makeSchemeDetails(char* Identifier, char* LangCode, char* DisplayName, char* Author, char* CompiledDate, bool IsStable);
+SchemeDetails* makeSchemeDetails(char* Identifier, char* LangCode, char* DisplayName, char* Author, char* CompiledDate, bool IsStable, char* Path, char* NativeDisplayName, char* Version, char* MinLibraryVersion, char* Base);
 
 void destroySchemeDetailsArray(void* cSchemeDetails);
 
@@ -79,4 +118,16 @@ Symbol* makeSymbol(int Identifier, int Type, int MatchType, char* Pattern, char*
 
 void destroySymbolArray(void* cSymbols);
 
+// Severity is 1 for error, 2 for warning
+typedef struct SchemeIssue_t {
+  int Severity;
+  int Kind;
+  Symbol* Symbol;
+  char* Message;
+} SchemeIssue;
+
+SchemeIssue* makeSchemeIssue(int Severity, int Kind, Symbol* Symbol, char* Message);
+
+void destroySchemeIssueArray(void* cSchemeIssues);
+
 #endif /* __C_SHARED_H__ */
\ No newline at end of file
@@ -12,9 +12,12 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/varnamproject/govarnam/govarnamgo"
+	"github.com/varnamproject/govarnam/registry"
 )
 
 var varnam *govarnamgo.VarnamHandle
@@ -39,6 +42,14 @@ func main() {
 
 	learnFlag := flag.Bool("learn", false, "Learn a word")
 	unlearnFlag := flag.Bool("unlearn", false, "Unlearn a word")
+	pinFlag := flag.Bool("pin", false, "Pin a learnt word to the top of suggestions")
+	unpinFlag := flag.Bool("unpin", false, "Unpin a pinned word")
+	blacklistFlag := flag.Bool("blacklist", false, "Never suggest or learn a word")
+	unblacklistFlag := flag.Bool("unblacklist", false, "Remove a word from blacklist")
+	overrideSymbolFlag := flag.Bool("override-symbol", false, "Make a pattern give your own value instead of the scheme's. 2 Arguments: Pattern & Value")
+	removeOverrideFlag := flag.Bool("remove-override", false, "Make an overridden pattern give the scheme's value again. Argument: Pattern")
+	setWeightFlag := flag.Bool("set-weight", false, "Change weight of a symbol to make the tokenizer prefer it. 3 Arguments: Pattern, Value & Weight")
+	resetWeightFlag := flag.Bool("reset-weight", false, "Use the scheme's weight for a symbol again. 2 Arguments: Pattern & Value")
 	trainFlag := flag.Bool("train", false, "Train a word with a particular pattern. 2 Arguments: Pattern & Word")
 
 	learnFromFileFlag := flag.Bool("learn-from-file", false, "Learn words in a file")
@@ -46,13 +57,30 @@ func main() {
 
 	exportFlag := flag.Bool("export", false, "Export learnings to file")
 	exportWordsPerFile := flag.Int("export-words-per-file", 30000, "Words per export file")
+	writeMappedVSTFlag := flag.Bool("write-mapped-vst", false, "Write the scheme as a mapped VST (VST v2), which loads faster. Argument: Output path")
+	exportHunspellFlag := flag.Bool("export-hunspell", false, "Export learnt words as hunspell dictionary. Argument: Output path without extension")
 	importFlag := flag.Bool("import", false, "Import learnings from file")
+	importLibvarnamFlag := flag.Bool("import-libvarnam", false, "Import learnings from a libvarnam learnings DB file")
+	importVarnamWebFlag := flag.Bool("import-varnam-web", false, "Import words & patterns from Varnam web editor JSON exports")
+	importHunspellFlag := flag.Bool("import-hunspell", false, "Import words from a hunspell dictionary. 2 Arguments: .dic file & .aff file (optional)")
 
 	indicDigitsFlag := flag.Bool("digits", false, "Use indic digits")
 
 	advanced := flag.Bool("advanced", false, "Show transliteration result in advanced mode")
+	greedy := flag.Bool("greedy", false, "Show only greedy tokenized output. Doesn't look up learnings")
+	sentenceFlag := flag.Bool("sentence", false, "Transliterate a whole sentence, using the top suggestion for each word")
+	toFlag := flag.String("to", "", "Convert text in the script of scheme given with -s to the script of this scheme ID. Argument: text")
+	isoFlag := flag.Bool("iso", false, "Romanize a word in ISO 15919 with diacritics. Argument: word in native script")
+	dumpVSTFlag := flag.Bool("dump-vst", false, "Show all symbols of the scheme with their weights & match types")
+	validateFlag := flag.Bool("validate", false, "Check the scheme for duplicate patterns, unreachable symbols, weight inconsistencies & invalid unicode. Fails if there are errors")
+	goldFlag := flag.Bool("gold", false, "Check if inputs in a TSV file give the expected word as top suggestion. Fails if any doesn't. Argument: file path")
+	dumpTokensFlag := flag.Bool("dump-tokens", false, "Show how a word is tokenized and the symbols each part can be. Argument: word")
 	reverseTransliterate := flag.Bool("reverse", false, "Reverse transliterate. Find which pattern to use for a specific word")
 
+	registryFlag := flag.String("registry", registry.DefaultURL(), "URL of registry index to install schemes & dictionaries from")
+	packagesFlag := flag.Bool("packages", false, "List schemes & dictionaries in registry")
+	installFlag := flag.Bool("install", false, "Download, verify & install a scheme or dictionary from registry. Dictionaries are imported into learnings of scheme given with -s. Argument: package ID")
+
 	flag.Parse()
 
 	if *versionFlag {
@@ -61,6 +89,43 @@ func main() {
 		return
 	}
 
+	if *packagesFlag {
+		index, err := registry.New(*registryFlag).Index(context.Background())
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		for _, pkg := range index.Packages {
+			fmt.Printf("%s\t%s\t%s\t%s\n", pkg.ID, pkg.Type, pkg.Version, pkg.Description)
+		}
+		return
+	}
+
+	installedDictionary := ""
+
+	if *installFlag {
+		reg := registry.New(*registryFlag)
+
+		pkg, err := reg.Find(context.Background(), flag.Arg(0))
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		installed, err := reg.InstallPackage(context.Background(), pkg)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Installed %s to %s\n", pkg.ID, installed)
+
+		if pkg.Type != registry.TypeDictionary || *schemeFlag == "" {
+			return
+		}
+
+		if pkg.Scheme != "" && pkg.Scheme != *schemeFlag {
+			log.Fatalf("Dictionary %s is for scheme %s, not importing into %s", pkg.ID, pkg.Scheme, *schemeFlag)
+		}
+		installedDictionary = installed
+	}
+
 	if *schemeFlag == "" {
 		fmt.Println("Specifiy a scheme ID with -s.\n\nUse --help for all available commands.")
 		return
@@ -79,7 +144,13 @@ func main() {
 
 	args := flag.Args()
 
-	if *reIndexFlag {
+	if installedDictionary != "" {
+		err := varnam.Import(installedDictionary)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Imported %s into learnings\n", filepath.Base(installedDictionary))
+	} else if *reIndexFlag {
 		err := varnam.ReIndexDictionary()
 		if err != nil {
 			log.Fatal(err.Error())
@@ -94,6 +165,46 @@ func main() {
 			log.Fatal(err.Error())
 		}
 		fmt.Printf("Trained %s => %s\n", pattern, word)
+	} else if *overrideSymbolFlag {
+		pattern := args[0]
+		value := args[1]
+
+		err := varnam.OverrideSymbol(govarnamgo.Symbol{Pattern: pattern, Value1: value})
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Overrode %s => %s\n", pattern, value)
+	} else if *removeOverrideFlag {
+		pattern := args[0]
+
+		err := varnam.RemoveSymbolOverride(pattern)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Removed override of %s\n", pattern)
+	} else if *setWeightFlag {
+		pattern := args[0]
+		value := args[1]
+
+		weight, err := strconv.Atoi(args[2])
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		err = varnam.SetSymbolWeight(pattern, value, weight)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Set weight of %s => %s to %d\n", pattern, value, weight)
+	} else if *resetWeightFlag {
+		pattern := args[0]
+		value := args[1]
+
+		err := varnam.ResetSymbolWeight(pattern, value)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Reset weight of %s => %s\n", pattern, value)
 	} else if *learnFlag {
 		word := args[0]
 
@@ -114,6 +225,38 @@ func main() {
 			fmt.Printf("Couldn't learn %s", word)
 			log.Fatal(err.Error())
 		}
+	} else if *pinFlag {
+		word := args[0]
+
+		err := varnam.Pin(word)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Pinned %s\n", word)
+	} else if *unpinFlag {
+		word := args[0]
+
+		err := varnam.Unpin(word)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Unpinned %s\n", word)
+	} else if *blacklistFlag {
+		word := args[0]
+
+		err := varnam.Blacklist(word)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Blacklisted %s\n", word)
+	} else if *unblacklistFlag {
+		word := args[0]
+
+		err := varnam.RemoveFromBlacklist(word)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("Removed %s from blacklist\n", word)
 	} else if *learnFromFileFlag {
 		learnStatus, err := varnam.LearnFromFile(args[0])
 		if err == nil {
@@ -135,6 +278,20 @@ func main() {
 		} else {
 			log.Fatal(err.Error())
 		}
+	} else if *writeMappedVSTFlag {
+		err := varnam.WriteMappedVST(args[0])
+		if err == nil {
+			fmt.Printf("Finished writing mapped VST to %s\n", args[0])
+		} else {
+			log.Fatal(err.Error())
+		}
+	} else if *exportHunspellFlag {
+		err := varnam.ExportToHunspell(args[0])
+		if err == nil {
+			fmt.Printf("Finished exporting to %s.dic & %s.aff\n", args[0], args[0])
+		} else {
+			log.Fatal(err.Error())
+		}
 	} else if *importFlag {
 		matches, err := filepath.Glob(args[0])
 
@@ -150,6 +307,40 @@ func main() {
 				log.Fatal(err.Error())
 			}
 		}
+	} else if *importLibvarnamFlag {
+		learnStatus, err := varnam.ImportFromLibvarnam(args[0])
+		if err == nil {
+			fmt.Printf("Finished importing from libvarnam. Total words: %d. Failed: %d\n", learnStatus.TotalWords, learnStatus.FailedWords)
+		} else {
+			log.Fatal(err.Error())
+		}
+	} else if *importVarnamWebFlag {
+		matches, err := filepath.Glob(args[0])
+
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		for _, match := range matches {
+			learnStatus, err := varnam.ImportFromVarnamWeb(match)
+			if err == nil {
+				fmt.Printf("Finished importing from file %s. Total words: %d. Failed: %d\n", match, learnStatus.TotalWords, learnStatus.FailedWords)
+			} else {
+				log.Fatal(err.Error())
+			}
+		}
+	} else if *importHunspellFlag {
+		affPath := ""
+		if len(args) > 1 {
+			affPath = args[1]
+		}
+
+		learnStatus, err := varnam.ImportFromHunspell(args[0], affPath)
+		if err == nil {
+			fmt.Printf("Finished importing from hunspell dictionary. Total words: %d. Failed: %d\n", learnStatus.TotalWords, learnStatus.FailedWords)
+		} else {
+			log.Fatal(err.Error())
+		}
 	} else if *reverseTransliterate {
 		sugs, err := varnam.ReverseTransliterate(args[0])
 		if err != nil {
@@ -169,6 +360,68 @@ func main() {
 			fmt.Println(sug.Word + " " + fmt.Sprint(sug.Weight))
 			lastWeight = sug.Weight
 		}
+	} else if *toFlag != "" {
+		converted, err := govarnamgo.TransliterateBetween(*schemeFlag, *toFlag, strings.Join(args, " "))
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Println(converted)
+	} else if *dumpVSTFlag {
+		dump, err := varnam.DumpVST()
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Print(dump)
+	} else if *validateFlag {
+		issues, err := varnam.ValidateScheme()
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		errors := 0
+		for _, issue := range issues {
+			severity := "warning"
+			if issue.Severity == 1 {
+				severity = "error"
+				errors++
+			}
+			fmt.Printf("%s: symbol %d %q => %q: %s\n", severity, issue.Symbol.Identifier, issue.Symbol.Pattern, issue.Symbol.Value1, issue.Message)
+		}
+
+		if errors > 0 {
+			log.Fatalf("%d errors in scheme", errors)
+		}
+		fmt.Println("No errors found")
+	} else if *goldFlag {
+		_, failed, report, err := varnam.RunGoldTests(args[0])
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+
+		fmt.Print(report)
+		if failed > 0 {
+			log.Fatalf("%d words failed", failed)
+		}
+	} else if *dumpTokensFlag {
+		dump, err := varnam.DumpTokens(args[0])
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Print(dump)
+	} else if *isoFlag {
+		romanized, err := varnam.RomanizeISO15919(strings.Join(args, " "))
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Println(romanized)
+	} else if *sentenceFlag {
+		sentence, err := varnam.TransliterateSentence(strings.Join(args, " "))
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Println(sentence)
+	} else if *greedy {
+		printSugs(varnam.TransliterateGreedyTokenized(args[0]))
 	} else if *advanced {
 		var result govarnamgo.TransliterationResult
 
@@ -0,0 +1,50 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+schemecompile compiles a scheme source written in TOML into a VST :
+
+	go run github.com/varnamproject/govarnam/cmd/schemecompile -src ml.toml -o ml.vst
+
+See package schemecompile for the source format.
+*/
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/varnamproject/govarnam/schemecompile"
+)
+
+func main() {
+	srcFlag := flag.String("src", "", "Path to scheme source file")
+	outputFlag := flag.String("o", "", "Output VST file. Defaults to source path with .vst extension")
+
+	flag.Parse()
+
+	if *srcFlag == "" {
+		fmt.Println("Specify the scheme source with -src.\n\nUse --help for all available options.")
+		os.Exit(1)
+	}
+
+	output := *outputFlag
+	if output == "" {
+		output = strings.TrimSuffix(*srcFlag, filepath.Ext(*srcFlag)) + ".vst"
+	}
+
+	err := schemecompile.CompileFile(*srcFlag, output)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	fmt.Println("Compiled " + output)
+}
@@ -0,0 +1,325 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+// Inputs benchmarked when a file isn't given. Common words of Indian
+// languages typed in Latin letters, short to long
+var benchInputs = []string{
+	"a", "ka", "amma", "vara", "nanni", "kerala", "bharat", "namaste",
+	"namaskaram", "malayalam", "vidyalayam", "pustakam", "sankhya",
+	"prathibha", "kshethram", "sundaram", "swagatham", "aanandam",
+	"vishwavidyalayam", "thiruvananthapuram", "pradhanamanthri",
+	"sarkar", "samskaram", "jnanam", "shraddha", "dhanyavaad",
+	"kozhikode", "computer", "internet", "2021",
+}
+
+// Latency distribution of a benchmarked operation
+type benchLatency struct {
+	Count int
+	Min   time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+
+	// Only for transliterations. VST & learnings DB queries
+	// per call, see govarnam.TransliterationDiagnostics
+	MeanQueries float64 `json:",omitempty"`
+	MaxQueries  int64   `json:",omitempty"`
+
+	// Calls that failed, like learning a word that can't be learnt
+	Errors int `json:",omitempty"`
+}
+
+type benchReport struct {
+	Scheme          string
+	DictionaryWords int
+	Rounds          int
+	Inputs          int
+
+	// Each input as a whole
+	Transliterate benchLatency
+
+	// Each prefix of input with TransliterateQuick, like when typing
+	Keystrokes benchLatency
+
+	// Learning the top suggestion of each input. Done on a copy
+	// of learnings, actual learnings are left as is
+	Learn *benchLatency `json:",omitempty"`
+}
+
+type benchRecorder struct {
+	durations []time.Duration
+	queries   []int64
+	errors    int
+}
+
+func (r *benchRecorder) add(duration time.Duration, queries int64) {
+	r.durations = append(r.durations, duration)
+	r.queries = append(r.queries, queries)
+}
+
+func (r *benchRecorder) transliterate(ctx context.Context, varnam *govarnam.Varnam, input string, opts govarnam.TransliterateOptions) {
+	start := time.Now()
+	result := varnam.TransliterateAdvancedWithOptions(ctx, input, opts)
+	duration := time.Since(start)
+
+	var queries int64
+	if result.Diagnostics != nil {
+		queries = result.Diagnostics.Queries
+	}
+	r.add(duration, queries)
+}
+
+func (r *benchRecorder) latency() benchLatency {
+	l := benchLatency{Count: len(r.durations), Errors: r.errors}
+	if l.Count == 0 {
+		return l
+	}
+
+	sorted := append([]time.Duration{}, r.durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	// Nearest rank
+	percentile := func(p int) time.Duration {
+		rank := (p*len(sorted) + 99) / 100
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1]
+	}
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	var totalQueries int64
+	for _, q := range r.queries {
+		totalQueries += q
+		if q > l.MaxQueries {
+			l.MaxQueries = q
+		}
+	}
+
+	l.Min = sorted[0]
+	l.P50 = percentile(50)
+	l.P90 = percentile(90)
+	l.P99 = percentile(99)
+	l.Max = sorted[len(sorted)-1]
+	l.Mean = total / time.Duration(len(sorted))
+	l.MeanQueries = float64(totalQueries) / float64(len(r.queries))
+
+	return l
+}
+
+func readBenchInputs(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var inputs []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if input := strings.TrimSpace(scanner.Text()); input != "" {
+			inputs = append(inputs, input)
+		}
+	}
+	if len(inputs) == 0 && scanner.Err() == nil {
+		return nil, fmt.Errorf("No inputs in %s", path)
+	}
+
+	return inputs, scanner.Err()
+}
+
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Learn the top suggestion of each input in a copy of learnings
+func benchLearn(varnam *govarnam.Varnam, inputs []string, rounds int) (benchLatency, error) {
+	dir, err := ioutil.TempDir("", "varnamcli-bench")
+	if err != nil {
+		return benchLatency{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	dictPath := filepath.Join(dir, filepath.Base(varnam.DictPath))
+
+	// Learnings are in WAL mode, recent changes may be in the -wal file
+	for _, suffix := range []string{"", "-wal"} {
+		if _, err := os.Stat(varnam.DictPath + suffix); err == nil {
+			if err := copyFile(varnam.DictPath+suffix, dictPath+suffix); err != nil {
+				return benchLatency{}, err
+			}
+		}
+	}
+
+	copied, err := govarnam.Init(varnam.VSTPath, dictPath)
+	if err != nil {
+		return benchLatency{}, err
+	}
+	defer copied.Close()
+
+	var words []string
+	for _, input := range inputs {
+		if sugs := varnam.TransliterateGreedyTokenized(input); len(sugs) > 0 {
+			words = append(words, sugs[0].Word)
+		}
+	}
+
+	var recorder benchRecorder
+	for round := 0; round < rounds; round++ {
+		for _, word := range words {
+			start := time.Now()
+			if err := copied.Learn(word, 0); err != nil {
+				recorder.errors++
+				continue
+			}
+			recorder.add(time.Since(start), 0)
+		}
+	}
+
+	return recorder.latency(), nil
+}
+
+func printBenchLatency(name string, l benchLatency) {
+	fmt.Printf("%s (%d calls)\n", name, l.Count)
+	fmt.Printf("  min %v, p50 %v, p90 %v, p99 %v, max %v, mean %v\n", l.Min, l.P50, l.P90, l.P99, l.Max, l.Mean)
+	if l.MaxQueries > 0 {
+		fmt.Printf("  queries per call: mean %.1f, max %d\n", l.MeanQueries, l.MaxQueries)
+	}
+	if l.Errors > 0 {
+		fmt.Printf("  errors: %d\n", l.Errors)
+	}
+}
+
+func benchCommand(args []string) error {
+	f := newInstanceFlags("bench")
+	rounds := f.flags.Int("rounds", 3, "Times to run each input")
+	inputsFile := f.flags.String("file", "", "Benchmark inputs in this file, one per line, instead of the standard set")
+	noLearn := f.flags.Bool("no-learn", false, "Skip benchmarking learning")
+	dictLimit := f.flags.Int("dictionary-limit", -1, "Override DictionarySuggestionsLimit")
+	patternDictLimit := f.flags.Int("pattern-dictionary-limit", -1, "Override PatternDictionarySuggestionsLimit")
+	tokenizerLimit := f.flags.Int("tokenizer-limit", -1, "Override TokenizerSuggestionsLimit")
+	quickBudget := f.flags.Duration("quick-budget", -1, "Override QuickBudget, for keystrokes")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if *dictLimit >= 0 {
+		varnam.DictionarySuggestionsLimit = *dictLimit
+	}
+	if *patternDictLimit >= 0 {
+		varnam.PatternDictionarySuggestionsLimit = *patternDictLimit
+	}
+	if *tokenizerLimit >= 0 {
+		varnam.TokenizerSuggestionsLimit = *tokenizerLimit
+	}
+	if *quickBudget >= 0 {
+		varnam.QuickBudget = *quickBudget
+	}
+	varnam.CollectDiagnostics = true
+
+	inputs := benchInputs
+	if *inputsFile != "" {
+		inputs, err = readBenchInputs(*inputsFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+
+	stats, err := varnam.DictionaryStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	report := benchReport{
+		Scheme:          varnam.SchemeDetails.Identifier,
+		DictionaryWords: stats.Words,
+		Rounds:          *rounds,
+		Inputs:          len(inputs),
+	}
+
+	var transliterate, keystrokes benchRecorder
+
+	for round := 0; round < *rounds; round++ {
+		for _, input := range inputs {
+			transliterate.transliterate(ctx, varnam, input, govarnam.TransliterateOptions{})
+
+			runes := []rune(input)
+			for i := 1; i <= len(runes); i++ {
+				keystrokes.transliterate(ctx, varnam, string(runes[:i]), govarnam.TransliterateOptions{Quick: true})
+			}
+		}
+	}
+
+	report.Transliterate = transliterate.latency()
+	report.Keystrokes = keystrokes.latency()
+
+	if !*noLearn {
+		learn, err := benchLearn(varnam, inputs, *rounds)
+		if err != nil {
+			return err
+		}
+		report.Learn = &learn
+	}
+
+	if *f.jsonOutput {
+		f.print(report)
+		return nil
+	}
+
+	fmt.Printf("Scheme: %s, dictionary words: %d\n", report.Scheme, report.DictionaryWords)
+	fmt.Printf("Inputs: %d, rounds: %d\n\n", report.Inputs, report.Rounds)
+	printBenchLatency("Transliterate", report.Transliterate)
+	printBenchLatency("Keystrokes (quick)", report.Keystrokes)
+	if report.Learn != nil {
+		printBenchLatency("Learn (on a copy of learnings)", *report.Learn)
+	}
+
+	return nil
+}
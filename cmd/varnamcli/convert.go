@@ -0,0 +1,137 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+// Other suggestions shown in report for a word
+const convertReportAlternatives = 4
+
+// Formats by file extension
+var convertFormatExtensions = map[string]string{
+	".html":     "html",
+	".htm":      "html",
+	".md":       "markdown",
+	".markdown": "markdown",
+	".srt":      "subtitles",
+	".vtt":      "subtitles",
+}
+
+// Transliterate functions of formats
+var convertFormats = map[string]func(varnam *govarnam.Varnam, ctx context.Context, text string) (string, []govarnam.ConvertedWord, error){
+	"text":      (*govarnam.Varnam).TransliterateDocument,
+	"html":      (*govarnam.Varnam).TransliterateHTML,
+	"markdown":  (*govarnam.Varnam).TransliterateMarkdown,
+	"subtitles": (*govarnam.Varnam).TransliterateSubtitles,
+}
+
+func convertCommand(args []string) error {
+	f := newInstanceFlags("convert")
+	output := f.flags.String("o", "", "Output file. Defaults to stdout")
+	format := f.flags.String("format", "", "Input format: text, html (only text nodes are transliterated) markdown (code & links are kept) or subtitles (SRT & WebVTT, only dialogue is transliterated). Found from file extension by default")
+	report := f.flags.String("report", "", "Write report of words without a confident match to this file. Defaults to stderr. With -json, the report is JSON")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if err := requireArgs(f.flags, 1); err != nil {
+		return err
+	}
+
+	var input []byte
+	if f.flags.Arg(0) == "-" {
+		input, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		input, err = ioutil.ReadFile(f.flags.Arg(0))
+	}
+	if err != nil {
+		return err
+	}
+
+	if *format == "" {
+		*format = convertFormatExtensions[strings.ToLower(filepath.Ext(f.flags.Arg(0)))]
+		if *format == "" {
+			*format = "text"
+		}
+	}
+
+	transliterate, found := convertFormats[*format]
+	if !found {
+		return fmt.Errorf("Unknown format %q", *format)
+	}
+
+	converted, words, err := transliterate(varnam, context.Background(), string(input))
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = io.WriteString(os.Stdout, converted)
+	} else {
+		err = ioutil.WriteFile(*output, []byte(converted), 0644)
+	}
+	if err != nil {
+		return err
+	}
+
+	reportOut := os.Stderr
+	if *report != "" {
+		file, err := os.Create(*report)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		reportOut = file
+	}
+
+	return writeConvertReport(reportOut, words, *f.jsonOutput)
+}
+
+func writeConvertReport(out io.Writer, words []govarnam.ConvertedWord, jsonOutput bool) error {
+	var uncertain []govarnam.ConvertedWord
+	for _, word := range words {
+		if !word.Confident {
+			uncertain = append(uncertain, word)
+		}
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(uncertain)
+	}
+
+	for _, word := range uncertain {
+		var alternatives []string
+		for i := 1; i < len(word.Suggestions) && i <= convertReportAlternatives; i++ {
+			alternatives = append(alternatives, word.Suggestions[i].Word)
+		}
+
+		line := fmt.Sprintf("line %d: %s => %s", word.Line, word.Input, word.Output)
+		if len(alternatives) > 0 {
+			line += " (or " + strings.Join(alternatives, ", ") + ")"
+		}
+		fmt.Fprintln(out, line)
+	}
+
+	_, err := fmt.Fprintf(out, "Converted %d words, %d without a confident match\n", len(words), len(uncertain))
+	return err
+}
@@ -0,0 +1,166 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+func formatTime(unix int) string {
+	if unix == 0 {
+		return "never"
+	}
+	return time.Unix(int64(unix), 0).Format("2006-01-02 15:04:05")
+}
+
+func statsCommand(args []string) error {
+	f := newInstanceFlags("stats")
+	days := f.flags.Int("days", 30, "Show learning activity of these many past days")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	ctx := context.Background()
+
+	stats, err := varnam.DictionaryStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().AddDate(0, 0, -*days)
+	learning, err := varnam.LearningStats(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	if *f.jsonOutput {
+		f.print(struct {
+			Dictionary govarnam.DictionaryStatistics
+			Learning   govarnam.LearningStatistics
+		}{stats, learning})
+		return nil
+	}
+
+	fmt.Printf("Words: %d\n", stats.Words)
+	fmt.Printf("Patterns: %d\n", stats.Patterns)
+	fmt.Printf("Pinned words: %d\n", stats.PinnedWords)
+	fmt.Printf("Blacklisted words: %d\n", stats.BlacklistedWords)
+	fmt.Printf("Domains: %d\n", stats.Domains)
+	fmt.Printf("First learnt: %s\n", formatTime(stats.FirstLearnedOn))
+	fmt.Printf("Last learnt: %s\n", formatTime(stats.LastLearnedOn))
+
+	fmt.Printf("\nLast %d days\n", *days)
+	for _, day := range learning.Days {
+		fmt.Printf("%s  learns %d, trains %d, new words %d\n", day.Day.Format("2006-01-02"), day.Learns, day.Trains, day.NewWords)
+	}
+
+	if len(learning.TopWords) > 0 {
+		fmt.Println("\nMost learnt")
+		for _, word := range learning.TopWords {
+			fmt.Printf("%s %d\n", word.Word, word.Learns)
+		}
+	}
+
+	return nil
+}
+
+func wordsCommand(args []string) error {
+	f := newInstanceFlags("words")
+	top := f.flags.Bool("top", false, "Most learnt words first")
+	pinned := f.flags.Bool("pinned", false, "Pinned words")
+	blacklisted := f.flags.Bool("blacklisted", false, "Blacklisted words")
+	f.flags.Bool("recent", true, "Recently learnt words first. This is the default")
+	limit := f.flags.Int("limit", 20, "Number of words")
+	offset := f.flags.Int("offset", 0, "Skip these many words")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	ctx := context.Background()
+
+	var sugs []govarnam.Suggestion
+
+	switch {
+	case *blacklisted:
+		words, err := varnam.GetBlacklist()
+		if err != nil {
+			return err
+		}
+		if *f.jsonOutput {
+			f.print(words)
+		} else if len(words) > 0 {
+			fmt.Println(strings.Join(words, "\n"))
+		}
+		return nil
+	case *pinned:
+		sugs, err = varnam.GetPinnedWords()
+	case *top:
+		sugs, err = varnam.GetMostLearntWords(ctx, *offset, *limit)
+	default:
+		sugs, err = varnam.GetRecentlyLearntWords(ctx, *offset, *limit)
+	}
+	if err != nil {
+		return err
+	}
+
+	f.print(sugs)
+	return nil
+}
+
+func wordInfoCommand(args []string) error {
+	f := newInstanceFlags("word-info")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if err := requireArgs(f.flags, 1); err != nil {
+		return err
+	}
+
+	for _, word := range f.flags.Args() {
+		details, err := varnam.GetWordDetails(context.Background(), word)
+		if err != nil {
+			return fmt.Errorf("%s: %s", word, err.Error())
+		}
+
+		if *f.jsonOutput {
+			f.print(details)
+			continue
+		}
+
+		fmt.Println(details.Word)
+		fmt.Printf("  Weight: %d\n", details.Weight)
+		fmt.Printf("  Last learnt: %s\n", formatTime(details.LearnedOn))
+		fmt.Printf("  Learns: %d, Trains: %d\n", details.Learns, details.Trains)
+		fmt.Printf("  Pinned: %t\n", details.Pinned)
+		if len(details.Patterns) > 0 {
+			fmt.Printf("  Patterns: %s\n", strings.Join(details.Patterns, ", "))
+		}
+		if len(details.Domains) > 0 {
+			fmt.Printf("  Domains: %s\n", strings.Join(details.Domains, ", "))
+		}
+		for _, selection := range details.Selections {
+			fmt.Printf("  Chosen for %s: %d times, last %s\n", selection.Pattern, selection.Count, formatTime(selection.LastSelected))
+		}
+	}
+
+	return nil
+}
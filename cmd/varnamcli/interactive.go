@@ -0,0 +1,285 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+// Candidates shown at a time, picked with number keys
+const interactivePageSize = 9
+
+const interactiveHelp = `Type to see suggestions. Keys :
+  1-9           Pick a suggestion
+  Space         Pick the highlighted suggestion and add a space
+  Tab, Arrows   Highlight next/previous suggestion
+  PgDn, PgUp    Next/previous page of suggestions
+  Esc           Use the input as is
+  Enter         Print the line and start a new one
+  Ctrl+D        Exit
+Picked suggestions are learnt.`
+
+func interactiveCommand(args []string) error {
+	f := newInstanceFlags("interactive")
+	lineMode := f.flags.Bool("line", false, "Read a line at a time instead of each key. Used when stdin isn't a terminal")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if !*lineMode {
+		restore, err := rawTerminal()
+		if err == nil {
+			defer restore()
+			return interactiveKeys(varnam, os.Stdin, os.Stdout)
+		}
+	}
+
+	return interactiveLines(varnam, os.Stdin, os.Stdout)
+}
+
+// Make terminal give each key as typed without echoing it.
+// Returns a function restoring the terminal
+func rawTerminal() (func(), error) {
+	stty := func(args ...string) (string, error) {
+		cmd := exec.Command("stty", args...)
+		cmd.Stdin = os.Stdin
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+
+	state, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+
+	// isig off so that Ctrl+C comes as a key and terminal gets restored
+	_, err = stty("-icanon", "-echo", "-isig", "min", "1")
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		stty(state)
+	}, nil
+}
+
+type keyReader struct {
+	reader *bufio.Reader
+}
+
+// Keys other than characters
+const (
+	keyNone = iota
+	keyUp
+	keyDown
+	keyRight
+	keyLeft
+	keyPageUp
+	keyPageDown
+	keyEscape
+)
+
+// Next key. Either a character or one of key*
+func (kr *keyReader) next() (rune, int, error) {
+	r, _, err := kr.reader.ReadRune()
+	if err != nil || r != '\x1b' {
+		return r, keyNone, err
+	}
+
+	// A lone Esc doesn't have anything following it
+	if kr.reader.Buffered() == 0 {
+		return r, keyEscape, nil
+	}
+
+	seq := []rune{}
+	for kr.reader.Buffered() > 0 {
+		r, _, err := kr.reader.ReadRune()
+		if err != nil {
+			return 0, keyNone, err
+		}
+		seq = append(seq, r)
+		if len(seq) > 1 && (unicode.IsLetter(r) || r == '~') {
+			break
+		}
+	}
+
+	switch string(seq) {
+	case "[A", "OA":
+		return 0, keyUp, nil
+	case "[B", "OB":
+		return 0, keyDown, nil
+	case "[C", "OC":
+		return 0, keyRight, nil
+	case "[D", "OD":
+		return 0, keyLeft, nil
+	case "[5~":
+		return 0, keyPageUp, nil
+	case "[6~":
+		return 0, keyPageDown, nil
+	}
+	return 0, keyNone, nil
+}
+
+// Draws the suggestions line & the text line below it
+func drawInteractive(out io.Writer, line string, composition *govarnam.Composition) {
+	var sugsLine strings.Builder
+
+	page, cursor := composition.Page()
+	for i, sug := range page {
+		if i == cursor {
+			// Reverse video for the highlighted one
+			fmt.Fprintf(&sugsLine, "\x1b[7m%d.%s\x1b[0m ", i+1, sug.Word)
+		} else {
+			fmt.Fprintf(&sugsLine, "%d.%s ", i+1, sug.Word)
+		}
+	}
+
+	preedit := ""
+	if !composition.IsEmpty() {
+		preedit = "\x1b[4m" + composition.Preedit() + "\x1b[0m"
+	}
+
+	fmt.Fprintf(out, "\x1b[1A\r\x1b[K%s\n\r\x1b[K%s%s", sugsLine.String(), line, preedit)
+}
+
+func interactiveKeys(varnam *govarnam.Varnam, in io.Reader, out io.Writer) error {
+	ctx := context.Background()
+	keys := keyReader{bufio.NewReader(in)}
+
+	composition := varnam.NewComposition()
+	composition.PageSize = interactivePageSize
+
+	line := ""
+
+	commit := func() {
+		text, err := composition.Commit()
+		if err != nil && varnam.Debug {
+			fmt.Fprintf(out, "\nCouldn't learn %s: %s\n\n", text, err.Error())
+		}
+		line += text
+	}
+
+	fmt.Fprintln(out, interactiveHelp)
+	fmt.Fprintln(out)
+
+	for {
+		drawInteractive(out, line, composition)
+
+		r, key, err := keys.next()
+		if err == io.EOF || r == '\x04' || r == '\x03' {
+			commit()
+			drawInteractive(out, line, composition)
+			fmt.Fprintln(out)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case key == keyDown || key == keyRight || r == '\t':
+			composition.MoveCursor(1)
+		case key == keyUp || key == keyLeft:
+			composition.MoveCursor(-1)
+		case key == keyPageDown:
+			composition.NextPage()
+		case key == keyPageUp:
+			composition.PreviousPage()
+		case key == keyEscape:
+			line += composition.CommitInput()
+		case key != keyNone:
+		case r == '\r' || r == '\n':
+			commit()
+			drawInteractive(out, line, composition)
+
+			// Leave the line printed & start a new one below
+			fmt.Fprint(out, "\n\n")
+			line = ""
+		case r == '\x7f' || r == '\b':
+			if !composition.IsEmpty() {
+				composition.Backspace(ctx)
+			} else if line != "" {
+				runes := []rune(line)
+				line = string(runes[:len(runes)-1])
+			}
+		case r == ' ':
+			commit()
+			line += " "
+		case r >= '1' && r <= '9' && !composition.IsEmpty():
+			page, cursor := composition.Page()
+			index := int(r - '1')
+			if index < len(page) {
+				composition.Select(composition.Cursor() - cursor + index)
+				commit()
+			}
+		case unicode.IsPrint(r):
+			composition.Append(ctx, string(r))
+		}
+	}
+}
+
+// For when stdin isn't a terminal. Each line read is transliterated
+// and suggestions are printed numbered. A number picks one of the
+// suggestions of the previous word & learns it
+func interactiveLines(varnam *govarnam.Varnam, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+
+	var (
+		lastInput string
+		lastSugs  []govarnam.Suggestion
+	)
+
+	fmt.Fprintln(out, "Type a word to see suggestions, then a number to pick one.")
+
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+
+		if index, err := strconv.Atoi(input); err == nil && len(lastSugs) > 0 {
+			if index < 1 || index > len(lastSugs) {
+				fmt.Fprintf(out, "Pick 1 to %d\n", len(lastSugs))
+				continue
+			}
+
+			word := lastSugs[index-1].Word
+			if err := varnam.ReportSelected(lastInput, word); err != nil {
+				fmt.Fprintf(out, "Couldn't learn %s: %s\n", word, err.Error())
+			} else {
+				fmt.Fprintf(out, "Learnt %s\n", word)
+			}
+			lastSugs = nil
+			continue
+		}
+
+		lastInput = input
+		lastSugs = varnam.Transliterate(input)
+		for i, sug := range lastSugs {
+			fmt.Fprintf(out, "%d. %s\n", i+1, sug.Word)
+		}
+	}
+}
@@ -0,0 +1,580 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+varnamcli uses govarnam from the command line, with subcommands :
+
+	go run github.com/varnamproject/govarnam/cmd/varnamcli transliterate -s ml malayalam
+	go run github.com/varnamproject/govarnam/cmd/varnamcli learn -s ml മലയാളം
+
+Unlike cli/, this uses the Go package directly and doesn't need
+libgovarnam installed. Use -json for output to be read by scripts.
+Run a subcommand with -help for its flags.
+*/
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/schemecompile"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+// Set in init, commands refer to it for their usage
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"transliterate": {
+			"[-advanced | -greedy] word... : Suggestions for each word",
+			transliterateCommand,
+		},
+		"reverse": {
+			"word... : Patterns that give each word",
+			reverseCommand,
+		},
+		"learn": {
+			"[-weight n] word... | -file path : Learn words, or all words in a text file",
+			learnCommand,
+		},
+		"train": {
+			"pattern word | -file path : Train pattern => word, or all pairs in a file",
+			trainCommand,
+		},
+		"unlearn": {
+			"word... : Unlearn words",
+			unlearnCommand,
+		},
+		"stats": {
+			"[-days n] : Counts of words, patterns etc. and learning activity",
+			statsCommand,
+		},
+		"words": {
+			"[-recent | -top | -pinned | -blacklisted] [-limit n] [-offset n] : List learnt words",
+			wordsCommand,
+		},
+		"word-info": {
+			"word... : Everything learnt about words",
+			wordInfoCommand,
+		},
+		"bench": {
+			"[-rounds n] [-file inputs] [-no-learn] [-dictionary-limit n] [-tokenizer-limit n] ... : Measure transliteration & learning latency",
+			benchCommand,
+		},
+		"export": {
+			"[-words-per-file n] [-min-confidence score] [-learned-after YYYY-MM-DD] [-domain d] path : Export learnings to JSON files",
+			exportCommand,
+		},
+		"import": {
+			"[-min-confidence score] [-learned-after YYYY-MM-DD] [-domain d] path... : Import learnings exported before. Paths can be globs",
+			importCommand,
+		},
+		"convert": {
+			"[-format text|html|markdown|subtitles] [-o output] [-report path] file : Transliterate a text file (- for stdin) with the top suggestion of each word. Words without a confident match are reported",
+			convertCommand,
+		},
+		"pipe": {
+			": Read a word or a JSON request (see server/socket) per line from stdin, write a JSON TransliterationResult or response per line",
+			pipeCommand,
+		},
+		"interactive": {
+			"[-line] : Type and see suggestions live, pick with number keys. Picked ones are learnt",
+			interactiveCommand,
+		},
+		"compile-scheme": {
+			"[-o output.vst] source.toml : Compile a scheme source into a VST",
+			compileSchemeCommand,
+		},
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: varnamcli <command> [flags] [arguments]\n\nCommands:\n")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun varnamcli <command> -help for flags of a command.\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	if name == "-h" || name == "-help" || name == "--help" || name == "help" {
+		usage()
+		return
+	}
+
+	cmd, found := commands[name]
+	if !found {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+// Flags every command opening an instance has
+type instanceFlags struct {
+	flags        *flag.FlagSet
+	scheme       *string
+	vstPath      *string
+	learningsDir *string
+	debug        *bool
+	jsonOutput   *bool
+}
+
+func newInstanceFlags(name string) *instanceFlags {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: varnamcli %s %s\n\n", name, commands[name].usage)
+		flags.PrintDefaults()
+	}
+
+	return &instanceFlags{
+		flags:        flags,
+		scheme:       flags.String("s", "", "Scheme ID"),
+		vstPath:      flags.String("vst", "", "Path to VST, instead of finding the VST of scheme ID"),
+		learningsDir: flags.String("learnings-dir", "", "Directory to keep learnings in"),
+		debug:        flags.Bool("debug", false, "Enable debugging outputs"),
+		jsonOutput:   flags.Bool("json", false, "Output JSON"),
+	}
+}
+
+// Parse flags & open the instance. Arguments are in f.flags.Args()
+func (f *instanceFlags) open(args []string) (*govarnam.Varnam, error) {
+	f.flags.Parse(args)
+
+	if *f.learningsDir != "" {
+		govarnam.SetLearningsDir(*f.learningsDir)
+	}
+
+	var (
+		varnam *govarnam.Varnam
+		err    error
+	)
+
+	if *f.vstPath != "" {
+		learningsPath := filepath.Join(*f.learningsDir, filepath.Base(*f.vstPath)+".learnings")
+		if *f.learningsDir == "" {
+			learningsPath = *f.vstPath + ".learnings"
+		}
+		varnam, err = govarnam.Init(*f.vstPath, learningsPath)
+	} else if *f.scheme != "" {
+		varnam, err = govarnam.InitFromID(*f.scheme)
+	} else {
+		return nil, fmt.Errorf("Specify a scheme ID with -s or a VST with -vst")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	varnam.Debug = *f.debug
+
+	return varnam, nil
+}
+
+// Print as JSON or with format for each suggestion
+func (f *instanceFlags) print(value interface{}) {
+	if *f.jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(value)
+		return
+	}
+
+	if sugs, ok := value.([]govarnam.Suggestion); ok {
+		printSugs(sugs)
+	} else {
+		fmt.Println(value)
+	}
+}
+
+func printSugs(sugs []govarnam.Suggestion) {
+	for _, sug := range sugs {
+		if sug.LearnedOn == 0 {
+			fmt.Println(sug.Word + " " + fmt.Sprint(sug.Weight))
+		} else {
+			fmt.Println(sug.Word + " " + fmt.Sprint(sug.Weight) + " " + time.Unix(int64(sug.LearnedOn), 0).String())
+		}
+	}
+}
+
+func requireArgs(flags *flag.FlagSet, count int) error {
+	if flags.NArg() < count {
+		flags.Usage()
+		return fmt.Errorf("Expected %d arguments, got %d", count, flags.NArg())
+	}
+	return nil
+}
+
+func transliterateCommand(args []string) error {
+	f := newInstanceFlags("transliterate")
+	advanced := f.flags.Bool("advanced", false, "Show transliteration result in advanced mode")
+	greedy := f.flags.Bool("greedy", false, "Show only greedy tokenized output. Doesn't look up learnings")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if err := requireArgs(f.flags, 1); err != nil {
+		return err
+	}
+
+	for _, word := range f.flags.Args() {
+		if f.flags.NArg() > 1 && !*f.jsonOutput {
+			fmt.Println("# " + word)
+		}
+
+		if *greedy {
+			f.print(varnam.TransliterateGreedyTokenized(word))
+		} else if *advanced {
+			result := varnam.TransliterateAdvanced(word)
+			if *f.jsonOutput {
+				f.print(result)
+				continue
+			}
+
+			fmt.Println("Greedy Tokenized")
+			printSugs(result.GreedyTokenized)
+
+			fmt.Println("Exact Words")
+			printSugs(result.ExactWords)
+
+			fmt.Println("Exact Matches")
+			printSugs(result.ExactMatches)
+
+			fmt.Println("Dictionary Suggestions")
+			printSugs(result.DictionarySuggestions)
+
+			fmt.Println("Pattern Dictionary Suggestions")
+			printSugs(result.PatternDictionarySuggestions)
+
+			fmt.Println("Tokenizer Suggestions")
+			printSugs(result.TokenizerSuggestions)
+		} else {
+			f.print(varnam.Transliterate(word))
+		}
+	}
+
+	return nil
+}
+
+func reverseCommand(args []string) error {
+	f := newInstanceFlags("reverse")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if err := requireArgs(f.flags, 1); err != nil {
+		return err
+	}
+
+	for _, word := range f.flags.Args() {
+		sugs, err := varnam.ReverseTransliterate(word)
+		if err != nil {
+			return err
+		}
+		f.print(sugs)
+	}
+
+	return nil
+}
+
+func printLearnStatus(f *instanceFlags, action string, status govarnam.LearnStatus) {
+	if *f.jsonOutput {
+		f.print(status)
+	} else {
+		fmt.Printf("Finished %s. Total words: %d. Failed: %d\n", action, status.TotalWords, status.FailedWords)
+	}
+}
+
+func learnCommand(args []string) error {
+	f := newInstanceFlags("learn")
+	weight := f.flags.Int("weight", 0, "Weight of the words. 0 for default")
+	file := f.flags.String("file", "", "Learn all words in a text file")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if *file != "" {
+		status, err := varnam.LearnFromFile(*file)
+		if err != nil {
+			return err
+		}
+		printLearnStatus(f, "learning from file", status)
+		return nil
+	}
+
+	if err := requireArgs(f.flags, 1); err != nil {
+		return err
+	}
+
+	for _, word := range f.flags.Args() {
+		if err := varnam.Learn(word, *weight); err != nil {
+			return fmt.Errorf("Couldn't learn %s: %s", word, err.Error())
+		}
+		if !*f.jsonOutput {
+			fmt.Printf("Learnt %s\n", word)
+		}
+	}
+
+	return nil
+}
+
+func trainCommand(args []string) error {
+	f := newInstanceFlags("train")
+	file := f.flags.String("file", "", "Train pattern => word pairs in a file")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if *file != "" {
+		status, err := varnam.TrainFromFile(*file)
+		if err != nil {
+			return err
+		}
+		printLearnStatus(f, "training from file", status)
+		return nil
+	}
+
+	if err := requireArgs(f.flags, 2); err != nil {
+		return err
+	}
+
+	pattern := f.flags.Arg(0)
+	word := f.flags.Arg(1)
+
+	if err := varnam.Train(pattern, word); err != nil {
+		return err
+	}
+	if !*f.jsonOutput {
+		fmt.Printf("Trained %s => %s\n", pattern, word)
+	}
+
+	return nil
+}
+
+func unlearnCommand(args []string) error {
+	f := newInstanceFlags("unlearn")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if err := requireArgs(f.flags, 1); err != nil {
+		return err
+	}
+
+	for _, word := range f.flags.Args() {
+		if err := varnam.Unlearn(word); err != nil {
+			return fmt.Errorf("Couldn't unlearn %s: %s", word, err.Error())
+		}
+		if !*f.jsonOutput {
+			fmt.Printf("Unlearnt %s\n", word)
+		}
+	}
+
+	return nil
+}
+
+// Flags filtering the words exported/imported
+type learningsFilterFlags struct {
+	minConfidence *float64
+	learnedAfter  *string
+	domain        *string
+}
+
+func newLearningsFilterFlags(flags *flag.FlagSet, domainUsage string) learningsFilterFlags {
+	return learningsFilterFlags{
+		minConfidence: flags.Float64("min-confidence", 0, "Only words with at least this score (0.5 - 1). Words learnt more score more"),
+		learnedAfter:  flags.String("learned-after", "", "Only words learnt after this date (YYYY-MM-DD)"),
+		domain:        flags.String("domain", "", domainUsage),
+	}
+}
+
+func (lf learningsFilterFlags) learnedAfterTime() (time.Time, error) {
+	if *lf.learnedAfter == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", *lf.learnedAfter, time.Local)
+	if err != nil {
+		return t, fmt.Errorf("Invalid -learned-after date %q, should be YYYY-MM-DD", *lf.learnedAfter)
+	}
+	return t, nil
+}
+
+// Progress bar on stderr, only when it's a terminal so that
+// logs & redirected output don't fill with it
+func progressBar(label string) func(done int, total int) {
+	if stat, err := os.Stderr.Stat(); err != nil || stat.Mode()&os.ModeCharDevice == 0 {
+		return func(done int, total int) {}
+	}
+
+	const width = 30
+
+	return func(done int, total int) {
+		filled := width
+		if total > 0 {
+			filled = done * width / total
+		}
+		fmt.Fprintf(os.Stderr, "\r%s [%s%s] %d/%d", label, strings.Repeat("#", filled), strings.Repeat(" ", width-filled), done, total)
+		if done >= total {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+}
+
+func exportCommand(args []string) error {
+	f := newInstanceFlags("export")
+	wordsPerFile := f.flags.Int("words-per-file", 30000, "Words per export file")
+	filters := newLearningsFilterFlags(f.flags, "Only words in this domain")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if err := requireArgs(f.flags, 1); err != nil {
+		return err
+	}
+
+	learnedAfter, err := filters.learnedAfterTime()
+	if err != nil {
+		return err
+	}
+
+	err = varnam.ExportWithOptions(f.flags.Arg(0), govarnam.ExportOptions{
+		WordsPerFile:  *wordsPerFile,
+		MinConfidence: *filters.minConfidence,
+		LearnedAfter:  learnedAfter,
+		Domain:        *filters.domain,
+		Progress:      progressBar("Exporting"),
+	})
+	if err != nil {
+		return err
+	}
+	if !*f.jsonOutput {
+		fmt.Println("Finished exporting to file")
+	}
+
+	return nil
+}
+
+func importCommand(args []string) error {
+	f := newInstanceFlags("import")
+	filters := newLearningsFilterFlags(f.flags, "Put imported words in this domain")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	if err := requireArgs(f.flags, 1); err != nil {
+		return err
+	}
+
+	learnedAfter, err := filters.learnedAfterTime()
+	if err != nil {
+		return err
+	}
+
+	for _, arg := range f.flags.Args() {
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("No files match %s", arg)
+		}
+
+		for _, match := range matches {
+			err := varnam.ImportWithOptions(match, govarnam.ImportOptions{
+				MinConfidence: *filters.minConfidence,
+				LearnedAfter:  learnedAfter,
+				Domain:        *filters.domain,
+				Progress:      progressBar("Importing " + filepath.Base(match)),
+			})
+			if err != nil {
+				return fmt.Errorf("Couldn't import %s: %s", match, err.Error())
+			}
+			if !*f.jsonOutput {
+				fmt.Printf("Finished importing from file %s\n", match)
+			}
+		}
+	}
+
+	return nil
+}
+
+func compileSchemeCommand(args []string) error {
+	flags := flag.NewFlagSet("compile-scheme", flag.ExitOnError)
+	output := flags.String("o", "", "Output VST file. Defaults to source path with .vst extension")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: varnamcli compile-scheme %s\n\n", commands["compile-scheme"].usage)
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if err := requireArgs(flags, 1); err != nil {
+		return err
+	}
+
+	src := flags.Arg(0)
+	if *output == "" {
+		*output = strings.TrimSuffix(src, filepath.Ext(src)) + ".vst"
+	}
+
+	if err := schemecompile.CompileFile(src, *output); err != nil {
+		return err
+	}
+	fmt.Println("Compiled " + *output)
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/socket"
+)
+
+func pipeCommand(args []string) error {
+	f := newInstanceFlags("pipe")
+
+	varnam, err := f.open(args)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	return pipe(varnam, os.Stdin, os.Stdout)
+}
+
+// Each line of in is a word or a JSON request of server/socket.
+// A word gives a TransliterationResult line, a request gives a
+// Response line. Output is flushed after each line so that the
+// other end can wait for it
+func pipe(varnam *govarnam.Varnam, in io.Reader, out io.Writer) error {
+	ctx := context.Background()
+	schemeID := varnam.SchemeDetails.Identifier
+
+	// Requests are only for the opened scheme
+	server := socket.NewServer()
+	server.Init = func(id string) (*govarnam.Varnam, error) {
+		if id != schemeID {
+			return nil, fmt.Errorf("Only scheme %q is opened", schemeID)
+		}
+		return varnam, nil
+	}
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 4096), 1<<20)
+
+	writer := bufio.NewWriter(out)
+	encoder := json.NewEncoder(writer)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		var value interface{}
+
+		if strings.HasPrefix(line, "{") {
+			var req socket.Request
+			if err := json.Unmarshal([]byte(line), &req); err != nil {
+				value = socket.Response{Error: fmt.Sprintf("Invalid request: %s", err)}
+			} else {
+				if req.Scheme == "" {
+					req.Scheme = schemeID
+				}
+				value = server.Handle(ctx, req)
+			}
+		} else {
+			value = varnam.TransliterateAdvanced(line)
+		}
+
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
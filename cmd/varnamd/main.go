@@ -0,0 +1,115 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+varnamd serves govarnam on a Unix socket with newline-delimited JSON,
+for local clients to share one instance :
+
+	go run github.com/varnamproject/govarnam/cmd/varnamd
+
+	echo '{"id":1,"method":"transliterate","scheme":"ml","word":"malayalam"}' | nc -U $XDG_RUNTIME_DIR/varnam.sock
+
+See package server/socket for the protocol.
+
+On SIGTERM or interrupt, it stops accepting connections, lets requests
+being answered finish (for up to -shutdown-timeout) and closes learnings
+after checkpointing them.
+*/
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/socket"
+)
+
+func defaultSocketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "varnam.sock")
+}
+
+func main() {
+	socketFlag := flag.String("socket", defaultSocketPath(), "Unix socket path to listen on")
+	readOnlyFlag := flag.Bool("read-only", false, "Disable learning, training & unlearning")
+	vstDirFlag := flag.String("vst-dir", "", "Directory to look for VSTs in")
+	learningsDirFlag := flag.String("learnings-dir", "", "Directory to keep learnings in")
+	shutdownTimeoutFlag := flag.Duration("shutdown-timeout", 10*time.Second, "Time requests being answered get to finish on SIGTERM or interrupt")
+
+	flag.Parse()
+
+	if *vstDirFlag != "" {
+		govarnam.SetVSTLookupDir(*vstDirFlag)
+	}
+	if *learningsDirFlag != "" {
+		govarnam.SetLearningsDir(*learningsDirFlag)
+	}
+
+	// A socket left by a daemon that didn't exit cleanly
+	if conn, err := net.Dial("unix", *socketFlag); err == nil {
+		conn.Close()
+		log.Fatalf("Another daemon is listening on %s", *socketFlag)
+	}
+	os.Remove(*socketFlag)
+
+	listener, err := net.Listen("unix", *socketFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Only the user should be able to talk to it, learnings are private
+	if err := os.Chmod(*socketFlag, 0600); err != nil {
+		listener.Close()
+		log.Fatal(err)
+	}
+
+	server := socket.NewServer()
+	server.ReadOnly = *readOnlyFlag
+
+	shutdownDone := make(chan struct{})
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		log.Print("Shutting down")
+
+		ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeoutFlag)
+		defer cancel()
+
+		// Closes listener, which removes the socket file too. Requests
+		// being answered, learnings too, are let to finish
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("Requests didn't finish in %v: %v", *shutdownTimeoutFlag, err)
+		}
+		close(shutdownDone)
+	}()
+
+	log.Printf("Listening on %s", *socketFlag)
+	err = server.Serve(listener)
+	if err != nil {
+		server.Close()
+		log.Fatal(err)
+	}
+	<-shutdownDone
+
+	// Already closed if requests finished in time
+	if err := server.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+varnamdbus serves govarnam on the session bus as org.varnam.Engine,
+for desktop components to share one instance :
+
+	go run github.com/varnamproject/govarnam/cmd/varnamdbus
+
+	gdbus call --session --dest org.varnam.Engine --object-path /org/varnam/Engine --method org.varnam.Engine.Transliterate ml malayalam
+
+See server/dbus/org.varnam.Engine.xml for the interface.
+
+On SIGTERM or interrupt, it leaves the bus and closes learnings after
+checkpointing them.
+*/
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/varnamproject/govarnam/govarnam"
+	varnamdbus "github.com/varnamproject/govarnam/server/dbus"
+)
+
+func main() {
+	readOnlyFlag := flag.Bool("read-only", false, "Disable learning, training & unlearning")
+	vstDirFlag := flag.String("vst-dir", "", "Directory to look for VSTs in")
+	learningsDirFlag := flag.String("learnings-dir", "", "Directory to keep learnings in")
+
+	flag.Parse()
+
+	if *vstDirFlag != "" {
+		govarnam.SetVSTLookupDir(*vstDirFlag)
+	}
+	if *learningsDirFlag != "" {
+		govarnam.SetLearningsDir(*learningsDirFlag)
+	}
+
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	service := varnamdbus.NewService()
+	service.ReadOnly = *readOnlyFlag
+
+	if err := service.Serve(conn); err != nil {
+		conn.Close()
+		log.Fatal(err)
+	}
+
+	log.Printf("Serving %s on the session bus", varnamdbus.BusName)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	log.Print("Shutting down")
+
+	// Leaves the bus, no calls come after this
+	conn.Close()
+
+	if err := service.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+varnamgrpc serves govarnam over gRPC :
+
+	go run github.com/varnamproject/govarnam/cmd/varnamgrpc -listen localhost:50051
+
+See server/grpc/varnam.proto for the API.
+
+To keep learnings of each user apart, identify users by metadata set
+by an authenticating proxy in front :
+
+	varnamgrpc -user-metadata x-varnam-user -users-dir /var/lib/varnam/users
+
+On SIGTERM or interrupt, it stops accepting connections, lets RPCs
+being answered finish and closes learnings after checkpointing them.
+*/
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	varnamgrpc "github.com/varnamproject/govarnam/server/grpc"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	listenFlag := flag.String("listen", "localhost:50051", "Address to listen on")
+	readOnlyFlag := flag.Bool("read-only", false, "Disable learning, training, unlearning & importing")
+	vstDirFlag := flag.String("vst-dir", "", "Directory to look for VSTs in")
+	learningsDirFlag := flag.String("learnings-dir", "", "Directory to keep learnings in")
+	userMetadataFlag := flag.String("user-metadata", "", "Keep learnings of each user apart, identifying users by this metadata key")
+	usersDirFlag := flag.String("users-dir", "", "Directory to keep learnings of each user in, with -user-metadata")
+	maxUserInstancesFlag := flag.Int("max-user-instances", varnamgrpc.DefaultMaxUserInstances, "Most user learnings kept open at a time")
+
+	flag.Parse()
+
+	if *vstDirFlag != "" {
+		govarnam.SetVSTLookupDir(*vstDirFlag)
+	}
+	if *learningsDirFlag != "" {
+		govarnam.SetLearningsDir(*learningsDirFlag)
+	}
+
+	listener, err := net.Listen("tcp", *listenFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	server := varnamgrpc.NewServer()
+	server.ReadOnly = *readOnlyFlag
+	server.MaxUserInstances = *maxUserInstancesFlag
+
+	if *userMetadataFlag != "" {
+		if *usersDirFlag == "" {
+			log.Fatal("-users-dir is required with -user-metadata")
+		}
+		server.User = varnamgrpc.UserFromMetadata(*userMetadataFlag)
+		server.UsersDir = *usersDirFlag
+	}
+
+	s := grpc.NewServer()
+	varnamgrpc.RegisterVarnamServer(s, server)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		log.Print("Shutting down")
+
+		// Serve returns after RPCs being answered finish
+		s.GracefulStop()
+	}()
+
+	log.Printf("Listening on %s", listener.Addr())
+	err = s.Serve(listener)
+
+	if closeErr := server.Close(); closeErr != nil {
+		log.Print(closeErr)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+varnamhost is a native messaging host, for browser extensions to
+transliterate text with govarnam and the user's learnings. See package
+server/nativemsg for the messages.
+
+Browsers find the host from a manifest. Print one for the extension
+and put it where the browser looks for them :
+
+	varnamhost manifest chrome chrome-extension://<extension ID>/ > ~/.config/google-chrome/NativeMessagingHosts/org.varnamproject.varnam.json
+	varnamhost manifest firefox <extension ID> > ~/.mozilla/native-messaging-hosts/org.varnamproject.varnam.json
+
+The extension can then talk to it :
+
+	const port = browser.runtime.connectNative("org.varnamproject.varnam")
+	port.onMessage.addListener((response) => console.log(response.result))
+	port.postMessage({id: 1, method: "transliterate_sentence", scheme: "ml", text: "ente peru"})
+
+VSTs & learnings are looked for where other govarnam programs look, so
+that words learnt in the browser are suggested elsewhere too.
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/varnamproject/govarnam/server/nativemsg"
+	"github.com/varnamproject/govarnam/server/socket"
+)
+
+// Name of the host in manifests & connectNative()
+const hostName = "org.varnamproject.varnam"
+
+type manifest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Path        string `json:"path"`
+	Type        string `json:"type"`
+
+	// Chrome
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+
+	// Firefox
+	AllowedExtensions []string `json:"allowed_extensions,omitempty"`
+}
+
+// Print manifest of browser for extensions
+func printManifest(browser string, extensions []string) error {
+	if len(extensions) == 0 {
+		return fmt.Errorf("Give the extensions allowed to connect")
+	}
+
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	m := manifest{
+		Name:        hostName,
+		Description: "Varnam transliteration",
+		Path:        path,
+		Type:        "stdio",
+	}
+
+	switch browser {
+	case "chrome", "chromium":
+		m.AllowedOrigins = extensions
+	case "firefox":
+		m.AllowedExtensions = extensions
+	default:
+		return fmt.Errorf("Unknown browser %q, should be chrome or firefox", browser)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(m)
+}
+
+func main() {
+	if len(os.Args) >= 3 && os.Args[1] == "manifest" {
+		if err := printManifest(os.Args[2], os.Args[3:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Browsers start the host with the extension's origin or
+	// manifest path as arguments, they aren't needed
+
+	// stdout is for messages. Anything else printed goes to stderr,
+	// browsers show it in their logs
+	out := os.Stdout
+	os.Stdout = os.Stderr
+
+	server := socket.NewServer()
+	err := nativemsg.Serve(context.Background(), server, os.Stdin, out)
+
+	server.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/rest"
+)
+
+// Settings that can be changed without restarting, in the JSON file
+// given with -config. Settings not in the file are taken from flags
+type config struct {
+	// Schemes served. All are if empty
+	Schemes []string `json:"schemes,omitempty"`
+
+	// Like -rate-limit, "requests per second[:burst]"
+	RateLimit            string `json:"rate_limit,omitempty"`
+	ClientRateLimit      string `json:"client_rate_limit,omitempty"`
+	ClientWriteRateLimit string `json:"client_write_rate_limit,omitempty"`
+
+	MaxUserInstances int `json:"max_user_instances,omitempty"`
+
+	// "confidence" (default) or "recency"
+	Ranking string `json:"ranking,omitempty"`
+
+	// govarnam's defaults if 0
+	DictionarySuggestionsLimit        int `json:"dictionary_suggestions_limit,omitempty"`
+	PatternDictionarySuggestionsLimit int `json:"pattern_dictionary_suggestions_limit,omitempty"`
+	TokenizerSuggestionsLimit         int `json:"tokenizer_suggestions_limit,omitempty"`
+}
+
+// Read config file at path, over settings of flags
+func readConfig(path string, flags config) (config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return flags, err
+	}
+
+	// Fields not in the file are left as they are
+	cfg := flags
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return flags, fmt.Errorf("%s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// Read config file again and change settings of handler. Instances
+// are kept open
+func reloadConfig(handler *rest.Handler, path string, flags config) error {
+	cfg, err := readConfig(path, flags)
+	if err != nil {
+		return err
+	}
+	return handler.Reload(cfg.apply)
+}
+
+// Set handler's settings. Nothing is changed on error
+func (cfg config) apply(handler *rest.Handler) error {
+	globalRateLimit, err := parseRateLimit(cfg.RateLimit)
+	if err != nil {
+		return err
+	}
+	clientRateLimit, err := parseRateLimit(cfg.ClientRateLimit)
+	if err != nil {
+		return err
+	}
+	clientWriteRateLimit, err := parseRateLimit(cfg.ClientWriteRateLimit)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Ranking != "" && cfg.Ranking != "confidence" && cfg.Ranking != "recency" {
+		return fmt.Errorf("Unknown ranking %q, should be confidence or recency", cfg.Ranking)
+	}
+
+	handler.Schemes = cfg.Schemes
+	handler.GlobalRateLimit = globalRateLimit
+	handler.ClientRateLimit = clientRateLimit
+	handler.ClientWriteRateLimit = clientWriteRateLimit
+	handler.MaxUserInstances = cfg.MaxUserInstances
+	handler.Configure = cfg.configure
+
+	return nil
+}
+
+// Set options of an instance
+func (cfg config) configure(varnam *govarnam.Varnam) {
+	varnam.DictionarySuggestionsLimit = orDefault(cfg.DictionarySuggestionsLimit, govarnam.VARNAM_DICTIONARY_SUGGESTIONS_LIMIT)
+	varnam.PatternDictionarySuggestionsLimit = orDefault(cfg.PatternDictionarySuggestionsLimit, govarnam.VARNAM_PATTERN_DICTIONARY_SUGGESTIONS_LIMIT)
+	varnam.TokenizerSuggestionsLimit = orDefault(cfg.TokenizerSuggestionsLimit, govarnam.VARNAM_TOKENIZER_SUGGESTIONS_LIMIT)
+
+	if cfg.Ranking == "recency" {
+		varnam.Ranker = govarnam.NewRecencyBlendedRanker()
+	} else {
+		varnam.Ranker = govarnam.ConfidenceFirstRanker{}
+	}
+}
+
+func orDefault(value int, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
+	}
+	return value
+}
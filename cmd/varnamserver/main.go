@@ -0,0 +1,355 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+varnamserver serves govarnam over HTTP with JSON :
+
+	go run github.com/varnamproject/govarnam/cmd/varnamserver -addr 127.0.0.1:8123
+
+	curl http://127.0.0.1:8123/tl/ml/namaskaaram
+
+See package server/rest for the endpoints.
+
+To keep learnings of each user apart, identify users by tokens in a
+file with lines of "token userID" (sent as Authorization: Bearer token) :
+
+	varnamserver -tokens-file tokens.txt -users-dir /var/lib/varnam/users
+
+or by a header set by an authenticating proxy in front :
+
+	varnamserver -user-header X-Varnam-User -users-dir /var/lib/varnam/users
+
+Before exposing the server publicly, require API keys for learning with
+a file having one key per line, sent by clients in X-API-Key header :
+
+	varnamserver -api-keys-file keys.txt
+
+Serve HTTPS and allow web pages of other origins to call it from
+browsers, without a reverse proxy :
+
+	varnamserver -addr :8443 -tls-cert cert.pem -tls-key key.pem -cors-origins https://example.com
+
+/healthz is for liveness & readiness probes of systemd or Kubernetes.
+It gives 503 if a scheme in -health-schemes or learnings can't be used :
+
+	varnamserver -health-schemes ml,hi
+	curl -f http://127.0.0.1:8123/healthz
+
+Rate limits are given as "requests per second[:burst]". Clients are
+told to retry later with 429 when they go over the limit :
+
+	varnamserver -client-rate-limit 20:40 -client-write-rate-limit 1:10 -rate-limit 500
+
+Metrics of requests, transliteration latency & learning are served at
+/metrics for Prometheus with -metrics. Like /status, it needs an API
+key if -api-keys-file is given :
+
+	varnamserver -metrics
+
+Settings can be changed without restarting & losing warm instances
+by keeping them in a JSON file given with -config. The file is read
+again on SIGHUP. Settings not in the file are taken from flags :
+
+	{
+		"schemes": ["ml", "hi"],
+		"rate_limit": "500",
+		"client_rate_limit": "20:40",
+		"client_write_rate_limit": "1:10",
+		"max_user_instances": 64,
+		"ranking": "recency",
+		"dictionary_suggestions_limit": 5,
+		"pattern_dictionary_suggestions_limit": 5,
+		"tokenizer_suggestions_limit": 10
+	}
+
+	varnamserver -config varnamserver.json
+	kill -HUP $(pidof varnamserver)
+
+On SIGTERM or interrupt, it stops accepting connections, lets requests
+being served finish (for up to -shutdown-timeout) and closes learnings
+after checkpointing them.
+*/
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/rest"
+)
+
+func main() {
+	addrFlag := flag.String("addr", "127.0.0.1:8123", "Address to listen on")
+	readOnlyFlag := flag.Bool("read-only", false, "Disable learning, training & unlearning")
+	vstDirFlag := flag.String("vst-dir", "", "Directory to look for VSTs in")
+	learningsDirFlag := flag.String("learnings-dir", "", "Directory to keep learnings in")
+	userHeaderFlag := flag.String("user-header", "", "Keep learnings of each user apart, identifying users by this header")
+	tokensFileFlag := flag.String("tokens-file", "", "Keep learnings of each user apart, identifying users by tokens in this file. Each line is \"token userID\"")
+	usersDirFlag := flag.String("users-dir", "", "Directory to keep learnings of each user in, with -user-header or -tokens-file")
+	apiKeysFileFlag := flag.String("api-keys-file", "", "Require one of the API keys in this file, one per line, for learning, training & unlearning")
+	maxUserInstancesFlag := flag.Int("max-user-instances", rest.DefaultMaxUserInstances, "Most user learnings kept open at a time")
+	tlsCertFlag := flag.String("tls-cert", "", "Serve HTTPS with this certificate (PEM). Needs -tls-key")
+	tlsKeyFlag := flag.String("tls-key", "", "Private key (PEM) of -tls-cert")
+	corsOriginsFlag := flag.String("cors-origins", "", "Comma separated origins allowed to call from browsers, like https://example.com. * for any")
+	rateLimitFlag := flag.String("rate-limit", "", "Limit of all requests together, as \"requests per second[:burst]\"")
+	clientRateLimitFlag := flag.String("client-rate-limit", "", "Limit of requests from each client, as \"requests per second[:burst]\"")
+	clientWriteRateLimitFlag := flag.String("client-write-rate-limit", "", "Limit of learning, training & unlearning by each client, as \"requests per second[:burst]\"")
+	clientIPHeaderFlag := flag.String("client-ip-header", "", "Identify clients for rate limits by IP in this header set by a proxy in front, like X-Real-IP")
+	healthSchemesFlag := flag.String("health-schemes", "", "Comma separated scheme IDs /healthz makes sure can be used, for readiness probes")
+	metricsFlag := flag.Bool("metrics", false, "Serve Prometheus metrics at /metrics")
+	configFlag := flag.String("config", "", "JSON file of settings that are read again on SIGHUP, like schemes, rate limits & ranking. See package docs")
+	shutdownTimeoutFlag := flag.Duration("shutdown-timeout", 10*time.Second, "Time requests being served get to finish on SIGTERM or interrupt")
+	corsHeadersFlag := flag.String("cors-headers", "", "Comma separated request headers allowed from browsers, in addition to Content-Type, Authorization & X-API-Key")
+
+	flag.Parse()
+
+	if *vstDirFlag != "" {
+		govarnam.SetVSTLookupDir(*vstDirFlag)
+	}
+	if *learningsDirFlag != "" {
+		govarnam.SetLearningsDir(*learningsDirFlag)
+	}
+
+	handler := rest.NewHandler()
+	handler.ReadOnly = *readOnlyFlag
+	handler.Metrics = *metricsFlag
+
+	if *apiKeysFileFlag != "" {
+		keys, err := readAPIKeys(*apiKeysFileFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		handler.WriteAuth = rest.APIKeys(keys...)
+	}
+
+	if *userHeaderFlag != "" && *tokensFileFlag != "" {
+		log.Fatal("Use only one of -user-header and -tokens-file")
+	}
+	if *userHeaderFlag != "" {
+		handler.User = rest.UserFromHeader(*userHeaderFlag)
+	}
+	if *tokensFileFlag != "" {
+		tokens, err := readTokens(*tokensFileFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		handler.User = rest.UserFromToken(tokens)
+	}
+	if handler.User != nil {
+		if *usersDirFlag == "" {
+			log.Fatal("-users-dir is required with -user-header or -tokens-file")
+		}
+		handler.UsersDir = *usersDirFlag
+	}
+
+	handler.HealthCheckSchemes = splitList(*healthSchemesFlag)
+	handler.AllowedOrigins = splitList(*corsOriginsFlag)
+	handler.AllowedHeaders = splitList(*corsHeadersFlag)
+	if *userHeaderFlag != "" {
+		handler.AllowedHeaders = append(handler.AllowedHeaders, *userHeaderFlag)
+	}
+
+	flagsConfig := config{
+		RateLimit:            *rateLimitFlag,
+		ClientRateLimit:      *clientRateLimitFlag,
+		ClientWriteRateLimit: *clientWriteRateLimitFlag,
+		MaxUserInstances:     *maxUserInstancesFlag,
+	}
+
+	cfg := flagsConfig
+	var err error
+	if *configFlag != "" {
+		if cfg, err = readConfig(*configFlag, flagsConfig); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err = cfg.apply(handler); err != nil {
+		log.Fatal(err)
+	}
+
+	if *configFlag != "" {
+		hangups := make(chan os.Signal, 1)
+		signal.Notify(hangups, syscall.SIGHUP)
+		go func() {
+			for range hangups {
+				if err := reloadConfig(handler, *configFlag, flagsConfig); err != nil {
+					log.Printf("Couldn't reload config: %v", err)
+				} else {
+					log.Printf("Reloaded %s", *configFlag)
+				}
+			}
+		}()
+	}
+	if *clientIPHeaderFlag != "" {
+		header := *clientIPHeaderFlag
+		handler.ClientID = func(r *http.Request) string {
+			if ip := r.Header.Get(header); ip != "" {
+				return ip
+			}
+			host, _, _ := net.SplitHostPort(r.RemoteAddr)
+			return host
+		}
+	}
+
+	if (*tlsCertFlag == "") != (*tlsKeyFlag == "") {
+		log.Fatal("Both -tls-cert and -tls-key are needed for HTTPS")
+	}
+
+	server := &http.Server{
+		Addr:    *addrFlag,
+		Handler: handler,
+	}
+
+	// Event streams last till clients close them, Shutdown would wait for them
+	server.RegisterOnShutdown(handler.CloseStreams)
+
+	shutdownDone := make(chan struct{})
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		log.Print("Shutting down")
+
+		ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeoutFlag)
+		defer cancel()
+
+		// Requests being served, learnings too, are let to finish
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("Requests didn't finish in %v: %v", *shutdownTimeoutFlag, err)
+		}
+		close(shutdownDone)
+	}()
+
+	if *tlsCertFlag != "" {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+		log.Printf("Listening on %s (HTTPS)", *addrFlag)
+		err = server.ListenAndServeTLS(*tlsCertFlag, *tlsKeyFlag)
+	} else {
+		log.Printf("Listening on %s", *addrFlag)
+		err = server.ListenAndServe()
+	}
+
+	if err != http.ErrServerClosed {
+		handler.Close()
+		log.Fatal(err)
+	}
+	<-shutdownDone
+
+	// Checkpoints learnings so that WALs aren't left behind
+	if err := handler.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Parse "requests per second[:burst]". Burst is the rate rounded up
+// if not given. Empty is no limit
+func parseRateLimit(value string) (rest.RateLimit, error) {
+	var limit rest.RateLimit
+	if value == "" {
+		return limit, nil
+	}
+
+	parts := strings.SplitN(value, ":", 2)
+
+	perSecond, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil || perSecond <= 0 {
+		return limit, fmt.Errorf("Invalid rate limit %q, should be like 10 or 10:20", value)
+	}
+	limit.PerSecond = perSecond
+	limit.Burst = int(math.Ceil(perSecond))
+
+	if len(parts) == 2 {
+		limit.Burst, err = strconv.Atoi(parts[1])
+		if err != nil || limit.Burst < 1 {
+			return limit, fmt.Errorf("Invalid burst in rate limit %q", value)
+		}
+	}
+
+	return limit, nil
+}
+
+// Split a comma separated list, leaving out empty items
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Read a key per line. Empty lines & lines starting with # are skipped
+func readAPIKeys(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var keys []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("No API keys in %s", path)
+	}
+	return keys, nil
+}
+
+// Read lines of "token userID". Empty lines & lines starting with # are skipped
+func readTokens(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tokens := map[string]string{}
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: should be \"token userID\"", path, lineNumber)
+		}
+		tokens[fields[0]] = fields[1]
+	}
+
+	return tokens, scanner.Err()
+}
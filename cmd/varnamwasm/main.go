@@ -0,0 +1,127 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+varnamwasm is govarnam for browsers. Build it with :
+
+	GOOS=js GOARCH=wasm go build -o varnam.wasm github.com/varnamproject/govarnam/cmd/varnamwasm
+
+and use it with varnam.js in this folder, which loads sql.js, the
+VST and learnings saved in IndexedDB. See varnam.js for usage.
+
+Functions are set on globalThis.govarnam. Each gives an object
+with "result" or "error".
+*/
+
+import (
+	"syscall/js"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+var instances = map[int]*govarnam.Varnam{}
+var lastInstanceID int
+
+func makeResult(result interface{}, err error) interface{} {
+	if err != nil {
+		return map[string]interface{}{"error": err.Error()}
+	}
+	return map[string]interface{}{"result": result}
+}
+
+func suggestionsToJS(sugs []govarnam.Suggestion) []interface{} {
+	result := make([]interface{}, len(sugs))
+	for i, sug := range sugs {
+		result[i] = map[string]interface{}{
+			"word":      sug.Word,
+			"weight":    sug.Weight,
+			"learnedOn": sug.LearnedOn,
+			"score":     sug.Score,
+		}
+	}
+	return result
+}
+
+// Wraps a function taking an instance ID as the first argument
+func withInstance(fn func(varnam *govarnam.Varnam, args []js.Value) interface{}) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 {
+			return makeResult(nil, errMissingInstance)
+		}
+		varnam, found := instances[args[0].Int()]
+		if !found {
+			return makeResult(nil, errMissingInstance)
+		}
+		return fn(varnam, args[1:])
+	})
+}
+
+type jsError string
+
+func (err jsError) Error() string {
+	return string(err)
+}
+
+const errMissingInstance = jsError("Unknown varnam instance")
+
+func main() {
+	api := map[string]interface{}{
+		// init(vstName, learningsName) => instance ID
+		"init": js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			varnam, err := govarnam.Init(args[0].String(), args[1].String())
+			if err != nil {
+				return makeResult(nil, err)
+			}
+			lastInstanceID++
+			instances[lastInstanceID] = varnam
+			return makeResult(lastInstanceID, nil)
+		}),
+
+		"close": withInstance(func(varnam *govarnam.Varnam, args []js.Value) interface{} {
+			for id, instance := range instances {
+				if instance == varnam {
+					delete(instances, id)
+				}
+			}
+			return makeResult(nil, varnam.Close())
+		}),
+
+		"transliterate": withInstance(func(varnam *govarnam.Varnam, args []js.Value) interface{} {
+			return makeResult(suggestionsToJS(varnam.Transliterate(args[0].String())), nil)
+		}),
+
+		"transliterateGreedyTokenized": withInstance(func(varnam *govarnam.Varnam, args []js.Value) interface{} {
+			return makeResult(suggestionsToJS(varnam.TransliterateGreedyTokenized(args[0].String())), nil)
+		}),
+
+		"reverseTransliterate": withInstance(func(varnam *govarnam.Varnam, args []js.Value) interface{} {
+			sugs, err := varnam.ReverseTransliterate(args[0].String())
+			return makeResult(suggestionsToJS(sugs), err)
+		}),
+
+		"learn": withInstance(func(varnam *govarnam.Varnam, args []js.Value) interface{} {
+			return makeResult(nil, varnam.Learn(args[0].String(), args[1].Int()))
+		}),
+
+		"train": withInstance(func(varnam *govarnam.Varnam, args []js.Value) interface{} {
+			return makeResult(nil, varnam.Train(args[0].String(), args[1].String()))
+		}),
+
+		"unlearn": withInstance(func(varnam *govarnam.Varnam, args []js.Value) interface{} {
+			return makeResult(nil, varnam.Unlearn(args[0].String()))
+		}),
+	}
+
+	js.Global().Set("govarnam", js.ValueOf(api))
+
+	// Functions above are called from JS till the page is closed
+	select {}
+}
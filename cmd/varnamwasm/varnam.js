@@ -0,0 +1,138 @@
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+ * govarnam in browsers. Load sql.js (built with FTS5) and Go's
+ * wasm_exec.js before this :
+ *
+ *   const varnam = await Varnam.load({
+ *     wasm: "varnam.wasm",
+ *     vst: "ml.vst",
+ *     scheme: "ml",
+ *     sqlJs: { locateFile: (file) => `/sql.js/${file}` },
+ *   });
+ *
+ *   varnam.transliterate("malayalam"); // [{word, weight, learnedOn, score}, ...]
+ *   await varnam.learn("മലയാളം");
+ *
+ * Learnings are kept in IndexedDB, saved after every learn, train
+ * and unlearn.
+ */
+(function () {
+  const idbName = "govarnam";
+  const idbStore = "learnings";
+
+  let wasmLoading = null;
+
+  function loadWasm(url) {
+    if (!wasmLoading) {
+      wasmLoading = (async () => {
+        const go = new Go();
+        const { instance } = await WebAssembly.instantiateStreaming(fetch(url), go.importObject);
+
+        // Runs main() till it waits for calls, globalThis.govarnam
+        // is set by then. The promise returned is of main's exit
+        go.run(instance);
+      })();
+    }
+    return wasmLoading;
+  }
+
+  function openIDB() {
+    return new Promise((resolve, reject) => {
+      const request = indexedDB.open(idbName, 1);
+      request.onupgradeneeded = () => request.result.createObjectStore(idbStore);
+      request.onsuccess = () => resolve(request.result);
+      request.onerror = () => reject(request.error);
+    });
+  }
+
+  async function idbRequest(mode, fn) {
+    const db = await openIDB();
+    return new Promise((resolve, reject) => {
+      const request = fn(db.transaction(idbStore, mode).objectStore(idbStore));
+      request.onsuccess = () => resolve(request.result);
+      request.onerror = () => reject(request.error);
+    }).finally(() => db.close());
+  }
+
+  // Values from Go are {result} or {error}
+  function unwrap(value) {
+    if (value.error) {
+      throw new Error(value.error);
+    }
+    return value.result;
+  }
+
+  class Varnam {
+    constructor(id, scheme) {
+      this.id = id;
+      this.scheme = scheme;
+      this.learningsName = "learnings:" + scheme;
+    }
+
+    static async load({ wasm, vst, scheme, sqlJs }) {
+      const SQL = await initSqlJs(sqlJs);
+      globalThis.govarnamSQL = SQL;
+      globalThis.govarnamDatabases = globalThis.govarnamDatabases || {};
+
+      const vstResponse = await fetch(vst);
+      if (!vstResponse.ok) {
+        throw new Error(`Couldn't fetch VST ${vst}`);
+      }
+      const vstName = "vst:" + scheme;
+      globalThis.govarnamDatabases[vstName] = new SQL.Database(new Uint8Array(await vstResponse.arrayBuffer()));
+
+      const learningsName = "learnings:" + scheme;
+      const learnings = await idbRequest("readonly", (store) => store.get(scheme));
+      globalThis.govarnamDatabases[learningsName] = new SQL.Database(learnings);
+
+      await loadWasm(wasm);
+
+      return new Varnam(unwrap(govarnam.init(vstName, learningsName)), scheme);
+    }
+
+    transliterate(word) {
+      return unwrap(govarnam.transliterate(this.id, word));
+    }
+
+    // Only tokenizer output, fastest. For live preview
+    transliterateGreedyTokenized(word) {
+      return unwrap(govarnam.transliterateGreedyTokenized(this.id, word));
+    }
+
+    reverseTransliterate(word) {
+      return unwrap(govarnam.reverseTransliterate(this.id, word));
+    }
+
+    async learn(word, weight = 0) {
+      unwrap(govarnam.learn(this.id, word, weight));
+      await this.save();
+    }
+
+    async train(pattern, word) {
+      unwrap(govarnam.train(this.id, pattern, word));
+      await this.save();
+    }
+
+    async unlearn(word) {
+      unwrap(govarnam.unlearn(this.id, word));
+      await this.save();
+    }
+
+    // Save learnings to IndexedDB
+    save() {
+      const data = globalThis.govarnamDatabases[this.learningsName].export();
+      return idbRequest("readwrite", (store) => store.put(data, this.scheme));
+    }
+
+    close() {
+      unwrap(govarnam.close(this.id));
+    }
+  }
+
+  globalThis.Varnam = Varnam;
+})();
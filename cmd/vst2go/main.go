@@ -0,0 +1,123 @@
+package main
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+vst2go compiles a VST into Go source so that the scheme
+can be embedded into a binary. Use it with go:generate :
+
+	//go:generate go run github.com/varnamproject/govarnam/cmd/vst2go -vst schemes/ml.vst -o ml_scheme.go -pkg main -var MLScheme
+
+And then initialize with :
+
+	varnam, err := govarnam.InitEmbedded(MLScheme, dictPath)
+*/
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+func generate(vstPath string, pkg string, varName string) ([]byte, error) {
+	if _, err := os.Stat(vstPath); err != nil {
+		return nil, err
+	}
+
+	varnam := govarnam.Varnam{}
+	err := varnam.InitVST(vstPath)
+	if err != nil {
+		return nil, err
+	}
+	defer varnam.Close()
+
+	symbols, err := varnam.GetAllSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	stemRules, err := varnam.GetAllStemRules()
+	if err != nil {
+		return nil, err
+	}
+
+	sd := varnam.SchemeDetails
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by vst2go from %s. DO NOT EDIT.\n\n", filepath.Base(vstPath))
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import \"github.com/varnamproject/govarnam/govarnam\"\n\n")
+	fmt.Fprintf(&buf, "// %s is the %q scheme compiled into the binary\n", varName, sd.Identifier)
+	fmt.Fprintf(&buf, "var %s = govarnam.EmbeddedScheme{\n", varName)
+	fmt.Fprintf(&buf, "SchemeDetails: govarnam.SchemeDetails{\n")
+	fmt.Fprintf(&buf, "Identifier: %q,\n", sd.Identifier)
+	fmt.Fprintf(&buf, "LangCode: %q,\n", sd.LangCode)
+	fmt.Fprintf(&buf, "DisplayName: %q,\n", sd.DisplayName)
+	fmt.Fprintf(&buf, "Author: %q,\n", sd.Author)
+	fmt.Fprintf(&buf, "CompiledDate: %q,\n", sd.CompiledDate)
+	fmt.Fprintf(&buf, "IsStable: %t,\n", sd.IsStable)
+	fmt.Fprintf(&buf, "NativeDisplayName: %q,\n", sd.NativeDisplayName)
+	fmt.Fprintf(&buf, "Version: %q,\n", sd.Version)
+	fmt.Fprintf(&buf, "MinLibraryVersion: %q,\n", sd.MinLibraryVersion)
+	// Symbols & stem rules of base are already in the variant's
+	fmt.Fprintf(&buf, "Base: %q,\n", sd.Base)
+	fmt.Fprintf(&buf, "EndOfWordLookup: %t,\n", sd.EndOfWordLookup)
+	fmt.Fprintf(&buf, "},\n")
+	fmt.Fprintf(&buf, "Symbols: []govarnam.Symbol{\n")
+	for _, s := range symbols {
+		fmt.Fprintf(
+			&buf,
+			"{Identifier: %d, Type: %d, MatchType: %d, Pattern: %q, Value1: %q, Value2: %q, Value3: %q, Tag: %q, Weight: %d, Priority: %d, AcceptCondition: %d, Flags: %d},\n",
+			s.Identifier, s.Type, s.MatchType, s.Pattern, s.Value1, s.Value2, s.Value3, s.Tag, s.Weight, s.Priority, s.AcceptCondition, s.Flags,
+		)
+	}
+	fmt.Fprintf(&buf, "},\n")
+	fmt.Fprintf(&buf, "StemRules: []govarnam.StemRule{\n")
+	for _, rule := range stemRules {
+		fmt.Fprintf(&buf, "{OldEnding: %q, NewEnding: %q, Exceptions: %#v},\n", rule.OldEnding, rule.NewEnding, rule.Exceptions)
+	}
+	fmt.Fprintf(&buf, "},\n")
+	fmt.Fprintf(&buf, "}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	vstFlag := flag.String("vst", "", "Path to VST file")
+	outputFlag := flag.String("o", "", "Output Go file. Prints to stdout if not given")
+	pkgFlag := flag.String("pkg", "main", "Package name of the generated file")
+	varFlag := flag.String("var", "Scheme", "Variable name of the embedded scheme")
+
+	flag.Parse()
+
+	if *vstFlag == "" {
+		fmt.Println("Specify the VST with -vst.\n\nUse --help for all available options.")
+		os.Exit(1)
+	}
+
+	source, err := generate(*vstFlag, *pkgFlag, *varFlag)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	if *outputFlag == "" {
+		os.Stdout.Write(source)
+		return
+	}
+
+	err = os.WriteFile(*outputFlag, source, 0644)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}
@@ -2,4 +2,9 @@ module github.com/varnamproject/govarnam
 
 go 1.16
 
-require github.com/mattn/go-sqlite3 v1.14.6
+require (
+	github.com/godbus/dbus/v5 v5.1.0
+	github.com/mattn/go-sqlite3 v1.14.6
+	google.golang.org/grpc v1.46.2
+	google.golang.org/protobuf v1.28.0
+)
@@ -0,0 +1,73 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import "strings"
+
+// How English letters are read in each language
+var acronymLetters = map[string]map[rune]string{
+	"ml": {
+		'A': "എ", 'B': "ബി", 'C': "സി", 'D': "ഡി", 'E': "ഇ", 'F': "എഫ്",
+		'G': "ജി", 'H': "എച്ച്", 'I': "ഐ", 'J': "ജെ", 'K': "കെ", 'L': "എൽ",
+		'M': "എം", 'N': "എൻ", 'O': "ഒ", 'P': "പി", 'Q': "ക്യു", 'R': "ആർ",
+		'S': "എസ്", 'T': "ടി", 'U': "യു", 'V': "വി", 'W': "ഡബ്ല്യു", 'X': "എക്സ്",
+		'Y': "വൈ", 'Z': "സെഡ്",
+	},
+	"hi": {
+		'A': "ए", 'B': "बी", 'C': "सी", 'D': "डी", 'E': "ई", 'F': "एफ़",
+		'G': "जी", 'H': "एच", 'I': "आई", 'J': "जे", 'K': "के", 'L': "एल",
+		'M': "एम", 'N': "एन", 'O': "ओ", 'P': "पी", 'Q': "क्यू", 'R': "आर",
+		'S': "एस", 'T': "टी", 'U': "यू", 'V': "वी", 'W': "डब्ल्यू", 'X': "एक्स",
+		'Y': "वाई", 'Z': "ज़ेड",
+	},
+}
+
+// Whether input is like USA, ISRO. Single letters are
+// not acronyms since schemes use capital letters
+func isAcronym(input string) bool {
+	if len(input) < 2 {
+		return false
+	}
+	for _, char := range input {
+		if char < 'A' || char > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Spell an acronym letter by letter in the language.
+// Eg: ISRO => ഐഎസ്ആർഒ
+func (varnam *Varnam) spellAcronym(acronym string) (string, bool) {
+	letters, ok := acronymLetters[varnam.SchemeDetails.LangCode]
+	if !ok {
+		return "", false
+	}
+
+	var spelling strings.Builder
+	for _, char := range acronym {
+		spelling.WriteString(letters[char])
+	}
+	return spelling.String(), true
+}
+
+// Suggestion for an acronym as per TransliterateOptions.Acronym.
+// ok is false if the input should be transliterated normally
+func (varnam *Varnam) acronymSuggestion(input string, mode int) (Suggestion, bool) {
+	if mode == VARNAM_ACRONYM_TRANSLITERATE || !isAcronym(input) {
+		return Suggestion{}, false
+	}
+
+	word := input
+	if mode == VARNAM_ACRONYM_SPELL {
+		if spelling, ok := varnam.spellAcronym(input); ok {
+			word = spelling
+		}
+	}
+
+	return Suggestion{word, VARNAM_TOKEN_BASIC_WEIGHT, 0, 1}, true
+}
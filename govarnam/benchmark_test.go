@@ -0,0 +1,41 @@
+package govarnam
+
+import (
+	"context"
+	"testing"
+)
+
+// Long enough to have a lot of permutations
+const benchmarkMLWord = "thiruvananthapurathekkulla"
+
+// Run with -benchmem to compare allocations
+func BenchmarkMLTokenizeWord(b *testing.B) {
+	varnam := getVarnamInstance("ml")
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		varnam.tokenizeWord(ctx, benchmarkMLWord, VARNAM_MATCH_ALL, false)
+	}
+}
+
+func BenchmarkMLTokensToSuggestions(b *testing.B) {
+	varnam := getVarnamInstance("ml")
+	ctx := context.Background()
+	tokens := varnam.tokenizeWord(ctx, benchmarkMLWord, VARNAM_MATCH_ALL, false)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		varnam.tokensToSuggestions(ctx, tokens, false, 100)
+	}
+}
+
+func BenchmarkMLTransliterateLongWord(b *testing.B) {
+	varnam := getVarnamInstance("ml")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		varnam.Transliterate(benchmarkMLWord)
+	}
+}
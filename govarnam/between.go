@@ -0,0 +1,173 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// Malayalam atomic chillus by the offset of the
+// consonant they're the vowelless form of
+var chilluConsonants = map[rune]rune{
+	0x7A: 0x23, 0x7B: 0x28, 0x7C: 0x30, 0x7D: 0x32, 0x7E: 0x33, 0x7F: 0x15,
+}
+
+// TransliterateBetween convert text in the script of one scheme
+// to the script of another. Eg: മലയാളം (ml) => मलयाळं (hi)
+// Text is split into symbols with the first scheme's VST and each
+// symbol is written with the second scheme's symbol of the same
+// pattern. If there's none, the character at the same place in the
+// other unicode block is used. Dictionaries are not looked up.
+func TransliterateBetween(fromSchemeID string, toSchemeID string, text string) (string, error) {
+	from, err := initVSTFromID(fromSchemeID)
+	if err != nil {
+		return "", err
+	}
+	defer from.Close()
+
+	to, err := initVSTFromID(toSchemeID)
+	if err != nil {
+		return "", err
+	}
+	defer to.Close()
+
+	return from.transliterateTo(context.Background(), to, text)
+}
+
+// Init only the VST of a scheme, for when learnings aren't needed
+func initVSTFromID(schemeID string) (*Varnam, error) {
+	vstPath, err := findVSTPath(schemeID)
+	if err != nil {
+		return nil, err
+	}
+
+	varnam := Varnam{}
+
+	err = varnam.InitVST(vstPath)
+	if err != nil {
+		return nil, err
+	}
+
+	varnam.setDefaultConfig()
+
+	return &varnam, nil
+}
+
+func (varnam *Varnam) transliterateTo(ctx context.Context, to *Varnam, text string) (string, error) {
+	tokens := varnam.splitTextByConjunct(ctx, text)
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+
+	var output strings.Builder
+
+	// Position of token in the current word
+	position := 0
+
+	for i, token := range tokens {
+		if token.tokenType != VARNAM_TOKEN_SYMBOL {
+			output.WriteString(token.character)
+			position = 0
+			continue
+		}
+
+		acceptCondition := VARNAM_TOKEN_ACCEPT_IF_IN_BETWEEN
+		if position == 0 {
+			acceptCondition = VARNAM_TOKEN_ACCEPT_IF_STARTS_WITH
+		} else if i == len(tokens)-1 || tokens[i+1].tokenType != VARNAM_TOKEN_SYMBOL {
+			acceptCondition = VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH
+		}
+
+		found := false
+		for _, symbol := range token.symbols {
+			target, ok := to.findSymbolByPattern(ctx, symbol.Pattern, acceptCondition)
+			if ok {
+				output.WriteString(getSymbolValue(target, position))
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			output.WriteString(varnam.convertByScriptOffset(to, token.character))
+		}
+
+		position++
+	}
+
+	return output.String(), nil
+}
+
+// Find the best symbol with exactly this pattern
+func (varnam *Varnam) findSymbolByPattern(ctx context.Context, pattern string, acceptCondition int) (Symbol, bool) {
+	if varnam.mappedVST != nil {
+		return varnam.mappedFindSymbolByPattern(ctx, pattern, acceptCondition)
+	}
+
+	var item Symbol
+
+	countQuery(ctx)
+
+	// Symbols specific to the position are preferred over generic ones
+	row := varnam.vstConn.QueryRowContext(ctx, "SELECT * FROM symbols WHERE pattern = ? AND (accept_condition = 0 OR accept_condition = ?) ORDER BY match_type ASC, accept_condition DESC, weight DESC, priority DESC LIMIT 1", pattern, acceptCondition)
+
+	err := row.Scan(&item.Identifier, &item.Type, &item.Pattern, &item.Value1, &item.Value2, &item.Value3, &item.Tag, &item.MatchType, &item.Priority, &item.AcceptCondition, &item.Flags, &item.Weight)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Print(err)
+		}
+		return item, false
+	}
+
+	return item, true
+}
+
+// Indic unicode blocks have the same layout, so a character
+// can be moved to another script by its offset in the block
+func (varnam *Varnam) convertByScriptOffset(to *Varnam, text string) string {
+	fromScript, ok := languageScripts[varnam.SchemeDetails.LangCode]
+	if !ok {
+		return text
+	}
+	toScript, ok := languageScripts[to.SchemeDetails.LangCode]
+	if !ok {
+		return text
+	}
+
+	fromStart := rune(fromScript.R16[0].Lo) &^ 0x7F
+	toStart := rune(toScript.R16[0].Lo) &^ 0x7F
+
+	var output strings.Builder
+
+	for _, char := range text {
+		offset := char - fromStart
+
+		if offset < 0 || offset > 0x7F {
+			output.WriteRune(char)
+			continue
+		}
+
+		// Other scripts may have different characters at chillus' place
+		if consonant, ok := chilluConsonants[offset]; ok && varnam.SchemeDetails.LangCode == "ml" && to.SchemeDetails.LangCode != "ml" {
+			output.WriteRune(toStart + consonant)
+			output.WriteRune(toStart + iso15919Virama)
+		} else if unicode.Is(toScript, toStart+offset) {
+			output.WriteRune(toStart + offset)
+		} else {
+			output.WriteRune(char)
+		}
+	}
+
+	return output.String()
+}
@@ -0,0 +1,86 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"path"
+	"testing"
+)
+
+// A tiny Devanagari scheme with the same patterns as ml
+func makeBetweenTestVST() *Varnam {
+	vstPath := path.Join(testTempDir, "between-hi.vst")
+
+	vm, err := VMInit(vstPath)
+	checkError(err)
+
+	err = vm.VMSetSchemeDetails(SchemeDetails{
+		Identifier: "between-hi",
+		LangCode:   "hi",
+	})
+	checkError(err)
+
+	checkError(vm.VMCreateToken("ka", "क", "", "", "", VARNAM_SYMBOL_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false))
+	checkError(vm.VMCreateToken("k", "क्", "", "", "", VARNAM_SYMBOL_DEAD_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false))
+	checkError(vm.VMCreateToken("la", "ल", "", "", "", VARNAM_SYMBOL_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false))
+	checkError(vm.VMCreateToken("ma", "म", "", "", "", VARNAM_SYMBOL_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false))
+	checkError(vm.VMCreateToken("aa", "आ", "ा", "", "", VARNAM_SYMBOL_VOWEL, VARNAM_MATCH_EXACT, 0, 0, false))
+	vm.Close()
+
+	varnam := Varnam{}
+	checkError(varnam.InitVST(vstPath))
+
+	return &varnam
+}
+
+func TestMLTransliterateBetween(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+	to := makeBetweenTestVST()
+	defer to.Close()
+
+	ctx := context.Background()
+
+	// By patterns, vowel sign in between
+	result, err := varnam.transliterateTo(ctx, to, "കാല")
+	checkError(err)
+	assertEqual(t, result, "काल")
+
+	result, err = varnam.transliterateTo(ctx, to, "ആമ")
+	checkError(err)
+	assertEqual(t, result, "आम")
+
+	// La is not in the test scheme, so by unicode offset
+	result, err = varnam.transliterateTo(ctx, to, "കള")
+	checkError(err)
+	assertEqual(t, result, "कळ")
+
+	// Non script characters are kept
+	result, err = varnam.transliterateTo(ctx, to, "കാല, മല")
+	checkError(err)
+	assertEqual(t, result, "काल, मल")
+
+	_, err = TransliterateBetween("ml", "non-existent", "കാല")
+	assertEqual(t, err != nil, true)
+}
+
+func TestConvertByScriptOffset(t *testing.T) {
+	from := Varnam{}
+	from.SchemeDetails.LangCode = "ml"
+
+	to := Varnam{}
+	to.SchemeDetails.LangCode = "hi"
+
+	assertEqual(t, from.convertByScriptOffset(&to, "ക"), "क")
+
+	// Chillu as consonant + virama
+	assertEqual(t, from.convertByScriptOffset(&to, "ൽ"), "ल्")
+
+	// No script for language
+	to.SchemeDetails.LangCode = "en"
+	assertEqual(t, from.convertByScriptOffset(&to, "ക"), "ക")
+}
@@ -0,0 +1,139 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Blacklist a word. It won't be learnt or given as a suggestion
+// again, even if tokenizer can make it. Word is unlearnt if learnt.
+func (varnam *Varnam) Blacklist(word string) error {
+	defer varnam.dictionaryChanged()
+
+	word = varnam.sanitizeWord(word)
+	if word == "" {
+		return fmt.Errorf("Nothing to blacklist")
+	}
+
+	_, err := varnam.dictWriteConn.Exec("INSERT OR IGNORE INTO blacklist(word) VALUES (?)", word)
+	if err != nil {
+		return err
+	}
+
+	if _, err := varnam.getWordInfo(word); err == nil {
+		return varnam.Unlearn(word)
+	}
+
+	return nil
+}
+
+// RemoveFromBlacklist allow a blacklisted word again
+func (varnam *Varnam) RemoveFromBlacklist(word string) error {
+	defer varnam.dictionaryChanged()
+
+	result, err := varnam.dictWriteConn.Exec("DELETE FROM blacklist WHERE word = ?", varnam.sanitizeWord(word))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("Word is not blacklisted")
+	}
+
+	return nil
+}
+
+// GetBlacklist get all blacklisted words
+func (varnam *Varnam) GetBlacklist() ([]string, error) {
+	var words []string
+
+	rows, err := varnam.dictConn.Query("SELECT word FROM blacklist ORDER BY word")
+	if err != nil {
+		return words, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var word string
+		rows.Scan(&word)
+		words = append(words, word)
+	}
+
+	return words, rows.Err()
+}
+
+// Find which of the words are blacklisted
+func (varnam *Varnam) getBlacklisted(ctx context.Context, words []string) (map[string]bool, error) {
+	blacklisted := map[string]bool{}
+
+	limitVariableNumber := getSQLiteLimit(sqliteLimitVariableNumber)
+
+	for len(words) > 0 {
+		lastIndex := int(math.Min(float64(limitVariableNumber), float64(len(words))))
+
+		var args []interface{}
+		for _, word := range words[0:lastIndex] {
+			args = append(args, word)
+		}
+
+		countQuery(ctx)
+		rows, err := varnam.dictConn.QueryContext(
+			ctx,
+			"SELECT word FROM blacklist WHERE word IN (?"+strings.Repeat(", ?", lastIndex-1)+")",
+			args...,
+		)
+		if err != nil {
+			return blacklisted, err
+		}
+
+		for rows.Next() {
+			var word string
+			rows.Scan(&word)
+			blacklisted[word] = true
+		}
+		rows.Close()
+
+		words = words[lastIndex:]
+	}
+
+	return blacklisted, nil
+}
+
+// Remove blacklisted words from suggestions
+func (varnam *Varnam) removeBlacklisted(ctx context.Context, sugs []Suggestion) []Suggestion {
+	if len(sugs) == 0 {
+		return sugs
+	}
+
+	var words []string
+	for _, sug := range sugs {
+		words = append(words, sug.Word)
+	}
+
+	blacklisted, err := varnam.getBlacklisted(ctx, words)
+	if err != nil || len(blacklisted) == 0 {
+		return sugs
+	}
+
+	var result []Suggestion
+	for _, sug := range sugs {
+		if !blacklisted[sug.Word] {
+			result = append(result, sug)
+		}
+	}
+
+	return result
+}
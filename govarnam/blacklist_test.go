@@ -0,0 +1,55 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestMLBlacklist(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലപ്പുറം", 0))
+
+	inSuggestions := func(input string, word string) bool {
+		for _, sug := range varnam.Transliterate(input) {
+			if sug.Word == word {
+				return true
+			}
+		}
+		return false
+	}
+
+	assertEqual(t, inSuggestions("malappuram", "മലപ്പുറം"), true)
+
+	checkError(varnam.Blacklist("മലപ്പുറം"))
+
+	// Unlearnt and not made by tokenizer either
+	_, err := varnam.getWordInfo("മലപ്പുറം")
+	assertEqual(t, err != nil, true)
+	assertEqual(t, inSuggestions("malappuram", "മലപ്പുറം"), false)
+
+	// Can't be learnt again
+	assertEqual(t, varnam.Learn("മലപ്പുറം", 0) != nil, true)
+
+	learnStatus, err := varnam.LearnMany([]WordInfo{{0, "മലപ്പുറം", 0, 0}, {0, "വയനാട്", 0, 0}})
+	checkError(err)
+	assertEqual(t, learnStatus.FailedWords, 1)
+
+	// Imports skip it too
+	checkError(varnam.insertWords([]WordInfo{{0, "മലപ്പുറം", VARNAM_LEARNT_WORD_MIN_WEIGHT, 0}}))
+	_, err = varnam.getWordInfo("മലപ്പുറം")
+	assertEqual(t, err != nil, true)
+
+	blacklist, err := varnam.GetBlacklist()
+	checkError(err)
+	assertEqual(t, len(blacklist), 1)
+	assertEqual(t, blacklist[0], "മലപ്പുറം")
+
+	checkError(varnam.RemoveFromBlacklist("മലപ്പുറം"))
+	assertEqual(t, varnam.RemoveFromBlacklist("മലപ്പുറം") != nil, true)
+
+	assertEqual(t, inSuggestions("malappuram", "മലപ്പുറം"), true)
+	checkError(varnam.Learn("മലപ്പുറം", 0))
+
+	checkError(varnam.Unlearn("മലപ്പുറം"))
+	checkError(varnam.Unlearn("വയനാട്"))
+}
@@ -21,13 +21,23 @@ func (varnam *Varnam) channelTokenizeWord(ctx context.Context, word string, matc
 	default:
 		start := time.Now()
 
+		ctx, span := varnam.startSpan(ctx, TraceTokenize)
 		tokens := varnam.tokenizeWord(ctx, word, matchType, partial)
+		if tokens != nil {
+			span.SetAttribute("varnam.tokens", len(*tokens))
+		}
+		span.End(ctx.Err())
 
 		if LOG_TIME_TAKEN {
 			log.Printf("%s took %v\n", "channelTokenizeWord", time.Since(start))
 		}
 
-		channel <- tokens
+		// Receiver would've stopped listening if cancelled.
+		// Don't block forever then.
+		select {
+		case <-ctx.Done():
+		case channel <- tokens:
+		}
 		close(channel)
 	}
 }
@@ -40,13 +50,19 @@ func (varnam *Varnam) channelTokensToSuggestions(ctx context.Context, tokens *[]
 	default:
 		start := time.Now()
 
+		ctx, span := varnam.startSpan(ctx, TraceTokenizer)
 		sugs := varnam.tokensToSuggestions(ctx, tokens, false, limit)
+		span.SetAttribute("varnam.suggestions", len(sugs))
+		span.End(ctx.Err())
 
 		if LOG_TIME_TAKEN {
 			log.Printf("%s took %v\n", "channelTokensToSuggestions", time.Since(start))
 		}
 
-		channel <- sugs
+		select {
+		case <-ctx.Done():
+		case channel <- sugs:
+		}
 		close(channel)
 	}
 }
@@ -65,7 +81,10 @@ func (varnam *Varnam) channelTokensToGreedySuggestions(ctx context.Context, toke
 			log.Printf("%s took %v\n", "channelTokensToGreedySuggestions", time.Since(start))
 		}
 
-		channel <- sugs
+		select {
+		case <-ctx.Done():
+		case channel <- sugs:
+		}
 		close(channel)
 	}
 }
@@ -84,6 +103,8 @@ func (varnam *Varnam) channelGetFromDictionary(ctx context.Context, word string,
 	default:
 		start := time.Now()
 
+		ctx, span := varnam.startSpan(ctx, TraceDictionary)
+
 		dictResult := varnam.getFromDictionary(ctx, tokens)
 
 		if varnam.Debug {
@@ -148,11 +169,19 @@ func (varnam *Varnam) channelGetFromDictionary(ctx context.Context, word string,
 			log.Printf("%s took %v\n", "channelGetFromDictionary", time.Since(start))
 		}
 
-		channel <- channelDictionaryResult{
+		result := channelDictionaryResult{
 			exactWords,
 			exactMatches,
 			moreSuggestions,
 		}
+
+		span.SetAttribute("varnam.suggestions", len(exactWords)+len(exactMatches)+len(moreSuggestions))
+		span.End(ctx.Err())
+
+		select {
+		case <-ctx.Done():
+		case channel <- result:
+		}
 		close(channel)
 	}
 }
@@ -170,6 +199,8 @@ func (varnam *Varnam) channelGetFromPatternDictionary(ctx context.Context, word
 	default:
 		start := time.Now()
 
+		ctx, span := varnam.startSpan(ctx, TracePatternDictionary)
+
 		patternDictSugs := varnam.getFromPatternDictionary(ctx, word)
 
 		if len(patternDictSugs) > 0 {
@@ -228,11 +259,19 @@ func (varnam *Varnam) channelGetFromPatternDictionary(ctx context.Context, word
 			log.Printf("%s took %v\n", "channelGetFromPatternDictionary", time.Since(start))
 		}
 
-		channel <- channelDictionaryResult{
+		result := channelDictionaryResult{
 			exactWords,
 			[]Suggestion{}, // Not applicable for patterns dictionary
 			moreSuggestions,
 		}
+
+		span.SetAttribute("varnam.suggestions", len(exactWords)+len(moreSuggestions))
+		span.End(ctx.Err())
+
+		select {
+		case <-ctx.Done():
+		case channel <- result:
+		}
 		close(channel)
 	}
 }
@@ -251,7 +290,10 @@ func (varnam *Varnam) channelGetMoreFromDictionary(ctx context.Context, sugs []S
 			log.Printf("%s took %v\n", "channelGetMoreFromDictionary", time.Since(start))
 		}
 
-		channel <- result
+		select {
+		case <-ctx.Done():
+		case channel <- result:
+		}
 		close(channel)
 	}
 }
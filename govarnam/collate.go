@@ -0,0 +1,125 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+// Collator decides the order of two words that have the same rank.
+// Compare should return a negative number when a comes before b,
+// a positive number when b comes before a and 0 if they are equal.
+type Collator interface {
+	Compare(a string, b string) int
+}
+
+// scriptCollator orders words the way a native speaker would
+// find them in a dictionary instead of by their UTF-8 bytes.
+// Only the script specific differences from codepoint order are
+// handled here, it's not a full UCA implementation.
+type scriptCollator struct {
+	// Characters that are written differently but sort as another sequence.
+	// Eg: Atomic chillu ൻ sorts as ന്
+	expansions map[rune][]rune
+
+	// Characters whose codepoint is far from where they should sort.
+	// The value is the character after which it should be placed.
+	placedAfter map[rune]rune
+}
+
+// Joiners have no effect on ordering
+var collationIgnorables = map[rune]bool{
+	[]rune(ZWJ)[0]:  true,
+	[]rune(ZWNJ)[0]: true,
+}
+
+var collationRules = map[string]scriptCollator{
+	"ml": {
+		expansions: map[rune][]rune{
+			'ൺ': []rune("ണ്"),
+			'ൻ': []rune("ന്"),
+			'ർ': []rune("ര്"),
+			'ൽ': []rune("ല്"),
+			'ൾ': []rune("ള്"),
+			'ൿ': []rune("ക്"),
+			'ൗ': []rune("ൌ"),
+		},
+		placedAfter: map[rune]rune{
+			'ൠ': 'ഋ',
+			'ൡ': 'ഌ',
+			'ൢ': 'ൃ',
+			'ൣ': 'ൄ',
+		},
+	},
+	"hi": {
+		// Nukta consonants sort as consonant + nukta
+		expansions: map[rune][]rune{
+			'\u0958': []rune("\u0915\u093c"),
+			'\u0959': []rune("\u0916\u093c"),
+			'\u095a': []rune("\u0917\u093c"),
+			'\u095b': []rune("\u091c\u093c"),
+			'\u095c': []rune("\u0921\u093c"),
+			'\u095d': []rune("\u0922\u093c"),
+			'\u095e': []rune("\u092b\u093c"),
+			'\u095f': []rune("\u092f\u093c"),
+		},
+		placedAfter: map[rune]rune{
+			'ॠ': 'ऋ',
+			'ॡ': 'ऌ',
+		},
+	},
+}
+
+// NewScriptCollator collator for the script of a language.
+// Falls back to codepoint order for languages without rules.
+func NewScriptCollator(langCode string) Collator {
+	// Pointer, so that it's comparable as config of cached results
+	if rules, ok := collationRules[langCode]; ok {
+		return &rules
+	}
+	return &scriptCollator{}
+}
+
+// Collation weight of each character in word
+func (c scriptCollator) keys(word string) []int {
+	var keys []int
+	for _, r := range word {
+		if collationIgnorables[r] {
+			continue
+		}
+
+		expansion, ok := c.expansions[r]
+		if !ok {
+			expansion = []rune{r}
+		}
+
+		for _, er := range expansion {
+			if after, ok := c.placedAfter[er]; ok {
+				// Leave a gap of 1 between every codepoint for these
+				keys = append(keys, int(after)*2+1)
+			} else {
+				keys = append(keys, int(er)*2)
+			}
+		}
+	}
+	return keys
+}
+
+func (c scriptCollator) Compare(a string, b string) int {
+	aKeys := c.keys(a)
+	bKeys := c.keys(b)
+
+	for i := 0; i < len(aKeys) && i < len(bKeys); i++ {
+		if aKeys[i] != bKeys[i] {
+			return aKeys[i] - bKeys[i]
+		}
+	}
+
+	if len(aKeys) != len(bKeys) {
+		return len(aKeys) - len(bKeys)
+	}
+
+	// Same in collation, but different spelling (chillu and
+	// consonant + virama). Let the caller keep its order
+	return 0
+}
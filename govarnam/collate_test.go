@@ -0,0 +1,45 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestMLCollator(t *testing.T) {
+	collator := NewScriptCollator("ml")
+
+	// Atomic chillu sorts as consonant + virama, not by its codepoint
+	assertEqual(t, collator.Compare("കൽപ്പന", "കല്ല്") < 0, true)
+	assertEqual(t, collator.Compare("കല്ല്", "കൽപ്പന") > 0, true)
+
+	// Joiners are ignored
+	assertEqual(t, collator.Compare("കല്‍പ്പന", "കല്ല്") < 0, true)
+
+	assertEqual(t, collator.Compare("മല", "മല") == 0, true)
+	assertEqual(t, collator.Compare("മല", "മലയാളം") < 0, true)
+
+	sugs := []Suggestion{
+		{"കല്ല്", 10, 0, 0},
+		{"കൽപ്പന", 10, 0, 0},
+		{"കട", 20, 0, 0},
+	}
+	sugs = SortSuggestionsWithCollator(sugs, collator)
+	assertEqual(t, sugs[0].Word, "കട")
+	assertEqual(t, sugs[1].Word, "കൽപ്പന")
+	assertEqual(t, sugs[2].Word, "കല്ല്")
+
+	// Without a collator ties are kept in order
+	sugs = []Suggestion{
+		{"കല്ല്", 10, 0, 0},
+		{"കൽപ്പന", 10, 0, 0},
+	}
+	sugs = SortSuggestions(sugs)
+	assertEqual(t, sugs[0].Word, "കല്ല്")
+}
+
+func TestHICollator(t *testing.T) {
+	collator := NewScriptCollator("hi")
+
+	// Precomposed nukta letter sorts as consonant + nukta
+	assertEqual(t, collator.Compare("\u0958\u092e", "\u0915\u093c\u0932") < 0, true)
+	assertEqual(t, collator.Compare("\u0958", "\u0916") < 0, true)
+}
@@ -0,0 +1,156 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+)
+
+// Composition state of an IME while a word is being typed : the
+// input, its candidates and the highlighted one. IME frontends
+// (fcitx5, ibus etc.) only need to pass keys and draw what this gives.
+// Not safe for concurrent use
+type Composition struct {
+	session    *Session
+	candidates []Suggestion
+	cursor     int
+
+	// Number of candidates shown at a time. 0 shows all
+	PageSize int
+}
+
+// NewComposition start composing a word
+func (varnam *Varnam) NewComposition() *Composition {
+	return &Composition{session: varnam.NewSession()}
+}
+
+// Input what has been typed so far
+func (composition *Composition) Input() string {
+	return composition.session.Input()
+}
+
+// IsEmpty whether nothing is being composed
+func (composition *Composition) IsEmpty() bool {
+	return len(composition.session.input) == 0
+}
+
+func (composition *Composition) update(ctx context.Context, result TransliterationResult) {
+	varnam := composition.session.varnam
+	input := composition.Input()
+
+	composition.candidates = varnam.suggestionsFromResult(ctx, input, result)
+	composition.cursor = 0
+}
+
+// Append characters typed & find candidates
+func (composition *Composition) Append(ctx context.Context, chars string) {
+	composition.update(ctx, composition.session.Append(ctx, chars))
+}
+
+// Backspace remove the last character typed & find candidates
+func (composition *Composition) Backspace(ctx context.Context) {
+	composition.update(ctx, composition.session.Backspace(ctx))
+}
+
+// Reset clear everything to start a new word
+func (composition *Composition) Reset() {
+	composition.session.Reset()
+	composition.candidates = nil
+	composition.cursor = 0
+}
+
+// Candidates all candidates of the input
+func (composition *Composition) Candidates() []Suggestion {
+	return composition.candidates
+}
+
+// Cursor index of the highlighted candidate in Candidates
+func (composition *Composition) Cursor() int {
+	return composition.cursor
+}
+
+// Page candidates in the page having the highlighted one
+// and the index of the highlighted one in it
+func (composition *Composition) Page() ([]Suggestion, int) {
+	if composition.PageSize <= 0 {
+		return composition.candidates, composition.cursor
+	}
+
+	start := composition.cursor - composition.cursor%composition.PageSize
+	end := start + composition.PageSize
+	if end > len(composition.candidates) {
+		end = len(composition.candidates)
+	}
+
+	return composition.candidates[start:end], composition.cursor - start
+}
+
+// MoveCursor highlight a candidate before (negative) or after.
+// Stops at first & last candidate
+func (composition *Composition) MoveCursor(by int) {
+	composition.Select(composition.cursor + by)
+}
+
+// NextPage highlight the first candidate of next page
+func (composition *Composition) NextPage() {
+	if composition.PageSize > 0 {
+		composition.Select(composition.cursor - composition.cursor%composition.PageSize + composition.PageSize)
+	}
+}
+
+// PreviousPage highlight the first candidate of previous page
+func (composition *Composition) PreviousPage() {
+	if composition.PageSize > 0 {
+		composition.Select(composition.cursor - composition.cursor%composition.PageSize - composition.PageSize)
+	}
+}
+
+// Select highlight candidate at index of Candidates
+func (composition *Composition) Select(index int) {
+	if index >= len(composition.candidates) {
+		index = len(composition.candidates) - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	composition.cursor = index
+}
+
+// Preedit text to show inline where the user is typing.
+// The highlighted candidate, or the input if there are none
+func (composition *Composition) Preedit() string {
+	if len(composition.candidates) == 0 {
+		return composition.Input()
+	}
+	return composition.candidates[composition.cursor].Word
+}
+
+// Commit the highlighted candidate. Returns the text to insert &
+// resets for next word. The choice is reported with ReportSelected
+// so that it ranks higher next time, text is given even if that fails
+func (composition *Composition) Commit() (string, error) {
+	if composition.IsEmpty() {
+		return "", nil
+	}
+
+	input := composition.Input()
+	text := composition.Preedit()
+	composition.Reset()
+
+	if text == input {
+		return text, nil
+	}
+
+	return text, composition.session.varnam.ReportSelected(input, text)
+}
+
+// CommitInput commit what was typed as is, without transliterating
+func (composition *Composition) CommitInput() string {
+	input := composition.Input()
+	composition.Reset()
+	return input
+}
@@ -0,0 +1,73 @@
+package govarnam
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMLComposition(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+	ctx := context.Background()
+
+	composition := varnam.NewComposition()
+	composition.PageSize = 3
+
+	assertEqual(t, composition.IsEmpty(), true)
+	assertEqual(t, composition.Preedit(), "")
+
+	composition.Append(ctx, "mal")
+	composition.Append(ctx, "a")
+	assertEqual(t, composition.Input(), "mala")
+	assertEqual(t, composition.Candidates()[0].Word, varnam.Transliterate("mala")[0].Word)
+	assertEqual(t, composition.Preedit(), "മല")
+
+	page, cursor := composition.Page()
+	assertEqual(t, len(page), 3)
+	assertEqual(t, cursor, 0)
+
+	composition.MoveCursor(1)
+	assertEqual(t, composition.Preedit(), composition.Candidates()[1].Word)
+
+	composition.NextPage()
+	page, cursor = composition.Page()
+	assertEqual(t, composition.Cursor(), 3)
+	assertEqual(t, cursor, 0)
+	assertEqual(t, page[0].Word, "മാല")
+
+	// Stays within candidates
+	composition.MoveCursor(100)
+	assertEqual(t, composition.Cursor(), len(composition.Candidates())-1)
+	composition.PreviousPage()
+	composition.PreviousPage()
+	assertEqual(t, composition.Cursor(), 0)
+
+	composition.Select(3)
+	text, err := composition.Commit()
+	checkError(err)
+	defer varnam.Unlearn("മാല")
+
+	assertEqual(t, text, "മാല")
+	assertEqual(t, composition.IsEmpty(), true)
+	assertEqual(t, len(composition.Candidates()), 0)
+
+	// Committed word ranks higher now
+	assertEqual(t, hasSelection(varnam, "mala", "മാല"), true)
+
+	composition.Append(ctx, "ab")
+	composition.Backspace(ctx)
+	assertEqual(t, composition.Input(), "a")
+	assertEqual(t, composition.CommitInput(), "a")
+	assertEqual(t, composition.IsEmpty(), true)
+}
+
+func hasSelection(varnam *Varnam, pattern string, word string) bool {
+	selections, err := varnam.GetSelections(context.Background(), pattern)
+	checkError(err)
+
+	for _, selection := range selections {
+		if selection.Word == word {
+			return true
+		}
+	}
+	return false
+}
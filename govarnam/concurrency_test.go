@@ -0,0 +1,153 @@
+package govarnam
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sync"
+	"testing"
+)
+
+// Run with -race. Transliterations, learnings & symbol changes
+// happen together on one instance
+func TestMLConcurrentUse(t *testing.T) {
+	varnam, err := Init(getVarnamInstance("ml").VSTPath, path.Join(testTempDir, "concurrent-ml.vst.learnings"))
+	checkError(err)
+	defer varnam.Close()
+
+	ctx := context.Background()
+	words := []string{"മലയാളം", "കേരളം", "തിരുവനന്തപുരം", "കൊല്ലം", "പത്തനംതിട്ട"}
+
+	var (
+		wg      sync.WaitGroup
+		errs    = make(chan error, 100)
+		learnts int
+		mutex   sync.Mutex
+	)
+
+	run := func(f func(i int) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				if err := f(i); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 4; i++ {
+		run(func(i int) error {
+			varnam.Transliterate("malayalam")
+			return nil
+		})
+	}
+
+	run(func(i int) error {
+		varnam.TransliterateGreedyTokenized("keralam")
+		return nil
+	})
+
+	run(func(i int) error {
+		session := varnam.NewSession()
+		session.Append(ctx, "thiru")
+		session.Append(ctx, "vananthapuram")
+		return nil
+	})
+
+	run(func(i int) error {
+		return varnam.Learn(words[i%len(words)], 0)
+	})
+
+	run(func(i int) error {
+		return varnam.Train(fmt.Sprintf("kollam%d", i), "കൊല്ലം")
+	})
+
+	run(func(i int) error {
+		_, err := varnam.LearnMany([]WordInfo{{word: "ആലപ്പുഴ"}, {word: "ഇടുക്കി"}})
+		return err
+	})
+
+	run(func(i int) error {
+		if err := varnam.Train("kottayam", "കോട്ടയം"); err != nil {
+			return err
+		}
+		return varnam.Unlearn("കോട്ടയം")
+	})
+
+	run(func(i int) error {
+		// Longer than patterns of scheme
+		if err := varnam.OverrideSymbol(Symbol{Pattern: "zhazhazha", Value1: "ഴ"}); err != nil {
+			return err
+		}
+		return varnam.RemoveSymbolOverride("zhazhazha")
+	})
+
+	run(func(i int) error {
+		return varnam.SetSymbolWeight("la", "ള", 100+i)
+	})
+
+	run(func(i int) error {
+		varnam.OnLearn(func(word string, weight int) {
+			mutex.Lock()
+			learnts++
+			mutex.Unlock()
+		})
+		if status := varnam.Status(ctx); !status.Healthy() {
+			return fmt.Errorf("Unhealthy: %+v", status)
+		}
+		_, err := varnam.GetRecentlyLearntWords(ctx, 0, 10)
+		return err
+	})
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	sugs, err := varnam.GetRecentlyLearntWords(ctx, 0, 10)
+	checkError(err)
+	assertEqual(t, len(sugs) >= len(words), true)
+	assertEqual(t, learnts > 0, true)
+
+	// Patterns of unlearnt words went with them
+	var orphans int
+	err = varnam.dictConn.QueryRow("SELECT COUNT(*) FROM patterns WHERE word_id NOT IN (SELECT id FROM words)").Scan(&orphans)
+	checkError(err)
+	assertEqual(t, orphans, 0)
+}
+
+func TestMLDictionaryReadConnections(t *testing.T) {
+	varnam, err := Init(getVarnamInstance("ml").VSTPath, path.Join(testTempDir, "read-connections-ml.vst.learnings"))
+	checkError(err)
+	defer varnam.Close()
+
+	checkError(varnam.Learn("മലയാളം", 0))
+
+	assertEqual(t, varnam.dictConn.Stats().MaxOpenConnections, VARNAM_DICTIONARY_READ_CONNECTIONS)
+
+	// Readers can't write
+	_, err = varnam.dictConn.Exec("DELETE FROM words")
+	assertEqual(t, err != nil, true)
+
+	varnam.SetDictionaryReadConnections(2)
+	assertEqual(t, varnam.dictConn.Stats().MaxOpenConnections, 2)
+
+	// Lookups more than connections wait for one
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sugs := varnam.Transliterate("malayalam")
+			assertEqual(t, len(sugs) > 0 && sugs[0].Word == "മലയാളം", true)
+		}()
+	}
+	wg.Wait()
+
+	assertEqual(t, varnam.dictConn.Stats().OpenConnections <= 2, true)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"time"
 )
 
 // Compile-time variables.
@@ -22,6 +23,14 @@ const STRUCT_INT_DEFAULT_VALUE = -1
 const ZWNJ = "\u200c"
 const ZWJ = "\u200d"
 
+// Bidirectional text marks. See Varnam.IsRTL
+const LRM = "\u200e" // Left-to-right mark
+const RLM = "\u200f" // Right-to-left mark
+const LRI = "\u2066" // Left-to-right isolate
+const RLI = "\u2067" // Right-to-left isolate
+const FSI = "\u2068" // First strong isolate
+const PDI = "\u2069" // Pop directional isolate
+
 /* Pattern matching */
 const VARNAM_MATCH_EXACT = 1
 const VARNAM_MATCH_POSSIBILITY = 2
@@ -58,6 +67,98 @@ const VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH = 3
 // VARNAM_LEARNT_WORD_MIN_WEIGHT Minimum weight/confidence for learnt words.
 const VARNAM_LEARNT_WORD_MIN_WEIGHT = 30
 
+// VARNAM_DOMAIN_WEIGHT_BOOST Weight added to words of the domains
+// chosen in TransliterateOptions
+const VARNAM_DOMAIN_WEIGHT_BOOST = 10
+
+// VARNAM_LEARNING_STATS_TOP_WORDS Number of most learnt words given by LearningStats
+const VARNAM_LEARNING_STATS_TOP_WORDS = 10
+
+// How a word having both native script and latin is tokenized.
+// See Varnam.ScriptBoundary
+const VARNAM_SCRIPT_BOUNDARY_JOIN = 0  // Tokenized as a single word
+const VARNAM_SCRIPT_BOUNDARY_SPLIT = 1 // Each latin run is tokenized as a word of its own
+
+// How input is read. See Varnam.InputMethod
+const VARNAM_INPUT_METHOD_PHONETIC = 0 // Patterns of the scheme
+const VARNAM_INPUT_METHOD_INSCRIPT = 1 // Keys of InScript keyboard layout
+
+// How joiners are given in suggestions. See Varnam.JoinerPolicy
+const VARNAM_JOINER_POLICY_KEEP = 0   // As made by the scheme & as learnt
+const VARNAM_JOINER_POLICY_ATOMIC = 1 // ZWJ forms are given as atomic letters (Eg: ൻ)
+const VARNAM_JOINER_POLICY_LEGACY = 2 // Atomic letters are given as ZWJ forms (Eg: ന്‍)
+const VARNAM_JOINER_POLICY_STRIP = 3  // ZWJ & ZWNJ are removed, atomic letters are used
+
+// Severity of a SchemeIssue
+const VARNAM_ISSUE_ERROR = 1   // Scheme shouldn't be used
+const VARNAM_ISSUE_WARNING = 2 // Might be intentional
+
+// Kind of a SchemeIssue. See ValidateScheme
+const VARNAM_ISSUE_DUPLICATE_PATTERN = 1 // Same pattern gives the same or conflicting values
+const VARNAM_ISSUE_UNREACHABLE = 2       // Pattern can't be typed
+const VARNAM_ISSUE_WEIGHT = 3            // Weight is invalid or inconsistent with other symbols
+const VARNAM_ISSUE_INVALID_UNICODE = 4   // Value is not a valid unicode sequence
+
+// What to do with all caps input like USA. See TransliterateOptions.Acronym
+const VARNAM_ACRONYM_TRANSLITERATE = 0 // Transliterate like any other input
+const VARNAM_ACRONYM_SKIP = 1          // Give the input as is
+const VARNAM_ACRONYM_SPELL = 2         // Spell letter by letter. Eg: USA => യുഎസ്എ
+
+// VARNAM_FUZZY_SCORE_FACTOR Score of fuzzy suggestions is reduced by this
+// since the user didn't type exactly that
+const VARNAM_FUZZY_SCORE_FACTOR = 0.5
+
+// VARNAM_TYPO_NEIGHBOUR_KEY_LIKELIHOOD Likelihood of hitting a neighbouring key
+// instead of the intended one in the default typo model
+const VARNAM_TYPO_NEIGHBOUR_KEY_LIKELIHOOD = 0.5
+
+// What to do with tokenizer suggestions when dictionary has enough
+// matches. See Varnam.TokenizerSuggestionsThreshold
+const VARNAM_TOKENIZER_SUGGESTIONS_DEMOTE = 0 // Given after all dictionary words, with lesser score
+const VARNAM_TOKENIZER_SUGGESTIONS_DROP = 1   // Not given at all
+
+// VARNAM_DEMOTED_TOKENIZER_SCORE_FACTOR Score of demoted tokenizer suggestions is reduced by this
+const VARNAM_DEMOTED_TOKENIZER_SCORE_FACTOR = 0.5
+
+// Ranker to use, for setting it from C. See Varnam.Ranker
+const VARNAM_RANKER_CONFIDENCE_FIRST = 0 // ConfidenceFirstRanker
+const VARNAM_RANKER_RECENCY_BLENDED = 1  // RecencyBlendedRanker with default values
+
+// VARNAM_RANKER_RECENCY_WEIGHT How much recency counts in RecencyBlendedRanker
+const VARNAM_RANKER_RECENCY_WEIGHT = 0.5
+
+// VARNAM_RANKER_RECENCY_HALF_LIFE Recency of a learnt word halves after this
+const VARNAM_RANKER_RECENCY_HALF_LIFE = 7 * 24 * time.Hour
+
+// VARNAM_CORRECTIONS_CANDIDATES Number of top tokenizer outputs
+// corrections are searched for. See Varnam.SuggestCorrections
+const VARNAM_CORRECTIONS_CANDIDATES = 3
+
+// VARNAM_CORRECTIONS_MAX_DISTANCE Most characters a correction can differ by
+const VARNAM_CORRECTIONS_MAX_DISTANCE = 2
+
+// VARNAM_CORRECTION_SCORE_FACTOR Score of corrections is reduced by this for every differing character
+const VARNAM_CORRECTION_SCORE_FACTOR = 0.5
+
+// VARNAM_QUICK_BUDGET Default time a quick transliteration gets. See Varnam.QuickBudget
+const VARNAM_QUICK_BUDGET = 30 * time.Millisecond
+
+// VARNAM_QUICK_DICTIONARY_SUGGESTIONS Number of dictionary suggestions given in quick mode
+const VARNAM_QUICK_DICTIONARY_SUGGESTIONS = 3
+
+// VARNAM_DICTIONARY_SUGGESTIONS_LIMIT Default of Varnam.DictionarySuggestionsLimit
+const VARNAM_DICTIONARY_SUGGESTIONS_LIMIT = 5
+
+// VARNAM_PATTERN_DICTIONARY_SUGGESTIONS_LIMIT Default of Varnam.PatternDictionarySuggestionsLimit
+const VARNAM_PATTERN_DICTIONARY_SUGGESTIONS_LIMIT = 5
+
+// VARNAM_TOKENIZER_SUGGESTIONS_LIMIT Default of Varnam.TokenizerSuggestionsLimit
+const VARNAM_TOKENIZER_SUGGESTIONS_LIMIT = 10
+
+// VARNAM_DICTIONARY_READ_CONNECTIONS Default number of connections dictionary is read from.
+// See Varnam.SetDictionaryReadConnections
+const VARNAM_DICTIONARY_READ_CONNECTIONS = 4
+
 const CHIL_TAG = "chill"
 
 /* VST creation */
@@ -73,6 +174,11 @@ const VARNAM_METADATA_SCHEME_DISPLAY_NAME = "scheme-display-name"
 const VARNAM_METADATA_SCHEME_AUTHOR = "scheme-author"
 const VARNAM_METADATA_SCHEME_COMPILED_DATE = "scheme-compiled-date"
 const VARNAM_METADATA_SCHEME_STABLE = "scheme-stable"
+const VARNAM_METADATA_SCHEME_NATIVE_DISPLAY_NAME = "scheme-native-display-name"
+const VARNAM_METADATA_SCHEME_VERSION = "scheme-version"
+const VARNAM_METADATA_SCHEME_MIN_LIBRARY_VERSION = "scheme-min-library-version"
+const VARNAM_METADATA_SCHEME_BASE = "scheme-base"
+const VARNAM_METADATA_SCHEME_END_OF_WORD_LOOKUP = "scheme-end-of-word-lookup"
 
 var VARNAM_VST_DIR = os.Getenv("VARNAM_VST_DIR")
 var VARNAM_LEARNINGS_DIR = os.Getenv("VARNAM_LEARNINGS_DIR")
@@ -87,15 +193,40 @@ func SetLearningsDir(path string) {
 	VARNAM_LEARNINGS_DIR = path
 }
 
+// Directories added with AddVSTLookupDir
+var userVSTLookupDirs []string
+
+// AddVSTLookupDir look for VSTs in dir too. These are looked
+// in after VARNAM_VST_DIR and before the standard directories
+func AddVSTLookupDir(dir string) {
+	userVSTLookupDirs = append(userVSTLookupDirs, dir)
+}
+
+// UserVSTDir directory for VSTs installed by the user, without root.
+// VSTs here are found by InitFromID
+func UserVSTDir() string {
+	home := os.Getenv("XDG_DATA_HOME")
+	if home != "" {
+		return path.Join(home, "varnam", "schemes")
+	}
+	return path.Join(os.Getenv("HOME"), ".local", "share", "varnam", "schemes")
+}
+
 // VARNAM_VST_DIR VST lookup directories according to priority
 func getVSTLookupDirs() []string {
-	return []string{
+	dirs := []string{
 		// libvarnam used to use "vst" folder
 		VARNAM_VST_DIR,
+	}
+	dirs = append(dirs, userVSTLookupDirs...)
+
+	return append(
+		dirs,
 		"schemes",
+		UserVSTDir(),
 		"/usr/local/share/varnam/schemes",
 		"/usr/share/varnam/schemes",
-	}
+	)
 }
 
 //FindVSTDir Get the VST storing directory
@@ -0,0 +1,95 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+// ControlCharacters characters that don't make a letter but
+// control how the word is made. Schemes usually have _ as
+// non-joiner. Setting Varnam.ControlCharacters replaces the
+// scheme's joiner & non-joiner patterns with these.
+type ControlCharacters struct {
+	// Gives a zero width joiner (ZWJ). Empty for none
+	Joiner string
+
+	// Gives a zero width non-joiner (ZWNJ). Empty for none
+	NonJoiner string
+
+	// The character after this is kept as is, without
+	// transliterating. Eg: with |, "|_" gives "_". Empty for none
+	Escape string
+}
+
+func hasRunePrefix(runes []rune, prefix string) bool {
+	prefixRunes := []rune(prefix)
+	if len(prefixRunes) == 0 || len(prefixRunes) > len(runes) {
+		return false
+	}
+	for i, char := range prefixRunes {
+		if runes[i] != char {
+			return false
+		}
+	}
+	return true
+}
+
+func makeJoinerToken(symbolType int, pattern string, value string, position int) Token {
+	symbol := Symbol{
+		Type:      symbolType,
+		MatchType: VARNAM_MATCH_EXACT,
+		Pattern:   pattern,
+		Value1:    value,
+		Value2:    value,
+	}
+	return Token{VARNAM_TOKEN_SYMBOL, []Symbol{symbol}, position + len([]rune(pattern)) - 1, pattern}
+}
+
+// Make a token if input at position starts with a control character.
+// Returns the token and number of characters (runes) it took
+func (controls *ControlCharacters) tokenize(runes []rune, position int) (Token, int, bool) {
+	input := runes[position:]
+
+	if hasRunePrefix(input, controls.Escape) {
+		length := len([]rune(controls.Escape))
+
+		if length == len(input) {
+			// Nothing to escape, escape is kept as is
+			return Token{VARNAM_TOKEN_CHAR, []Symbol{}, position, controls.Escape}, length, true
+		}
+		return Token{VARNAM_TOKEN_CHAR, []Symbol{}, position + length, string(input[length])}, length + 1, true
+	}
+
+	if hasRunePrefix(input, controls.NonJoiner) {
+		return makeJoinerToken(VARNAM_SYMBOL_NON_JOINER, controls.NonJoiner, ZWNJ, position), len([]rune(controls.NonJoiner)), true
+	}
+
+	if hasRunePrefix(input, controls.Joiner) {
+		return makeJoinerToken(VARNAM_SYMBOL_JOINER, controls.Joiner, ZWJ, position), len([]rune(controls.Joiner)), true
+	}
+
+	return Token{}, 0, false
+}
+
+// Cut sequence before the first control character in it
+// so that patterns of the scheme won't take it in
+func (controls *ControlCharacters) cut(sequence []rune) []rune {
+	for i := 1; i < len(sequence); i++ {
+		if hasRunePrefix(sequence[i:], controls.Escape) || hasRunePrefix(sequence[i:], controls.NonJoiner) || hasRunePrefix(sequence[i:], controls.Joiner) {
+			return sequence[:i]
+		}
+	}
+	return sequence
+}
+
+// Scheme's joiner patterns are not used when ControlCharacters is set
+func removeJoinerSymbols(symbols []Symbol) []Symbol {
+	var result []Symbol
+	for _, symbol := range symbols {
+		if symbol.Type != VARNAM_SYMBOL_JOINER && symbol.Type != VARNAM_SYMBOL_NON_JOINER {
+			result = append(result, symbol)
+		}
+	}
+	return result
+}
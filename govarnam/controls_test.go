@@ -0,0 +1,35 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestMLControlCharacters(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	greedy := func(input string) string {
+		return varnam.TransliterateGreedyTokenized(input)[0].Word
+	}
+
+	// Scheme's _ is non-joiner
+	assertEqual(t, greedy("thaazh_vara"), "താഴ്"+ZWNJ+"വര")
+
+	varnam.ControlCharacters = &ControlCharacters{Joiner: "^", NonJoiner: "~", Escape: "|"}
+	defer func() {
+		varnam.ControlCharacters = nil
+	}()
+
+	// _ is a normal character now
+	assertEqual(t, greedy("thaazh_vara"), "താഴ്_വര")
+	assertEqual(t, greedy("thaazh~vara"), "താഴ്"+ZWNJ+"വര")
+	assertEqual(t, greedy("avan^"), "അവന്"+ZWJ)
+
+	// Escaped characters are kept as is
+	assertEqual(t, greedy("mala|~"), "മല~")
+	assertEqual(t, greedy("mala||"), "മല|")
+	assertEqual(t, greedy("mala|"), "മല|")
+
+	// Controls can't be a part of scheme's patterns
+	varnam.ControlCharacters = &ControlCharacters{NonJoiner: "a"}
+	assertEqual(t, greedy("ma"), "മ്"+ZWNJ)
+}
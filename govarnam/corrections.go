@@ -0,0 +1,165 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+)
+
+// Number of edits (insert, delete, substitute a character) to make a into b
+func editDistance(a []rune, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+// Edits allowed for a word, short words get less
+// so that corrections are not entirely different words
+func correctionMaxDistance(word []rune) int {
+	distance := len(word) / 3
+	if distance > VARNAM_CORRECTIONS_MAX_DISTANCE {
+		distance = VARNAM_CORRECTIONS_MAX_DISTANCE
+	}
+	return distance
+}
+
+// Whether nothing was found in dictionary for the input
+func hasNoDictionaryMatches(result TransliterationResult) bool {
+	return len(result.ExactWords) == 0 &&
+		len(result.ExactMatches) == 0 &&
+		len(result.DictionarySuggestions) == 0 &&
+		len(result.PatternDictionarySuggestions) == 0 &&
+		len(result.FuzzySuggestions) == 0
+}
+
+// Dictionary words starting with the same letter as word, and of
+// length within maxDistance. Edit distance is found after this
+func (varnam *Varnam) getCorrectionCandidates(ctx context.Context, word []rune, maxDistance int) []Suggestion {
+	var results []Suggestion
+
+	select {
+	case <-ctx.Done():
+		return results
+	default:
+		queryCtx, cancel := varnam.watchdogContext(ctx)
+		defer cancel()
+		defer varnam.watchdogCheck(queryCtx, ctx, string(word))
+
+		countQuery(ctx)
+
+		// Range on word so that index is used
+		rows, err := varnam.dictConn.QueryContext(
+			queryCtx,
+			"SELECT word, weight, learned_on FROM words WHERE word >= ? AND word < ? AND LENGTH(word) BETWEEN ? AND ?",
+			string(word[0]),
+			string(word[0]+1),
+			len(word)-maxDistance,
+			len(word)+maxDistance,
+		)
+		if err != nil {
+			log.Print(err)
+			return results
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item Suggestion
+			rows.Scan(&item.Word, &item.Weight, &item.LearnedOn)
+			results = append(results, item)
+		}
+
+		err = rows.Err()
+		if err != nil {
+			log.Print(err)
+		}
+
+		return results
+	}
+}
+
+// Find dictionary words a few edits away from the top
+// tokenizer outputs. For when input was misspelt and so
+// nothing was found in dictionary
+func (varnam *Varnam) getCorrections(ctx context.Context, result TransliterationResult, opts TransliterateOptions) []Suggestion {
+	var (
+		candidates []string
+		sugs       []Suggestion
+	)
+
+	for _, sug := range dedupSuggestions(append(append([]Suggestion{}, result.GreedyTokenized...), result.TokenizerSuggestions...)) {
+		if len(candidates) == VARNAM_CORRECTIONS_CANDIDATES {
+			break
+		}
+		candidates = append(candidates, sug.Word)
+	}
+
+	// Least edits a word is away from any candidate
+	distances := map[string]int{}
+
+	for _, candidate := range candidates {
+		word := []rune(candidate)
+
+		maxDistance := correctionMaxDistance(word)
+		if maxDistance == 0 {
+			continue
+		}
+
+		for _, sug := range varnam.getCorrectionCandidates(ctx, word, maxDistance) {
+			distance := editDistance(word, []rune(sug.Word))
+			if distance == 0 || distance > maxDistance {
+				continue
+			}
+
+			if previous, found := distances[sug.Word]; !found || distance < previous {
+				distances[sug.Word] = distance
+			}
+			sugs = append(sugs, sug)
+		}
+	}
+
+	sugs = varnam.rankDictionarySuggestions(ctx, RankSourceCorrections, varnam.filterDictionarySuggestions(ctx, opts, dedupSuggestions(sugs)))
+
+	sort.SliceStable(sugs, func(i, j int) bool {
+		return distances[sugs[i].Word] < distances[sugs[j].Word]
+	})
+
+	if len(sugs) > varnam.DictionarySuggestionsLimit {
+		sugs = sugs[:varnam.DictionarySuggestionsLimit]
+	}
+
+	for i := range sugs {
+		sugs[i].Score *= math.Pow(VARNAM_CORRECTION_SCORE_FACTOR, float64(distances[sugs[i].Word]))
+	}
+
+	return sugs
+}
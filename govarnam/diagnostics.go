@@ -0,0 +1,43 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// TransliterationDiagnostics where time went in a transliteration.
+// Stages run in parallel, so each duration is from the start
+// of transliteration till that stage finished.
+// Only made if Varnam.CollectDiagnostics is set
+type TransliterationDiagnostics struct {
+	Tokenization      time.Duration
+	Dictionary        time.Duration
+	PatternDictionary time.Duration
+	GreedyTokenized   time.Duration
+	Tokenizer         time.Duration
+	Total             time.Duration
+
+	// Number of VST & learnings DB queries ran
+	Queries int64
+}
+
+type queryCounterKey struct{}
+
+func withQueryCounter(ctx context.Context) (context.Context, *int64) {
+	counter := new(int64)
+	return context.WithValue(ctx, queryCounterKey{}, counter), counter
+}
+
+// Count a query if diagnostics are being collected
+func countQuery(ctx context.Context) {
+	if counter, ok := ctx.Value(queryCounterKey{}).(*int64); ok {
+		atomic.AddInt64(counter, 1)
+	}
+}
@@ -0,0 +1,27 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestMLDiagnostics(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	assertEqual(t, varnam.TransliterateAdvanced("malayalam").Diagnostics == nil, true)
+
+	varnam.CollectDiagnostics = true
+	defer func() { varnam.CollectDiagnostics = false }()
+
+	diagnostics := varnam.TransliterateAdvanced("malayalam").Diagnostics
+
+	assertEqual(t, diagnostics.Tokenization > 0, true)
+	assertEqual(t, diagnostics.Dictionary >= diagnostics.Tokenization, true)
+	assertEqual(t, diagnostics.PatternDictionary >= diagnostics.Tokenization, true)
+	assertEqual(t, diagnostics.GreedyTokenized >= diagnostics.Tokenization, true)
+	assertEqual(t, diagnostics.Total >= diagnostics.Dictionary, true)
+	assertEqual(t, diagnostics.Queries > 0, true)
+
+	// Longer words need more VST lookups
+	longer := varnam.TransliterateAdvanced("malayalamanu").Diagnostics
+	assertEqual(t, longer.Queries > diagnostics.Queries, true)
+}
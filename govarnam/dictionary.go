@@ -57,7 +57,7 @@ type searchDictionaryResult struct {
 func (varnam *Varnam) InitDict(dictPath string) error {
 	var err error
 
-	if !fileExists(dictPath) {
+	if !dbPathIsName && !fileExists(dictPath) {
 		log.Printf("Making Varnam Learnings Dir for %s\n", dictPath)
 		err := os.MkdirAll(path.Dir(dictPath), 0750)
 		if err != nil {
@@ -65,10 +65,20 @@ func (varnam *Varnam) InitDict(dictPath string) error {
 		}
 	}
 
-	varnam.dictConn, err = openDB(dictPath)
+	varnam.dictConn, err = openReadOnlyDB(dictPath)
 	if err != nil {
 		return err
 	}
+	varnam.SetDictionaryReadConnections(VARNAM_DICTIONARY_READ_CONNECTIONS)
+
+	// Writes are made one at a time on a connection of their own.
+	// With WAL, reads on dictConn aren't blocked by them
+	varnam.dictWriteConn, err = openDB(dictPath)
+	if err != nil {
+		varnam.dictConn.Close()
+		return err
+	}
+	varnam.dictWriteConn.SetMaxOpenConns(1)
 
 	varnam.DictPath = dictPath
 
@@ -78,7 +88,7 @@ func (varnam *Varnam) InitDict(dictPath string) error {
 		return err
 	}
 
-	mg, err := InitMigrate(varnam.dictConn, migrationsFS)
+	mg, err := InitMigrate(varnam.dictWriteConn, migrationsFS)
 	if err != nil {
 		return err
 	}
@@ -87,18 +97,48 @@ func (varnam *Varnam) InitDict(dictPath string) error {
 	if ranMigrations != 0 {
 		log.Printf("ran %d migrations", ranMigrations)
 	}
+	if err != nil {
+		return err
+	}
+
+	err = varnam.loadSymbolOverrides()
+	if err == nil {
+		err = varnam.loadSymbolWeights()
+	}
 
 	// Since SQLite v3.12.0, default page size is 4096
-	varnam.dictConn.Exec("PRAGMA page_size=4096;")
+	varnam.dictWriteConn.Exec("PRAGMA page_size=4096;")
 	// WAL makes writes & reads happen concurrently => significantly fast
-	varnam.dictConn.Exec("PRAGMA journal_mode=wal;")
+	varnam.dictWriteConn.Exec("PRAGMA journal_mode=wal;")
 
 	return err
 }
 
+// SetDictionaryReadConnections set how many dictionary lookups can
+// be made at the same time. Connections are kept open, lookups
+// wait for a free one when all are in use. WAL lets them read
+// while learnings are being written
+func (varnam *Varnam) SetDictionaryReadConnections(n int) {
+	if n < 1 {
+		n = 1
+	}
+	varnam.dictConn.SetMaxOpenConns(n)
+	varnam.dictConn.SetMaxIdleConns(n)
+}
+
 // ReIndexDictionary re-indexes dictionary
 func (varnam *Varnam) ReIndexDictionary() error {
-	_, err := varnam.dictConn.Exec("INSERT INTO words_fts(words_fts) VALUES('rebuild');")
+	_, err := varnam.dictWriteConn.Exec("INSERT INTO words_fts(words_fts) VALUES('rebuild');")
+	return err
+}
+
+// Checkpoint write learnings in the WAL to the learnings file & truncate
+// the WAL. Close does this, long running servers can do it when idle
+func (varnam *Varnam) Checkpoint() error {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFunc()
+
+	_, err := varnam.dictWriteConn.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE);")
 	return err
 }
 
@@ -177,10 +217,15 @@ func (varnam *Varnam) searchDictionary(ctx context.Context, words []string, sear
 				`
 			vals = append(vals, varnam.DictionarySuggestionsLimit)
 		} else if searchType == searchExactWords {
-			query = "SELECT * FROM words WHERE word IN ((?) " + likes + ")"
+			query = "SELECT id, word, weight, learned_on FROM words WHERE word IN ((?) " + likes + ")"
 		}
 
-		rows, err := varnam.dictConn.QueryContext(ctx, query, vals...)
+		queryCtx, cancel := varnam.watchdogContext(ctx)
+		defer cancel()
+		defer varnam.watchdogCheck(queryCtx, ctx, words)
+
+		countQuery(ctx)
+		rows, err := varnam.dictConn.QueryContext(queryCtx, query, vals...)
 
 		if err != nil {
 			log.Print(err)
@@ -358,7 +403,12 @@ func (varnam *Varnam) getFromPatternDictionary(ctx context.Context, pattern stri
 	case <-ctx.Done():
 		return results
 	default:
-		rows, err := varnam.dictConn.QueryContext(ctx, "SELECT LENGTH(pts.pattern), w.word, w.weight, w.learned_on FROM `patterns` pts LEFT JOIN words w ON w.id = pts.word_id WHERE ? LIKE (pts.pattern || '%') OR pattern LIKE ? ORDER BY LENGTH(pts.pattern) DESC LIMIT ?", pattern, pattern+"%", varnam.PatternDictionarySuggestionsLimit)
+		queryCtx, cancel := varnam.watchdogContext(ctx)
+		defer cancel()
+		defer varnam.watchdogCheck(queryCtx, ctx, pattern)
+
+		countQuery(ctx)
+		rows, err := varnam.dictConn.QueryContext(queryCtx, "SELECT LENGTH(pts.pattern), w.word, w.weight, w.learned_on FROM `patterns` pts LEFT JOIN words w ON w.id = pts.word_id WHERE ? LIKE (pts.pattern || '%') OR pattern LIKE ? ORDER BY LENGTH(pts.pattern) DESC LIMIT ?", pattern, pattern+"%", varnam.PatternDictionarySuggestionsLimit)
 
 		if err != nil {
 			log.Print(err)
@@ -414,6 +464,46 @@ func (varnam *Varnam) GetRecentlyLearntWords(ctx context.Context, offset int, li
 	}
 }
 
+// GetRandomLearnedWords get a random sample of words learnt by user.
+// If preferLessUsed is true, words that haven't been used for
+// a long time are more likely to come. For practice/quiz features
+func (varnam *Varnam) GetRandomLearnedWords(ctx context.Context, limit int, preferLessUsed bool) ([]Suggestion, error) {
+	var result []Suggestion
+
+	select {
+	case <-ctx.Done():
+		return result, nil
+	default:
+		order := "RANDOM()"
+		if preferLessUsed {
+			// Random number scaled by seconds since last use.
+			// An hour is added so that just learnt words have a chance too
+			order = "(ABS(RANDOM()) % 1000000) * (strftime('%s', 'now') - learned_on + 3600) DESC"
+		}
+
+		rows, err := varnam.dictConn.QueryContext(ctx, "SELECT word, weight, learned_on FROM words WHERE learned_on > 0 ORDER BY "+order+" LIMIT ?", limit)
+
+		if err != nil {
+			return result, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item Suggestion
+			rows.Scan(&item.Word, &item.Weight, &item.LearnedOn)
+			result = append(result, item)
+		}
+
+		err = rows.Err()
+		if err != nil {
+			log.Print(err)
+			return result, err
+		}
+
+		return result, nil
+	}
+}
+
 // GetSuggestions get word suggestions from dictionary
 func (varnam *Varnam) GetSuggestions(ctx context.Context, word string) []Suggestion {
 	var sugs []Suggestion
@@ -436,6 +526,7 @@ func convertSearchDictResultToSuggestion(searchResults []searchDictionaryResult,
 			searchResults[i].match,
 			searchResults[i].weight,
 			searchResults[i].learnedOn,
+			0,
 		}
 		if word {
 			sug.Word = searchResults[i].word
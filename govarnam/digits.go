@@ -0,0 +1,24 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import "context"
+
+type indicDigitsKey struct{}
+
+// Override LangRules.IndicDigits for everything done with ctx
+func withIndicDigits(ctx context.Context, indicDigits bool) context.Context {
+	return context.WithValue(ctx, indicDigitsKey{}, indicDigits)
+}
+
+// Whether 0-9 should be made native digits
+func (varnam *Varnam) useIndicDigits(ctx context.Context) bool {
+	if indicDigits, ok := ctx.Value(indicDigitsKey{}).(bool); ok {
+		return indicDigits
+	}
+	return varnam.LangRules.IndicDigits
+}
@@ -0,0 +1,173 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// The word as it is stored by Learn
+func (varnam *Varnam) learntForm(word string) string {
+	return strings.Join(varnam.splitWordByConjunct(varnam.sanitizeWord(word)), "")
+}
+
+// Put an already learnt word in a domain
+func (varnam *Varnam) addWordToDomain(word string, domain string) error {
+	defer varnam.dictionaryChanged()
+
+	domain = strings.TrimSpace(domain)
+	if domain == "" {
+		return fmt.Errorf("Domain can't be empty")
+	}
+
+	wordInfo, err := varnam.getWordInfo(word)
+	if err != nil {
+		return err
+	}
+
+	_, err = varnam.dictWriteConn.Exec("INSERT OR IGNORE INTO word_domains(word_id, domain) VALUES (?, ?)", wordInfo.id, domain)
+	return err
+}
+
+// LearnInDomain learn a word as part of a domain like "medical".
+// Choose domains when transliterating with TransliterateOptions
+func (varnam *Varnam) LearnInDomain(word string, weight int, domain string) error {
+	err := varnam.Learn(word, weight)
+	if err != nil {
+		return err
+	}
+
+	return varnam.addWordToDomain(varnam.learntForm(word), domain)
+}
+
+// TrainInDomain train a pattern => word as part of a domain
+func (varnam *Varnam) TrainInDomain(pattern string, word string, domain string) error {
+	err := varnam.Train(pattern, word)
+	if err != nil {
+		return err
+	}
+
+	return varnam.addWordToDomain(varnam.learntForm(word), domain)
+}
+
+// RemoveFromDomain take out a word from a domain.
+// It stays learnt as a general word if it's not in any other domain
+func (varnam *Varnam) RemoveFromDomain(word string, domain string) error {
+	defer varnam.dictionaryChanged()
+
+	_, err := varnam.dictWriteConn.Exec(
+		"DELETE FROM word_domains WHERE domain = ? AND word_id = (SELECT id FROM words WHERE word = ?)",
+		domain,
+		varnam.sanitizeWord(word),
+	)
+	return err
+}
+
+// GetDomains get all domains that has words
+func (varnam *Varnam) GetDomains() ([]string, error) {
+	var domains []string
+
+	rows, err := varnam.dictConn.Query("SELECT DISTINCT domain FROM word_domains ORDER BY domain")
+	if err != nil {
+		return domains, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var domain string
+		rows.Scan(&domain)
+		domains = append(domains, domain)
+	}
+
+	return domains, rows.Err()
+}
+
+// Find domains of words. Words without domains won't be in result
+func (varnam *Varnam) getWordDomains(ctx context.Context, words []string) (map[string][]string, error) {
+	wordDomains := map[string][]string{}
+
+	limitVariableNumber := getSQLiteLimit(sqliteLimitVariableNumber)
+
+	for len(words) > 0 {
+		lastIndex := int(math.Min(float64(limitVariableNumber), float64(len(words))))
+
+		var args []interface{}
+		for _, word := range words[0:lastIndex] {
+			args = append(args, word)
+		}
+
+		countQuery(ctx)
+		rows, err := varnam.dictConn.QueryContext(
+			ctx,
+			"SELECT w.word, d.domain FROM word_domains d INNER JOIN words w ON w.id = d.word_id WHERE w.word IN (?"+strings.Repeat(", ?", lastIndex-1)+")",
+			args...,
+		)
+		if err != nil {
+			return wordDomains, err
+		}
+
+		for rows.Next() {
+			var word, domain string
+			rows.Scan(&word, &domain)
+			wordDomains[word] = append(wordDomains[word], domain)
+		}
+		rows.Close()
+
+		words = words[lastIndex:]
+	}
+
+	return wordDomains, nil
+}
+
+// Boost or leave out domain words according to options
+func (varnam *Varnam) applyDomains(ctx context.Context, opts TransliterateOptions, sugs []Suggestion) []Suggestion {
+	if len(sugs) == 0 || (len(opts.Domains) == 0 && !opts.RestrictToDomains) {
+		return sugs
+	}
+
+	var words []string
+	for _, sug := range sugs {
+		words = append(words, sug.Word)
+	}
+
+	wordDomains, err := varnam.getWordDomains(ctx, words)
+	if err != nil || len(wordDomains) == 0 {
+		return sugs
+	}
+
+	var result []Suggestion
+
+	for _, sug := range sugs {
+		domains, inDomain := wordDomains[sug.Word]
+		if !inDomain {
+			// General word
+			result = append(result, sug)
+			continue
+		}
+
+		chosen := false
+		for _, domain := range domains {
+			for _, optDomain := range opts.Domains {
+				if strings.EqualFold(domain, optDomain) {
+					chosen = true
+				}
+			}
+		}
+
+		if chosen {
+			sug.Weight += VARNAM_DOMAIN_WEIGHT_BOOST
+			result = append(result, sug)
+		} else if !opts.RestrictToDomains {
+			result = append(result, sug)
+		}
+	}
+
+	return result
+}
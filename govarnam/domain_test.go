@@ -0,0 +1,59 @@
+package govarnam
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMLDomains(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("പനി", 0))
+	checkError(varnam.Learn("പനി", 0))
+	checkError(varnam.LearnInDomain("പനിനീർ", 0, "medical"))
+
+	contains := func(sugs []Suggestion, word string) bool {
+		for _, sug := range sugs {
+			if sug.Word == word {
+				return true
+			}
+		}
+		return false
+	}
+
+	ctx := context.Background()
+
+	// Domain words are given normally without options
+	result := varnam.TransliterateAdvanced("pani")
+	assertEqual(t, contains(result.DictionarySuggestions, "പനിനീർ"), true)
+
+	// Left out from everyday typing
+	result = varnam.TransliterateAdvancedWithOptions(ctx, "pani", TransliterateOptions{RestrictToDomains: true})
+	assertEqual(t, contains(result.DictionarySuggestions, "പനിനീർ"), false)
+	assertEqual(t, contains(result.ExactWords, "പനി"), true)
+
+	// Boosted when domain is chosen
+	result = varnam.TransliterateAdvancedWithOptions(ctx, "pani", TransliterateOptions{Domains: []string{"Medical"}, RestrictToDomains: true})
+	assertEqual(t, result.DictionarySuggestions[0].Word, "പനിനീർ")
+	assertEqual(t, result.DictionarySuggestions[0].Weight >= VARNAM_LEARNT_WORD_MIN_WEIGHT+VARNAM_DOMAIN_WEIGHT_BOOST, true)
+
+	domains, err := varnam.GetDomains()
+	checkError(err)
+	assertEqual(t, len(domains), 1)
+	assertEqual(t, domains[0], "medical")
+
+	checkError(varnam.RemoveFromDomain("പനിനീർ", "medical"))
+
+	result = varnam.TransliterateAdvancedWithOptions(ctx, "pani", TransliterateOptions{RestrictToDomains: true})
+	assertEqual(t, contains(result.DictionarySuggestions, "പനിനീർ"), true)
+
+	// Unlearning removes it from domains
+	checkError(varnam.TrainInDomain("rosewater", "പനിനീർ", "medical"))
+	checkError(varnam.Unlearn("പനിനീർ"))
+
+	domains, err = varnam.GetDomains()
+	checkError(err)
+	assertEqual(t, len(domains), 0)
+
+	checkError(varnam.Unlearn("പനി"))
+}
@@ -0,0 +1,127 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Vowels that are commonly missed while typing
+const fuzzyMissedVowels = "aeiou"
+
+// An input one typo away and how likely the typo is
+type fuzzyEdit struct {
+	input      string
+	likelihood float64
+}
+
+// Inputs that are one typo away from input. Handles :
+//
+//	doubled letter (mallayalam => malayalam)
+//	letter that should've been doubled (malayalm => mallayalm)
+//	missing vowel (malayalm => malayalam)
+//	wrong letter as per typo model (malayslam => malayalam)
+func fuzzyEdits(input string, model TypoModel) []fuzzyEdit {
+	var edits []fuzzyEdit
+
+	// Index of an edit in edits
+	seen := map[string]int{input: -1}
+
+	add := func(edit string, likelihood float64) {
+		if i, found := seen[edit]; found {
+			if i >= 0 && edits[i].likelihood < likelihood {
+				edits[i].likelihood = likelihood
+			}
+			return
+		}
+		seen[edit] = len(edits)
+		edits = append(edits, fuzzyEdit{edit, likelihood})
+	}
+
+	runes := []rune(input)
+
+	for i := range runes {
+		if i > 0 && runes[i] == runes[i-1] {
+			add(string(runes[:i])+string(runes[i+1:]), 1)
+		}
+	}
+
+	for i := range runes {
+		add(string(runes[:i+1])+string(runes[i:]), 1)
+	}
+
+	for i := 0; i <= len(runes); i++ {
+		for _, vowel := range fuzzyMissedVowels {
+			add(string(runes[:i])+string(vowel)+string(runes[i:]), 1)
+		}
+	}
+
+	if model != nil {
+		for i, char := range runes {
+			for _, substitute := range model.Substitutes(char) {
+				add(string(runes[:i])+string(substitute.Char)+string(runes[i+1:]), substitute.Likelihood)
+			}
+		}
+	}
+
+	return edits
+}
+
+// Learnt words that exactly match an input one typo away from word.
+// Words from more likely typos come first
+func (varnam *Varnam) getFuzzySuggestions(ctx context.Context, word string, opts TransliterateOptions) []Suggestion {
+	var sugs []Suggestion
+
+	// Likelihood of the typo that gave the word
+	likelihoods := map[string]float64{}
+
+	// Edits share most of their symbols
+	if getSymbolCache(ctx) == nil {
+		ctx = context.WithValue(ctx, symbolCacheContextKey{}, newSymbolCache())
+	}
+
+	for _, edit := range fuzzyEdits(strings.TrimSpace(word), varnam.TypoModel) {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		tokens := varnam.tokenizeWord(ctx, edit.input, VARNAM_MATCH_ALL, false)
+		if len(*tokens) == 0 {
+			continue
+		}
+
+		dictResult := varnam.getFromDictionary(ctx, tokens)
+		if len(dictResult.exactMatches) == 0 {
+			continue
+		}
+
+		for _, sug := range varnam.getMoreFromDictionary(ctx, dictResult.exactMatches).exactWords {
+			if edit.likelihood > likelihoods[sug.Word] {
+				likelihoods[sug.Word] = edit.likelihood
+			}
+			sugs = append(sugs, sug)
+		}
+	}
+
+	sugs = varnam.rankDictionarySuggestions(ctx, RankSourceFuzzy, varnam.filterDictionarySuggestions(ctx, opts, dedupSuggestions(sugs)))
+
+	sort.SliceStable(sugs, func(i, j int) bool {
+		return likelihoods[sugs[i].Word] > likelihoods[sugs[j].Word]
+	})
+
+	if len(sugs) > varnam.DictionarySuggestionsLimit {
+		sugs = sugs[:varnam.DictionarySuggestionsLimit]
+	}
+
+	for i := range sugs {
+		sugs[i].Score *= VARNAM_FUZZY_SCORE_FACTOR * likelihoods[sugs[i].Word]
+	}
+
+	return sugs
+}
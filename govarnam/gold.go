@@ -0,0 +1,185 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// A gold file is a TSV of input and the word expected as the top
+// suggestion for it, one per line :
+//
+//	pazham	പഴം
+//	# comments and empty lines are skipped
+//
+// Scheme authors run it against a changed scheme to find words
+// that no longer come out right.
+
+// GoldCase result of a line in gold file
+type GoldCase struct {
+	// Line number in file
+	Line int
+
+	Input    string
+	Expected string
+
+	// Top suggestion, empty if there were no suggestions
+	Got string
+
+	// Position (from 1) of Expected in suggestions. 0 if not found
+	Rank int
+}
+
+// Passed whether expected word was the top suggestion
+func (gold GoldCase) Passed() bool {
+	return gold.Got == gold.Expected
+}
+
+// Diff how the top suggestion differs from expected word.
+// Empty if it passed
+func (gold GoldCase) Diff() string {
+	if gold.Passed() {
+		return ""
+	}
+
+	var diff strings.Builder
+
+	fmt.Fprintf(&diff, "%d: %s\n", gold.Line, gold.Input)
+	fmt.Fprintf(&diff, "- %s\n", gold.Expected)
+	fmt.Fprintf(&diff, "+ %s\n", gold.Got)
+
+	expected := []rune(gold.Expected)
+	got := []rune(gold.Got)
+
+	// Characters look alike in most fonts, show code points
+	i := 0
+	for i < len(expected) && i < len(got) && expected[i] == got[i] {
+		i++
+	}
+
+	describe := func(runes []rune) string {
+		if i >= len(runes) {
+			return "end of word"
+		}
+		return fmt.Sprintf("'%c' (%U)", runes[i], runes[i])
+	}
+	fmt.Fprintf(&diff, "  differs at character %d: expected %s, got %s\n", i+1, describe(expected), describe(got))
+
+	if gold.Rank > 0 {
+		fmt.Fprintf(&diff, "  expected word is suggestion #%d\n", gold.Rank)
+	} else {
+		fmt.Fprintf(&diff, "  expected word is not in suggestions\n")
+	}
+
+	return diff.String()
+}
+
+// GoldReport results of running a gold file
+type GoldReport struct {
+	Cases []GoldCase
+
+	Passed int
+	Failed int
+}
+
+// Failures cases that didn't pass
+func (report GoldReport) Failures() []GoldCase {
+	var failures []GoldCase
+	for _, gold := range report.Cases {
+		if !gold.Passed() {
+			failures = append(failures, gold)
+		}
+	}
+	return failures
+}
+
+// Write diffs of failed cases and a summary
+func (report GoldReport) Write(w io.Writer) error {
+	for _, gold := range report.Failures() {
+		_, err := fmt.Fprintln(w, gold.Diff())
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d passed, %d failed, %d total\n", report.Passed, report.Failed, len(report.Cases))
+	return err
+}
+
+// RunGoldTests transliterate inputs in a gold TSV and check if the
+// expected word comes as the top suggestion. Learnings in dictionary
+// affect suggestions, use an empty dictionary to test the scheme alone
+func (varnam *Varnam) RunGoldTests(ctx context.Context, r io.Reader) (GoldReport, error) {
+	var report GoldReport
+
+	scanner := bufio.NewScanner(r)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 || strings.TrimSpace(fields[0]) == "" || strings.TrimSpace(fields[1]) == "" {
+			return report, fmt.Errorf("Line %d is not in correct format, expected input<TAB>word", lineNumber)
+		}
+
+		gold := GoldCase{
+			Line:     lineNumber,
+			Input:    strings.TrimSpace(fields[0]),
+			Expected: strings.TrimSpace(fields[1]),
+		}
+
+		sugs := varnam.TransliterateWithOptions(ctx, gold.Input, TransliterateOptions{})
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+
+		if len(sugs) > 0 {
+			gold.Got = sugs[0].Word
+		}
+		for i, sug := range sugs {
+			if sug.Word == gold.Expected {
+				gold.Rank = i + 1
+				break
+			}
+		}
+
+		if gold.Passed() {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Cases = append(report.Cases, gold)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// RunGoldTestsFromFile RunGoldTests with a gold TSV file
+func (varnam *Varnam) RunGoldTestsFromFile(ctx context.Context, filePath string) (GoldReport, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return GoldReport{}, err
+	}
+	defer file.Close()
+
+	return varnam.RunGoldTests(ctx, file)
+}
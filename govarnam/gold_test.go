@@ -0,0 +1,34 @@
+package govarnam
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMLGoldTests(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	gold := "# words\n\npazham\tപഴം\nla\tള\n"
+
+	report, err := varnam.RunGoldTests(context.Background(), strings.NewReader(gold))
+	checkError(err)
+
+	assertEqual(t, len(report.Cases), 2)
+	assertEqual(t, report.Passed, 1)
+	assertEqual(t, report.Failed, 1)
+
+	failures := report.Failures()
+	assertEqual(t, len(failures), 1)
+	assertEqual(t, failures[0].Line, 4)
+	assertEqual(t, failures[0].Got, "ല")
+	assertEqual(t, failures[0].Rank > 1, true)
+	assertEqual(t, strings.Contains(failures[0].Diff(), "expected 'ള' (U+0D33), got 'ല' (U+0D32)"), true)
+
+	var out strings.Builder
+	checkError(report.Write(&out))
+	assertEqual(t, strings.HasSuffix(out.String(), "1 passed, 1 failed, 2 total\n"), true)
+
+	_, err = varnam.RunGoldTests(context.Background(), strings.NewReader("pazham\n"))
+	assertEqual(t, err != nil, true)
+}
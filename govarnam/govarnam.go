@@ -11,13 +11,12 @@ import (
 	sql "database/sql"
 	"fmt"
 	"log"
-	"sort"
+	"path"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
-
-	// sqlite3
-	_ "github.com/mattn/go-sqlite3"
 )
 
 // LangRules language reulated config
@@ -35,6 +34,28 @@ type SchemeDetails struct {
 	Author       string
 	CompiledDate string
 	IsStable     bool
+
+	// Name of the language in its own script. Eg: മലയാളം
+	NativeDisplayName string
+
+	// Version of the scheme
+	Version string
+
+	// Oldest govarnam version the scheme works with.
+	// InitVST fails on older versions
+	MinLibraryVersion string
+
+	// Identifier of the scheme this is a variant of.
+	// Symbols of base are used for patterns not in this
+	Base string
+
+	// Whether the last token of a word, if it's longer than a
+	// character, is looked up again for symbols only used at the
+	// end of a word. Eg: "sh" of "khush" is श and not श्
+	EndOfWordLookup bool
+
+	// Location of VST. Set by GetAllSchemeDetails
+	Path string
 }
 
 type VSTMakerConfig struct {
@@ -46,12 +67,48 @@ type VSTMakerConfig struct {
 }
 
 // Varnam config
+//
+// A Varnam is safe for concurrent use: transliterating, learning,
+// training, unlearning, symbol overrides & weights and registering
+// OnLearn, OnTrain & OnUnlearn hooks can happen from many goroutines.
+// Exported fields (config, PatternWordPartializers, SuggestionFilters
+// etc.) are not guarded, set them before using the instance from more
+// than one goroutine. ReloadScheme & Close should be called when it's
+// not in use. Sessions & Compositions are for one goroutine each
 type Varnam struct {
+	// Lookups reusing VST symbols cached by sessions & batches, see
+	// Status. Kept first for 64-bit alignment needed by sync/atomic
+	symbolCacheHits   int64
+	symbolCacheMisses int64
+
+	// Incremented when symbols change: on ReloadScheme, symbol
+	// overrides & weights. Sessions drop their cache then
+	schemeGeneration int64
+
+	// Incremented when learnings change. Cached results are dropped then
+	dictGeneration int64
+
 	VSTPath  string
 	DictPath string
 
-	vstConn  *sql.DB
-	dictConn *sql.DB
+	vstConn *sql.DB
+
+	// Learnings are read from dictConn, a pool of read only
+	// connections, and written to dictWriteConn, which has only one
+	dictConn      *sql.DB
+	dictWriteConn *sql.DB
+
+	// Set if VST is a mapped VST, vstConn is nil then
+	mappedVST *mappedVST
+
+	// To know whether VST file has changed, see ReloadSchemeIfChanged
+	vstFileInfo vstFileInfo
+
+	// User's symbols, see OverrideSymbol
+	symbolOverrides *symbolOverlay
+
+	// User's weights of symbols, see SetSymbolWeight
+	symbolWeights *symbolWeights
 
 	LangRules     LangRules
 	SchemeDetails SchemeDetails
@@ -59,6 +116,17 @@ type Varnam struct {
 
 	PatternWordPartializers []func(*Suggestion)
 
+	// Called in order with the merged suggestions before Transliterate
+	// & TransliterateGreedyTokenized return. See RegisterSuggestionFilter
+	SuggestionFilters []func(word string, sugs []Suggestion) []Suggestion
+
+	resultCache resultCache
+
+	hooksMutex   sync.RWMutex
+	learnHooks   []func(word string, weight int)
+	trainHooks   []func(pattern string, word string)
+	unlearnHooks []func(word string)
+
 	// Maximum suggestions to obtain from dictionary
 	DictionarySuggestionsLimit int
 
@@ -72,10 +140,114 @@ type Varnam struct {
 	// Tokenizer results are not exactly the best, but it's alright
 	TokenizerSuggestionsAlways bool
 
+	// Tokenizer made suggestions are noise when the dictionary has
+	// found at least this many ExactWords & ExactMatches. They're
+	// then dropped or demoted as per TokenizerSuggestionsOnThreshold.
+	// 0 disables
+	TokenizerSuggestionsThreshold int
+
+	// VARNAM_TOKENIZER_SUGGESTIONS_DEMOTE or VARNAM_TOKENIZER_SUGGESTIONS_DROP
+	TokenizerSuggestionsOnThreshold int
+
+	// Tokenizer permutations having VST weight less than this
+	// fraction (0 - 1) of the best permutation's weight are dropped.
+	// Relative because weight adds up with length of input. 0 disables
+	TokenizerWeightThreshold float64
+
+	// Dictionary & patterns dictionary are searched only if input
+	// has at least this many characters. Shorter inputs (like the
+	// first keystroke) get only tokenizer suggestions, which is fast.
+	// 0 always searches
+	DictionaryMinInputLength int
+
+	// Number of recent results kept, so that a word typed again is
+	// given without looking up. Learning anything or changing config
+	// drops them. 0 disables. See ClearResultCache
+	ResultCacheSize int
+
 	// Whether only exact scheme match should be considered
 	// for dictionary search and discard possibility matches
 	DictionaryMatchExact bool
 
+	// Hard ceiling for a single dictionary or VST lookup.
+	// Slower statements are interrupted and partial results are used.
+	// 0 disables it
+	QueryTimeout time.Duration
+
+	// A word found by more than one category is given only once
+	// by Transliterate, at its highest position. Set this for
+	// debugging to see every category's finding in the list.
+	// TransliterateAdvanced's categories always have everything
+	KeepDuplicateSuggestions bool
+
+	// How input having both native script and latin is tokenized.
+	// VARNAM_SCRIPT_BOUNDARY_JOIN tokenizes latin runs as parts of one
+	// word, VARNAM_SCRIPT_BOUNDARY_SPLIT tokenizes each latin run as a
+	// word of its own so that they get the starting & ending forms
+	ScriptBoundary int
+
+	// Characters acting as joiner, non-joiner & escape instead of
+	// the scheme's joiner patterns. nil to use the scheme's
+	ControlCharacters *ControlCharacters
+
+	// How input is read. VARNAM_INPUT_METHOD_PHONETIC makes words
+	// from patterns of the scheme. With VARNAM_INPUT_METHOD_INSCRIPT
+	// input is the keys typed on InScript keyboard layout (Eg: "kf"
+	// => कि). Learnings are the same for both
+	InputMethod int
+
+	// How zero width joiner & non-joiner are given in suggestions.
+	// See VARNAM_JOINER_POLICY_*. Old renderers (eg: old Android)
+	// need VARNAM_JOINER_POLICY_LEGACY to show chillus
+	JoinerPolicy int
+
+	// Match patterns of the scheme without considering case.
+	// When both e & E are in the scheme, both are tried and
+	// the one with more weight is preferred
+	CaseInsensitive bool
+
+	// Tamil: leave out Grantha letters (ஜ ஶ ஷ ஸ ஹ). Patterns give
+	// the native letters written instead (ja => ச) and learnt words
+	// having them are not suggested. TransliterateAdvanced's
+	// dictionary categories still have them
+	NoGrantha bool
+
+	// If no word is exactly found in dictionary, try inputs that
+	// are a typo away from it (doubled/missing letters) and give the
+	// found words as TransliterationResult.FuzzySuggestions.
+	// Makes a lot more dictionary lookups
+	FuzzyMatching bool
+
+	// Wrong characters fuzzy matching should try to correct.
+	// QWERTY keyboard by default. nil to not try substitutions
+	TypoModel TypoModel
+
+	// If nothing is found in dictionary, find learnt words a few
+	// characters away from tokenizer output and give them as
+	// TransliterationResult.Corrections
+	SuggestCorrections bool
+
+	// Time TransliterateQuick gets. Stages not done
+	// by then are left out. 0 disables the limit
+	QuickBudget time.Duration
+
+	// Record time taken by each stage and number of queries
+	// ran in TransliterationResult.Diagnostics. Adds a bit of overhead
+	CollectDiagnostics bool
+
+	// Traces transliteration & learning (LearnContext etc.).
+	// nil disables tracing
+	Tracer Tracer
+
+	// Orders dictionary suggestions having the same weight.
+	// Tokenizer suggestions are left in VST order.
+	// Set to nil to keep the order in which they were found.
+	Collator Collator
+
+	// Orders suggestions within each category of TransliterationResult.
+	// ConfidenceFirstRanker by default, see RecencyBlendedRanker
+	Ranker Ranker
+
 	VSTMakerConfig VSTMakerConfig
 
 	// See setDefaultConfig() for the default values
@@ -86,8 +258,32 @@ type Suggestion struct {
 	Word      string
 	Weight    int
 	LearnedOn int
+
+	// Weight normalized to 0 - 1, comparable between
+	// dictionary and tokenizer suggestions. See score.go
+	Score float64
 }
 
+// TransliterationStage part of TransliterationResult that got filled
+type TransliterationStage int
+
+const (
+	// TransliterationStageGreedyTokenized GreedyTokenized is ready
+	TransliterationStageGreedyTokenized TransliterationStage = iota + 1
+
+	// TransliterationStageDictionary ExactWords, ExactMatches and DictionarySuggestions are ready
+	TransliterationStageDictionary
+
+	// TransliterationStagePatternDictionary PatternDictionarySuggestions is ready
+	TransliterationStagePatternDictionary
+
+	// TransliterationStageTokenizer TokenizerSuggestions is ready
+	TransliterationStageTokenizer
+
+	// TransliterationStageComplete nothing more will come
+	TransliterationStageComplete
+)
+
 // TransliterationResult result
 type TransliterationResult struct {
 	// Exactly found words in dictionary if there is any.
@@ -108,11 +304,29 @@ type TransliterationResult struct {
 	// Has a limit. The first few results will be VARNAM_MATCH_EXACT.
 	// This will only be filled if there are no exact matches.
 	// Related: See Config.TokenizerSuggestionsAlways
+	// & Varnam.TokenizerWeightThreshold
 	TokenizerSuggestions []Suggestion
 
 	// VARNAM_MATCH_EXACT results from tokenizer.
 	// No limit, mostly gives 1 or less than 3 outputs
 	GreedyTokenized []Suggestion
+
+	// Learnt words found by correcting typos in input.
+	// Only if Varnam.FuzzyMatching is set
+	FuzzySuggestions []Suggestion
+
+	// "Did you mean" words from dictionary that are a few characters
+	// away from tokenizer output. Only if Varnam.SuggestCorrections
+	// is set and nothing else was found in dictionary. Not part of
+	// Transliterate's output since they're not what was typed
+	Corrections []Suggestion
+
+	// Only if Varnam.CollectDiagnostics is set
+	Diagnostics *TransliterationDiagnostics
+
+	// Native script & latin runs of the input.
+	// Only if the input has both. Native runs are kept as is
+	Segments []ScriptSegment
 }
 
 func (varnam *Varnam) log(msg string) {
@@ -121,6 +335,58 @@ func (varnam *Varnam) log(msg string) {
 	}
 }
 
+// Scratch space for making permutations of tokens. Pooled since
+// tokenizer runs on every keystroke and for every partial match
+type permutationBuffers struct {
+	// Symbol values of the word being made
+	word []string
+
+	// Index of the symbol used from each token
+	positions []int
+}
+
+var permutationBuffersPool = sync.Pool{
+	New: func() interface{} {
+		return &permutationBuffers{}
+	},
+}
+
+// Buffers for n tokens, positions start at 0
+func getPermutationBuffers(n int) *permutationBuffers {
+	buffers := permutationBuffersPool.Get().(*permutationBuffers)
+
+	if cap(buffers.word) < n {
+		buffers.word = make([]string, n)
+		buffers.positions = make([]int, n)
+	}
+	buffers.word = buffers.word[:n]
+	buffers.positions = buffers.positions[:n]
+
+	for i := range buffers.positions {
+		buffers.positions[i] = 0
+	}
+
+	return buffers
+}
+
+// Number of words tokens can make, up to limit
+func permutationsCount(tokens []Token, limit int) int {
+	if limit <= 0 {
+		return 0
+	}
+
+	count := 1
+	for _, token := range tokens {
+		if token.tokenType == VARNAM_TOKEN_SYMBOL && len(token.symbols) > 1 {
+			count *= len(token.symbols)
+			if count >= limit {
+				return limit
+			}
+		}
+	}
+	return count
+}
+
 /**
  * Convert tokens into suggestions.
  * partial - set true if only a part of a word is being tokenized and not an entire word
@@ -136,10 +402,15 @@ func (varnam *Varnam) tokensToSuggestions(ctx context.Context, tokensPointer *[]
 	default:
 		tokens = removeLessWeightedSymbols(tokens)
 
+		results = make([]Suggestion, 0, permutationsCount(tokens, limit))
+
+		buffers := getPermutationBuffers(len(tokens))
+		defer permutationBuffersPool.Put(buffers)
+
 		addWord := func(word []string, weight int) {
 			// TODO avoid division, performance improvement ?
 			weight = weight / 100
-			results = append(results, Suggestion{strings.Join(word, ""), weight, 0})
+			results = append(results, Suggestion{strings.Join(word, ""), weight, 0, 0})
 		}
 
 		// Tracks index of each token possibilities
@@ -150,7 +421,7 @@ func (varnam *Varnam) tokensToSuggestions(ctx context.Context, tokensPointer *[]
 		// [0 0 0 1] => വ ർ ധി ച്ചു
 		// [0 0 1 0] => വ ർ ഥി ചു
 		// [0 0 1 1] => വ ർ ഥി ച്ചു
-		tokenPositions := make([]int, len(tokens))
+		tokenPositions := buffers.positions
 
 		// We go right to left.
 		// We try possibilities from the last character (k) where there are multiple possibilities.
@@ -167,8 +438,9 @@ func (varnam *Varnam) tokensToSuggestions(ctx context.Context, tokensPointer *[]
 		}
 
 		for len(results) < limit {
-			// One loop will make one word
-			word := make([]string, len(tokens))
+			// One loop will make one word. Every position is
+			// set, so the buffer is reused for all words
+			word := buffers.word
 			weight := 0
 
 			// i is the character position we're making
@@ -202,6 +474,8 @@ func (varnam *Varnam) tokensToSuggestions(ctx context.Context, tokensPointer *[]
 					weight += symbolWeight
 				} else if t.tokenType == VARNAM_TOKEN_CHAR {
 					word[i] = t.character
+				} else {
+					word[i] = ""
 				}
 				i--
 			}
@@ -238,18 +512,40 @@ func (varnam *Varnam) tokensToSuggestions(ctx context.Context, tokensPointer *[]
 }
 
 func (varnam *Varnam) setDefaultConfig() {
-	varnam.DictionarySuggestionsLimit = 5
-	varnam.PatternDictionarySuggestionsLimit = 5
+	varnam.DictionarySuggestionsLimit = VARNAM_DICTIONARY_SUGGESTIONS_LIMIT
+	varnam.PatternDictionarySuggestionsLimit = VARNAM_PATTERN_DICTIONARY_SUGGESTIONS_LIMIT
 
-	varnam.TokenizerSuggestionsLimit = 10
+	varnam.TokenizerSuggestionsLimit = VARNAM_TOKENIZER_SUGGESTIONS_LIMIT
 	varnam.TokenizerSuggestionsAlways = true
+	varnam.TokenizerSuggestionsThreshold = 0
+	varnam.TokenizerSuggestionsOnThreshold = VARNAM_TOKENIZER_SUGGESTIONS_DEMOTE
+
+	varnam.QuickBudget = VARNAM_QUICK_BUDGET
 
 	varnam.DictionaryMatchExact = false
 
+	varnam.DictionaryMinInputLength = 0
+
+	varnam.QueryTimeout = 2 * time.Second
+
+	varnam.KeepDuplicateSuggestions = false
+
+	varnam.CollectDiagnostics = false
+
+	varnam.ScriptBoundary = VARNAM_SCRIPT_BOUNDARY_JOIN
+
+	varnam.JoinerPolicy = VARNAM_JOINER_POLICY_KEEP
+
+	varnam.TypoModel = NewQWERTYTypoModel()
+
 	varnam.LangRules.IndicDigits = false
 
 	varnam.LangRules.Virama, _ = varnam.getVirama()
 
+	varnam.Collator = NewScriptCollator(varnam.SchemeDetails.LangCode)
+
+	varnam.Ranker = ConfidenceFirstRanker{}
+
 	if varnam.SchemeDetails.LangCode == "ml" {
 		varnam.RegisterPatternWordPartializer(varnam.mlPatternWordPartializer)
 	}
@@ -257,38 +553,147 @@ func (varnam *Varnam) setDefaultConfig() {
 
 // SortSuggestions by weight and learned on time
 func SortSuggestions(sugs []Suggestion) []Suggestion {
-	// TODO write tests
-	sort.SliceStable(sugs, func(i, j int) bool {
-		if (sugs[i].LearnedOn == 0 || sugs[j].LearnedOn == 0) && !(sugs[i].LearnedOn == 0 && sugs[j].LearnedOn == 0) {
-			return sugs[i].LearnedOn > sugs[j].LearnedOn
-		}
-		return sugs[i].Weight > sugs[j].Weight
-	})
-	return sugs
+	return SortSuggestionsWithCollator(sugs, nil)
+}
+
+// SortSuggestionsWithCollator same as SortSuggestions but ties
+// are ordered with collator. Ties keep their order if collator is nil
+func SortSuggestionsWithCollator(sugs []Suggestion, collator Collator) []Suggestion {
+	return sortSuggestionsWithRanker(sugs, RankSourceUnknown, ConfidenceFirstRanker{}, collator)
+}
+
+// Sort dictionary suggestions with the instance's ranker & collator
+func (varnam *Varnam) sortDictionarySuggestions(source RankSource, sugs []Suggestion) []Suggestion {
+	return varnam.sortSuggestions(sugs, source, varnam.Collator)
+}
+
+// Drop or change suggestions from dictionary according to
+// blacklist and options. Do this only once for a suggestion
+func (varnam *Varnam) filterDictionarySuggestions(ctx context.Context, opts TransliterateOptions, sugs []Suggestion) []Suggestion {
+	sugs = varnam.removeBlacklisted(ctx, sugs)
+	return varnam.applyDomains(ctx, opts, sugs)
+}
+
+// Final order & score of dictionary suggestions
+func (varnam *Varnam) rankDictionarySuggestions(ctx context.Context, source RankSource, sugs []Suggestion) []Suggestion {
+	return varnam.promotePinned(ctx, varnam.sortDictionarySuggestions(source, scoreDictionarySuggestions(sugs)))
 }
 
 // Returns tokens and all found suggestions
 func (varnam *Varnam) transliterate(ctx context.Context, word string) (
+	*[]Token,
+	TransliterationResult) {
+	return varnam.transliterateStaged(ctx, word, TransliterateOptions{}, nil)
+}
+
+// Same as transliterate, but calls emit with the result
+// collected so far whenever a stage finishes
+func (varnam *Varnam) transliterateStaged(ctx context.Context, word string, opts TransliterateOptions, emit func(TransliterationStage, TransliterationResult)) (
 	*[]Token,
 	TransliterationResult) {
 	var (
 		result TransliterationResult
 	)
 
+	// Staged results aren't cached, stages are wanted then
+	cacheable := emit == nil && varnam.cacheableResult(opts)
+
+	if emit == nil {
+		emit = func(TransliterationStage, TransliterationResult) {}
+	} else {
+		// result's slices will be re-sorted in later stages.
+		// Give out a copy so that receiver can hold on to it.
+		userEmit := emit
+		emit = func(stage TransliterationStage, result TransliterationResult) {
+			clone := cloneTransliterationResult(result)
+			varnam.applyJoinerPolicyToResult(&clone)
+			varnam.isolateLTRRunsInResult(&clone)
+			userEmit(stage, clone)
+		}
+	}
+
 	start := time.Now()
 
+	ctx, span := varnam.startSpan(ctx, TraceTransliterate)
+	span.SetAttribute("varnam.input_length", utf8.RuneCountInString(word))
+	defer func() {
+		span.SetAttribute("varnam.suggestions", countSuggestions(result))
+		span.End(ctx.Err())
+	}()
+
+	var (
+		cacheKey   resultCacheKey
+		generation resultGeneration
+	)
+	if cacheable {
+		cacheKey = makeResultCacheKey(word, opts)
+
+		// Taken before transliterating, so that a result made
+		// while learning isn't taken as having the new learnings
+		generation = varnam.resultGeneration()
+
+		if cached, found := varnam.resultCache.get(cacheKey, generation); found {
+			span.SetAttribute("varnam.cached", true)
+			result = cached
+			return nil, result
+		}
+	}
+
+	if sug, ok := varnam.acronymSuggestion(word, opts.Acronym); ok {
+		result.GreedyTokenized = []Suggestion{sug}
+		emit(TransliterationStageGreedyTokenized, result)
+		emit(TransliterationStageComplete, result)
+		return nil, result
+	}
+
+	if opts.IndicDigits != nil {
+		ctx = withIndicDigits(ctx, *opts.IndicDigits)
+	}
+
+	if segments := varnam.SegmentByScript(word); isMixedScript(segments) {
+		result.Segments = segments
+	}
+
+	// Filled always, but only given out if asked for
+	diagnostics := &TransliterationDiagnostics{}
+	queries := new(int64)
+
+	if varnam.CollectDiagnostics {
+		ctx, queries = withQueryCounter(ctx)
+		result.Diagnostics = diagnostics
+	}
+
+	// Marks a stage as finished in diagnostics
+	stageDone := func(duration *time.Duration) {
+		*duration = time.Since(start)
+		diagnostics.Total = *duration
+		diagnostics.Queries = atomic.LoadInt64(queries)
+	}
+
+	// Stages are run with stageCtx. In quick mode it ends after
+	// the budget and whatever's found by then is given out
+	stageCtx := ctx
+	if opts.Quick && varnam.QuickBudget > 0 {
+		var cancel context.CancelFunc
+		stageCtx, cancel = context.WithTimeout(ctx, varnam.QuickBudget)
+		defer cancel()
+	}
+
 	tokensPointerChan := make(chan *[]Token)
-	go varnam.channelTokenizeWord(ctx, word, VARNAM_MATCH_ALL, false, tokensPointerChan)
+	go varnam.channelTokenizeWord(stageCtx, word, VARNAM_MATCH_ALL, false, tokensPointerChan)
 
 	select {
-	case <-ctx.Done():
+	case <-stageCtx.Done():
 		return nil, result
 
 	case tokensPointer := <-tokensPointerChan:
-		if len(*tokensPointer) == 0 {
+		// Channel is closed without a value when cancelled
+		if tokensPointer == nil || len(*tokensPointer) == 0 {
 			return nil, result
 		}
 
+		stageDone(&diagnostics.Tokenization)
+
 		if varnam.Debug {
 			fmt.Println(*tokensPointer)
 		}
@@ -305,84 +710,240 @@ func (varnam *Varnam) transliterate(ctx context.Context, word string) (
 
 		exactTokens = removeNonExactTokens(exactTokens)
 
-		if varnam.DictionaryMatchExact {
-			go varnam.channelGetFromDictionary(ctx, word, &exactTokens, dictSugsChan)
-		} else {
-			go varnam.channelGetFromDictionary(ctx, word, tokensPointer, dictSugsChan)
-		}
+		go varnam.channelTokensToGreedySuggestions(stageCtx, &exactTokens, greedyTokenizedChan)
 
-		go varnam.channelGetFromPatternDictionary(ctx, word, patternDictSugsChan)
-		go varnam.channelTokensToGreedySuggestions(ctx, &exactTokens, greedyTokenizedChan)
+		// Tokenizer is started only after dictionary results
+		// are known. It stays nil (blocks forever) till then.
+		var tokenizerSugsChan chan []Suggestion
 
-		tokenizerSugsChan := make(chan []Suggestion)
-		tokenizerSugsCalled := false
+		// Whichever finishes first is given out first.
+		// A received channel is set to nil so that it won't be selected again.
+		pending := 1
 
-		select {
-		case <-ctx.Done():
-			return nil, result
+		if varnam.shouldSearchDictionary(word) {
+			if varnam.DictionaryMatchExact {
+				go varnam.channelGetFromDictionary(stageCtx, word, &exactTokens, dictSugsChan)
+			} else {
+				go varnam.channelGetFromDictionary(stageCtx, word, tokensPointer, dictSugsChan)
+			}
+			pending++
 
-		case channelDictResult := <-dictSugsChan:
-			// From dictionary
-			result.ExactWords = channelDictResult.exactWords
-			result.ExactMatches = channelDictResult.exactMatches
-			result.DictionarySuggestions = channelDictResult.suggestions
+			if opts.Quick {
+				patternDictSugsChan = nil
+			} else {
+				go varnam.channelGetFromPatternDictionary(stageCtx, word, patternDictSugsChan)
+				pending++
+			}
+		} else {
+			// Input is too short, only tokenizer is used.
+			// Greedy tokenized is enough for quick results
+			dictSugsChan = nil
+			patternDictSugsChan = nil
+
+			if !opts.Quick {
+				tokenizerSugsChan = make(chan []Suggestion)
+				go varnam.channelTokensToSuggestions(stageCtx, tokensPointer, varnam.TokenizerSuggestionsLimit, tokenizerSugsChan)
+				pending++
+			}
+		}
 
+	stages:
+		for pending > 0 {
 			select {
-			case <-ctx.Done():
+			case <-stageCtx.Done():
+				if ctx.Err() == nil && opts.Quick {
+					// Out of budget
+					break stages
+				}
 				return nil, result
+
+			case channelDictResult := <-dictSugsChan:
+				dictSugsChan = nil
+				pending--
+
+				// From dictionary
+				result.ExactWords = varnam.rankDictionarySuggestions(ctx, RankSourceExactWords, append(result.ExactWords, varnam.filterDictionarySuggestions(ctx, opts, channelDictResult.exactWords)...))
+				result.ExactMatches = varnam.rankDictionarySuggestions(ctx, RankSourceExactMatches, varnam.filterDictionarySuggestions(ctx, opts, channelDictResult.exactMatches))
+				result.DictionarySuggestions = varnam.rankDictionarySuggestions(ctx, RankSourceDictionary, varnam.filterDictionarySuggestions(ctx, opts, channelDictResult.suggestions))
+
+				if (len(result.ExactMatches) == 0 || varnam.TokenizerSuggestionsAlways) && !varnam.dropTokenizerSuggestions(result) && !opts.Quick {
+					tokenizerSugsChan = make(chan []Suggestion)
+					go varnam.channelTokensToSuggestions(stageCtx, tokensPointer, varnam.TokenizerSuggestionsLimit, tokenizerSugsChan)
+					pending++
+				}
+
+				stageDone(&diagnostics.Dictionary)
+
+				emit(TransliterationStageDictionary, result)
+
 			case channelPatternDictResult := <-patternDictSugsChan:
+				patternDictSugsChan = nil
+				pending--
+
 				// From patterns dictionary
-				result.ExactWords = append(result.ExactWords, channelPatternDictResult.exactWords...)
-				result.PatternDictionarySuggestions = SortSuggestions(channelPatternDictResult.suggestions)
+				result.ExactWords = varnam.rankDictionarySuggestions(ctx, RankSourceExactWords, append(result.ExactWords, varnam.filterDictionarySuggestions(ctx, opts, channelPatternDictResult.exactWords)...))
+				result.PatternDictionarySuggestions = varnam.rankDictionarySuggestions(ctx, RankSourcePatternDictionary, varnam.filterDictionarySuggestions(ctx, opts, channelPatternDictResult.suggestions))
 
-				if len(result.ExactMatches) == 0 || varnam.TokenizerSuggestionsAlways {
-					go varnam.channelTokensToSuggestions(ctx, tokensPointer, varnam.TokenizerSuggestionsLimit, tokenizerSugsChan)
-					tokenizerSugsCalled = true
-				}
+				stageDone(&diagnostics.PatternDictionary)
 
-				select {
-				case <-ctx.Done():
-					return nil, result
+				emit(TransliterationStagePatternDictionary, result)
 
-				// Add greedy tokenized suggestions. This will only give exact match (VARNAM_MATCH_EXACT) results
-				case greedyTokenizedResult := <-greedyTokenizedChan:
-					result.GreedyTokenized = SortSuggestions(greedyTokenizedResult)
+			// Add greedy tokenized suggestions. This will only give exact match (VARNAM_MATCH_EXACT) results
+			case greedyTokenizedResult := <-greedyTokenizedChan:
+				greedyTokenizedChan = nil
+				pending--
 
-					// Sort everything now
+				result.GreedyTokenized = varnam.sortSuggestions(scoreTokenizerSuggestions(varnam.removeBlacklisted(ctx, greedyTokenizedResult)), RankSourceGreedyTokenized, nil)
 
-					result.ExactWords = SortSuggestions(result.ExactWords)
-					result.ExactMatches = SortSuggestions(result.ExactMatches)
-					result.DictionarySuggestions = SortSuggestions(result.DictionarySuggestions)
-					result.PatternDictionarySuggestions = SortSuggestions(result.PatternDictionarySuggestions)
+				stageDone(&diagnostics.GreedyTokenized)
 
-					if tokenizerSugsCalled {
-						select {
-						case <-ctx.Done():
-							return nil, result
+				emit(TransliterationStageGreedyTokenized, result)
 
-						case tokenizerSugs := <-tokenizerSugsChan:
-							result.TokenizerSuggestions = SortSuggestions(tokenizerSugs)
+			case tokenizerSugs := <-tokenizerSugsChan:
+				tokenizerSugsChan = nil
+				pending--
 
-							if LOG_TIME_TAKEN {
-								log.Printf("%s took %v\n", "transliteration", time.Since(start))
-							}
+				tokenizerSugs = removeLowWeightedSuggestions(tokenizerSugs, varnam.TokenizerWeightThreshold)
 
-							return tokensPointer, result
-						}
+				result.TokenizerSuggestions = varnam.sortSuggestions(scoreTokenizerSuggestions(varnam.removeBlacklisted(ctx, tokenizerSugs)), RankSourceTokenizer, nil)
 
-					} else {
-						if LOG_TIME_TAKEN {
-							log.Printf("%s took %v\n", "transliteration", time.Since(start))
-						}
+				stageDone(&diagnostics.Tokenizer)
 
-						return tokensPointer, result
-					}
+				emit(TransliterationStageTokenizer, result)
+			}
+		}
+
+		// Stages give empty results when cancelled,
+		// what we have is not complete
+		if ctx.Err() != nil {
+			return nil, result
+		}
+
+		if varnam.dropTokenizerSuggestions(result) {
+			result.GreedyTokenized = nil
+			result.TokenizerSuggestions = nil
+		} else if varnam.demoteTokenizerSuggestions(result) {
+			for _, sugs := range [][]Suggestion{result.GreedyTokenized, result.TokenizerSuggestions} {
+				for i := range sugs {
+					sugs[i].Score *= VARNAM_DEMOTED_TOKENIZER_SCORE_FACTOR
 				}
 			}
 		}
+
+		if opts.Quick && len(result.DictionarySuggestions) > VARNAM_QUICK_DICTIONARY_SUGGESTIONS {
+			result.DictionarySuggestions = result.DictionarySuggestions[:VARNAM_QUICK_DICTIONARY_SUGGESTIONS]
+		}
+
+		if varnam.FuzzyMatching && len(result.ExactWords) == 0 && !opts.Quick {
+			result.FuzzySuggestions = varnam.getFuzzySuggestions(ctx, word, opts)
+
+			if ctx.Err() != nil {
+				return nil, result
+			}
+		}
+
+		if varnam.SuggestCorrections && hasNoDictionaryMatches(result) && !opts.Quick {
+			result.Corrections = varnam.getCorrections(ctx, result, opts)
+
+			if ctx.Err() != nil {
+				return nil, result
+			}
+		}
+
+		stageDone(&diagnostics.Total)
+
+		if LOG_TIME_TAKEN {
+			log.Printf("%s took %v\n", "transliteration", time.Since(start))
+		}
+
+		emit(TransliterationStageComplete, result)
+
+		varnam.applyJoinerPolicyToResult(&result)
+		varnam.isolateLTRRunsInResult(&result)
+
+		if cacheable {
+			varnam.resultCache.set(cacheKey, generation, result, varnam.ResultCacheSize)
+		}
+
+		return tokensPointer, result
 	}
 }
 
+// TransliterateStaged transliterate and give out partial results as
+// soon as each stage finishes. emit is called from the calling goroutine
+// with everything found so far. Greedy tokenized and exact results
+// usually come first, the last call is always TransliterationStageComplete
+// with the full result. Meant for servers & IMEs to render progressively.
+func (varnam *Varnam) TransliterateStaged(ctx context.Context, word string, emit func(TransliterationStage, TransliterationResult)) TransliterationResult {
+	_, result := varnam.transliterateStaged(ctx, word, TransliterateOptions{}, emit)
+	return result
+}
+
+// TransliterateAsync same as TransliterateStaged, but results are
+// given through the returned channel. Each value has everything found
+// so far. Channel is closed after the complete result or on cancel
+func (varnam *Varnam) TransliterateAsync(ctx context.Context, word string) <-chan TransliterationResult {
+	channel := make(chan TransliterationResult)
+
+	go func() {
+		defer close(channel)
+
+		varnam.TransliterateStaged(ctx, word, func(stage TransliterationStage, result TransliterationResult) {
+			select {
+			case <-ctx.Done():
+			case channel <- result:
+			}
+		})
+	}()
+
+	return channel
+}
+
+// TransliterateOptions options for a single transliteration
+type TransliterateOptions struct {
+	// Words learnt in these domains are boosted. See LearnInDomain
+	Domains []string
+
+	// Only give dictionary words from Domains and words
+	// that are not in any domain. Other domain words are left out.
+	RestrictToDomains bool
+
+	// Overrides LangRules.IndicDigits for this call if set.
+	// Whether 0-9 in input are given as the language's digits
+	IndicDigits *bool
+
+	// What to do if input is in all caps like USA or ISRO.
+	// See VARNAM_ACRONYM_*. Languages without letter spellings
+	// give the input as is for VARNAM_ACRONYM_SPELL
+	Acronym int
+
+	// For calling on every keystroke. Gives only exact matches, a
+	// few dictionary suggestions & greedy tokenized output, whatever
+	// is found within Varnam.QuickBudget. Tokenizer permutations,
+	// fuzzy matching etc. are skipped, do a normal call for them
+	// when the user pauses typing. See TransliterateQuick
+	Quick bool
+}
+
+// TransliterateAdvancedWithOptions transliterate with a detailed structure as result with options
+func (varnam *Varnam) TransliterateAdvancedWithOptions(ctx context.Context, word string, opts TransliterateOptions) TransliterationResult {
+	_, result := varnam.transliterateStaged(ctx, word, opts, nil)
+	return result
+}
+
+// TransliterateWithOptions transliterate with output array with options
+func (varnam *Varnam) TransliterateWithOptions(ctx context.Context, word string, opts TransliterateOptions) []Suggestion {
+	_, result := varnam.transliterateStaged(ctx, word, opts, nil)
+	return varnam.suggestionsFromResult(ctx, word, result)
+}
+
+// TransliterateQuick transliterate a partially typed word within
+// Varnam.QuickBudget. Meant for every keystroke, do a normal
+// Transliterate when user pauses to get all suggestions
+func (varnam *Varnam) TransliterateQuick(ctx context.Context, word string) []Suggestion {
+	return varnam.TransliterateWithOptions(ctx, word, TransliterateOptions{Quick: true})
+}
+
 // TransliterateAdvanced transliterate with a detailed structure as result
 func (varnam *Varnam) TransliterateAdvanced(word string) TransliterationResult {
 	ctx := context.Background()
@@ -398,11 +959,99 @@ func (varnam *Varnam) TransliterateAdvancedWithContext(ctx context.Context, word
 
 	default:
 		_, result := varnam.transliterate(ctx, word)
-		resultChannel <- result
+
+		select {
+		case <-ctx.Done():
+		case resultChannel <- result:
+		}
 		close(resultChannel)
 	}
 }
 
+func cloneTransliterationResult(result TransliterationResult) TransliterationResult {
+	var diagnostics *TransliterationDiagnostics
+	if result.Diagnostics != nil {
+		copied := *result.Diagnostics
+		diagnostics = &copied
+	}
+
+	clone := func(sugs []Suggestion) []Suggestion {
+		if sugs == nil {
+			return nil
+		}
+		return append([]Suggestion{}, sugs...)
+	}
+
+	return TransliterationResult{
+		clone(result.ExactWords),
+		clone(result.ExactMatches),
+		clone(result.DictionarySuggestions),
+		clone(result.PatternDictionarySuggestions),
+		clone(result.TokenizerSuggestions),
+		clone(result.GreedyTokenized),
+		clone(result.FuzzySuggestions),
+		clone(result.Corrections),
+		diagnostics,
+		append([]ScriptSegment(nil), result.Segments...),
+	}
+}
+
+// Ranked all suggestions of the result as a single list, most
+// likely first. Categories are ranked in this order :
+//
+//  1. ExactWords
+//  2. ExactMatches
+//  3. FuzzySuggestions
+//  4. PatternDictionarySuggestions
+//  5. DictionarySuggestions
+//  6. GreedyTokenized
+//  7. TokenizerSuggestions
+//
+// Each category is already sorted by weight & learnt time
+// (pinned words first), that order is kept within the category.
+// Dictionary words come before tokenizer output because they're
+// real words the user has used before. Unlike Transliterate,
+// greedy tokenized output is not moved to 2nd position.
+// A word found by more than one category is only given once,
+// at its highest rank.
+func (result TransliterationResult) Ranked() []Suggestion {
+	var ranked []Suggestion
+
+	for _, sugs := range [][]Suggestion{
+		result.ExactWords,
+		result.ExactMatches,
+		result.FuzzySuggestions,
+		result.PatternDictionarySuggestions,
+		result.DictionarySuggestions,
+		result.GreedyTokenized,
+		result.TokenizerSuggestions,
+	} {
+		ranked = append(ranked, sugs...)
+	}
+
+	return dedupSuggestions(ranked)
+}
+
+// Whether input is long enough to search dictionaries.
+// See Varnam.DictionaryMinInputLength
+func (varnam *Varnam) shouldSearchDictionary(word string) bool {
+	return utf8.RuneCountInString(word) >= varnam.DictionaryMinInputLength
+}
+
+// Whether dictionary found enough words to consider
+// tokenizer made suggestions as noise
+func (varnam *Varnam) hasStrongDictionaryMatches(result TransliterationResult) bool {
+	return varnam.TokenizerSuggestionsThreshold > 0 && len(result.ExactWords)+len(result.ExactMatches) >= varnam.TokenizerSuggestionsThreshold
+}
+
+func (varnam *Varnam) dropTokenizerSuggestions(result TransliterationResult) bool {
+	return varnam.TokenizerSuggestionsOnThreshold == VARNAM_TOKENIZER_SUGGESTIONS_DROP && varnam.hasStrongDictionaryMatches(result)
+}
+
+func (varnam *Varnam) demoteTokenizerSuggestions(result TransliterationResult) bool {
+	return varnam.TokenizerSuggestionsOnThreshold == VARNAM_TOKENIZER_SUGGESTIONS_DEMOTE && varnam.hasStrongDictionaryMatches(result)
+}
+
 // Flatten TransliterationResult struct to a suggestion array
 func flattenTR(result TransliterationResult) []Suggestion {
 	var combined []Suggestion
@@ -413,6 +1062,8 @@ func flattenTR(result TransliterationResult) []Suggestion {
 		dictCombined = append(dictCombined, result.ExactMatches...)
 	}
 
+	dictCombined = append(dictCombined, result.FuzzySuggestions...)
+
 	dictCombined = append(dictCombined, result.PatternDictionarySuggestions...)
 	dictCombined = append(dictCombined, result.DictionarySuggestions...)
 
@@ -423,6 +1074,7 @@ func flattenTR(result TransliterationResult) []Suggestion {
 		combined = append(combined, result.GreedyTokenized...)
 		combined = append(combined, result.ExactWords...)
 		combined = append(combined, result.ExactMatches...)
+		combined = append(combined, result.FuzzySuggestions...)
 		combined = append(combined, result.PatternDictionarySuggestions...)
 		combined = append(combined, result.DictionarySuggestions...)
 	} else {
@@ -449,9 +1101,56 @@ func flattenTR(result TransliterationResult) []Suggestion {
 	return combined
 }
 
+// Remove repeated words, the first one is kept
+func dedupSuggestions(sugs []Suggestion) []Suggestion {
+	var (
+		result []Suggestion
+		seen   = make(map[string]bool)
+	)
+
+	for _, sug := range sugs {
+		if !seen[sug.Word] {
+			seen[sug.Word] = true
+			result = append(result, sug)
+		}
+	}
+
+	return result
+}
+
+// Final suggestion list of Transliterate from the result
+func (varnam *Varnam) suggestionsFromResult(ctx context.Context, word string, result TransliterationResult) []Suggestion {
+	var sugs []Suggestion
+
+	if varnam.demoteTokenizerSuggestions(result) {
+		// Greedy tokenized isn't put at 2nd position then
+		sugs = varnam.promotePinned(ctx, result.Ranked())
+	} else {
+		sugs = varnam.promotePinned(ctx, flattenTR(result))
+	}
+
+	if !varnam.KeepDuplicateSuggestions {
+		sugs = dedupSuggestions(sugs)
+	}
+
+	return varnam.filterSuggestions(word, sugs)
+}
+
+// Pass suggestions through the registered filters
+func (varnam *Varnam) filterSuggestions(word string, sugs []Suggestion) []Suggestion {
+	if varnam.noGrantha() {
+		sugs = removeGranthaSuggestions(sugs)
+	}
+
+	for _, filter := range varnam.SuggestionFilters {
+		sugs = filter(word, sugs)
+	}
+	return sugs
+}
+
 // Transliterate transliterate with output array
 func (varnam *Varnam) Transliterate(word string) []Suggestion {
-	return flattenTR(varnam.TransliterateAdvanced(word))
+	return varnam.suggestionsFromResult(context.Background(), word, varnam.TransliterateAdvanced(word))
 }
 
 // TransliterateWithContext Transliterate but with Go context
@@ -461,17 +1160,74 @@ func (varnam *Varnam) TransliterateWithContext(ctx context.Context, word string,
 		return
 	default:
 		_, result := varnam.transliterate(ctx, word)
-		resultChannel <- flattenTR(result)
+		sugs := varnam.suggestionsFromResult(ctx, word, result)
+
+		select {
+		case <-ctx.Done():
+		case resultChannel <- sugs:
+		}
 		close(resultChannel)
 	}
 }
 
-// TransliterateGreedyTokenized transliterate word, only tokenizer results
+// TransliterateBatch transliterate many words in one go. Result
+// for words[i] is at [i]. VST lookups are shared between words and
+// a repeated word is transliterated only once, useful for converting
+// documents. Words left when ctx is cancelled get nil
+func (varnam *Varnam) TransliterateBatch(ctx context.Context, words []string) [][]Suggestion {
+	results := make([][]Suggestion, len(words))
+
+	varnam.transliterateBatch(ctx, words, func(i int, sugs []Suggestion, result TransliterationResult) {
+		results[i] = sugs
+	})
+
+	return results
+}
+
+// transliterateBatch calls cb with the suggestions & the full
+// result of each word. cb isn't called for words left when ctx
+// is cancelled
+func (varnam *Varnam) transliterateBatch(ctx context.Context, words []string, cb func(i int, sugs []Suggestion, result TransliterationResult)) {
+	type done struct {
+		sugs   []Suggestion
+		result TransliterationResult
+	}
+	doneWords := make(map[string]done)
+
+	batchCtx := context.WithValue(ctx, symbolCacheContextKey{}, newSymbolCache())
+
+	for i, word := range words {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if d, found := doneWords[word]; found {
+			cb(i, d.sugs, d.result)
+			continue
+		}
+
+		_, result := varnam.transliterate(batchCtx, word)
+		if ctx.Err() != nil {
+			break
+		}
+
+		d := done{varnam.suggestionsFromResult(batchCtx, word, result), result}
+		doneWords[word] = d
+		cb(i, d.sugs, d.result)
+	}
+}
+
+// TransliterateGreedyTokenized transliterate word, only tokenizer results.
+// Learnings are not looked up at all, this is the fastest way to
+// transliterate. Useful for live preview of what's being typed
 func (varnam *Varnam) TransliterateGreedyTokenized(word string) []Suggestion {
 	ctx := context.Background()
 
 	tokens := varnam.tokenizeWord(ctx, word, VARNAM_MATCH_EXACT, false)
-	return varnam.tokensToSuggestions(ctx, tokens, false, varnam.TokenizerSuggestionsLimit)
+	sugs := varnam.applyJoinerPolicyToSuggestions(scoreTokenizerSuggestions(varnam.tokensToSuggestions(ctx, tokens, false, varnam.TokenizerSuggestionsLimit)))
+	sugs = varnam.isolateLTRRunsInSuggestions(sugs)
+
+	return varnam.filterSuggestions(word, sugs)
 }
 
 // ReverseTransliterate do a reverse transliteration
@@ -504,6 +1260,17 @@ func (varnam *Varnam) RegisterPatternWordPartializer(cb func(*Suggestion)) {
 	varnam.PatternWordPartializers = append(varnam.PatternWordPartializers, cb)
 }
 
+// RegisterSuggestionFilter A suggestion filter gets the input word and
+// the final suggestion list of Transliterate. It can drop, reorder or
+// modify suggestions and should return the new list.
+// Useful for policy filters or custom deduplication.
+// Filters also apply to TransliterateGreedyTokenized so that previews
+// don't show what's filtered out. TransliterateAdvanced's categories
+// are not filtered
+func (varnam *Varnam) RegisterSuggestionFilter(cb func(word string, sugs []Suggestion) []Suggestion) {
+	varnam.SuggestionFilters = append(varnam.SuggestionFilters, cb)
+}
+
 // Init Initialize varnam. Dictionary will be created if it doesn't exist
 func Init(vstPath string, dictPath string) (*Varnam, error) {
 	varnam := Varnam{}
@@ -524,6 +1291,13 @@ func Init(vstPath string, dictPath string) (*Varnam, error) {
 
 // InitFromID Init from ID. Scheme ID doesn't necessarily be a language code
 func InitFromID(schemeID string) (*Varnam, error) {
+	return InitFromIDInLearningsDir(schemeID, "")
+}
+
+// InitFromIDInLearningsDir like InitFromID but learnings are kept in
+// learningsDir instead of VARNAM_LEARNINGS_DIR. Used for keeping
+// learnings of different users apart. Empty learningsDir is same as InitFromID
+func InitFromIDInLearningsDir(schemeID string, learningsDir string) (*Varnam, error) {
 	var (
 		vstPath  string
 		dictPath string
@@ -542,7 +1316,11 @@ func InitFromID(schemeID string) (*Varnam, error) {
 	}
 
 	// One dictionary for one language, not for different scheme
-	dictPath = findLearningsFilePath(varnam.SchemeDetails.LangCode)
+	if learningsDir != "" {
+		dictPath = path.Join(learningsDir, varnam.SchemeDetails.LangCode+".vst.learnings")
+	} else {
+		dictPath = findLearningsFilePath(varnam.SchemeDetails.LangCode)
+	}
 
 	err = varnam.InitDict(dictPath)
 	if err != nil {
@@ -554,13 +1332,20 @@ func InitFromID(schemeID string) (*Varnam, error) {
 	return &varnam, nil
 }
 
-// Close close db connections
+// Close checkpoint learnings & close db connections. Transliterations
+// & learnings using the instance should be finished before
 func (varnam *Varnam) Close() error {
-	if varnam.vstConn != nil {
-		varnam.vstConn.Close()
+	varnam.closeVST()
+	if varnam.dictConn == nil {
+		return nil
+	}
+
+	err := varnam.Checkpoint()
+	if closeErr := varnam.dictConn.Close(); err == nil {
+		err = closeErr
 	}
-	if varnam.dictConn != nil {
-		varnam.dictConn.Close()
+	if closeErr := varnam.dictWriteConn.Close(); err == nil {
+		err = closeErr
 	}
-	return nil
+	return err
 }
@@ -0,0 +1,31 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import "strings"
+
+// Devanagari letters with nukta have precomposed forms which
+// Unicode normalization decomposes (composition exclusions).
+// Schemes use the decomposed forms: consonant + nukta
+var nuktaForms = [][2]string{
+	{"\u0958", "\u0915\u093C"}, // क़
+	{"\u0959", "\u0916\u093C"}, // ख़
+	{"\u095A", "\u0917\u093C"}, // ग़
+	{"\u095B", "\u091C\u093C"}, // ज़
+	{"\u095C", "\u0921\u093C"}, // ड़
+	{"\u095D", "\u0922\u093C"}, // ढ़
+	{"\u095E", "\u092B\u093C"}, // फ़
+	{"\u095F", "\u092F\u093C"}, // य़
+}
+
+// Replace precomposed nukta letters with consonant + nukta
+func toDecomposedNuktaForms(word string) string {
+	for _, forms := range nuktaForms {
+		word = strings.Replace(word, forms[0], forms[1], -1)
+	}
+	return word
+}
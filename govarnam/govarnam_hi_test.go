@@ -0,0 +1,17 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestHINuktaForms(t *testing.T) {
+	varnam := Varnam{}
+	varnam.SchemeDetails.LangCode = "hi"
+
+	// Precomposed ज़ & फ़
+	assertEqual(t, varnam.sanitizeWord("ज़मीन"), "ज़मीन")
+	assertEqual(t, varnam.sanitizeWord("फ़िक्र।"), "फ़िक्र")
+
+	// Already decomposed
+	assertEqual(t, varnam.sanitizeWord("ज़मीन"), "ज़मीन")
+}
@@ -2,8 +2,12 @@ package govarnam
 
 import (
 	"context"
+	"errors"
 	"log"
+	"os"
 	"path"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -102,7 +106,7 @@ func TestMLLearn(t *testing.T) {
 	// varnam.Debug(true)
 	sugs := varnam.TransliterateAdvanced("malayala").DictionarySuggestions
 
-	assertEqual(t, sugs[0], Suggestion{"മലയാളം", VARNAM_LEARNT_WORD_MIN_WEIGHT, sugs[0].LearnedOn})
+	assertEqual(t, sugs[0], Suggestion{"മലയാളം", VARNAM_LEARNT_WORD_MIN_WEIGHT, sugs[0].LearnedOn, sugs[0].Score})
 
 	// Check the time learnt is right (UTC) ?
 	learnedOn := time.Unix(int64(sugs[1].LearnedOn), 0)
@@ -111,7 +115,7 @@ func TestMLLearn(t *testing.T) {
 		t.Errorf("Learn time %v (%v) not in between %v and %v", learnedOn, sugs[1].LearnedOn, start1SecondBefore, end1SecondAfter)
 	}
 
-	assertEqual(t, sugs[1], Suggestion{"മലയാളത്തിൽ", VARNAM_LEARNT_WORD_MIN_WEIGHT, sugs[1].LearnedOn})
+	assertEqual(t, sugs[1], Suggestion{"മലയാളത്തിൽ", VARNAM_LEARNT_WORD_MIN_WEIGHT, sugs[1].LearnedOn, sugs[1].Score})
 
 	// Learn the word again
 	// This word will now be at the top
@@ -120,7 +124,7 @@ func TestMLLearn(t *testing.T) {
 	checkError(err)
 
 	sug := varnam.TransliterateAdvanced("malayala").DictionarySuggestions[0]
-	assertEqual(t, sug, Suggestion{"മലയാളത്തിൽ", VARNAM_LEARNT_WORD_MIN_WEIGHT + 1, sug.LearnedOn})
+	assertEqual(t, sug, Suggestion{"മലയാളത്തിൽ", VARNAM_LEARNT_WORD_MIN_WEIGHT + 1, sug.LearnedOn, sug.Score})
 
 	// Subsequent pattern can be smaller now (no need of "thth")
 	assertEqual(t, varnam.TransliterateAdvanced("malayalathil").ExactWords[0].Word, "മലയാളത്തിൽ")
@@ -192,6 +196,7 @@ func TestMLTrain(t *testing.T) {
 
 	err = varnam.Unlearn("computer")
 	assertEqual(t, err.Error(), "nothing to unlearn")
+	assertEqual(t, errors.Is(err, ErrNothingToUnlearn), true)
 }
 
 func TestAnyCharacterInputWillWorkFine(t *testing.T) {
@@ -417,11 +422,70 @@ func TestMLExportAndImport(t *testing.T) {
 	`)
 	varnam.Import(filePath)
 
-	assertEqual(t, varnam.TransliterateAdvanced("algeria").ExactWords[0], Suggestion{
-		Word:      "അൾജീരിയ",
-		Weight:    VARNAM_LEARNT_WORD_MIN_WEIGHT + 25,
-		LearnedOn: 1531131220,
+	// Score depends on other learnings, not what's imported
+	sug := varnam.TransliterateAdvanced("algeria").ExactWords[0]
+	assertEqual(t, sug.Word, "അൾജീരിയ")
+	assertEqual(t, sug.Weight, VARNAM_LEARNT_WORD_MIN_WEIGHT+25)
+	assertEqual(t, sug.LearnedOn, 1531131220)
+}
+
+func TestMLExportImportWithOptions(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.LearnInDomain("കൊല്ലം", 40, "places"))
+	checkError(varnam.Train("kollam", "കൊല്ലം"))
+	checkError(varnam.Learn("കുന്ന്", 0))
+
+	exportFileIntendedPath := path.Join(testTempDir, "export-options")
+	exportFilePath := exportFileIntendedPath + "-1.vlf"
+
+	progressDone, progressTotal := 0, 0
+	checkError(varnam.ExportWithOptions(exportFileIntendedPath, ExportOptions{
+		WordsPerFile:  300,
+		MinConfidence: 0.8,
+		Domain:        "places",
+		Progress: func(done int, total int) {
+			progressDone, progressTotal = done, total
+		},
+	}))
+	assertEqual(t, progressDone, 1)
+	assertEqual(t, progressTotal, 1)
+
+	b, err := os.ReadFile(exportFilePath)
+	checkError(err)
+	assertEqual(t, strings.Contains(string(b), "കൊല്ലം"), true)
+	assertEqual(t, strings.Contains(string(b), "കുന്ന്"), false)
+
+	// Not confident enough
+	err = varnam.ExportWithOptions(path.Join(testTempDir, "export-confident"), ExportOptions{
+		WordsPerFile:  300,
+		MinConfidence: 0.9,
+		Domain:        "places",
 	})
+	checkError(err)
+	_, err = os.Stat(path.Join(testTempDir, "export-confident-1.vlf"))
+	assertEqual(t, os.IsNotExist(err), true)
+
+	checkError(varnam.Unlearn("കൊല്ലം"))
+
+	// Filtered out
+	checkError(varnam.ImportWithOptions(exportFilePath, ImportOptions{
+		LearnedAfter: time.Now().Add(time.Hour),
+		Progress:     func(done int, total int) {},
+	}))
+	_, err = varnam.GetWordDetails(context.Background(), "കൊല്ലം")
+	assertEqual(t, err != nil, true)
+
+	checkError(varnam.ImportWithOptions(exportFilePath, ImportOptions{
+		MinConfidence: 0.8,
+		Domain:        "imported",
+		Progress:      func(done int, total int) {},
+	}))
+	details, err := varnam.GetWordDetails(context.Background(), "കൊല്ലം")
+	checkError(err)
+	assertEqual(t, details.Weight >= 40, true)
+	assertEqual(t, strings.Join(details.Domains, ","), "imported")
+	assertEqual(t, strings.Join(details.Patterns, ","), "kollam")
 }
 
 func TestMLSearchSymbolTable(t *testing.T) {
@@ -480,6 +544,103 @@ func TestMLRecentlyLearnedWords(t *testing.T) {
 	assertEqual(t, result[0].Word, "ആലപ്പുഴ")
 }
 
+func TestMLLearnAt(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	past := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	checkError(varnam.LearnAt("തിരുവനന്തപുരം", 0, past))
+
+	wordInfo, err := varnam.getWordInfo("തിരുവനന്തപുരം")
+	checkError(err)
+	assertEqual(t, wordInfo.learnedOn, int(past.Unix()))
+	assertEqual(t, wordInfo.weight, VARNAM_LEARNT_WORD_MIN_WEIGHT)
+
+	// Older time doesn't replace a later one
+	checkError(varnam.LearnAt("തിരുവനന്തപുരം", 0, past.AddDate(-1, 0, 0)))
+	wordInfo, _ = varnam.getWordInfo("തിരുവനന്തപുരം")
+	assertEqual(t, wordInfo.learnedOn, int(past.Unix()))
+	assertEqual(t, wordInfo.weight, VARNAM_LEARNT_WORD_MIN_WEIGHT+1)
+
+	// Learn stamps now
+	checkError(varnam.Learn("തിരുവനന്തപുരം", 0))
+	wordInfo, _ = varnam.getWordInfo("തിരുവനന്തപുരം")
+	assertEqual(t, wordInfo.learnedOn > int(past.Unix()), true)
+
+	checkError(varnam.Unlearn("തിരുവനന്തപുരം"))
+}
+
+func TestMLTouch(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	past := time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	checkError(varnam.LearnAt("കോഴിക്കോട്", 0, past))
+	checkError(varnam.Touch("കോഴിക്കോട്"))
+
+	wordInfo, err := varnam.getWordInfo("കോഴിക്കോട്")
+	checkError(err)
+	assertEqual(t, wordInfo.learnedOn > int(past.Unix()), true)
+	assertEqual(t, wordInfo.weight, VARNAM_LEARNT_WORD_MIN_WEIGHT)
+
+	assertEqual(t, varnam.Touch("മലപ്പുറം") != nil, true)
+
+	checkError(varnam.Unlearn("കോഴിക്കോട്"))
+}
+
+func TestMLCanLearn(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.CanLearn("മലയാളം"))
+	checkError(varnam.ValidateTrain("malayalam", "മലയാളം"))
+
+	// Nothing was learnt
+	_, err := varnam.getWordInfo("മലയാളം")
+	assertEqual(t, err != nil, true)
+
+	err = varnam.CanLearn("മലabcയാളം")
+	validationErr, ok := err.(*ValidationError)
+	assertEqual(t, ok, true)
+	assertEqual(t, validationErr.Character, "a")
+	assertEqual(t, validationErr.Position, 2)
+
+	err = varnam.CanLearn("മ")
+	assertEqual(t, err.Error(), "Can't learn a single conjunct")
+
+	err = varnam.CanLearn("abc")
+	assertEqual(t, err.Error(), "Nothing to learn")
+
+	err = varnam.ValidateTrain(" ", "മലയാളം")
+	assertEqual(t, err.Error(), "Pattern can't be empty")
+}
+
+func TestMLRandomLearnedWords(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	words := []string{"ആലപ്പുഴ", "എറണാകുളം", "പാലക്കാട്", "കോഴിക്കോട്"}
+	for _, word := range words {
+		varnam.Learn(word, 0)
+	}
+
+	result, err := varnam.GetRandomLearnedWords(context.Background(), 3, false)
+	checkError(err)
+	assertEqual(t, len(result), 3)
+
+	for _, sug := range result {
+		assertEqual(t, sug.LearnedOn > 0, true)
+	}
+
+	// A word not used for 10 years should come first
+	_, err = varnam.dictWriteConn.Exec("UPDATE words SET learned_on = strftime('%s', 'now') - 10 * 365 * 86400 WHERE word = ?", "എറണാകുളം")
+	checkError(err)
+
+	result, err = varnam.GetRandomLearnedWords(context.Background(), 1, true)
+	checkError(err)
+	assertEqual(t, result[0].Word, "എറണാകുളം")
+
+	varnam.Learn("എറണാകുളം", 0)
+}
+
 func TestMLGetSuggestions(t *testing.T) {
 	varnam := getVarnamInstance("ml")
 
@@ -494,3 +655,625 @@ func TestMLGetSuggestions(t *testing.T) {
 
 	assertEqual(t, result[0].Word, "ആലപ്പുഴ")
 }
+
+func TestMLLearnHooks(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	var (
+		learnt     []string
+		trained    []string
+		unlearnt   []string
+		lastWeight int
+	)
+
+	varnam.OnLearn(func(word string, weight int) {
+		learnt = append(learnt, word)
+		lastWeight = weight
+	})
+	varnam.OnTrain(func(pattern string, word string) {
+		trained = append(trained, pattern+" "+word)
+	})
+	varnam.OnUnlearn(func(word string) {
+		unlearnt = append(unlearnt, word)
+	})
+
+	defer func() {
+		varnam.learnHooks = nil
+		varnam.trainHooks = nil
+		varnam.unlearnHooks = nil
+	}()
+
+	checkError(varnam.Learn("ആലപ്പുഴ", 0))
+	assertEqual(t, len(learnt), 1)
+	assertEqual(t, learnt[0], "ആലപ്പുഴ")
+	assertEqual(t, lastWeight, VARNAM_LEARNT_WORD_MIN_WEIGHT)
+
+	// Failed learns shouldn't trigger
+	assertEqual(t, varnam.Learn("Шаблон", 0) != nil, true)
+	assertEqual(t, len(learnt), 1)
+
+	varnam.LearnMany([]WordInfo{{0, "കോട്ടയം", 40, 0}, {0, "Шаблон", 0, 0}})
+	assertEqual(t, len(learnt), 2)
+	assertEqual(t, lastWeight, 40)
+
+	// Weight given is the one stored
+	checkError(varnam.Learn("ആലപ്പുഴ", 0))
+	assertEqual(t, lastWeight, VARNAM_LEARNT_WORD_MIN_WEIGHT+1)
+
+	varnam.LearnMany([]WordInfo{{0, "കോട്ടയം", 40, 0}})
+	wordInfo, err := varnam.getWordInfo("കോട്ടയം")
+	checkError(err)
+	assertEqual(t, lastWeight, 41)
+	assertEqual(t, wordInfo.weight, lastWeight)
+
+	checkError(varnam.Train("alleppey", "ആലപ്പുഴ"))
+	assertEqual(t, len(trained), 1)
+	assertEqual(t, trained[0], "alleppey ആലപ്പുഴ")
+
+	checkError(varnam.Unlearn("ആലപ്പുഴ"))
+	checkError(varnam.Unlearn("കോട്ടയം"))
+	assertEqual(t, len(unlearnt), 2)
+	assertEqual(t, unlearnt[0], "ആലപ്പുഴ")
+}
+
+func TestMLTransliterateStaged(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+	defer varnam.Unlearn("മലയാളം")
+
+	var stages []TransliterationStage
+	var lastResult TransliterationResult
+
+	result := varnam.TransliterateStaged(context.Background(), "malayalam", func(stage TransliterationStage, partial TransliterationResult) {
+		stages = append(stages, stage)
+		lastResult = partial
+
+		if stage == TransliterationStageGreedyTokenized {
+			assertEqual(t, len(partial.GreedyTokenized) > 0, true)
+		}
+	})
+
+	// greedy, dictionary, pattern dictionary, tokenizer & complete
+	assertEqual(t, len(stages), 5)
+	assertEqual(t, stages[len(stages)-1], TransliterationStageComplete)
+	assertEqual(t, reflect.DeepEqual(lastResult, result), true)
+	assertEqual(t, reflect.DeepEqual(result, varnam.TransliterateAdvanced("malayalam")), true)
+}
+
+func TestMLSuggestionFilter(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	defer func() {
+		varnam.SuggestionFilters = nil
+	}()
+
+	unfiltered := varnam.Transliterate("mala")
+
+	var filterInput string
+	varnam.RegisterSuggestionFilter(func(word string, sugs []Suggestion) []Suggestion {
+		filterInput = word
+
+		var result []Suggestion
+		for _, sug := range sugs {
+			if sug.Word != unfiltered[0].Word {
+				result = append(result, sug)
+			}
+		}
+		return result
+	})
+
+	// Filters run in the order they were registered
+	varnam.RegisterSuggestionFilter(func(word string, sugs []Suggestion) []Suggestion {
+		return append(sugs, Suggestion{"test", 0, 0, 0})
+	})
+
+	sugs := varnam.Transliterate("mala")
+	assertEqual(t, filterInput, "mala")
+	assertEqual(t, sugs[len(sugs)-1].Word, "test")
+
+	for _, sug := range sugs {
+		assertEqual(t, sug.Word != unfiltered[0].Word, true)
+	}
+}
+
+func TestMLSuggestionFilterGreedyTokenized(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	defer func() {
+		varnam.SuggestionFilters = nil
+	}()
+
+	varnam.RegisterSuggestionFilter(func(word string, sugs []Suggestion) []Suggestion {
+		for i := range sugs {
+			sugs[i].Word = strings.Replace(sugs[i].Word, "ന", "*", -1)
+		}
+		return sugs
+	})
+
+	assertEqual(t, varnam.TransliterateGreedyTokenized("pani")[0].Word, "പ*ി")
+}
+
+func TestMLGreedyTokenizedSkipsLearnings(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Train("pani", "ആലപ്പുഴ"))
+	defer varnam.Unlearn("ആലപ്പുഴ")
+
+	assertEqual(t, varnam.Transliterate("pani")[0].Word, "ആലപ്പുഴ")
+
+	for _, sug := range varnam.TransliterateGreedyTokenized("pani") {
+		assertEqual(t, sug.Word != "ആലപ്പുഴ", true)
+	}
+}
+
+func TestMLRanked(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+	checkError(varnam.Train("malayalam", "മലയാളം"))
+	checkError(varnam.Learn("മലയാളി", 0))
+	defer varnam.Unlearn("മലയാളം")
+	defer varnam.Unlearn("മലയാളി")
+
+	result := varnam.TransliterateAdvanced("malayalam")
+	ranked := result.Ranked()
+
+	assertEqual(t, ranked[0].Word, "മലയാളം")
+
+	// Tokenizer output is last
+	last := result.TokenizerSuggestions[len(result.TokenizerSuggestions)-1]
+	assertEqual(t, ranked[len(ranked)-1], last)
+
+	// Every word is given once
+	seen := make(map[string]bool)
+	for _, sug := range ranked {
+		assertEqual(t, seen[sug.Word], false)
+		seen[sug.Word] = true
+	}
+
+	assertEqual(t, len(TransliterationResult{}.Ranked()), 0)
+}
+
+func TestMLDedupSuggestions(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+	checkError(varnam.Train("malayalam", "മലയാളം"))
+	defer varnam.Unlearn("മലയാളം")
+
+	count := func(sugs []Suggestion, word string) int {
+		n := 0
+		for _, sug := range sugs {
+			if sug.Word == word {
+				n++
+			}
+		}
+		return n
+	}
+
+	assertEqual(t, count(varnam.Transliterate("malayalam"), "മലയാളം"), 1)
+	assertEqual(t, count(varnam.TransliterateAdvanced("malayalam").Ranked(), "മലയാളം"), 1)
+
+	varnam.KeepDuplicateSuggestions = true
+	defer func() { varnam.KeepDuplicateSuggestions = false }()
+
+	// Found in both dictionary and patterns dictionary, and by tokenizer
+	assertEqual(t, count(varnam.Transliterate("malayalam"), "മലയാളം") > 1, true)
+}
+
+func TestMLTransliterateAsync(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+	defer varnam.Unlearn("മലയാളം")
+
+	var results []TransliterationResult
+	for result := range varnam.TransliterateAsync(context.Background(), "malayalam") {
+		results = append(results, result)
+	}
+
+	assertEqual(t, len(results), 5)
+	assertEqual(t, reflect.DeepEqual(results[len(results)-1], varnam.TransliterateAdvanced("malayalam")), true)
+
+	// Closed when cancelled even if not read
+	ctx, cancel := context.WithCancel(context.Background())
+	channel := varnam.TransliterateAsync(ctx, "malayalam")
+	cancel()
+
+	for range channel {
+	}
+}
+
+func TestMLTransliterateCancel(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sugs := varnam.TransliterateWithOptions(ctx, "malayalam", TransliterateOptions{})
+	assertEqual(t, len(sugs), 0)
+
+	// Nobody reads the result when cancelled.
+	// Nothing should be left running.
+	goroutines := runtime.NumGoroutine()
+
+	for i := 0; i < 20; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(i)*100*time.Microsecond)
+		varnam.TransliterateWithContext(ctx, "malayalam", make(chan []Suggestion))
+		varnam.TransliterateAdvancedWithContext(ctx, "malayalam", make(chan TransliterationResult))
+		cancel()
+	}
+
+	for i := 0; i < 100 && runtime.NumGoroutine() > goroutines; i++ {
+		time.Sleep(20 * time.Millisecond)
+	}
+	assertEqual(t, runtime.NumGoroutine() <= goroutines, true)
+}
+
+func TestMLTransliterateBatch(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	words := []string{"malayalam", "pani", "malayalam", "*nama@skaaram"}
+	results := varnam.TransliterateBatch(context.Background(), words)
+
+	assertEqual(t, len(results), len(words))
+	for i, word := range words {
+		assertEqual(t, reflect.DeepEqual(results[i], varnam.Transliterate(word)), true)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results = varnam.TransliterateBatch(ctx, words)
+	assertEqual(t, len(results), len(words))
+	assertEqual(t, results[0] == nil, true)
+}
+
+func TestMLTransliterateSentence(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	top := func(word string) string {
+		return varnam.Transliterate(word)[0].Word
+	}
+
+	sentence, err := varnam.TransliterateSentence(context.Background(), "  malayalam, pani!\tЖ (nanni)")
+	checkError(err)
+	assertEqual(t, sentence, "  "+top("malayalam")+", "+top("pani")+"!\t"+top("Ж")+" ("+top("nanni")+")")
+
+	sentence, err = varnam.TransliterateSentence(context.Background(), "")
+	checkError(err)
+	assertEqual(t, sentence, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = varnam.TransliterateSentence(ctx, "malayalam pani")
+	assertEqual(t, err, context.Canceled)
+}
+
+func TestMLTransliterateDocument(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+	defer varnam.Unlearn("മലയാളം")
+
+	output, words, err := varnam.TransliterateDocument(context.Background(), "malayalam,\n\nkhzq 2021 മലയാളം")
+	checkError(err)
+	assertEqual(t, output, "മലയാളം,\n\n"+varnam.Transliterate("khzq")[0].Word+" 2021 മലയാളം")
+
+	assertEqual(t, len(words), 4)
+
+	assertEqual(t, words[0].Input, "malayalam")
+	assertEqual(t, words[0].Output, "മലയാളം")
+	assertEqual(t, words[0].Line, 1)
+	assertEqual(t, words[0].Confident, true)
+
+	assertEqual(t, words[1].Input, "khzq")
+	assertEqual(t, words[1].Line, 3)
+	assertEqual(t, words[1].Confident, false)
+	assertEqual(t, len(words[1].Suggestions) > 0, true)
+
+	assertEqual(t, words[2].Confident, true)
+	assertEqual(t, words[3].Confident, true)
+}
+
+func TestMLIndicDigitsOverride(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	assertEqual(t, varnam.LangRules.IndicDigits, false)
+	assertEqual(t, varnam.Transliterate("2021")[0].Word, "2021")
+
+	indicDigits := true
+	sugs := varnam.TransliterateWithOptions(context.Background(), "2021", TransliterateOptions{IndicDigits: &indicDigits})
+	assertEqual(t, sugs[0].Word, "൨൦൨൧")
+
+	varnam.LangRules.IndicDigits = true
+	defer func() {
+		varnam.LangRules.IndicDigits = false
+	}()
+
+	assertEqual(t, varnam.Transliterate("2021")[0].Word, "൨൦൨൧")
+
+	indicDigits = false
+	sugs = varnam.TransliterateWithOptions(context.Background(), "2021", TransliterateOptions{IndicDigits: &indicDigits})
+	assertEqual(t, sugs[0].Word, "2021")
+}
+
+func TestMLJoinerPolicy(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	defer func() {
+		varnam.JoinerPolicy = VARNAM_JOINER_POLICY_KEEP
+	}()
+
+	assertEqual(t, varnam.TransliterateGreedyTokenized("avan")[0].Word, "അവൻ")
+	assertEqual(t, varnam.TransliterateGreedyTokenized("thaazh_vara")[0].Word, "താഴ്"+ZWNJ+"വര")
+
+	varnam.JoinerPolicy = VARNAM_JOINER_POLICY_LEGACY
+	assertEqual(t, varnam.TransliterateGreedyTokenized("avan")[0].Word, "അവന്"+ZWJ)
+	assertEqual(t, varnam.TransliterateAdvanced("avan").GreedyTokenized[0].Word, "അവന്"+ZWJ)
+
+	var stagedWord string
+	varnam.TransliterateStaged(context.Background(), "avan", func(stage TransliterationStage, result TransliterationResult) {
+		if stage == TransliterationStageGreedyTokenized {
+			stagedWord = result.GreedyTokenized[0].Word
+		}
+	})
+	assertEqual(t, stagedWord, "അവന്"+ZWJ)
+
+	varnam.JoinerPolicy = VARNAM_JOINER_POLICY_STRIP
+	assertEqual(t, varnam.TransliterateGreedyTokenized("thaazh_vara")[0].Word, "താഴ്വര")
+	assertEqual(t, varnam.TransliterateGreedyTokenized("avan")[0].Word, "അവൻ")
+
+	varnam.JoinerPolicy = VARNAM_JOINER_POLICY_ATOMIC
+	assertEqual(t, varnam.applyJoinerPolicy("അവന്"+ZWJ), "അവൻ")
+}
+
+func TestMLCaseInsensitive(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	assertEqual(t, varnam.TransliterateGreedyTokenized("Mala")[0].Word, "Mഅല")
+
+	varnam.CaseInsensitive = true
+	defer func() {
+		varnam.CaseInsensitive = false
+	}()
+
+	// M is not in scheme, m is used
+	assertEqual(t, varnam.TransliterateGreedyTokenized("Mala")[0].Word, "മല")
+
+	// Typed case is preferred if the scheme has it
+	assertEqual(t, varnam.TransliterateGreedyTokenized("kEraLam")[0].Word, "കേരളം")
+	assertEqual(t, varnam.TransliterateGreedyTokenized("e")[0].Word, "എ")
+	assertEqual(t, varnam.TransliterateGreedyTokenized("E")[0].Word, "ഏ")
+
+	// Other case is a possibility
+	sugs := varnam.TransliterateAdvanced("e").TokenizerSuggestions
+	assertEqual(t, sugs[0].Word, "എ")
+	assertEqual(t, sugs[1].Word, "ഏ")
+}
+
+func TestMLAcronym(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+	ctx := context.Background()
+
+	// Transliterated as usual by default
+	sugs := varnam.TransliterateWithOptions(ctx, "ISRO", TransliterateOptions{})
+	assertEqual(t, sugs[0].Word != "ISRO", true)
+
+	sugs = varnam.TransliterateWithOptions(ctx, "ISRO", TransliterateOptions{Acronym: VARNAM_ACRONYM_SKIP})
+	assertEqual(t, len(sugs), 1)
+	assertEqual(t, sugs[0].Word, "ISRO")
+
+	sugs = varnam.TransliterateWithOptions(ctx, "USA", TransliterateOptions{Acronym: VARNAM_ACRONYM_SPELL})
+	assertEqual(t, sugs[0].Word, "യുഎസ്എ")
+
+	// Not acronyms
+	assertEqual(t, varnam.TransliterateWithOptions(ctx, "E", TransliterateOptions{Acronym: VARNAM_ACRONYM_SKIP})[0].Word, "ഏ")
+	assertEqual(t, varnam.TransliterateWithOptions(ctx, "Usa", TransliterateOptions{Acronym: VARNAM_ACRONYM_SKIP})[0].Word != "Usa", true)
+}
+
+func TestMLFuzzyMatching(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	err := varnam.Learn("മലയാളം", 0)
+	checkError(err)
+
+	defer func() {
+		varnam.FuzzyMatching = false
+		varnam.Unlearn("മലയാളം")
+	}()
+
+	assertEqual(t, len(varnam.TransliterateAdvanced("malayalm").FuzzySuggestions), 0)
+
+	varnam.FuzzyMatching = true
+
+	// Missing vowel
+	result := varnam.TransliterateAdvanced("malayalm")
+	assertEqual(t, result.FuzzySuggestions[0].Word, "മലയാളം")
+	assertEqual(t, result.FuzzySuggestions[0].Score < 0.5, true)
+	assertEqual(t, varnam.Transliterate("malayalm")[0].Word, "മലയാളം")
+
+	// Doubled letter
+	assertEqual(t, varnam.TransliterateAdvanced("mallayalam").FuzzySuggestions[0].Word, "മലയാളം")
+
+	// Not needed when the word is found
+	assertEqual(t, len(varnam.TransliterateAdvanced("malayalam").FuzzySuggestions), 0)
+}
+
+func TestMLTokenizerSuggestionsThreshold(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+	checkError(varnam.Learn("മലയാളത്തിൽ", 0))
+
+	defer func() {
+		varnam.TokenizerSuggestionsThreshold = 0
+		varnam.TokenizerSuggestionsOnThreshold = VARNAM_TOKENIZER_SUGGESTIONS_DEMOTE
+		varnam.Unlearn("മലയാളം")
+		varnam.Unlearn("മലയാളത്തിൽ")
+	}()
+
+	result := varnam.TransliterateAdvanced("malayala")
+	assertEqual(t, len(result.ExactMatches), 1)
+	greedy := result.GreedyTokenized[0]
+
+	// Greedy tokenized is at 2nd position normally
+	assertEqual(t, varnam.Transliterate("malayala")[1].Word, greedy.Word)
+
+	varnam.TokenizerSuggestionsThreshold = 2
+	assertEqual(t, varnam.Transliterate("malayala")[1].Word, greedy.Word)
+
+	varnam.TokenizerSuggestionsThreshold = 1
+
+	// After all dictionary words
+	sugs := varnam.Transliterate("malayala")
+	assertEqual(t, sugs[1].Word, result.DictionarySuggestions[0].Word)
+	assertEqual(t, sugs[3].Word, greedy.Word)
+	assertEqual(t, sugs[3].Score, greedy.Score*VARNAM_DEMOTED_TOKENIZER_SCORE_FACTOR)
+
+	varnam.TokenizerSuggestionsOnThreshold = VARNAM_TOKENIZER_SUGGESTIONS_DROP
+
+	result = varnam.TransliterateAdvanced("malayala")
+	assertEqual(t, len(result.GreedyTokenized), 0)
+	assertEqual(t, len(result.TokenizerSuggestions), 0)
+	assertEqual(t, len(varnam.Transliterate("malayala")), 3)
+
+	// Tokenizer is still used when dictionary has nothing
+	assertEqual(t, len(varnam.TransliterateAdvanced("pani").GreedyTokenized), 1)
+}
+
+func TestMLDictionaryMinInputLength(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+
+	varnam.CollectDiagnostics = true
+
+	defer func() {
+		varnam.DictionaryMinInputLength = 0
+		varnam.CollectDiagnostics = false
+		varnam.Unlearn("മലയാളം")
+	}()
+
+	assertEqual(t, len(varnam.TransliterateAdvanced("mala").DictionarySuggestions), 1)
+
+	varnam.DictionaryMinInputLength = 5
+
+	result := varnam.TransliterateAdvanced("mala")
+	assertEqual(t, len(result.DictionarySuggestions), 0)
+	assertEqual(t, len(result.ExactMatches), 0)
+	assertEqual(t, result.GreedyTokenized[0].Word, "മല")
+	assertEqual(t, len(result.TokenizerSuggestions) > 0, true)
+	assertEqual(t, result.Diagnostics.Dictionary, time.Duration(0))
+
+	assertEqual(t, varnam.TransliterateAdvanced("malayalam").ExactWords[0].Word, "മലയാളം")
+}
+
+func TestMLCorrections(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+
+	defer func() {
+		varnam.SuggestCorrections = false
+		varnam.Unlearn("മലയാളം")
+	}()
+
+	assertEqual(t, len(varnam.TransliterateAdvanced("melayaaLam").Corrections), 0)
+
+	varnam.SuggestCorrections = true
+
+	// One character away
+	result := varnam.TransliterateAdvanced("melayaaLam")
+	assertEqual(t, len(result.DictionarySuggestions), 0)
+	assertEqual(t, result.Corrections[0].Word, "മലയാളം")
+	assertEqual(t, result.Corrections[0].Score < dictionaryScore(result.Corrections[0]), true)
+
+	// Two characters away has lesser score
+	farther := varnam.TransliterateAdvanced("melayaalam")
+	assertEqual(t, farther.Corrections[0].Word, "മലയാളം")
+	assertEqual(t, farther.Corrections[0].Score < result.Corrections[0].Score, true)
+
+	// Not a part of the flat list
+	for _, sug := range varnam.Transliterate("melayaaLam") {
+		assertEqual(t, sug.Word != "മലയാളം", true)
+	}
+
+	// Not when dictionary has matches
+	assertEqual(t, len(varnam.TransliterateAdvanced("malayaaLam").Corrections), 0)
+
+	assertEqual(t, editDistance([]rune("മലയാളം"), []rune("മെലയാലം")), 2)
+	assertEqual(t, editDistance([]rune(""), []rune("മല")), 2)
+}
+
+func TestMLTransliterateQuick(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	words := []string{"മലയാളം", "മലയാളി", "മലയാളികൾ", "മലയാളത്തിൽ", "മലപ്പുറം"}
+	for _, word := range words {
+		checkError(varnam.Learn(word, 0))
+	}
+
+	defer func() {
+		varnam.QuickBudget = VARNAM_QUICK_BUDGET
+		for _, word := range words {
+			varnam.Unlearn(word)
+		}
+	}()
+
+	// No limit so that the test doesn't depend on machine speed
+	varnam.QuickBudget = 0
+
+	ctx := context.Background()
+
+	full := varnam.TransliterateAdvanced("mala")
+	assertEqual(t, len(full.DictionarySuggestions) > VARNAM_QUICK_DICTIONARY_SUGGESTIONS, true)
+	assertEqual(t, len(full.TokenizerSuggestions) > 0, true)
+
+	result := varnam.TransliterateAdvancedWithOptions(ctx, "mala", TransliterateOptions{Quick: true})
+	assertEqual(t, len(result.DictionarySuggestions), VARNAM_QUICK_DICTIONARY_SUGGESTIONS)
+	assertEqual(t, result.DictionarySuggestions[0].Word, full.DictionarySuggestions[0].Word)
+	assertEqual(t, len(result.TokenizerSuggestions), 0)
+	assertEqual(t, len(result.PatternDictionarySuggestions), 0)
+	assertEqual(t, result.GreedyTokenized[0].Word, "മല")
+
+	sugs := varnam.TransliterateQuick(ctx, "malayaaLam")
+	assertEqual(t, sugs[0].Word, "മലയാളം")
+
+	session := varnam.NewSession()
+	session.Append(ctx, "mala")
+	assertEqual(t, len(session.TransliterateQuick(ctx).TokenizerSuggestions), 0)
+	assertEqual(t, len(session.Transliterate(ctx).TokenizerSuggestions) > 0, true)
+
+	// Out of budget gives whatever is done, without blocking
+	varnam.QuickBudget = time.Nanosecond
+	result = varnam.TransliterateAdvancedWithOptions(ctx, "malayaaLam", TransliterateOptions{Quick: true})
+	assertEqual(t, len(result.TokenizerSuggestions), 0)
+}
+
+func TestMLTokenizerWeightThreshold(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	defer func() {
+		varnam.TokenizerWeightThreshold = 0
+	}()
+
+	all := varnam.TransliterateAdvanced("mala").TokenizerSuggestions
+	assertEqual(t, len(all) > 3, true)
+
+	// Only permutations as good as the best one
+	varnam.TokenizerWeightThreshold = 1
+	sugs := varnam.TransliterateAdvanced("mala").TokenizerSuggestions
+	assertEqual(t, len(sugs), 1)
+	assertEqual(t, sugs[0].Word, "മല")
+
+	varnam.TokenizerWeightThreshold = 0.7
+	sugs = varnam.TransliterateAdvanced("mala").TokenizerSuggestions
+	assertEqual(t, len(sugs) > 1, true)
+	assertEqual(t, len(sugs) < len(all), true)
+	for _, sug := range sugs {
+		assertEqual(t, float64(sug.Weight) >= 0.7*float64(sugs[0].Weight), true)
+	}
+}
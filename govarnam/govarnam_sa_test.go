@@ -0,0 +1,30 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestSASanitization(t *testing.T) {
+	varnam := Varnam{}
+	varnam.SchemeDetails.LangCode = "sa"
+
+	assertEqual(t, varnam.sanitizeWord("नमः॥"), "नमः")
+	assertEqual(t, varnam.sanitizeWord("रामः।"), "रामः")
+
+	// Vedic accents are kept
+	assertEqual(t, varnam.sanitizeWord("अ॑ग्निमी॒ळे"), "अ॑ग्निमी॒ळे")
+}
+
+func TestSASegmentByScript(t *testing.T) {
+	varnam := Varnam{}
+	varnam.SchemeDetails.LangCode = "sa"
+
+	// Accent marks are of no script, they stay with the letter before
+	segments := varnam.SegmentByScript("अ॑ग्नि")
+	assertEqual(t, len(segments), 1)
+	assertEqual(t, segments[0].Native, true)
+
+	segments = varnam.SegmentByScript("अ॒gni")
+	assertEqual(t, len(segments), 2)
+	assertEqual(t, segments[0].Text, "अ॒")
+}
@@ -0,0 +1,65 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import "strings"
+
+// Letters borrowed into Tamil from Grantha script to write
+// Sanskrit & foreign sounds. Purists write native letters instead
+const tamilGranthaLetters = "ஜஶஷஸஹ"
+
+func hasGranthaLetters(str string) bool {
+	return strings.ContainsAny(str, tamilGranthaLetters)
+}
+
+// Whether Grantha letters are to be left out
+func (varnam *Varnam) noGrantha() bool {
+	return varnam.NoGrantha && varnam.SchemeDetails.LangCode == "ta"
+}
+
+// Remove symbols having Grantha letters. Schemes give the native
+// letters as possibility matches of the same pattern, the first
+// of them is made an exact match when no exact match is left
+func removeGranthaSymbols(symbols []Symbol, matchType int) []Symbol {
+	hasExact := map[string]bool{}
+	for _, symbol := range symbols {
+		if symbol.MatchType == VARNAM_MATCH_EXACT && !hasGranthaLetters(symbol.Value1) && !hasGranthaLetters(symbol.Value2) {
+			hasExact[symbol.Pattern] = true
+		}
+	}
+
+	var results []Symbol
+	for _, symbol := range symbols {
+		if hasGranthaLetters(symbol.Value1) || hasGranthaLetters(symbol.Value2) {
+			continue
+		}
+
+		if symbol.MatchType == VARNAM_MATCH_POSSIBILITY && !hasExact[symbol.Pattern] {
+			symbol.MatchType = VARNAM_MATCH_EXACT
+			hasExact[symbol.Pattern] = true
+		}
+
+		if matchType != VARNAM_MATCH_ALL && symbol.MatchType != matchType {
+			continue
+		}
+
+		results = append(results, symbol)
+	}
+
+	return results
+}
+
+// Remove suggestions having Grantha letters, like learnt words
+func removeGranthaSuggestions(sugs []Suggestion) []Suggestion {
+	var results []Suggestion
+	for _, sug := range sugs {
+		if !hasGranthaLetters(sug.Word) {
+			results = append(results, sug)
+		}
+	}
+	return results
+}
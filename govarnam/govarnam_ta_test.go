@@ -0,0 +1,28 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestTARemoveGranthaSymbols(t *testing.T) {
+	symbols := []Symbol{
+		{Pattern: "ja", Value1: "ஜ", MatchType: VARNAM_MATCH_EXACT},
+		{Pattern: "ja", Value1: "ச", MatchType: VARNAM_MATCH_POSSIBILITY},
+		{Pattern: "j", Value1: "ஜ்", MatchType: VARNAM_MATCH_EXACT},
+		{Pattern: "ka", Value1: "க", MatchType: VARNAM_MATCH_EXACT},
+		{Pattern: "ka", Value1: "ஹ", MatchType: VARNAM_MATCH_POSSIBILITY},
+	}
+
+	results := removeGranthaSymbols(symbols, VARNAM_MATCH_ALL)
+	assertEqual(t, len(results), 2)
+	assertEqual(t, results[0].Value1, "ச")
+	assertEqual(t, results[0].MatchType, VARNAM_MATCH_EXACT)
+	assertEqual(t, results[1].Value1, "க")
+
+	results = removeGranthaSymbols(symbols, VARNAM_MATCH_EXACT)
+	assertEqual(t, len(results), 2)
+
+	sugs := removeGranthaSuggestions([]Suggestion{{Word: "ஜலம்"}, {Word: "சலம்"}})
+	assertEqual(t, len(sugs), 1)
+	assertEqual(t, sugs[0].Word, "சலம்")
+}
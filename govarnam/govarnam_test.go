@@ -96,6 +96,43 @@ func TestEnv(t *testing.T) {
 	assertEqual(t, fileExists(path.Join(testTempDir, "ml.vst.learnings")), true)
 }
 
+func TestGetAllSchemeDetails(t *testing.T) {
+	dir := path.Join(testTempDir, "discovery")
+	checkError(os.MkdirAll(dir, 0750))
+
+	makeVST := func(name string, id string) {
+		vm, err := VMInit(path.Join(dir, name))
+		checkError(err)
+		checkError(vm.VMSetSchemeDetails(SchemeDetails{Identifier: id, LangCode: "ml", DisplayName: "Discovery"}))
+		checkError(vm.VMCreateToken("ka", "ക", "", "", "", VARNAM_SYMBOL_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false))
+		vm.Close()
+	}
+
+	makeVST("discovery.vst", "discovery")
+
+	// Should be hidden by the ml.vst in VARNAM_VST_DIR
+	makeVST("ml.vst", "ml-duplicate")
+
+	AddVSTLookupDir(dir)
+	defer func() {
+		userVSTLookupDirs = nil
+	}()
+
+	schemeDetails, err := GetAllSchemeDetails()
+	checkError(err)
+
+	found := map[string]SchemeDetails{}
+	for _, sd := range schemeDetails {
+		assertEqual(t, fileExists(sd.Path), true)
+		found[sd.Identifier] = sd
+	}
+
+	assertEqual(t, found["discovery"].Path, path.Join(dir, "discovery.vst"))
+	assertEqual(t, found["discovery"].DisplayName, "Discovery")
+	assertEqual(t, found["ml"].Path, path.Join(VARNAM_VST_DIR, "ml.vst"))
+	assertEqual(t, found["ml-duplicate"].Path, "")
+}
+
 func TestMain(m *testing.M) {
 	schemeDetails, err := GetAllSchemeDetails()
 
@@ -103,7 +140,7 @@ func TestMain(m *testing.M) {
 		log.Fatal(err)
 	}
 
-	testTempDir, err = os.TempDir("", "govarnam_test")
+	testTempDir, err = os.MkdirTemp("", "govarnam_test")
 	checkError(err)
 
 	for _, schemeDetail := range schemeDetails {
@@ -0,0 +1,156 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"strings"
+)
+
+// Elements whose content isn't text to transliterate
+var htmlRawTextElements = []string{"script", "style"}
+
+// Index of the end of the tag starting at html[start] (just after
+// the '>'). '>' in quoted attribute values don't end the tag
+func htmlTagEnd(html string, start int) int {
+	var quote byte
+
+	for i := start + 1; i < len(html); i++ {
+		char := html[i]
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == '>':
+			return i + 1
+		}
+	}
+	return len(html)
+}
+
+// Index of the end of what starts with prefix at html[start] and
+// ends with suffix. End of html if suffix isn't found
+func htmlBlockEnd(html string, start int, prefix string, suffix string) int {
+	end := strings.Index(html[start+len(prefix):], suffix)
+	if end == -1 {
+		return len(html)
+	}
+	return start + len(prefix) + end + len(suffix)
+}
+
+// Name of the element an opening tag is of, in lowercase
+func htmlTagName(tag string) string {
+	name := strings.TrimPrefix(tag, "<")
+	end := strings.IndexAny(name, " \t\r\n/>")
+	if end != -1 {
+		name = name[:end]
+	}
+	return strings.ToLower(name)
+}
+
+// Split text (not markup) of HTML into words & the rest. Character
+// references (&amp; &#3349;) are kept as is like punctuation
+func splitHTMLText(text string) []sentencePart {
+	var parts []sentencePart
+
+	plainStart := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] != '&' {
+			continue
+		}
+
+		end := strings.IndexByte(text[i:], ';')
+		if end == -1 || strings.ContainsAny(text[i+1:i+end], " \t\r\n<&") {
+			// A lone &, not a reference
+			continue
+		}
+		end += i + 1
+
+		parts = append(parts, splitSentence(text[plainStart:i])...)
+		parts = append(parts, sentencePart{text[i:end], false})
+		plainStart = end
+		i = end - 1
+	}
+
+	return append(parts, splitSentence(text[plainStart:])...)
+}
+
+// Split HTML into words of text nodes & the rest : tags with their
+// attributes, comments, CDATA, content of script & style and
+// character references. Joining the parts gives back the HTML
+func splitHTML(html string) []sentencePart {
+	var parts []sentencePart
+
+	textStart := 0
+	i := 0
+
+	markup := func(end int) {
+		if textStart < i {
+			parts = append(parts, splitHTMLText(html[textStart:i])...)
+		}
+		parts = append(parts, sentencePart{html[i:end], false})
+		i = end
+		textStart = end
+	}
+
+	for i < len(html) {
+		if html[i] != '<' || i+1 == len(html) {
+			i++
+			continue
+		}
+
+		next := html[i+1]
+		rest := html[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			markup(htmlBlockEnd(html, i, "<!--", "-->"))
+		case strings.HasPrefix(rest, "<![CDATA["):
+			markup(htmlBlockEnd(html, i, "<![CDATA[", "]]>"))
+		case next == '/' || next == '!' || next == '?':
+			markup(htmlTagEnd(html, i))
+		case (next >= 'a' && next <= 'z') || (next >= 'A' && next <= 'Z'):
+			end := htmlTagEnd(html, i)
+			name := htmlTagName(html[i:end])
+			markup(end)
+
+			for _, rawElement := range htmlRawTextElements {
+				if name != rawElement {
+					continue
+				}
+
+				// Content till the closing tag is kept as is
+				closing := strings.Index(strings.ToLower(html[i:]), "</"+rawElement)
+				if closing == -1 {
+					markup(len(html))
+				} else if closing > 0 {
+					markup(i + closing)
+				}
+			}
+		default:
+			// A lone <, like in "a < b"
+			i++
+		}
+	}
+
+	if textStart < len(html) {
+		parts = append(parts, splitHTMLText(html[textStart:])...)
+	}
+
+	return parts
+}
+
+// TransliterateHTML transliterate text in an HTML document like
+// TransliterateDocument. Only text nodes are transliterated, tags,
+// attributes, comments, script & style content and character
+// references are kept as is.
+func (varnam *Varnam) TransliterateHTML(ctx context.Context, html string) (string, []ConvertedWord, error) {
+	return varnam.convertParts(ctx, splitHTML(html))
+}
@@ -0,0 +1,42 @@
+package govarnam
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSplitHTML(t *testing.T) {
+	html := `<!DOCTYPE html><p class="a>b" title='x'>pani &amp; nanni&nbsp;a < b</p>` +
+		`<!-- ente <b>comment</b> --><SCRIPT>var pani = "<p>";</script><style></style>` +
+		`<![CDATA[ pani ]]>R&D ok`
+
+	var words []string
+	var joined strings.Builder
+
+	for _, part := range splitHTML(html) {
+		if part.isWord {
+			words = append(words, part.text)
+		}
+		joined.WriteString(part.text)
+	}
+
+	assertEqual(t, joined.String(), html)
+	assertEqual(t, strings.Join(words, " "), "pani nanni a < b R&D ok")
+}
+
+func TestMLTransliterateHTML(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	top := func(word string) string {
+		return varnam.Transliterate(word)[0].Word
+	}
+
+	output, words, err := varnam.TransliterateHTML(context.Background(), "<p title=\"pani\">\n  pani&nbsp;<b>nanni</b>\n</p>\n<script>pani()</script>")
+	checkError(err)
+	assertEqual(t, output, "<p title=\"pani\">\n  "+top("pani")+"&nbsp;<b>"+top("nanni")+"</b>\n</p>\n<script>pani()</script>")
+
+	assertEqual(t, len(words), 2)
+	assertEqual(t, words[0].Line, 2)
+	assertEqual(t, words[1].Input, "nanni")
+}
@@ -0,0 +1,385 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A hunspell dictionary is a pair of files :
+//   .dic - Word count in first line and then one word per line as word/FLAGS
+//   .aff - Affix rules. SFX & PFX rules are applied on a word if it has the flag
+// Only the parts needed to get the word forms are parsed. Compounding,
+// suggestion and morphology options are ignored.
+
+type hunspellAffix struct {
+	suffix    bool
+	strip     string
+	add       string
+	condition *regexp.Regexp
+}
+
+type hunspellAffixGroup struct {
+	crossProduct bool
+	rules        []hunspellAffix
+}
+
+type hunspellAff struct {
+	flagType      string
+	affixes       map[string]*hunspellAffixGroup
+	needAffix     string
+	forbiddenWord string
+}
+
+// Split the flags string according to FLAG type in .aff
+func (aff *hunspellAff) parseFlags(flags string) []string {
+	var result []string
+
+	switch aff.flagType {
+	case "long":
+		runes := []rune(flags)
+		for i := 0; i+1 < len(runes); i += 2 {
+			result = append(result, string(runes[i:i+2]))
+		}
+	case "num":
+		for _, flag := range strings.Split(flags, ",") {
+			if flag != "" {
+				result = append(result, flag)
+			}
+		}
+	default:
+		// Both "UTF-8" and the default single character flags
+		for _, r := range flags {
+			result = append(result, string(r))
+		}
+	}
+
+	return result
+}
+
+func parseHunspellAff(affPath string) (*hunspellAff, error) {
+	aff := &hunspellAff{
+		affixes: map[string]*hunspellAffixGroup{},
+	}
+
+	file, err := os.Open(affPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "SET":
+			if strings.ToUpper(fields[1]) != "UTF-8" {
+				return nil, fmt.Errorf("hunspell encoding %s is not supported, convert it to UTF-8", fields[1])
+			}
+		case "FLAG":
+			aff.flagType = fields[1]
+		case "NEEDAFFIX":
+			aff.needAffix = fields[1]
+		case "FORBIDDENWORD":
+			aff.forbiddenWord = fields[1]
+		case "SFX", "PFX":
+			if len(fields) < 4 {
+				continue
+			}
+
+			flag := fields[1]
+			group, exists := aff.affixes[flag]
+
+			if !exists {
+				// Header line : SFX flag cross_product number_of_rules
+				aff.affixes[flag] = &hunspellAffixGroup{crossProduct: fields[2] == "Y"}
+				continue
+			}
+
+			// Rule line : SFX flag stripping affix[/flags] [condition]
+			affix := hunspellAffix{suffix: fields[0] == "SFX"}
+
+			if fields[2] != "0" {
+				affix.strip = fields[2]
+			}
+
+			affix.add = strings.SplitN(fields[3], "/", 2)[0]
+			if affix.add == "0" {
+				affix.add = ""
+			}
+
+			condition := "."
+			if len(fields) > 4 {
+				condition = fields[4]
+			}
+
+			if affix.suffix {
+				affix.condition, err = regexp.Compile("(" + condition + ")$")
+			} else {
+				affix.condition, err = regexp.Compile("^(" + condition + ")")
+			}
+			if err != nil {
+				log.Printf("Skipping affix rule with bad condition %s: %s", condition, err.Error())
+				continue
+			}
+
+			group.rules = append(group.rules, affix)
+		}
+	}
+
+	return aff, scanner.Err()
+}
+
+func (affix hunspellAffix) apply(word string) (string, bool) {
+	if !affix.condition.MatchString(word) {
+		return "", false
+	}
+
+	if affix.suffix {
+		if !strings.HasSuffix(word, affix.strip) {
+			return "", false
+		}
+		return strings.TrimSuffix(word, affix.strip) + affix.add, true
+	}
+
+	if !strings.HasPrefix(word, affix.strip) {
+		return "", false
+	}
+	return affix.add + strings.TrimPrefix(word, affix.strip), true
+}
+
+// Get all forms of a word from its affix flags
+func (aff *hunspellAff) expand(word string, flags []string) []string {
+	var (
+		forms    []string
+		prefixed []string
+
+		crossSuffixes []hunspellAffix
+	)
+
+	needAffix := false
+
+	for _, flag := range flags {
+		if flag == aff.forbiddenWord {
+			return nil
+		}
+		if flag == aff.needAffix {
+			needAffix = true
+			continue
+		}
+
+		group, exists := aff.affixes[flag]
+		if !exists {
+			continue
+		}
+
+		for _, affix := range group.rules {
+			form, ok := affix.apply(word)
+			if !ok {
+				continue
+			}
+
+			forms = append(forms, form)
+
+			if affix.suffix && group.crossProduct {
+				crossSuffixes = append(crossSuffixes, affix)
+			} else if !affix.suffix && group.crossProduct {
+				prefixed = append(prefixed, form)
+			}
+		}
+	}
+
+	// Words that have both a prefix and suffix
+	for _, form := range prefixed {
+		for _, affix := range crossSuffixes {
+			if crossForm, ok := affix.apply(form); ok {
+				forms = append(forms, crossForm)
+			}
+		}
+	}
+
+	if !needAffix {
+		forms = append([]string{word}, forms...)
+	}
+
+	return forms
+}
+
+// ImportFromHunspell import words in a hunspell dictionary as corpus words.
+// affPath is optional, words are expanded with its affix rules if given.
+// The words are not marked as learnt so that they come after
+// the words learnt by user. Existing words are not changed.
+func (varnam *Varnam) ImportFromHunspell(dicPath string, affPath string) (LearnStatus, error) {
+	learnStatus := LearnStatus{0, 0}
+
+	aff := &hunspellAff{}
+
+	if affPath != "" {
+		var err error
+		aff, err = parseHunspellAff(affPath)
+		if err != nil {
+			return learnStatus, err
+		}
+	}
+
+	file, err := os.Open(dicPath)
+	if err != nil {
+		return learnStatus, err
+	}
+	defer file.Close()
+
+	var words []WordInfo
+
+	// Same word form can come from different base words
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// First line is the approximate word count
+		if lineNumber == 1 {
+			if _, err := strconv.Atoi(line); err == nil {
+				continue
+			}
+		}
+
+		// Morphological fields are after a tab or space
+		entry := strings.Fields(line)[0]
+
+		word := entry
+		var flags []string
+
+		if slashIndex := strings.Index(entry, "/"); slashIndex > 0 {
+			word = entry[0:slashIndex]
+			flags = aff.parseFlags(entry[slashIndex+1:])
+		}
+
+		for _, form := range aff.expand(word, flags) {
+			learnStatus.TotalWords++
+
+			form = varnam.sanitizeWord(form)
+			if form == "" || len(varnam.splitWordByConjunct(form)) < 2 {
+				learnStatus.FailedWords++
+				continue
+			}
+
+			if seen[form] {
+				continue
+			}
+			seen[form] = true
+
+			words = append(words, WordInfo{0, form, VARNAM_LEARNT_WORD_MIN_WEIGHT, 0})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return learnStatus, err
+	}
+
+	err = varnam.insertWords(words)
+	if err != nil {
+		return learnStatus, err
+	}
+
+	if varnam.Debug {
+		log.Printf("Imported %d words from hunspell dictionary", len(words))
+	}
+
+	return learnStatus, nil
+}
+
+// ExportToHunspell write words learnt by user as a hunspell dictionary.
+// basePath.dic and basePath.aff are made. Corpus words (not learnt) are
+// not exported. No affix rules are made, every word form is in .dic
+func (varnam *Varnam) ExportToHunspell(basePath string) error {
+	dicPath := basePath + ".dic"
+	affPath := basePath + ".aff"
+
+	if fileExists(dicPath) || fileExists(affPath) {
+		return fmt.Errorf("Output file already exists")
+	}
+
+	rows, err := varnam.dictConn.Query("SELECT word FROM words WHERE learned_on > 0 ORDER BY word")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var words []string
+
+	// For the TRY option. Hunspell tries these characters
+	// in this order when making spelling suggestions
+	charFrequency := map[rune]int{}
+
+	for rows.Next() {
+		var word string
+		rows.Scan(&word)
+
+		// Slash separates word & flags
+		if strings.Contains(word, "/") {
+			continue
+		}
+
+		words = append(words, word)
+
+		for _, r := range word {
+			charFrequency[r]++
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var chars []rune
+	for r := range charFrequency {
+		chars = append(chars, r)
+	}
+	sort.Slice(chars, func(i, j int) bool {
+		if charFrequency[chars[i]] == charFrequency[chars[j]] {
+			return chars[i] < chars[j]
+		}
+		return charFrequency[chars[i]] > charFrequency[chars[j]]
+	})
+
+	aff := "SET UTF-8\n"
+	if len(chars) > 0 {
+		aff += "TRY " + string(chars) + "\n"
+	}
+
+	err = os.WriteFile(affPath, []byte(aff), 0644)
+	if err != nil {
+		return err
+	}
+
+	dic := fmt.Sprintln(len(words))
+	if len(words) > 0 {
+		dic += strings.Join(words, "\n") + "\n"
+	}
+
+	return os.WriteFile(dicPath, []byte(dic), 0644)
+}
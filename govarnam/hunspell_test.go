@@ -0,0 +1,111 @@
+package govarnam
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestMLImportFromHunspell(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	affPath := path.Join(testTempDir, "ml.aff")
+	dicPath := path.Join(testTempDir, "ml.dic")
+
+	aff := `SET UTF-8
+NEEDAFFIX X
+
+SFX A Y 2
+SFX A 0 യെ .
+SFX A 0 യുടെ .
+
+SFX B Y 1
+SFX B ം ത്തിൽ ം
+`
+	dic := `4
+പൂച്ച/A
+മരം/B
+കുട്ടി/AX
+# comment
+വീട്	po:noun
+`
+
+	checkError(os.WriteFile(affPath, []byte(aff), 0644))
+	checkError(os.WriteFile(dicPath, []byte(dic), 0644))
+
+	learnStatus, err := varnam.ImportFromHunspell(dicPath, affPath)
+	checkError(err)
+	assertEqual(t, learnStatus.TotalWords, 8)
+	assertEqual(t, learnStatus.FailedWords, 0)
+
+	for _, word := range []string{"പൂച്ച", "പൂച്ചയെ", "പൂച്ചയുടെ", "മരം", "മരത്തിൽ", "കുട്ടിയെ", "വീട്"} {
+		wordInfo, err := varnam.getWordInfo(word)
+		checkError(err)
+
+		// Not learnt by user
+		assertEqual(t, wordInfo.weight, VARNAM_LEARNT_WORD_MIN_WEIGHT)
+		assertEqual(t, wordInfo.learnedOn, 0)
+	}
+
+	// NEEDAFFIX word shouldn't be imported as is
+	_, err = varnam.getWordInfo("കുട്ടി")
+	assertEqual(t, err != nil, true)
+
+	// Learnt words are not changed
+	checkError(varnam.Learn("മലയാളം", 0))
+	wordInfo, _ := varnam.getWordInfo("മലയാളം")
+
+	checkError(os.WriteFile(dicPath, []byte("മലയാളം\n"), 0644))
+	_, err = varnam.ImportFromHunspell(dicPath, "")
+	checkError(err)
+
+	wordInfoAfter, _ := varnam.getWordInfo("മലയാളം")
+	assertEqual(t, *wordInfoAfter, *wordInfo)
+
+	for _, word := range []string{"പൂച്ച", "പൂച്ചയെ", "പൂച്ചയുടെ", "മരം", "മരത്തിൽ", "കുട്ടിയെ", "വീട്", "മലയാളം"} {
+		checkError(varnam.Unlearn(word))
+	}
+}
+
+func TestMLExportToHunspell(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	basePath := path.Join(testTempDir, "export-hunspell")
+
+	checkError(varnam.Learn("പാലക്കാട്", 0))
+	checkError(varnam.Learn("കൊല്ലം", 0))
+
+	// Corpus words are not exported
+	dicPath := path.Join(testTempDir, "corpus.dic")
+	checkError(os.WriteFile(dicPath, []byte("വയനാട്\n"), 0644))
+	_, err := varnam.ImportFromHunspell(dicPath, "")
+	checkError(err)
+
+	checkError(varnam.ExportToHunspell(basePath))
+
+	dic, err := os.ReadFile(basePath + ".dic")
+	checkError(err)
+	assertEqual(t, string(dic), "2\nകൊല്ലം\nപാലക്കാട്\n")
+
+	aff, err := os.ReadFile(basePath + ".aff")
+	checkError(err)
+	// Most used characters first. ക, ല & ് are used 3 times
+	assertEqual(t, strings.HasPrefix(string(aff), "SET UTF-8\nTRY ക"), true)
+
+	// Exported dictionary can be imported back
+	varnam.Unlearn("പാലക്കാട്")
+	learnStatus, err := varnam.ImportFromHunspell(basePath+".dic", basePath+".aff")
+	checkError(err)
+	assertEqual(t, learnStatus.TotalWords, 2)
+
+	_, err = varnam.getWordInfo("പാലക്കാട്")
+	checkError(err)
+
+	// Won't overwrite
+	assertEqual(t, varnam.ExportToHunspell(basePath) != nil, true)
+
+	for _, word := range []string{"പാലക്കാട്", "കൊല്ലം", "വയനാട്"} {
+		checkError(varnam.Unlearn(word))
+	}
+}
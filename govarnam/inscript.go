@@ -0,0 +1,228 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// InScript keys & the characters they type, as offsets from the
+// start of the script's unicode block. Indic blocks follow the same
+// order (from ISCII), so one layout works for all scripts.
+// Characters the script doesn't have are not typed
+var inscriptKeys = map[rune][]rune{
+	'`':  {0x4A}, // ॊ
+	'=':  {0x43}, // ृ
+	'q':  {0x4C}, // ौ
+	'w':  {0x48}, // ै
+	'e':  {0x3E}, // ा
+	'r':  {0x40}, // ी
+	't':  {0x42}, // ू
+	'y':  {0x2C}, // ब
+	'u':  {0x39}, // ह
+	'i':  {0x17}, // ग
+	'o':  {0x26}, // द
+	'p':  {0x1C}, // ज
+	'[':  {0x21}, // ड
+	']':  {0x3C}, // ़
+	'\\': {0x49}, // ॉ
+	'a':  {0x4B}, // ो
+	's':  {0x47}, // े
+	'd':  {0x4D}, // ्
+	'f':  {0x3F}, // ि
+	'g':  {0x41}, // ु
+	'h':  {0x2A}, // प
+	'j':  {0x30}, // र
+	'k':  {0x15}, // क
+	'l':  {0x24}, // त
+	';':  {0x1A}, // च
+	'\'': {0x1F}, // ट
+	'z':  {0x46}, // ॆ
+	'x':  {0x02}, // ं
+	'c':  {0x2E}, // म
+	'v':  {0x28}, // न
+	'b':  {0x35}, // व
+	'n':  {0x32}, // ल
+	'm':  {0x38}, // स
+	'/':  {0x2F}, // य
+
+	'~': {0x12},             // ऒ
+	'!': {0x0D},             // ऍ
+	'@': {0x45},             // ॅ
+	'#': {0x4D, 0x30},       // ्र
+	'$': {0x30, 0x4D},       // र्
+	'%': {0x1C, 0x4D, 0x1E}, // ज्ञ
+	'^': {0x24, 0x4D, 0x30}, // त्र
+	'&': {0x15, 0x4D, 0x37}, // क्ष
+	'*': {0x36, 0x4D, 0x30}, // श्र
+	'_': {0x03},             // ः
+	'+': {0x0B},             // ऋ
+	'Q': {0x14},             // औ
+	'W': {0x10},             // ऐ
+	'E': {0x06},             // आ
+	'R': {0x08},             // ई
+	'T': {0x0A},             // ऊ
+	'Y': {0x2D},             // भ
+	'U': {0x19},             // ङ
+	'I': {0x18},             // घ
+	'O': {0x27},             // ध
+	'P': {0x1D},             // झ
+	'{': {0x22},             // ढ
+	'}': {0x1E},             // ञ
+	'|': {0x11},             // ऑ
+	'A': {0x13},             // ओ
+	'S': {0x0F},             // ए
+	'D': {0x05},             // अ
+	'F': {0x07},             // इ
+	'G': {0x09},             // उ
+	'H': {0x2B},             // फ
+	'J': {0x31},             // ऱ
+	'K': {0x16},             // ख
+	'L': {0x25},             // थ
+	':': {0x1B},             // छ
+	'"': {0x20},             // ठ
+	'Z': {0x0E},             // ऎ
+	'X': {0x01},             // ँ
+	'C': {0x23},             // ण
+	'V': {0x29},             // ऩ
+	'B': {0x34},             // ऴ
+	'N': {0x33},             // ळ
+	'M': {0x36},             // श
+	'<': {0x37},             // ष
+	'?': {0x2F, 0x3C},       // य़
+}
+
+// Start of unicode block of each language's script
+var inscriptScriptStart = map[string]rune{
+	"as": 0x0980,
+	"bn": 0x0980,
+	"gu": 0x0A80,
+	"hi": 0x0900,
+	"kn": 0x0C80,
+	"ml": 0x0D00,
+	"mr": 0x0900,
+	"ne": 0x0900,
+	"or": 0x0B00,
+	"pa": 0x0A00,
+	"sa": 0x0900,
+	"ta": 0x0B80,
+	"te": 0x0C00,
+}
+
+// Keys typing something else than the offset in layout.
+// Empty if the key doesn't type anything in the language
+var inscriptLanguageKeys = map[string]map[rune]string{
+	"as": {
+		'j': "ৰ",
+		'b': "ৱ",
+	},
+	"ml": {
+		// No nukta, used for chillus (ന്‍) instead
+		']': ZWJ,
+		'?': "",
+	},
+}
+
+// Languages having DANDA, typed with >
+var inscriptDandaLanguages = map[string]bool{
+	"as": true,
+	"bn": true,
+	"hi": true,
+	"mr": true,
+	"ne": true,
+	"or": true,
+	"pa": true,
+	"sa": true,
+}
+
+// What key types in the language. false if nothing
+func (varnam *Varnam) inscriptKeyValue(key rune) (string, bool) {
+	langCode := varnam.SchemeDetails.LangCode
+
+	if value, ok := inscriptLanguageKeys[langCode][key]; ok {
+		return value, value != ""
+	}
+
+	if key == '>' && inscriptDandaLanguages[langCode] {
+		return "।", true
+	}
+
+	start, ok := inscriptScriptStart[langCode]
+	if !ok {
+		return "", false
+	}
+
+	if key >= '0' && key <= '9' {
+		// Digits are typed as numbers are in phonetic input
+		return string(start + 0x66 + key - '0'), true
+	}
+
+	offsets, ok := inscriptKeys[key]
+	if !ok {
+		return "", false
+	}
+
+	script := languageScripts[langCode]
+
+	var value []rune
+	for _, offset := range offsets {
+		char := start + offset
+		if !unicode.Is(script, char) {
+			return "", false
+		}
+		value = append(value, char)
+	}
+
+	return string(value), true
+}
+
+// InscriptToNative text typed with the keys on InScript layout.
+// Keys that don't type anything in the language are kept as is
+func (varnam *Varnam) InscriptToNative(keys string) string {
+	var result strings.Builder
+	for _, token := range *varnam.tokenizeInscript(context.Background(), keys) {
+		if token.tokenType == VARNAM_TOKEN_SYMBOL {
+			result.WriteString(token.symbols[0].Value1)
+		} else {
+			result.WriteString(token.character)
+		}
+	}
+	return result.String()
+}
+
+// Each InScript key is a token having a single symbol,
+// the character it types. Input is not ambiguous, the
+// dictionary gives words starting with what's typed
+func (varnam *Varnam) tokenizeInscript(ctx context.Context, word string) *[]Token {
+	var results []Token
+
+	for i, key := range []rune(word) {
+		value, ok := varnam.inscriptKeyValue(key)
+
+		if ok && key >= '0' && key <= '9' && !varnam.useIndicDigits(ctx) {
+			ok = false
+		}
+
+		if !ok {
+			results = append(results, Token{VARNAM_TOKEN_CHAR, []Symbol{}, i, string(key)})
+			continue
+		}
+
+		symbol := Symbol{
+			Type:      VARNAM_SYMBOL_OTHER,
+			MatchType: VARNAM_MATCH_EXACT,
+			Pattern:   string(key),
+			Value1:    value,
+			Value2:    value,
+		}
+		results = append(results, Token{VARNAM_TOKEN_SYMBOL, []Symbol{symbol}, i, string(key)})
+	}
+
+	return &results
+}
@@ -0,0 +1,45 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestInscriptToNative(t *testing.T) {
+	varnam := Varnam{}
+	varnam.SchemeDetails.LangCode = "ml"
+
+	assertEqual(t, varnam.InscriptToNative("ECs"), "ആണേ")
+	assertEqual(t, varnam.InscriptToNative("Zhdha"), "എപ്പോ")
+	assertEqual(t, varnam.InscriptToNative("Gh/aif;d;g"), "ഉപയോഗിച്ചു")
+	assertEqual(t, varnam.InscriptToNative("cdc"), "മ്മ")
+
+	// Chillu
+	assertEqual(t, varnam.InscriptToNative("vd]"), "ന്‍")
+
+	// Digits stay unless asked for
+	assertEqual(t, varnam.InscriptToNative("E12"), "ആ12")
+	varnam.LangRules.IndicDigits = true
+	assertEqual(t, varnam.InscriptToNative("E12"), "ആ൧൨")
+
+	varnam = Varnam{}
+	varnam.SchemeDetails.LangCode = "hi"
+
+	assertEqual(t, varnam.InscriptToNative("vcmdls"), "नमस्ते")
+	assertEqual(t, varnam.InscriptToNative("kf>"), "कि।")
+}
+
+func TestMLInscriptInput(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+	varnam.InputMethod = VARNAM_INPUT_METHOD_INSCRIPT
+	defer func() {
+		varnam.InputMethod = VARNAM_INPUT_METHOD_PHONETIC
+	}()
+
+	assertEqual(t, varnam.TransliterateGreedyTokenized("ECs")[0].Word, "ആണേ")
+
+	// Words learnt are suggested for InScript input too
+	checkError(varnam.Learn("മലയാളം", 0))
+	sugs := varnam.Transliterate("cn/")
+	assertEqual(t, sugs[0].Word, "മലയ")
+	assertEqual(t, sugs[1].Word, "മലയാളം")
+}
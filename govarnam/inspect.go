@@ -0,0 +1,132 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+var symbolTypeNames = map[int]string{
+	VARNAM_SYMBOL_VOWEL:           "vowel",
+	VARNAM_SYMBOL_CONSONANT:       "consonant",
+	VARNAM_SYMBOL_DEAD_CONSONANT:  "dead_consonant",
+	VARNAM_SYMBOL_CONSONANT_VOWEL: "consonant_vowel",
+	VARNAM_SYMBOL_NUMBER:          "number",
+	VARNAM_SYMBOL_SYMBOL:          "symbol",
+	VARNAM_SYMBOL_ANUSVARA:        "anusvara",
+	VARNAM_SYMBOL_VISARGA:         "visarga",
+	VARNAM_SYMBOL_VIRAMA:          "virama",
+	VARNAM_SYMBOL_OTHER:           "other",
+	VARNAM_SYMBOL_NON_JOINER:      "non_joiner",
+	VARNAM_SYMBOL_JOINER:          "joiner",
+	VARNAM_SYMBOL_PERIOD:          "period",
+}
+
+var acceptConditionNames = map[int]string{
+	VARNAM_TOKEN_ACCEPT_ALL:            "all",
+	VARNAM_TOKEN_ACCEPT_IF_STARTS_WITH: "starts",
+	VARNAM_TOKEN_ACCEPT_IF_IN_BETWEEN:  "between",
+	VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH:   "ends",
+}
+
+// A symbol in one line. Eg:
+// consonant        ka => ക  [exact, all, weight 200, priority 0]
+func formatSymbol(symbol Symbol) string {
+	typeName, found := symbolTypeNames[symbol.Type]
+	if !found {
+		typeName = fmt.Sprint(symbol.Type)
+	}
+
+	matchType := "exact"
+	if symbol.MatchType == VARNAM_MATCH_POSSIBILITY {
+		matchType = "possibility"
+	}
+
+	acceptCondition, found := acceptConditionNames[symbol.AcceptCondition]
+	if !found {
+		acceptCondition = fmt.Sprint(symbol.AcceptCondition)
+	}
+
+	values := fmt.Sprintf("%q", symbol.Value1)
+	if symbol.Value2 != "" {
+		values += fmt.Sprintf(" / %q", symbol.Value2)
+	}
+	if symbol.Value3 != "" {
+		values += fmt.Sprintf(" / %q", symbol.Value3)
+	}
+
+	line := fmt.Sprintf("%-16s %q => %s  [%s, %s, weight %d, priority %d", typeName, symbol.Pattern, values, matchType, acceptCondition, symbol.Weight, symbol.Priority)
+	if symbol.Tag != "" {
+		line += ", tag " + symbol.Tag
+	}
+	return line + "]"
+}
+
+// DumpVST write all symbols of the scheme in a readable form,
+// one symbol per line
+func (varnam *Varnam) DumpVST(w io.Writer) error {
+	symbols, err := varnam.GetAllSymbols()
+	if err != nil {
+		return err
+	}
+
+	sd := varnam.SchemeDetails
+	fmt.Fprintf(w, "# %s (%s) by %s, compiled on %s\n", sd.Identifier, sd.DisplayName, sd.Author, sd.CompiledDate)
+	fmt.Fprintf(w, "# %d symbols\n", len(symbols))
+
+	for _, symbol := range symbols {
+		_, err = fmt.Fprintln(w, formatSymbol(symbol))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DumpTokens write how input is tokenized. Each part of input
+// is followed by the symbols it can be, in the order tried
+func (varnam *Varnam) DumpTokens(ctx context.Context, input string, w io.Writer) error {
+	tokens := *varnam.tokenizeWord(ctx, input, VARNAM_MATCH_ALL, false)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	inputRunes := []rune(input)
+	start := 0
+
+	for _, token := range tokens {
+		// Token position is of its last character
+		end := token.position + 1
+		if end > len(inputRunes) {
+			end = len(inputRunes)
+		}
+
+		part := string(inputRunes[start:end])
+		start = end
+
+		if token.tokenType != VARNAM_TOKEN_SYMBOL {
+			fmt.Fprintf(w, "%q  not in scheme, kept as %q\n", part, token.character)
+			continue
+		}
+
+		fmt.Fprintf(w, "%q\n", part)
+		for _, symbol := range token.symbols {
+			_, err := fmt.Fprintln(w, "    "+formatSymbol(symbol))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package govarnam
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMLDumpVST(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	var buf bytes.Buffer
+	checkError(varnam.DumpVST(&buf))
+
+	symbols, err := varnam.GetAllSymbols()
+	checkError(err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assertEqual(t, strings.HasPrefix(lines[0], "# ml "), true)
+	assertEqual(t, len(lines), len(symbols)+2)
+	assertEqual(t, strings.Contains(buf.String(), `consonant        "ka" => "ക" / "ക"  [exact, all, weight 200, priority 0]`), true)
+}
+
+func TestMLDumpTokens(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	var buf bytes.Buffer
+	checkError(varnam.DumpTokens(context.Background(), "mala1", &buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assertEqual(t, lines[0], `"ma"`)
+	assertEqual(t, strings.HasPrefix(lines[1], `    consonant        "ma" => "മ"`), true)
+	assertEqual(t, lines[len(lines)-1], `"1"  not in scheme, kept as "1"`)
+	assertEqual(t, strings.Contains(buf.String(), "\n\"la\"\n"), true)
+}
@@ -0,0 +1,192 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Indic unicode blocks have the same layout. The tables below
+// are by the offset of a character from the start of its block.
+// Eg: 0x15 is KA in all of them (क, ক, ക ...)
+
+var iso15919Consonants = map[rune]string{
+	0x15: "k", 0x16: "kh", 0x17: "g", 0x18: "gh", 0x19: "ṅ",
+	0x1A: "c", 0x1B: "ch", 0x1C: "j", 0x1D: "jh", 0x1E: "ñ",
+	0x1F: "ṭ", 0x20: "ṭh", 0x21: "ḍ", 0x22: "ḍh", 0x23: "ṇ",
+	0x24: "t", 0x25: "th", 0x26: "d", 0x27: "dh", 0x28: "n", 0x29: "ṉ",
+	0x2A: "p", 0x2B: "ph", 0x2C: "b", 0x2D: "bh", 0x2E: "m",
+	0x2F: "y", 0x30: "r", 0x31: "ṟ", 0x32: "l", 0x33: "ḷ", 0x34: "ḻ", 0x35: "v",
+	0x36: "ś", 0x37: "ṣ", 0x38: "s", 0x39: "h", 0x3A: "ṯ",
+
+	// Precomposed nukta consonants
+	0x58: "q", 0x59: "k͟h", 0x5A: "ġ", 0x5B: "z", 0x5C: "ṛ", 0x5D: "ṛh", 0x5E: "f", 0x5F: "ẏ",
+}
+
+// Consonant + nukta
+var iso15919Nukta = map[string]string{
+	"k": "q", "kh": "k͟h", "g": "ġ", "j": "z", "ḍ": "ṛ", "ḍh": "ṛh", "ph": "f", "y": "ẏ",
+}
+
+// Malayalam atomic chillus, consonants without a vowel
+var iso15919Chillus = map[rune]string{
+	0x7A: "ṇ", 0x7B: "n", 0x7C: "r", 0x7D: "l", 0x7E: "ḷ", 0x7F: "k",
+}
+
+var iso15919Vowels = map[rune]string{
+	0x05: "a", 0x06: "ā", 0x07: "i", 0x08: "ī", 0x09: "u", 0x0A: "ū",
+	0x0B: "r̥", 0x60: "r̥̄", 0x0C: "l̥", 0x61: "l̥̄",
+	0x0D: "ê", 0x0E: "e", 0x0F: "ē", 0x10: "ai",
+	0x11: "ô", 0x12: "o", 0x13: "ō", 0x14: "au",
+}
+
+var iso15919VowelSigns = map[rune]string{
+	0x3E: "ā", 0x3F: "i", 0x40: "ī", 0x41: "u", 0x42: "ū",
+	0x43: "r̥", 0x44: "r̥̄", 0x62: "l̥", 0x63: "l̥̄",
+	0x45: "ê", 0x46: "e", 0x47: "ē", 0x48: "ai",
+	0x49: "ô", 0x4A: "o", 0x4B: "ō", 0x4C: "au",
+
+	// Malayalam AU length mark, used as AU sign
+	0x57: "au",
+}
+
+var iso15919Others = map[rune]string{
+	0x01: "m̐", 0x02: "ṁ", 0x03: "ḥ", 0x3D: "’",
+}
+
+// Language specific differences from the tables above
+var iso15919LanguageOthers = map[string]map[rune]string{
+	// Aytham ஃ is at visarga's place
+	"ta": {0x03: "ḵ"},
+}
+
+// Consonants that would be read as aspirated if followed by h
+var iso15919Aspirable = map[string]bool{
+	"k": true, "g": true, "c": true, "j": true, "ṭ": true,
+	"ḍ": true, "t": true, "d": true, "p": true, "b": true,
+}
+
+const (
+	iso15919NuktaSign  = 0x3C
+	iso15919Virama     = 0x4D
+	iso15919DigitZero  = 0x66
+	iso15919DigitNine  = 0x6F
+	devanagariDanda    = '।'
+	devanagariDblDanda = '॥'
+)
+
+// RomanizeISO15919 write a word (or text) in the language's script
+// in latin as per ISO 15919, with diacritics. Unlike ReverseTransliterate
+// which gives patterns of the scheme, this has only one output and is
+// meant for reading. Characters not in the script are kept as is.
+// Eg: മലയാളം => malayāḷaṁ
+func (varnam *Varnam) RomanizeISO15919(text string) (string, error) {
+	script, ok := languageScripts[varnam.SchemeDetails.LangCode]
+	if !ok {
+		return "", fmt.Errorf("ISO 15919 is not supported for language %s", varnam.SchemeDetails.LangCode)
+	}
+
+	// Scripts start at a multiple of 0x80
+	blockStart := rune(script.R16[0].Lo) &^ 0x7F
+
+	others := iso15919LanguageOthers[varnam.SchemeDetails.LangCode]
+
+	var output strings.Builder
+
+	// Consonant whose vowel is not known yet
+	pending := ""
+
+	// Consonant written with a virama just before
+	dead := ""
+
+	flush := func(vowel string) {
+		if pending != "" {
+			output.WriteString(pending + vowel)
+			pending = ""
+		}
+	}
+
+	for _, char := range text {
+		offset := char - blockStart
+
+		if string(char) == ZWJ || string(char) == ZWNJ {
+			continue
+		}
+
+		if char == devanagariDanda || char == devanagariDblDanda {
+			flush("a")
+			output.WriteString(strings.Repeat(".", int(char-devanagariDanda)+1))
+			dead = ""
+			continue
+		}
+
+		if offset < 0 || offset > 0x7F {
+			flush("a")
+			output.WriteRune(char)
+			dead = ""
+			continue
+		}
+
+		if consonant, ok := iso15919Consonants[offset]; ok {
+			flush("a")
+
+			// kh is KHA, k:h is KA + HA
+			if consonant == "h" && iso15919Aspirable[dead] {
+				output.WriteString(":")
+			}
+
+			pending = consonant
+			dead = ""
+			continue
+		}
+
+		if offset == iso15919NuktaSign {
+			if nukta, ok := iso15919Nukta[pending]; ok {
+				pending = nukta
+			}
+			continue
+		}
+
+		if offset == iso15919Virama {
+			dead = pending
+			flush("")
+			continue
+		}
+
+		dead = ""
+
+		if sign, ok := iso15919VowelSigns[offset]; ok {
+			flush(sign)
+			continue
+		}
+
+		flush("a")
+
+		if chillu, ok := iso15919Chillus[offset]; ok && varnam.SchemeDetails.LangCode == "ml" {
+			output.WriteString(chillu)
+		} else if vowel, ok := iso15919Vowels[offset]; ok {
+			// ai is AI, a:i is A + I
+			if (vowel == "i" || vowel == "u") && strings.HasSuffix(output.String(), "a") {
+				output.WriteString(":")
+			}
+			output.WriteString(vowel)
+		} else if other, ok := others[offset]; ok {
+			output.WriteString(other)
+		} else if other, ok := iso15919Others[offset]; ok {
+			output.WriteString(other)
+		} else if offset >= iso15919DigitZero && offset <= iso15919DigitNine {
+			output.WriteRune('0' + offset - iso15919DigitZero)
+		} else {
+			output.WriteRune(char)
+		}
+	}
+
+	flush("a")
+
+	return output.String(), nil
+}
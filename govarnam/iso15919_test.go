@@ -0,0 +1,58 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestMLRomanizeISO15919(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	romanize := func(text string) string {
+		romanized, err := varnam.RomanizeISO15919(text)
+		checkError(err)
+		return romanized
+	}
+
+	assertEqual(t, romanize("മലയാളം"), "malayāḷaṁ")
+	assertEqual(t, romanize("കേരളം"), "kēraḷaṁ")
+	assertEqual(t, romanize("തിരുവനന്തപുരം"), "tiruvanantapuraṁ")
+	assertEqual(t, romanize("കോഴിക്കോട്"), "kōḻikkōṭ")
+
+	// Atomic chillu & chillu made with ZWJ
+	assertEqual(t, romanize("അവൻ"), "avan")
+	assertEqual(t, romanize("അവന്‍"), "avan")
+
+	// Vowels
+	assertEqual(t, romanize("ഐ"), "ai")
+	assertEqual(t, romanize("അഇ"), "a:i")
+	assertEqual(t, romanize("കഇ"), "ka:i")
+	assertEqual(t, romanize("കൈ"), "kai")
+	assertEqual(t, romanize("ഔ"), "au")
+	assertEqual(t, romanize("കൗ"), "kau")
+
+	// KA + HA is not KHA
+	assertEqual(t, romanize("ഖ"), "kha")
+	assertEqual(t, romanize("ക്ഹ"), "k:ha")
+
+	// Other characters are kept
+	assertEqual(t, romanize("൨൦൨൧ malayalam, മല!"), "2021 malayalam, mala!")
+}
+
+func TestHIRomanizeISO15919(t *testing.T) {
+	varnam := Varnam{}
+	varnam.SchemeDetails.LangCode = "hi"
+
+	romanized, err := varnam.RomanizeISO15919("हिन्दी।")
+	checkError(err)
+	assertEqual(t, romanized, "hindī.")
+
+	romanized, _ = varnam.RomanizeISO15919("क़लम")
+	assertEqual(t, romanized, "qalama")
+
+	romanized, _ = varnam.RomanizeISO15919("क़")
+	assertEqual(t, romanized, "qa")
+
+	varnam.SchemeDetails.LangCode = "xx"
+	_, err = varnam.RomanizeISO15919("क")
+	assertEqual(t, err != nil, true)
+}
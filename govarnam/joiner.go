@@ -0,0 +1,76 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import "strings"
+
+// Atomic letters and their older form made with ZWJ
+var legacyJoinerForms = map[string][][2]string{
+	"ml": {
+		{"ൻ", "ന്‍"},
+		{"ൺ", "ണ്‍"},
+		{"ൽ", "ല്‍"},
+		{"ൾ", "ള്‍"},
+		{"ർ", "ര്‍"},
+	},
+}
+
+// Replace old ZWJ forms with atomic letters
+func (varnam *Varnam) toAtomicForms(word string) string {
+	for _, forms := range legacyJoinerForms[varnam.SchemeDetails.LangCode] {
+		word = strings.Replace(word, forms[1], forms[0], -1)
+	}
+	return word
+}
+
+// Replace atomic letters with old ZWJ forms
+func (varnam *Varnam) toLegacyForms(word string) string {
+	for _, forms := range legacyJoinerForms[varnam.SchemeDetails.LangCode] {
+		word = strings.Replace(word, forms[0], forms[1], -1)
+	}
+	return word
+}
+
+// Change the joiners in word as per Varnam.JoinerPolicy
+func (varnam *Varnam) applyJoinerPolicy(word string) string {
+	switch varnam.JoinerPolicy {
+	case VARNAM_JOINER_POLICY_ATOMIC:
+		return varnam.toAtomicForms(word)
+	case VARNAM_JOINER_POLICY_LEGACY:
+		return varnam.toLegacyForms(varnam.toAtomicForms(word))
+	case VARNAM_JOINER_POLICY_STRIP:
+		word = varnam.toAtomicForms(word)
+		word = strings.Replace(word, ZWJ, "", -1)
+		return strings.Replace(word, ZWNJ, "", -1)
+	}
+	return word
+}
+
+func (varnam *Varnam) applyJoinerPolicyToSuggestions(sugs []Suggestion) []Suggestion {
+	if varnam.JoinerPolicy == VARNAM_JOINER_POLICY_KEEP {
+		return sugs
+	}
+	for i := range sugs {
+		sugs[i].Word = varnam.applyJoinerPolicy(sugs[i].Word)
+	}
+	return sugs
+}
+
+func (varnam *Varnam) applyJoinerPolicyToResult(result *TransliterationResult) {
+	for _, sugs := range [][]Suggestion{
+		result.ExactWords,
+		result.ExactMatches,
+		result.DictionarySuggestions,
+		result.PatternDictionarySuggestions,
+		result.TokenizerSuggestions,
+		result.GreedyTokenized,
+		result.FuzzySuggestions,
+		result.Corrections,
+	} {
+		varnam.applyJoinerPolicyToSuggestions(sugs)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 	sql "database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -18,8 +19,6 @@ import (
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/mattn/go-sqlite3"
 )
 
 // WordInfo represent a item in words table
@@ -45,16 +44,26 @@ type exportFormat struct {
 func (varnam *Varnam) languageSpecificSanitization(word string) string {
 	if varnam.SchemeDetails.LangCode == "ml" {
 		/* Malayalam has got two ways to write chil letters. Converting the old style to new atomic chil one */
-		word = strings.Replace(word, "ന്‍", "ൻ", -1)
-		word = strings.Replace(word, "ണ്‍", "ൺ", -1)
-		word = strings.Replace(word, "ല്‍", "ൽ", -1)
-		word = strings.Replace(word, "ള്‍", "ൾ", -1)
-		word = strings.Replace(word, "ര്‍", "ർ", -1)
+		word = varnam.toAtomicForms(word)
+	}
+
+	if varnam.SchemeDetails.LangCode == "sa" {
+		/* Sanskrit's DANDA & DOUBLE DANDA */
+		word = strings.Replace(word, "।", "", -1)
+		word = strings.Replace(word, "॥", "", -1)
 	}
 
 	if varnam.SchemeDetails.LangCode == "hi" {
 		/* Hindi's DANDA (Purna viram) */
 		word = strings.Replace(word, "।", "", -1)
+
+		/* Words copied from elsewhere may have precomposed nukta letters */
+		word = toDecomposedNuktaForms(word)
+	}
+
+	if varnam.IsRTL() {
+		/* Contextual letter forms in text copied from PDFs etc. */
+		word = toNominalArabicForms(word)
 	}
 
 	return word
@@ -65,6 +74,9 @@ func (varnam *Varnam) sanitizeWord(word string) string {
 	// Remove leading & trailing whitespaces
 	word = strings.TrimSpace(word)
 
+	// Suggestions of RTL languages may have these
+	word = removeDirectionMarks(word)
+
 	word = varnam.languageSpecificSanitization(word)
 
 	// Remove leading ZWJ & ZWNJ
@@ -82,69 +94,253 @@ func (varnam *Varnam) sanitizeWord(word string) string {
 	return word
 }
 
-// Learn a word. If already exist, increases weight
-func (varnam *Varnam) Learn(word string, weight int) error {
-	word = varnam.sanitizeWord(word)
-	conjuncts := varnam.splitWordByConjunct(word)
+// OnLearn register a callback that is called after a word
+// is learnt with Learn, LearnMany or Train. weight is the
+// word's weight after learning.
+// Callbacks are called synchronously, keep them fast.
+func (varnam *Varnam) OnLearn(cb func(word string, weight int)) {
+	varnam.hooksMutex.Lock()
+	defer varnam.hooksMutex.Unlock()
 
-	if len(conjuncts) == 0 {
-		return fmt.Errorf("Nothing to learn")
+	varnam.learnHooks = append(varnam.learnHooks, cb)
+}
+
+// OnTrain register a callback that is called after a pattern => word is trained
+func (varnam *Varnam) OnTrain(cb func(pattern string, word string)) {
+	varnam.hooksMutex.Lock()
+	defer varnam.hooksMutex.Unlock()
+
+	varnam.trainHooks = append(varnam.trainHooks, cb)
+}
+
+// OnUnlearn register a callback that is called after a word
+// or an english pattern is unlearnt
+func (varnam *Varnam) OnUnlearn(cb func(word string)) {
+	varnam.hooksMutex.Lock()
+	defer varnam.hooksMutex.Unlock()
+
+	varnam.unlearnHooks = append(varnam.unlearnHooks, cb)
+}
+
+func (varnam *Varnam) runLearnHooks(word string, weight int) {
+	varnam.hooksMutex.RLock()
+	hooks := varnam.learnHooks
+	varnam.hooksMutex.RUnlock()
+
+	for _, cb := range hooks {
+		cb(word, weight)
+	}
+}
+
+func (varnam *Varnam) runTrainHooks(pattern string, word string) {
+	varnam.hooksMutex.RLock()
+	hooks := varnam.trainHooks
+	varnam.hooksMutex.RUnlock()
+
+	for _, cb := range hooks {
+		cb(pattern, word)
 	}
+}
+
+func (varnam *Varnam) runUnlearnHooks(word string) {
+	varnam.hooksMutex.RLock()
+	hooks := varnam.unlearnHooks
+	varnam.hooksMutex.RUnlock()
 
-	if len(conjuncts) == 1 {
-		return fmt.Errorf("Can't learn a single conjunct")
+	for _, cb := range hooks {
+		cb(word)
 	}
+}
+
+// Learn a word. If already exist, increases weight
+func (varnam *Varnam) Learn(word string, weight int) error {
+	return varnam.LearnAt(word, weight, time.Now())
+}
 
-	// reconstruct word
-	word = strings.Join(conjuncts, "")
+// LearnAt same as Learn, but with the given learnt time instead of now.
+// For importing history and syncing. If the word already exists,
+// its learnt time is only changed if learnedOn is later
+func (varnam *Varnam) LearnAt(word string, weight int, learnedOn time.Time) error {
+	word, err := varnam.validateLearn(word)
+	if err != nil {
+		return err
+	}
 
 	if weight == 0 {
 		weight = VARNAM_LEARNT_WORD_MIN_WEIGHT - 1
 	}
 
-	query := "INSERT OR IGNORE INTO words(word, weight, learned_on) VALUES (trim(?), ?, strftime('%s', 'now'))"
+	storedWeight, err := varnam.persistLearning(word, weight, learnedOn)
+	if err != nil {
+		return err
+	}
+
+	err = varnam.logLearning([]string{word}, learningDay(learnedOn), 1, 0)
+	if err != nil {
+		return err
+	}
+
+	err = varnam.learnStems(word, weight, learnedOn)
+	if err != nil {
+		return err
+	}
+
+	varnam.runLearnHooks(word, storedWeight)
+
+	return nil
+}
+
+// Learn stems of a word as per the scheme's stem rules.
+// Stems that can't be learnt are skipped
+func (varnam *Varnam) learnStems(word string, weight int, learnedOn time.Time) error {
+	rules, err := varnam.getStemRules()
+	if err != nil {
+		return err
+	}
+
+	for _, stem := range rules.stems(word) {
+		stem, err := varnam.validateLearn(stem)
+		if err != nil {
+			continue
+		}
+
+		_, err = varnam.persistLearning(stem, weight, learnedOn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Insert word or increase its weight. Gives the weight stored
+func (varnam *Varnam) persistLearning(word string, weight int, learnedOn time.Time) (int, error) {
+	defer varnam.dictionaryChanged()
+
+	query := "INSERT OR IGNORE INTO words(word, weight, learned_on) VALUES (trim(?), ?, ?)"
 
 	bgContext := context.Background()
 
 	ctx, cancelFunc := context.WithTimeout(bgContext, 5*time.Second)
 	defer cancelFunc()
 
-	stmt, err := varnam.dictConn.PrepareContext(ctx, query)
+	stmt, err := varnam.dictWriteConn.PrepareContext(ctx, query)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, word, weight)
+	_, err = stmt.ExecContext(ctx, word, weight, learnedOn.Unix())
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	query = "UPDATE words SET weight = weight + 1, learned_on = strftime('%s', 'now') WHERE word = ?"
+	query = "UPDATE words SET weight = weight + 1, learned_on = MAX(IFNULL(learned_on, 0), ?) WHERE word = ?"
 	ctx, cancelFunc = context.WithTimeout(bgContext, 5*time.Second)
 	defer cancelFunc()
 
-	stmt, err = varnam.dictConn.PrepareContext(ctx, query)
+	stmt, err = varnam.dictWriteConn.PrepareContext(ctx, query)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer stmt.Close()
 
-	_, err = stmt.ExecContext(ctx, word)
+	_, err = stmt.ExecContext(ctx, learnedOn.Unix(), word)
+	if err != nil {
+		return 0, err
+	}
+
+	weights, err := varnam.getStoredWeights(bgContext, []string{word})
+	return weights[word], err
+}
+
+// Weights of words as in words DB. Read with the writer
+// connection so that a just made learning is seen
+func (varnam *Varnam) getStoredWeights(ctx context.Context, words []string) (map[string]int, error) {
+	weights := map[string]int{}
+
+	limitVariableNumber := getSQLiteLimit(sqliteLimitVariableNumber)
+
+	for len(words) > 0 {
+		lastIndex := int(math.Min(float64(limitVariableNumber), float64(len(words))))
+
+		var args []interface{}
+		for _, word := range words[0:lastIndex] {
+			args = append(args, word)
+		}
+
+		rows, err := varnam.dictWriteConn.QueryContext(
+			ctx,
+			"SELECT word, weight FROM words WHERE word IN (?"+strings.Repeat(", ?", lastIndex-1)+")",
+			args...,
+		)
+		if err != nil {
+			return weights, err
+		}
+
+		for rows.Next() {
+			var (
+				word   string
+				weight int
+			)
+			if err := rows.Scan(&word, &weight); err != nil {
+				rows.Close()
+				return weights, err
+			}
+			weights[word] = weight
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return weights, err
+		}
+
+		words = words[lastIndex:]
+	}
+
+	return weights, nil
+}
+
+// Touch mark a learnt word as used now without changing its weight
+func (varnam *Varnam) Touch(word string) error {
+	defer varnam.dictionaryChanged()
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFunc()
+
+	result, err := varnam.dictWriteConn.ExecContext(
+		ctx,
+		"UPDATE words SET learned_on = strftime('%s', 'now') WHERE word = ?",
+		varnam.learntForm(word),
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affected == 0 {
+		return fmt.Errorf("Word doesn't exist")
+	}
+
 	return nil
 }
 
+// ErrNothingToUnlearn Unlearn is given a pattern that's not learnt
+var ErrNothingToUnlearn = errors.New("nothing to unlearn")
+
 // Unlearn a word, remove from words DB and pattern if there is
 func (varnam *Varnam) Unlearn(word string) error {
+	defer varnam.dictionaryChanged()
+
 	conjuncts := varnam.splitWordByConjunct(strings.TrimSpace(word))
 
 	if len(conjuncts) == 0 {
 		// Word must be english ? See if that's the case
-		stmt, err := varnam.dictConn.Prepare("DELETE FROM patterns WHERE pattern = ?")
+		stmt, err := varnam.dictWriteConn.Prepare("DELETE FROM patterns WHERE pattern = ?")
 		if err != nil {
 			return err
 		}
@@ -161,37 +357,49 @@ func (varnam *Varnam) Unlearn(word string) error {
 		}
 
 		if affected == 0 {
-			return fmt.Errorf("nothing to unlearn")
+			return ErrNothingToUnlearn
 		}
-		return nil
-	}
-
-	varnam.dictConn.Exec("PRAGMA foreign_keys = ON")
 
-	query := "DELETE FROM words WHERE word = ?"
-	stmt, err := varnam.dictConn.Prepare(query)
-	if err != nil {
-		return err
+		varnam.runUnlearnHooks(word)
+		return nil
 	}
-	defer stmt.Close()
 
-	_, err = stmt.Exec(word)
+	err := varnam.deleteWord(word)
 	if err != nil {
 		return err
 	}
 
-	// No need to remove from `patterns` since FOREIGN KEY ON DELETE CASCADE will work
-
 	if varnam.Debug {
 		fmt.Printf("Removed %s\n", word)
 	}
 
-	varnam.dictConn.Exec("PRAGMA foreign_keys = OFF")
+	varnam.runUnlearnHooks(word)
 	return nil
 }
 
+// Delete word with its patterns
+func (varnam *Varnam) deleteWord(word string) error {
+	// foreign_keys is of a connection. Other writes wait till
+	// it's turned off since there's only one
+	ctx := context.Background()
+	conn, err := varnam.dictWriteConn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+	defer conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF")
+
+	// No need to remove from `patterns` since FOREIGN KEY ON DELETE CASCADE will work
+	_, err = conn.ExecContext(ctx, "DELETE FROM words WHERE word = ?", word)
+	return err
+}
+
 // LearnMany words in bulk. Faster learning
 func (varnam *Varnam) LearnMany(words []WordInfo) (LearnStatus, error) {
+	defer varnam.dictionaryChanged()
+
 	var (
 		insertionValues []string
 		insertionArgs   []interface{}
@@ -199,14 +407,41 @@ func (varnam *Varnam) LearnMany(words []WordInfo) (LearnStatus, error) {
 		updationValues []string
 		updationArgs   []interface{}
 
+		// For hooks
+		learntWords []string
+
 		learnStatus LearnStatus = LearnStatus{len(words), 0}
 	)
 
-	for _, wordInfo := range words {
-		word := varnam.sanitizeWord(wordInfo.word)
+	var sanitizedWords []string
+	for i := range words {
+		sanitizedWords = append(sanitizedWords, varnam.sanitizeWord(words[i].word))
+	}
+
+	rules, err := varnam.getStemRules()
+	if err != nil {
+		return learnStatus, err
+	}
+
+	// Stems are learnt along, but not counted in status
+	var stems []WordInfo
+
+	blacklisted, err := varnam.getBlacklisted(context.Background(), sanitizedWords)
+	if err != nil {
+		return learnStatus, err
+	}
+
+	for i, wordInfo := range words {
+		word := sanitizedWords[i]
 		weight := wordInfo.weight
 		conjuncts := varnam.splitWordByConjunct(word)
 
+		if blacklisted[word] {
+			log.Printf("Not learning blacklisted word: %s", word)
+			learnStatus.FailedWords++
+			continue
+		}
+
 		if len(conjuncts) == 0 {
 			log.Printf("Nothing to learn from %s", word)
 			learnStatus.FailedWords++
@@ -232,8 +467,39 @@ func (varnam *Varnam) LearnMany(words []WordInfo) (LearnStatus, error) {
 		insertionValues = append(insertionValues, "(trim(?), ?, strftime('%s', 'now'))")
 		insertionArgs = append(insertionArgs, word, weight)
 
+		learntWords = append(learntWords, word)
+
 		updationValues = append(updationValues, "word = ?")
 		updationArgs = append(updationArgs, word)
+
+		for _, stem := range rules.stems(word) {
+			stems = append(stems, WordInfo{0, stem, weight, 0})
+		}
+	}
+
+	if len(stems) > 0 {
+		var stemWords []string
+		for _, stem := range stems {
+			stemWords = append(stemWords, stem.word)
+		}
+
+		blacklistedStems, err := varnam.getBlacklisted(context.Background(), stemWords)
+		if err != nil {
+			return learnStatus, err
+		}
+
+		for _, stem := range stems {
+			conjuncts := varnam.splitWordByConjunct(stem.word)
+			if blacklistedStems[stem.word] || len(conjuncts) < 2 {
+				continue
+			}
+
+			insertionValues = append(insertionValues, "(trim(?), ?, strftime('%s', 'now'))")
+			insertionArgs = append(insertionArgs, strings.Join(conjuncts, ""), stem.weight)
+
+			updationValues = append(updationValues, "word = ?")
+			updationArgs = append(updationArgs, strings.Join(conjuncts, ""))
+		}
 	}
 
 	if len(insertionArgs) == 0 {
@@ -245,7 +511,7 @@ func (varnam *Varnam) LearnMany(words []WordInfo) (LearnStatus, error) {
 		strings.Join(insertionValues, ", "),
 	)
 
-	stmt, err := varnam.dictConn.Prepare(query)
+	stmt, err := varnam.dictWriteConn.Prepare(query)
 	if err != nil {
 		return learnStatus, err
 	}
@@ -257,14 +523,14 @@ func (varnam *Varnam) LearnMany(words []WordInfo) (LearnStatus, error) {
 
 	// There is a limit on number of OR that can be done
 	// Reference: https://stackoverflow.com/questions/9570197/sqlite-expression-maximum-depth-limit
-	depthLimit := sqlite3Conn.GetLimit(sqlite3.SQLITE_LIMIT_EXPR_DEPTH) - 1
+	depthLimit := getSQLiteLimit(sqliteLimitExprDepth) - 1
 
 	for len(updationValues) > 0 {
 		lastIndex := int(math.Min(float64(depthLimit), float64(len(updationValues))))
 
 		query = "UPDATE words SET weight = weight + 1, learned_on = strftime('%s', 'now') WHERE " + strings.Join(updationValues[0:lastIndex], " OR ")
 
-		stmt, err = varnam.dictConn.Prepare(query)
+		stmt, err = varnam.dictWriteConn.Prepare(query)
 		if err != nil {
 			return learnStatus, err
 		}
@@ -279,11 +545,28 @@ func (varnam *Varnam) LearnMany(words []WordInfo) (LearnStatus, error) {
 		updationArgs = updationArgs[lastIndex:]
 	}
 
+	err = varnam.logLearning(learntWords, learningDay(time.Now()), 1, 0)
+	if err != nil {
+		return learnStatus, err
+	}
+
+	// Words that already existed got weight + 1, not the given one
+	storedWeights, err := varnam.getStoredWeights(context.Background(), learntWords)
+	if err != nil {
+		return learnStatus, err
+	}
+
+	for _, word := range learntWords {
+		varnam.runLearnHooks(word, storedWeights[word])
+	}
+
 	return learnStatus, nil
 }
 
 // Train a word with a particular pattern. Pattern => word
 func (varnam *Varnam) Train(pattern string, word string) error {
+	defer varnam.dictionaryChanged()
+
 	word = varnam.sanitizeWord(word)
 
 	err := varnam.Learn(word, 0)
@@ -301,7 +584,7 @@ func (varnam *Varnam) Train(pattern string, word string) error {
 	defer cancelFunc()
 
 	query := "INSERT OR IGNORE INTO patterns(pattern, word_id) VALUES (?, ?)"
-	stmt, err := varnam.dictConn.PrepareContext(ctx, query)
+	stmt, err := varnam.dictWriteConn.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -312,6 +595,13 @@ func (varnam *Varnam) Train(pattern string, word string) error {
 		return err
 	}
 
+	err = varnam.logLearning([]string{varnam.learntForm(word)}, learningDay(time.Now()), 0, 1)
+	if err != nil {
+		return err
+	}
+
+	varnam.runTrainHooks(pattern, word)
+
 	return nil
 }
 
@@ -338,6 +628,43 @@ func (varnam *Varnam) getWordInfo(word string) (*WordInfo, error) {
 	return nil, fmt.Errorf("Word doesn't exist")
 }
 
+// Insert words as they are. Existing words are left untouched
+func (varnam *Varnam) insertWords(words []WordInfo) error {
+	defer varnam.dictionaryChanged()
+
+	limitVariableNumber := getSQLiteLimit(sqliteLimitVariableNumber)
+
+	insertsPerTransaction := int(float64(limitVariableNumber) / 3) // We have 3 fields per item
+
+	for len(words) > 0 {
+		lastIndex := int(math.Min(float64(insertsPerTransaction), float64(len(words))))
+
+		var (
+			args   []interface{}
+			values []string
+		)
+
+		for _, item := range words[0:lastIndex] {
+			values = append(values, "(trim(?), ?, ?)")
+			args = append(args, item.word, item.weight, item.learnedOn)
+		}
+
+		query := fmt.Sprintf(
+			"INSERT OR IGNORE INTO words(word, weight, learned_on) VALUES %s",
+			strings.Join(values, ", "),
+		)
+
+		_, err := varnam.dictWriteConn.Exec(query, args...)
+		if err != nil {
+			return err
+		}
+
+		words = words[lastIndex:]
+	}
+
+	return nil
+}
+
 // LearnFromFile Learn all words in a file
 func (varnam *Varnam) LearnFromFile(filePath string) (LearnStatus, error) {
 	learnStatus := LearnStatus{0, 0}
@@ -348,7 +675,7 @@ func (varnam *Varnam) LearnFromFile(filePath string) (LearnStatus, error) {
 	}
 	defer file.Close()
 
-	limitVariableNumber := sqlite3Conn.GetLimit(sqlite3.SQLITE_LIMIT_VARIABLE_NUMBER)
+	limitVariableNumber := getSQLiteLimit(sqliteLimitVariableNumber)
 	log.Printf("default SQLITE_LIMIT_VARIABLE_NUMBER: %d", limitVariableNumber)
 
 	// We have 2 fields per item, word and weight
@@ -554,47 +881,129 @@ func rowsToJSON(rows *sql.Rows) ([]map[string]interface{}, error) {
 	return tableData, nil
 }
 
+// ExportOptions filters & progress reporting of ExportWithOptions
+type ExportOptions struct {
+	// Words per export file. Files are named filePath-1.vlf, filePath-2.vlf...
+	WordsPerFile int
+
+	// Only words scoring this much (see Suggestion.Score).
+	// 0.5 and below gives all words
+	MinConfidence float64
+
+	// Only words learnt after this
+	LearnedAfter time.Time
+
+	// Only words in this domain
+	Domain string
+
+	// Called after each file is written with words exported so far
+	Progress func(done int, total int)
+}
+
+// ImportOptions filters & progress reporting of ImportWithOptions
+type ImportOptions struct {
+	// Only words scoring this much (see Suggestion.Score)
+	MinConfidence float64
+
+	// Only words learnt after this
+	LearnedAfter time.Time
+
+	// Put imported words in this domain
+	Domain string
+
+	// Called after each batch of words & patterns inserted.
+	// When nil, progress is printed to stdout
+	Progress func(done int, total int)
+}
+
+// Least weight a learnt word should have to score minScore.
+// Inverse of dictionaryScore
+func minWeightForScore(minScore float64) int {
+	if minScore >= 1 {
+		return math.MaxInt32
+	}
+
+	// score = 0.5 + 0.5 * t / (t + halfLife), t is times learnt
+	ratio := 2*minScore - 1
+	// Small amount subtracted so that float errors don't round up
+	timesLearnt := math.Ceil(dictionaryScoreHalfLife*ratio/(1-ratio) - 1e-9)
+
+	return int(timesLearnt) + VARNAM_LEARNT_WORD_MIN_WEIGHT - 1
+}
+
 // Export learnings as JSON to a file
 func (varnam *Varnam) Export(filePath string, wordsPerFile int) error {
+	return varnam.ExportWithOptions(filePath, ExportOptions{WordsPerFile: wordsPerFile})
+}
+
+// ExportWithOptions export learnings as JSON to files, only the words
+// matching filters in opts. Patterns of the words are exported too
+func (varnam *Varnam) ExportWithOptions(filePath string, opts ExportOptions) error {
 	if fileExists(filePath) {
 		return fmt.Errorf("Output file already exists")
 	}
 
-	patternsCount := -1
-	wordsCount := -1
+	if opts.WordsPerFile <= 0 {
+		return fmt.Errorf("Words per file should be more than 0")
+	}
 
-	countRows, err := varnam.dictConn.Query("SELECT COUNT(*) AS patternsCount FROM patterns UNION SELECT COUNT(*) AS wordsCount FROM words")
-	if err != nil {
-		return err
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if opts.MinConfidence > 0.5 {
+		conditions = append(conditions, "learned_on > 0 AND weight >= ?")
+		args = append(args, minWeightForScore(opts.MinConfidence))
+	}
+	if !opts.LearnedAfter.IsZero() {
+		conditions = append(conditions, "learned_on > ?")
+		args = append(args, opts.LearnedAfter.Unix())
+	}
+	if opts.Domain != "" {
+		conditions = append(conditions, "id IN (SELECT word_id FROM word_domains WHERE domain = ?)")
+		args = append(args, opts.Domain)
 	}
-	defer countRows.Close()
 
-	for countRows.Next() {
-		if patternsCount == -1 {
-			countRows.Scan(&patternsCount)
-		} else {
-			countRows.Scan(&wordsCount)
-		}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	patternsCount := 0
+	wordsCount := 0
+
+	err := varnam.dictConn.QueryRow(
+		"SELECT COUNT(*), (SELECT COUNT(*) FROM patterns WHERE word_id IN (SELECT id FROM words "+where+")) FROM words "+where,
+		append(append([]interface{}{}, args...), args...)...,
+	).Scan(&wordsCount, &patternsCount)
+	if err != nil {
+		return err
 	}
 
-	totalPages := int(math.Ceil(float64(wordsCount) / float64(wordsPerFile)))
+	totalPages := int(math.Ceil(float64(wordsCount) / float64(opts.WordsPerFile)))
 
 	if varnam.Debug {
 		log.Printf("Words: %d. Patterns: %d", wordsCount, patternsCount)
 		log.Printf("Pages: %d", totalPages)
 	}
 
+	exported := 0
+
 	page := 1
 	for page <= totalPages {
-		wordsTableQuery := fmt.Sprintf("SELECT word AS w, weight AS c, learned_on AS l FROM words ORDER BY c DESC LIMIT %d OFFSET %d", wordsPerFile, (page-1)*wordsPerFile)
+		wordsTableQuery := fmt.Sprintf("SELECT word AS w, weight AS c, learned_on AS l FROM words %s ORDER BY c DESC LIMIT %d OFFSET %d", where, opts.WordsPerFile, (page-1)*opts.WordsPerFile)
 
-		wordsRows, err := varnam.dictConn.Query(wordsTableQuery)
+		wordsRows, err := varnam.dictConn.Query(wordsTableQuery, args...)
 		if err != nil {
 			return err
 		}
 		defer wordsRows.Close()
 
 		wordsData, err := rowsToJSON(wordsRows)
+		if err != nil {
+			return err
+		}
 
 		patternsRows, err := varnam.dictConn.Query(
 			`
@@ -605,11 +1014,12 @@ func (varnam *Varnam) Export(filePath string, wordsPerFile int) error {
 			WHERE patterns.word_id IN (
 				SELECT id FROM words WHERE word IN (
 					SELECT w FROM (
-						` + wordsTableQuery + `
+						`+wordsTableQuery+`
 					)
 				)
 			)
 			`,
+			args...,
 		)
 		if err != nil {
 			return err
@@ -617,10 +1027,16 @@ func (varnam *Varnam) Export(filePath string, wordsPerFile int) error {
 		defer patternsRows.Close()
 
 		patternsData, err := rowsToJSON(patternsRows)
+		if err != nil {
+			return err
+		}
 
 		output := exportFormat{wordsData, patternsData}
 
 		jsonData, err := json.Marshal(output)
+		if err != nil {
+			return err
+		}
 
 		filePathWithPageNumber := filePath + "-" + fmt.Sprint(page) + ".vlf"
 		err = os.WriteFile(filePathWithPageNumber, jsonData, 0644)
@@ -628,14 +1044,41 @@ func (varnam *Varnam) Export(filePath string, wordsPerFile int) error {
 			return err
 		}
 
+		exported += len(wordsData)
+		if opts.Progress != nil {
+			opts.Progress(exported, wordsCount)
+		}
+
 		page++
 	}
 
 	return nil
 }
 
+// Whether an exported word passes filters of opts
+func (opts ImportOptions) matches(item map[string]interface{}) bool {
+	weight, _ := item["c"].(float64)
+	learnedOn, _ := item["l"].(float64)
+
+	if opts.MinConfidence > 0.5 && (learnedOn == 0 || int(weight) < minWeightForScore(opts.MinConfidence)) {
+		return false
+	}
+	if !opts.LearnedAfter.IsZero() && int64(learnedOn) <= opts.LearnedAfter.Unix() {
+		return false
+	}
+	return true
+}
+
 // Import learnings from file
 func (varnam *Varnam) Import(filePath string) error {
+	return varnam.ImportWithOptions(filePath, ImportOptions{})
+}
+
+// ImportWithOptions import learnings from a file made by Export,
+// only the words matching filters in opts
+func (varnam *Varnam) ImportWithOptions(filePath string, opts ImportOptions) error {
+	defer varnam.dictionaryChanged()
+
 	if !fileExists(filePath) {
 		return fmt.Errorf("Import file not found")
 	}
@@ -649,12 +1092,35 @@ func (varnam *Varnam) Import(filePath string) error {
 		return fmt.Errorf("Parsing JSON failed, err: %s", err.Error())
 	}
 
-	limitVariableNumber := sqlite3Conn.GetLimit(sqlite3.SQLITE_LIMIT_VARIABLE_NUMBER)
+	// Filter words and keep patterns of only those words
+	var (
+		wordsDict    []map[string]interface{}
+		patternsDict []map[string]interface{}
+		importWords  = map[interface{}]bool{}
+	)
+	for _, item := range dbData.WordsDict {
+		if opts.matches(item) {
+			wordsDict = append(wordsDict, item)
+			importWords[item["w"]] = true
+		}
+	}
+	for _, item := range dbData.PatternsDict {
+		if importWords[item["w"]] {
+			patternsDict = append(patternsDict, item)
+		}
+	}
+
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(done int, total int) {}
+	}
+
+	limitVariableNumber := getSQLiteLimit(sqliteLimitVariableNumber)
 	log.Printf("default SQLITE_LIMIT_VARIABLE_NUMBER: %d", limitVariableNumber)
 
 	insertsPerTransaction := int(math.Min(
 		float64(limitVariableNumber)/4, // We have 4 fields per item
-		float64(len(dbData.WordsDict)),
+		float64(len(wordsDict)),
 	))
 
 	var (
@@ -664,18 +1130,18 @@ func (varnam *Varnam) Import(filePath string) error {
 
 	insertions := 0
 	count := 0
-	for i, item := range dbData.WordsDict {
+	for i, item := range wordsDict {
 		values = append(values, "(trim(?), ?, ?)")
 		args = append(args, item["w"], item["c"], item["l"])
 
 		count++
-		if count == insertsPerTransaction || i == len(dbData.WordsDict)-1 {
+		if count == insertsPerTransaction || i == len(wordsDict)-1 {
 			query := fmt.Sprintf(
 				"INSERT OR IGNORE INTO words(word, weight, learned_on) VALUES %s",
 				strings.Join(values, ", "),
 			)
 
-			stmt, err := varnam.dictConn.Prepare(query)
+			stmt, err := varnam.dictWriteConn.Prepare(query)
 			if err != nil {
 				return err
 			}
@@ -691,7 +1157,19 @@ func (varnam *Varnam) Import(filePath string) error {
 			insertions += count
 			count = 0
 
-			fmt.Printf("Inserted %d words\n", insertions)
+			if opts.Progress == nil {
+				fmt.Printf("Inserted %d words\n", insertions)
+			}
+			progress(insertions, len(wordsDict)+len(patternsDict))
+		}
+	}
+
+	if opts.Domain != "" {
+		for _, item := range wordsDict {
+			word, _ := item["w"].(string)
+			if err := varnam.addWordToDomain(strings.TrimSpace(word), opts.Domain); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -700,23 +1178,24 @@ func (varnam *Varnam) Import(filePath string) error {
 
 	insertsPerTransaction = int(math.Min(
 		float64(limitVariableNumber)/2, // We have 2 fields per item
-		float64(len(dbData.PatternsDict)),
+		float64(len(patternsDict)),
 	))
 
+	wordInsertions := insertions
 	insertions = 0
 	count = 0
-	for i, item := range dbData.PatternsDict {
+	for i, item := range patternsDict {
 		values = append(values, "(?, (SELECT id FROM words WHERE word = ?))")
 		args = append(args, item["p"], item["w"])
 
 		count++
-		if count == insertsPerTransaction || i == len(dbData.WordsDict)-1 {
+		if count == insertsPerTransaction || i == len(patternsDict)-1 {
 			query := fmt.Sprintf(
 				"INSERT OR IGNORE INTO patterns(pattern, word_id) VALUES %s",
 				strings.Join(values, ", "),
 			)
 
-			stmt, err := varnam.dictConn.Prepare(query)
+			stmt, err := varnam.dictWriteConn.Prepare(query)
 			if err != nil {
 				return err
 			}
@@ -732,7 +1211,10 @@ func (varnam *Varnam) Import(filePath string) error {
 			insertions += count
 			count = 0
 
-			fmt.Printf("Inserted %d patterns\n", insertions)
+			if opts.Progress == nil {
+				fmt.Printf("Inserted %d patterns\n", insertions)
+			}
+			progress(wordInsertions+insertions, len(wordsDict)+len(patternsDict))
 		}
 	}
 
@@ -0,0 +1,146 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	sql "database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// libvarnam learnings DB has these tables :
+//   words (id, word, confidence, learned_on)
+//   patterns_content (pattern, word_id, learned)
+// learned_on is a date string in older versions.
+// patterns_content stores every pattern that made a word. Only the
+// explicitly trained ones (learned = 1) are imported since govarnam's
+// patterns table is only for words that can't be tokenized.
+
+// ImportFromLibvarnam import words and trained patterns from a
+// learnings DB made by libvarnam (varnamc)
+func (varnam *Varnam) ImportFromLibvarnam(dbPath string) (LearnStatus, error) {
+	defer varnam.dictionaryChanged()
+
+	learnStatus := LearnStatus{0, 0}
+
+	if !fileExists(dbPath) {
+		return learnStatus, fmt.Errorf("libvarnam learnings file not found")
+	}
+
+	// Read only, libvarnam's file is left untouched
+	oldConn, err := sql.Open("sqlite3", "file:"+dbPath+"?mode=ro")
+	if err != nil {
+		return learnStatus, err
+	}
+	defer oldConn.Close()
+
+	rows, err := oldConn.Query(`
+		SELECT
+			word,
+			IFNULL(confidence, 1),
+			CASE
+				WHEN typeof(learned_on) = 'integer' THEN learned_on
+				ELSE IFNULL(CAST(strftime('%s', learned_on) AS INTEGER), 0)
+			END
+		FROM words
+	`)
+	if err != nil {
+		return learnStatus, fmt.Errorf("not a libvarnam learnings file: %s", err.Error())
+	}
+
+	// Imported words go through Learn, so they're validated,
+	// blacklist is checked & learn hooks are called
+	for rows.Next() {
+		var (
+			word      string
+			weight    int
+			learnedOn int64
+		)
+		err := rows.Scan(&word, &weight, &learnedOn)
+		if err != nil {
+			rows.Close()
+			return learnStatus, err
+		}
+
+		learnStatus.TotalWords++
+
+		// libvarnam's confidence starts from 1. LearnAt adds 1 more
+		err = varnam.LearnAt(word, weight+VARNAM_LEARNT_WORD_MIN_WEIGHT-2, time.Unix(learnedOn, 0))
+		if _, ok := err.(*ValidationError); ok {
+			if varnam.Debug {
+				log.Print(err)
+			}
+			learnStatus.FailedWords++
+			continue
+		}
+		if err != nil {
+			rows.Close()
+			return learnStatus, err
+		}
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return learnStatus, err
+	}
+
+	if varnam.Debug {
+		log.Printf("Imported %d words from libvarnam", learnStatus.TotalWords-learnStatus.FailedWords)
+	}
+
+	patternsQuery := "SELECT p.pattern, w.word FROM patterns_content p INNER JOIN words w ON w.id = p.word_id"
+
+	var hasLearnedColumn bool
+	oldConn.QueryRow("SELECT COUNT(*) > 0 FROM pragma_table_info('patterns_content') WHERE name = 'learned'").Scan(&hasLearnedColumn)
+
+	if hasLearnedColumn {
+		patternsQuery += " WHERE p.learned = 1"
+	}
+
+	patternRows, err := oldConn.Query(patternsQuery)
+	if err != nil {
+		// Very old files may not have patterns
+		log.Print(err)
+		return learnStatus, nil
+	}
+	defer patternRows.Close()
+
+	stmt, err := varnam.dictWriteConn.Prepare("INSERT OR IGNORE INTO patterns(pattern, word_id) SELECT ?, id FROM words WHERE word = ?")
+	if err != nil {
+		return learnStatus, err
+	}
+	defer stmt.Close()
+
+	for patternRows.Next() {
+		var pattern, word string
+		err = patternRows.Scan(&pattern, &word)
+		if err != nil {
+			return learnStatus, err
+		}
+
+		// Words that couldn't be learnt are left out with their patterns
+		word, err = varnam.validateLearn(word)
+		if _, ok := err.(*ValidationError); ok {
+			continue
+		}
+		if err != nil {
+			return learnStatus, err
+		}
+
+		result, err := stmt.Exec(pattern, word)
+		if err != nil {
+			return learnStatus, err
+		}
+
+		if inserted, _ := result.RowsAffected(); inserted > 0 {
+			varnam.runTrainHooks(pattern, word)
+		}
+	}
+
+	return learnStatus, patternRows.Err()
+}
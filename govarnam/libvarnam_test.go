@@ -0,0 +1,83 @@
+package govarnam
+
+import (
+	"database/sql"
+	"path"
+	"testing"
+)
+
+func TestMLImportFromLibvarnam(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	var (
+		learnt  []string
+		trained []string
+	)
+	varnam.OnLearn(func(word string, weight int) {
+		learnt = append(learnt, word)
+	})
+	varnam.OnTrain(func(pattern string, word string) {
+		trained = append(trained, pattern+" "+word)
+	})
+	defer func() {
+		varnam.learnHooks = nil
+		varnam.trainHooks = nil
+	}()
+
+	checkError(varnam.Blacklist("കോട്ടയം"))
+	defer varnam.RemoveFromBlacklist("കോട്ടയം")
+
+	dbPath := path.Join(testTempDir, "libvarnam.learnings")
+
+	db, err := sql.Open("sqlite3", dbPath)
+	checkError(err)
+
+	_, err = db.Exec(`
+		CREATE TABLE metadata (key TEXT UNIQUE, value TEXT);
+		CREATE TABLE words (id INTEGER PRIMARY KEY, word TEXT UNIQUE, confidence INTEGER DEFAULT 1, learned_on DATE);
+		CREATE TABLE patterns_content (pattern TEXT, word_id INTEGER, learned INTEGER DEFAULT 0, PRIMARY KEY(pattern, word_id));
+		INSERT INTO words VALUES (1, 'മലപ്പുറം', 5, '2015-06-01');
+		INSERT INTO words VALUES (2, 'കണ്ണൂര്‍', 1, '2016-01-20');
+		INSERT INTO words VALUES (3, 'കോട്ടയം', 2, '2016-01-20');
+		INSERT INTO words VALUES (4, 'ക', 1, '2016-01-20');
+		INSERT INTO patterns_content VALUES ('malappuram', 1, 1);
+		INSERT INTO patterns_content VALUES ('malapuram', 1, 0);
+		INSERT INTO patterns_content VALUES ('kottayam', 3, 1);
+	`)
+	checkError(err)
+	db.Close()
+
+	learnStatus, err := varnam.ImportFromLibvarnam(dbPath)
+	checkError(err)
+	assertEqual(t, learnStatus.TotalWords, 4)
+
+	// Blacklisted & single conjunct words aren't learnt
+	assertEqual(t, learnStatus.FailedWords, 2)
+	_, err = varnam.getWordInfo("കോട്ടയം")
+	assertEqual(t, err != nil, true)
+
+	// Learn hooks are called, stems aside
+	assertEqual(t, len(learnt), 2)
+	assertEqual(t, len(trained), 1)
+	assertEqual(t, trained[0], "malappuram മലപ്പുറം")
+
+	wordInfo, err := varnam.getWordInfo("മലപ്പുറം")
+	checkError(err)
+	assertEqual(t, wordInfo.weight, VARNAM_LEARNT_WORD_MIN_WEIGHT+4)
+	assertEqual(t, wordInfo.learnedOn, 1433116800)
+
+	// ZWJ chillu is converted to atomic chillu
+	_, err = varnam.getWordInfo("കണ്ണൂർ")
+	checkError(err)
+
+	// Only explicitly trained patterns are imported
+	var count int
+	varnam.dictConn.QueryRow("SELECT COUNT(*) FROM patterns WHERE word_id = ?", wordInfo.id).Scan(&count)
+	assertEqual(t, count, 1)
+
+	_, err = varnam.ImportFromLibvarnam(path.Join(testTempDir, "non-existent"))
+	assertEqual(t, err != nil, true)
+
+	checkError(varnam.Unlearn("മലപ്പുറം"))
+	checkError(varnam.Unlearn("കണ്ണൂർ"))
+}
@@ -0,0 +1,185 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// Markdown syntax characters end words too, so that **word** is
+// transliterated as word
+const markdownPunctuation = sentencePunctuation + "*_#>~|"
+
+// Start of a code fence, ``` or ~~~ indented up to 3 spaces
+var markdownFenceRegex = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+
+// Link reference definition, [label]: target "title"
+var markdownLinkDefinitionRegex = regexp.MustCompile(`^ {0,3}\[[^\]]+\]:\s`)
+
+// Bare URLs & email addresses in prose
+var markdownURLRegex = regexp.MustCompile(`^(?i)(https?|ftp|mailto):[^\s<>()]+`)
+
+// Index of the end of the inline code span starting at text[start]
+// with a run of backticks. -1 if it isn't closed
+func markdownCodeSpanEnd(text string, start int) int {
+	ticks := start
+	for ticks < len(text) && text[ticks] == '`' {
+		ticks++
+	}
+	fence := text[start:ticks]
+
+	for i := ticks; i < len(text); {
+		end := strings.Index(text[i:], fence)
+		if end == -1 {
+			return -1
+		}
+		end += i
+
+		// Closing run should be of the same length
+		after := end + len(fence)
+		if after < len(text) && text[after] == '`' {
+			for after < len(text) && text[after] == '`' {
+				after++
+			}
+			i = after
+			continue
+		}
+		return after
+	}
+	return -1
+}
+
+// Index of the ')' ending a link target starting at text[start]
+// (the '('). Balanced parentheses in URLs are kept in
+func markdownLinkTargetEnd(text string, start int) int {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		case '\n':
+			return -1
+		}
+	}
+	return -1
+}
+
+// Split a line of prose into words & the rest. Inline code,
+// link targets, URLs and HTML tags aren't words
+func splitMarkdownInline(text string) []sentencePart {
+	var parts []sentencePart
+
+	proseStart := 0
+	i := 0
+
+	markup := func(end int) {
+		parts = append(parts, splitWords(text[proseStart:i], markdownPunctuation)...)
+		parts = append(parts, sentencePart{text[i:end], false})
+		i = end
+		proseStart = end
+	}
+
+	for i < len(text) {
+		char := text[i]
+
+		switch {
+		case char == '\\' && i+1 < len(text):
+			// Escaped character
+			markup(i + 2)
+			continue
+		case char == '`':
+			if end := markdownCodeSpanEnd(text, i); end != -1 {
+				markup(end)
+				continue
+			}
+		case char == ']' && i+1 < len(text) && text[i+1] == '(':
+			// ](target "title")
+			if end := markdownLinkTargetEnd(text, i+1); end != -1 {
+				markup(end)
+				continue
+			}
+		case char == ']' && i+1 < len(text) && text[i+1] == '[':
+			// ][reference label]
+			if end := strings.IndexByte(text[i+2:], ']'); end != -1 {
+				markup(i + 2 + end + 1)
+				continue
+			}
+		case char == '<' && i+1 < len(text) && (isASCIILetter(text[i+1]) || text[i+1] == '/' || text[i+1] == '!'):
+			// Autolinks (<https://...>) & HTML tags
+			markup(htmlTagEnd(text, i))
+			continue
+		case isASCIILetter(char) && (i == 0 || !isASCIILetter(text[i-1])):
+			if url := markdownURLRegex.FindString(text[i:]); url != "" {
+				markup(i + len(strings.TrimRight(url, ".,;:!?")))
+				continue
+			}
+		}
+		i++
+	}
+
+	return append(parts, splitWords(text[proseStart:], markdownPunctuation)...)
+}
+
+func isASCIILetter(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+// Split Markdown into words of prose & the rest : fenced code blocks,
+// inline code, link targets, link reference definitions, URLs and
+// HTML tags. Joining the parts gives back the Markdown
+func splitMarkdown(markdown string) []sentencePart {
+	var parts []sentencePart
+
+	fence := ""
+
+	for _, line := range strings.SplitAfter(markdown, "\n") {
+		if line == "" {
+			continue
+		}
+
+		if fence != "" {
+			// Inside a fenced code block, till a fence as long
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			parts = append(parts, sentencePart{line, false})
+			continue
+		}
+
+		if match := markdownFenceRegex.FindStringSubmatch(line); match != nil {
+			fence = match[1]
+			parts = append(parts, sentencePart{line, false})
+			continue
+		}
+
+		if markdownLinkDefinitionRegex.MatchString(line) {
+			parts = append(parts, sentencePart{line, false})
+			continue
+		}
+
+		parts = append(parts, splitMarkdownInline(line)...)
+	}
+
+	return parts
+}
+
+// TransliterateMarkdown transliterate prose in a Markdown document
+// like TransliterateDocument. Code blocks, inline code, URLs, link
+// targets and HTML tags are kept as is.
+func (varnam *Varnam) TransliterateMarkdown(ctx context.Context, markdown string) (string, []ConvertedWord, error) {
+	return varnam.convertParts(ctx, splitMarkdown(markdown))
+}
@@ -0,0 +1,45 @@
+package govarnam
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSplitMarkdown(t *testing.T) {
+	markdown := "# pani **nanni**\n\n" +
+		"Use `pani nanni` and ``a ` b`` [ente *link*](https://example.com/a_(b) \"title\") [ref][ente-ref].\n" +
+		"See https://varnamproject.com, <https://a.b> <b>vazhi</b> \\*x\n" +
+		"```go\nvar pani = 1\n```\n" +
+		"~~~~\n```\nkodu\n~~~~\n" +
+		"[ente-ref]: https://example.com \"pani\"\n" +
+		"  - avasanam"
+
+	var words []string
+	var joined strings.Builder
+
+	for _, part := range splitMarkdown(markdown) {
+		if part.isWord {
+			words = append(words, part.text)
+		}
+		joined.WriteString(part.text)
+	}
+
+	assertEqual(t, joined.String(), markdown)
+	assertEqual(t, strings.Join(words, " "), "pani nanni Use and ente link ref See vazhi x - avasanam")
+}
+
+func TestMLTransliterateMarkdown(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	top := func(word string) string {
+		return varnam.Transliterate(word)[0].Word
+	}
+
+	output, words, err := varnam.TransliterateMarkdown(context.Background(), "**pani** `nanni`\n\n```\nnanni\n```\n[pani](nanni.md)")
+	checkError(err)
+	assertEqual(t, output, "**"+top("pani")+"** `nanni`\n\n```\nnanni\n```\n["+top("pani")+"](nanni.md)")
+
+	assertEqual(t, len(words), 2)
+	assertEqual(t, words[1].Line, 6)
+}
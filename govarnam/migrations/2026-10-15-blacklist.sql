@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS blacklist (
+  word TEXT PRIMARY KEY
+);
+
+-- Blacklisted words are never inserted, whichever way they come
+-- (learn, import). Single row is skipped, rest of the INSERT continues.
+CREATE TRIGGER IF NOT EXISTS words_bi_blacklist BEFORE INSERT ON words
+  WHEN EXISTS (SELECT 1 FROM blacklist WHERE word = new.word)
+  BEGIN
+    SELECT RAISE(IGNORE);
+  END;
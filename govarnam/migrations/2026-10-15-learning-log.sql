@@ -0,0 +1,11 @@
+-- Number of times a word was learnt & trained in a day.
+-- day is the unix time of start of the day (UTC)
+CREATE TABLE IF NOT EXISTS learning_log (
+  word_id INTEGER NOT NULL,
+  day INTEGER NOT NULL,
+  learns INTEGER NOT NULL DEFAULT 0,
+  trains INTEGER NOT NULL DEFAULT 0,
+  FOREIGN KEY(word_id) REFERENCES words(id) ON DELETE CASCADE,
+  PRIMARY KEY(word_id, day)
+);
+CREATE INDEX IF NOT EXISTS learning_log_day ON learning_log(day);
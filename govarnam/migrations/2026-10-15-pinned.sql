@@ -0,0 +1 @@
+ALTER TABLE words ADD COLUMN pinned INTEGER NOT NULL DEFAULT 0;
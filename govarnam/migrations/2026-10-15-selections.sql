@@ -0,0 +1,9 @@
+-- How many times a word was chosen by the user for a pattern
+CREATE TABLE IF NOT EXISTS selections (
+  pattern TEXT NOT NULL COLLATE NOCASE,
+  word_id INTEGER NOT NULL,
+  count INTEGER NOT NULL DEFAULT 0,
+  last_selected INTEGER,
+  FOREIGN KEY(word_id) REFERENCES words(id) ON DELETE CASCADE,
+  PRIMARY KEY(pattern, word_id)
+);
@@ -0,0 +1,8 @@
+-- Words can be in domains like "medical", "legal".
+-- A word without any row here is a general word.
+CREATE TABLE IF NOT EXISTS word_domains (
+  word_id INTEGER NOT NULL,
+  domain TEXT NOT NULL COLLATE NOCASE,
+  FOREIGN KEY(word_id) REFERENCES words(id) ON DELETE CASCADE,
+  PRIMARY KEY(word_id, domain)
+);
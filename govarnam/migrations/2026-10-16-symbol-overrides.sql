@@ -0,0 +1,16 @@
+-- User's own symbols of a scheme. They replace the
+-- scheme's symbols of the same pattern
+CREATE TABLE IF NOT EXISTS symbol_overrides (
+  scheme_id TEXT NOT NULL,
+  type INTEGER NOT NULL,
+  pattern TEXT NOT NULL,
+  value1 TEXT NOT NULL,
+  value2 TEXT NOT NULL DEFAULT '',
+  value3 TEXT NOT NULL DEFAULT '',
+  tag TEXT NOT NULL DEFAULT '',
+  match_type INTEGER NOT NULL,
+  priority INTEGER NOT NULL DEFAULT 0,
+  accept_condition INTEGER NOT NULL DEFAULT 0,
+  weight INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY(scheme_id, pattern, value1)
+);
@@ -0,0 +1,9 @@
+-- User's weights for symbols of a scheme. They
+-- replace the weight given in scheme
+CREATE TABLE IF NOT EXISTS symbol_weights (
+  scheme_id TEXT NOT NULL,
+  pattern TEXT NOT NULL,
+  value1 TEXT NOT NULL,
+  weight INTEGER NOT NULL,
+  PRIMARY KEY(scheme_id, pattern, value1)
+);
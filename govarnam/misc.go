@@ -7,35 +7,50 @@ package govarnam
  */
 
 import (
+	"fmt"
 	"io/fs"
 	"log"
 	"path/filepath"
 )
 
-// GetAllSchemePaths get available IDs' location as a string array
+// GetAllSchemePaths get available IDs' location as a string array.
+// All VST lookup directories are looked in. If the same scheme ID is
+// in more than one, the one findVSTPath would pick is given
 func GetAllSchemePaths() ([]string, error) {
-	vstsDir, err := FindVSTDir()
+	var (
+		schemePaths []string
+		foundDir    bool
+	)
 
-	if err != nil {
-		return nil, err
-	}
+	seen := map[string]bool{}
 
-	var schemeIDs []string
-
-	filepath.WalkDir(vstsDir, func(s string, d fs.DirEntry, e error) error {
-		if e != nil {
-			return e
-		}
-		if filepath.Ext(d.Name()) == ".vst" {
-			schemeIDs = append(schemeIDs, s)
+	for _, vstsDir := range getVSTLookupDirs() {
+		if !dirExists(vstsDir) {
+			continue
 		}
-		return nil
-	})
+		foundDir = true
 
-	return schemeIDs, nil
+		filepath.WalkDir(vstsDir, func(s string, d fs.DirEntry, e error) error {
+			if e != nil {
+				return e
+			}
+			if filepath.Ext(d.Name()) == ".vst" && !seen[d.Name()] {
+				seen[d.Name()] = true
+				schemePaths = append(schemePaths, s)
+			}
+			return nil
+		})
+	}
+
+	if !foundDir {
+		return nil, fmt.Errorf("Couldn't find VST directory")
+	}
+
+	return schemePaths, nil
 }
 
 // GetAllSchemeDetails get information of all schemes available
+// with the location of each
 func GetAllSchemeDetails() ([]SchemeDetails, error) {
 	schemePaths, err := GetAllSchemePaths()
 
@@ -50,7 +65,10 @@ func GetAllSchemeDetails() ([]SchemeDetails, error) {
 		err := varnam.InitVST(vstPath)
 
 		if err == nil {
-			schemeDetails = append(schemeDetails, varnam.SchemeDetails)
+			sd := varnam.SchemeDetails
+			sd.Path = vstPath
+
+			schemeDetails = append(schemeDetails, sd)
 			varnam.Close()
 		} else {
 			log.Println(err)
@@ -0,0 +1,310 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Symbol overrides of the scheme, loaded from learnings
+type symbolOverlay struct {
+	mutex sync.RWMutex
+
+	// Symbols by pattern
+	symbols map[string][]Symbol
+
+	// Length of longest pattern in runes
+	longest int
+}
+
+func (overlay *symbolOverlay) has(pattern string) bool {
+	_, found := overlay.symbols[pattern]
+	return found
+}
+
+// Overrides of a pattern
+func (overlay *symbolOverlay) find(pattern string, caseInsensitive bool) []Symbol {
+	if !caseInsensitive {
+		return overlay.symbols[pattern]
+	}
+
+	var results []Symbol
+	for overridePattern, symbols := range overlay.symbols {
+		if strings.EqualFold(overridePattern, pattern) {
+			results = append(results, symbols...)
+		}
+	}
+	return results
+}
+
+// Longest pattern of the scheme & overrides, tokenizer
+// looks for patterns this long
+func (varnam *Varnam) patternLongestLength() int {
+	length := varnam.LangRules.PatternLongestLength
+
+	if varnam.symbolOverrides != nil {
+		varnam.symbolOverrides.mutex.RLock()
+		if varnam.symbolOverrides.longest > length {
+			length = varnam.symbolOverrides.longest
+		}
+		varnam.symbolOverrides.mutex.RUnlock()
+	}
+
+	return length
+}
+
+// Whether symbol can be used for the match type & accept condition
+func symbolAccepted(symbol Symbol, matchType int, acceptCondition int) bool {
+	if matchType != VARNAM_MATCH_ALL && symbol.MatchType != matchType {
+		return false
+	}
+	return symbol.AcceptCondition == VARNAM_TOKEN_ACCEPT_ALL || symbol.AcceptCondition == acceptCondition
+}
+
+// Same order as symbols from VST
+func sortSymbols(symbols []Symbol) {
+	sort.SliceStable(symbols, func(i, j int) bool {
+		a, b := symbols[i], symbols[j]
+		if len(a.Pattern) != len(b.Pattern) {
+			return len(a.Pattern) > len(b.Pattern)
+		}
+		if a.MatchType != b.MatchType {
+			return a.MatchType < b.MatchType
+		}
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		return a.Priority > b.Priority
+	})
+}
+
+func (varnam *Varnam) loadSymbolOverrides() error {
+	symbols, err := varnam.GetSymbolOverrides()
+	if err != nil {
+		return err
+	}
+
+	overlay := &symbolOverlay{symbols: map[string][]Symbol{}}
+
+	for _, symbol := range symbols {
+		overlay.symbols[symbol.Pattern] = append(overlay.symbols[symbol.Pattern], symbol)
+
+		// So that tokenizer looks for the whole pattern
+		length := utf8.RuneCountInString(symbol.Pattern)
+		if length > overlay.longest {
+			overlay.longest = length
+		}
+	}
+
+	// Symbols cached by sessions don't have the overrides
+	atomic.AddInt64(&varnam.schemeGeneration, 1)
+
+	if varnam.symbolOverrides == nil {
+		varnam.symbolOverrides = overlay
+		return nil
+	}
+
+	varnam.symbolOverrides.mutex.Lock()
+	varnam.symbolOverrides.symbols = overlay.symbols
+	varnam.symbolOverrides.longest = overlay.longest
+	varnam.symbolOverrides.mutex.Unlock()
+
+	return nil
+}
+
+// OverrideSymbol make pattern give the user's own value instead of
+// what the scheme gives. Eg: make "zha" give "ഴ". All symbols of the
+// scheme for the pattern are replaced by the overrides of the pattern,
+// a pattern not in scheme is added. Overrides are kept in learnings.
+// If Type is not set, type of the scheme's symbol having the same
+// value is used. MatchType is VARNAM_MATCH_EXACT if not set
+func (varnam *Varnam) OverrideSymbol(symbol Symbol) error {
+	if symbol.Pattern == "" || symbol.Value1 == "" {
+		return fmt.Errorf("pattern or value1 is empty")
+	}
+
+	if symbol.MatchType == 0 {
+		symbol.MatchType = VARNAM_MATCH_EXACT
+	}
+
+	if symbol.MatchType != VARNAM_MATCH_EXACT && symbol.MatchType != VARNAM_MATCH_POSSIBILITY {
+		return fmt.Errorf("matchType should be either VARNAM_MATCH_EXACT or VARNAM_MATCH_POSSIBILITY")
+	}
+
+	if symbol.AcceptCondition < VARNAM_TOKEN_ACCEPT_ALL || symbol.AcceptCondition > VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH {
+		return fmt.Errorf("invalid accept condition specified. It should be one of VARNAM_TOKEN_ACCEPT_XXX")
+	}
+
+	if symbol.Type == 0 {
+		symbol.Type = VARNAM_SYMBOL_OTHER
+
+		search := NewSearchSymbol()
+		search.Value1 = symbol.Value1
+		results, err := varnam.SearchSymbolTable(context.Background(), search)
+		if err != nil {
+			return err
+		}
+		if len(results) > 0 {
+			symbol.Type = results[0].Type
+		}
+	}
+
+	if symbol.Type < VARNAM_SYMBOL_VOWEL || symbol.Type > VARNAM_SYMBOL_PERIOD {
+		return fmt.Errorf("invalid symbol type")
+	}
+
+	_, err := varnam.dictWriteConn.Exec(
+		"INSERT OR REPLACE INTO symbol_overrides (scheme_id, type, pattern, value1, value2, value3, tag, match_type, priority, accept_condition, weight) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		varnam.SchemeDetails.Identifier,
+		symbol.Type,
+		symbol.Pattern,
+		symbol.Value1,
+		symbol.Value2,
+		symbol.Value3,
+		symbol.Tag,
+		symbol.MatchType,
+		symbol.Priority,
+		symbol.AcceptCondition,
+		symbol.Weight,
+	)
+	if err != nil {
+		return err
+	}
+
+	return varnam.loadSymbolOverrides()
+}
+
+// RemoveSymbolOverride remove overrides of a pattern.
+// Pattern gives what the scheme gives again
+func (varnam *Varnam) RemoveSymbolOverride(pattern string) error {
+	result, err := varnam.dictWriteConn.Exec("DELETE FROM symbol_overrides WHERE scheme_id = ? AND pattern = ?", varnam.SchemeDetails.Identifier, pattern)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("Pattern is not overridden")
+	}
+
+	return varnam.loadSymbolOverrides()
+}
+
+// GetSymbolOverrides get all symbol overrides of the scheme
+func (varnam *Varnam) GetSymbolOverrides() ([]Symbol, error) {
+	var results []Symbol
+
+	rows, err := varnam.dictConn.Query("SELECT type, pattern, value1, value2, value3, tag, match_type, priority, accept_condition, weight FROM symbol_overrides WHERE scheme_id = ? ORDER BY pattern, match_type, weight DESC", varnam.SchemeDetails.Identifier)
+	if err != nil {
+		return results, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item Symbol
+		rows.Scan(&item.Type, &item.Pattern, &item.Value1, &item.Value2, &item.Value3, &item.Tag, &item.MatchType, &item.Priority, &item.AcceptCondition, &item.Weight)
+		results = append(results, item)
+	}
+
+	return results, rows.Err()
+}
+
+// Replace symbols found in VST for a prefix of pattern
+// with the overrides of prefixes of pattern
+func (varnam *Varnam) applySymbolOverrides(symbols []Symbol, pattern []rune, matchType int, acceptCondition int) []Symbol {
+	overlay := varnam.symbolOverrides
+	if overlay == nil {
+		return symbols
+	}
+
+	overlay.mutex.RLock()
+	defer overlay.mutex.RUnlock()
+
+	if len(overlay.symbols) == 0 {
+		return symbols
+	}
+
+	var results []Symbol
+	for _, symbol := range symbols {
+		if !overlay.has(symbol.Pattern) {
+			results = append(results, symbol)
+		}
+	}
+
+	overridden := false
+	for i := range pattern {
+		prefix := string(pattern[:i+1])
+
+		for _, symbol := range overlay.find(prefix, varnam.CaseInsensitive) {
+			if symbolAccepted(symbol, matchType, acceptCondition) {
+				results = append(results, symbol)
+				overridden = true
+			}
+		}
+	}
+
+	if overridden {
+		sortSymbols(results)
+	}
+
+	return results
+}
+
+// Replace symbols found in VST for a value with the
+// overrides having the value
+func (varnam *Varnam) applyValueSymbolOverrides(symbols []Symbol, value string, matchType int, acceptCondition int) []Symbol {
+	overlay := varnam.symbolOverrides
+	if overlay == nil {
+		return symbols
+	}
+
+	overlay.mutex.RLock()
+	defer overlay.mutex.RUnlock()
+
+	if len(overlay.symbols) == 0 {
+		return symbols
+	}
+
+	var results []Symbol
+	for _, symbol := range symbols {
+		if !overlay.has(symbol.Pattern) {
+			results = append(results, symbol)
+		}
+	}
+
+	overridden := false
+	for _, overrides := range overlay.symbols {
+		for _, symbol := range overrides {
+			if (symbol.Value1 == value || symbol.Value2 == value) && symbolAccepted(symbol, matchType, acceptCondition) {
+				results = append(results, symbol)
+				overridden = true
+			}
+		}
+	}
+
+	if overridden {
+		// Exact matches first, like in searchPattern
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].MatchType != results[j].MatchType {
+				return results[i].MatchType < results[j].MatchType
+			}
+			return results[i].Weight > results[j].Weight
+		})
+	}
+
+	return results
+}
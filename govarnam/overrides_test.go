@@ -0,0 +1,43 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestMLSymbolOverrides(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	assertEqual(t, varnam.TransliterateGreedyTokenized("pazham")[0].Word, "പഴം")
+
+	checkError(varnam.OverrideSymbol(Symbol{Pattern: "zha", Value1: "ള"}))
+
+	// Pattern not in scheme
+	checkError(varnam.OverrideSymbol(Symbol{Pattern: "qq", Value1: "ക്ക", Type: VARNAM_SYMBOL_CONSONANT}))
+
+	defer func() {
+		varnam.RemoveSymbolOverride("zha")
+		varnam.RemoveSymbolOverride("qq")
+	}()
+
+	assertEqual(t, varnam.TransliterateGreedyTokenized("pazham")[0].Word, "പളം")
+	assertEqual(t, varnam.TransliterateGreedyTokenized("paqq")[0].Word, "പക്ക")
+
+	overrides, err := varnam.GetSymbolOverrides()
+	checkError(err)
+	assertEqual(t, len(overrides), 2)
+	assertEqual(t, overrides[1].Pattern, "zha")
+	assertEqual(t, overrides[1].Type, VARNAM_SYMBOL_CONSONANT)
+
+	// Overrides are kept in learnings
+	reopened, err := Init(varnam.VSTPath, varnam.DictPath)
+	checkError(err)
+	assertEqual(t, reopened.TransliterateGreedyTokenized("pazham")[0].Word, "പളം")
+	reopened.Close()
+
+	checkError(varnam.RemoveSymbolOverride("zha"))
+	assertEqual(t, varnam.TransliterateGreedyTokenized("pazham")[0].Word, "പഴം")
+	assertEqual(t, varnam.RemoveSymbolOverride("zha") != nil, true)
+
+	assertEqual(t, varnam.OverrideSymbol(Symbol{Pattern: "zha"}) != nil, true)
+	assertEqual(t, varnam.OverrideSymbol(Symbol{Pattern: "zha", Value1: "ള", MatchType: VARNAM_MATCH_ALL}) != nil, true)
+}
@@ -0,0 +1,116 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+func (varnam *Varnam) setPinned(word string, pinned int) error {
+	defer varnam.dictionaryChanged()
+
+	result, err := varnam.dictWriteConn.Exec("UPDATE words SET pinned = ? WHERE word = ?", pinned, varnam.sanitizeWord(word))
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("Word doesn't exist")
+	}
+
+	return nil
+}
+
+// Pin a learnt word. Pinned words always come above other
+// suggestions whenever they're found. Useful for names
+func (varnam *Varnam) Pin(word string) error {
+	return varnam.setPinned(word, 1)
+}
+
+// Unpin a pinned word
+func (varnam *Varnam) Unpin(word string) error {
+	return varnam.setPinned(word, 0)
+}
+
+// GetPinnedWords get all pinned words
+func (varnam *Varnam) GetPinnedWords() ([]Suggestion, error) {
+	var result []Suggestion
+
+	rows, err := varnam.dictConn.Query("SELECT word, weight, learned_on FROM words WHERE pinned = 1 ORDER BY word")
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item Suggestion
+		rows.Scan(&item.Word, &item.Weight, &item.LearnedOn)
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// Move pinned words to the top. Order is kept otherwise
+func (varnam *Varnam) promotePinned(ctx context.Context, sugs []Suggestion) []Suggestion {
+	if len(sugs) == 0 {
+		return sugs
+	}
+
+	pinned := map[string]bool{}
+
+	limitVariableNumber := getSQLiteLimit(sqliteLimitVariableNumber)
+
+	for start := 0; start < len(sugs); start += limitVariableNumber {
+		end := int(math.Min(float64(start+limitVariableNumber), float64(len(sugs))))
+
+		var args []interface{}
+		for _, sug := range sugs[start:end] {
+			args = append(args, sug.Word)
+		}
+
+		countQuery(ctx)
+		rows, err := varnam.dictConn.QueryContext(
+			ctx,
+			"SELECT word FROM words WHERE pinned = 1 AND word IN (?"+strings.Repeat(", ?", end-start-1)+")",
+			args...,
+		)
+		if err != nil {
+			return sugs
+		}
+
+		for rows.Next() {
+			var word string
+			rows.Scan(&word)
+			pinned[word] = true
+		}
+		rows.Close()
+	}
+
+	if len(pinned) == 0 {
+		return sugs
+	}
+
+	var top, rest []Suggestion
+	for _, sug := range sugs {
+		if pinned[sug.Word] {
+			top = append(top, sug)
+		} else {
+			rest = append(rest, sug)
+		}
+	}
+
+	return append(top, rest...)
+}
@@ -0,0 +1,45 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestMLPin(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("കോട്ടയം", 0))
+	checkError(varnam.Learn("കോട്ട", 0))
+
+	// More weight
+	checkError(varnam.Learn("കോട്ട", 0))
+	checkError(varnam.Learn("കോട്ട", 0))
+
+	sugs := varnam.Transliterate("kOtta")
+	assertEqual(t, sugs[0].Word, "കോട്ട")
+
+	checkError(varnam.Pin("കോട്ടയം"))
+
+	sugs = varnam.Transliterate("kOtta")
+	assertEqual(t, sugs[0].Word, "കോട്ടയം")
+
+	pinned, err := varnam.GetPinnedWords()
+	checkError(err)
+	assertEqual(t, len(pinned), 1)
+	assertEqual(t, pinned[0].Word, "കോട്ടയം")
+
+	// Pinning is kept when learnt again
+	checkError(varnam.Learn("കോട്ടയം", 0))
+	pinned, _ = varnam.GetPinnedWords()
+	assertEqual(t, len(pinned), 1)
+
+	checkError(varnam.Unpin("കോട്ടയം"))
+
+	sugs = varnam.Transliterate("kOtta")
+	assertEqual(t, sugs[0].Word, "കോട്ട")
+
+	// Only learnt words can be pinned
+	assertEqual(t, varnam.Pin("കോട്ടപ്പുറം") != nil, true)
+
+	checkError(varnam.Unlearn("കോട്ടയം"))
+	checkError(varnam.Unlearn("കോട്ട"))
+}
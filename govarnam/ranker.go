@@ -0,0 +1,165 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"math"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// RankSource category of TransliterationResult a suggestion is ranked in
+type RankSource int
+
+const (
+	// RankSourceUnknown suggestions sorted outside of Transliterate
+	RankSourceUnknown RankSource = iota
+	RankSourceExactWords
+	RankSourceExactMatches
+	RankSourceDictionary
+	RankSourcePatternDictionary
+	RankSourceFuzzy
+	RankSourceCorrections
+	RankSourceTokenizer
+	RankSourceGreedyTokenized
+)
+
+// RankCandidate a suggestion with everything a Ranker can use.
+// Weight, Score (confidence) and LearnedOn (recency) are
+// of the embedded Suggestion
+type RankCandidate struct {
+	Suggestion
+
+	Source RankSource
+
+	// Characters in the suggested word
+	MatchLength int
+}
+
+// Ranker decides the order of suggestions within a category.
+// Less should report whether a is to be placed before b.
+// Candidates that are not less than each other are ordered by
+// Varnam.Collator if it's a dictionary category
+type Ranker interface {
+	Less(a RankCandidate, b RankCandidate) bool
+}
+
+// ConfidenceFirstRanker learnt words first and then by weight.
+// This is the default
+type ConfidenceFirstRanker struct{}
+
+// Less learnt word comes first, else the one with more weight
+func (ConfidenceFirstRanker) Less(a RankCandidate, b RankCandidate) bool {
+	if (a.LearnedOn == 0) != (b.LearnedOn == 0) {
+		return a.LearnedOn > b.LearnedOn
+	}
+	return a.Weight > b.Weight
+}
+
+// RecencyBlendedRanker mixes score with how recently a word was
+// learnt, so that words used lately come up even if they were
+// learnt less number of times than others
+type RecencyBlendedRanker struct {
+	// How much recency counts, from 0 to 1. Rest is score
+	RecencyWeight float64
+
+	// Recency of a word halves after this much time
+	HalfLife time.Duration
+
+	// Time to rank at, set once for a sort. Zero is time.Now()
+	now time.Time
+}
+
+// A Ranker whose order depends on the time
+type timedRanker interface {
+	// Same ranker, ranking as of now
+	at(now time.Time) Ranker
+}
+
+// NewRecencyBlendedRanker a RecencyBlendedRanker with default values
+func NewRecencyBlendedRanker() RecencyBlendedRanker {
+	return RecencyBlendedRanker{
+		RecencyWeight: VARNAM_RANKER_RECENCY_WEIGHT,
+		HalfLife:      VARNAM_RANKER_RECENCY_HALF_LIFE,
+	}
+}
+
+func (ranker RecencyBlendedRanker) rank(candidate RankCandidate, now time.Time) float64 {
+	recency := 0.0
+
+	if candidate.LearnedOn != 0 && ranker.HalfLife > 0 {
+		age := now.Sub(time.Unix(int64(candidate.LearnedOn), 0))
+		if age < 0 {
+			age = 0
+		}
+		recency = math.Pow(0.5, float64(age)/float64(ranker.HalfLife))
+	}
+
+	return (1-ranker.RecencyWeight)*candidate.Score + ranker.RecencyWeight*recency
+}
+
+func (ranker RecencyBlendedRanker) at(now time.Time) Ranker {
+	ranker.now = now
+	return ranker
+}
+
+// Less the one with more blended rank comes first.
+// Same rank is ordered like ConfidenceFirstRanker
+func (ranker RecencyBlendedRanker) Less(a RankCandidate, b RankCandidate) bool {
+	now := ranker.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	rankA := ranker.rank(a, now)
+	rankB := ranker.rank(b, now)
+
+	if rankA != rankB {
+		return rankA > rankB
+	}
+	return ConfidenceFirstRanker{}.Less(a, b)
+}
+
+// Sort suggestions with ranker. Ties are ordered
+// with collator, they keep their order if it's nil
+func sortSuggestionsWithRanker(sugs []Suggestion, source RankSource, ranker Ranker, collator Collator) []Suggestion {
+	candidates := make([]RankCandidate, len(sugs))
+	for i, sug := range sugs {
+		candidates[i] = RankCandidate{sug, source, utf8.RuneCountInString(sug.Word)}
+	}
+
+	// Every compare is of the same time, else order can change
+	// in the middle of sorting
+	if timed, ok := ranker.(timedRanker); ok {
+		ranker = timed.at(time.Now())
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if ranker.Less(candidates[i], candidates[j]) {
+			return true
+		}
+		if ranker.Less(candidates[j], candidates[i]) || collator == nil {
+			return false
+		}
+		return collator.Compare(candidates[i].Word, candidates[j].Word) < 0
+	})
+
+	for i := range candidates {
+		sugs[i] = candidates[i].Suggestion
+	}
+	return sugs
+}
+
+// Sort suggestions of a category with the instance's ranker
+func (varnam *Varnam) sortSuggestions(sugs []Suggestion, source RankSource, collator Collator) []Suggestion {
+	var ranker Ranker = ConfidenceFirstRanker{}
+	if varnam.Ranker != nil {
+		ranker = varnam.Ranker
+	}
+	return sortSuggestionsWithRanker(sugs, source, ranker, collator)
+}
@@ -0,0 +1,76 @@
+package govarnam
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecencyBlendedRanker(t *testing.T) {
+	now := int(time.Now().Unix())
+	monthAgo := int(time.Now().Add(-30 * 24 * time.Hour).Unix())
+
+	sugs := []Suggestion{
+		// Learnt many times long ago
+		{"മലയാളം", VARNAM_LEARNT_WORD_MIN_WEIGHT + 20, monthAgo, 0},
+		// Learnt once just now
+		{"മലയാളി", VARNAM_LEARNT_WORD_MIN_WEIGHT, now, 0},
+		{"മലയ", 5, 0, 0},
+	}
+	sugs = scoreDictionarySuggestions(sugs)
+
+	confidenceFirst := sortSuggestionsWithRanker(append([]Suggestion{}, sugs...), RankSourceDictionary, ConfidenceFirstRanker{}, nil)
+	assertEqual(t, confidenceFirst[0].Word, "മലയാളം")
+	assertEqual(t, confidenceFirst[1].Word, "മലയാളി")
+	assertEqual(t, confidenceFirst[2].Word, "മലയ")
+
+	recencyBlended := sortSuggestionsWithRanker(append([]Suggestion{}, sugs...), RankSourceDictionary, NewRecencyBlendedRanker(), nil)
+	assertEqual(t, recencyBlended[0].Word, "മലയാളി")
+	assertEqual(t, recencyBlended[1].Word, "മലയാളം")
+	assertEqual(t, recencyBlended[2].Word, "മലയ")
+
+	// Recency not counted
+	scoreOnly := RecencyBlendedRanker{RecencyWeight: 0, HalfLife: time.Hour}
+	assertEqual(t, scoreOnly.Less(RankCandidate{Suggestion: sugs[0]}, RankCandidate{Suggestion: sugs[1]}), true)
+
+	// Ranked as of the time set for the sort. A month later, both
+	// were learnt long ago and the one learnt more comes first
+	later := NewRecencyBlendedRanker().at(time.Now().Add(30 * 24 * time.Hour))
+	assertEqual(t, later.Less(RankCandidate{Suggestion: sugs[0]}, RankCandidate{Suggestion: sugs[1]}), true)
+}
+
+// Shortest word first, remembering what it ranked
+type testLengthRanker struct {
+	sources map[RankSource]bool
+}
+
+func (ranker testLengthRanker) Less(a RankCandidate, b RankCandidate) bool {
+	ranker.sources[a.Source] = true
+	return a.MatchLength < b.MatchLength
+}
+
+func TestMLRanker(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	ranker := testLengthRanker{map[RankSource]bool{}}
+	varnam.Ranker = ranker
+
+	words := []string{"മലയാളത്തിൽ", "മലയാളി", "മലയാളം"}
+	for _, word := range words {
+		checkError(varnam.Learn(word, 0))
+	}
+
+	defer func() {
+		varnam.Ranker = ConfidenceFirstRanker{}
+		for _, word := range words {
+			varnam.Unlearn(word)
+		}
+	}()
+
+	result := varnam.TransliterateAdvanced("malayala")
+
+	assertEqual(t, len(result.DictionarySuggestions) > 1, true)
+	for i := 1; i < len(result.DictionarySuggestions); i++ {
+		assertEqual(t, len([]rune(result.DictionarySuggestions[i-1].Word)) <= len([]rune(result.DictionarySuggestions[i].Word)), true)
+	}
+	assertEqual(t, ranker.sources[RankSourceDictionary], true)
+}
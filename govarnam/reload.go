@@ -0,0 +1,96 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// When & how big the VST file was when it was loaded
+type vstFileInfo struct {
+	modTime time.Time
+	size    int64
+}
+
+func statVST(vstPath string) vstFileInfo {
+	info, err := os.Stat(vstPath)
+	if err != nil {
+		return vstFileInfo{}
+	}
+	return vstFileInfo{info.ModTime(), info.Size()}
+}
+
+// ReloadScheme load the VST again, for picking up a scheme
+// updated on disk. Dictionary connection is kept open and symbol
+// overrides are loaded again. Sessions drop the symbols they have
+// cached. On error the loaded scheme is kept as is.
+// Shouldn't be called while transliterating
+func (varnam *Varnam) ReloadScheme() error {
+	if varnam.VSTPath == "" {
+		return fmt.Errorf("Scheme is not loaded from a VST file")
+	}
+
+	reloaded := Varnam{}
+	err := reloaded.InitVST(varnam.VSTPath)
+	if err != nil {
+		return err
+	}
+
+	if reloaded.SchemeDetails.LangCode != varnam.SchemeDetails.LangCode {
+		reloaded.closeVST()
+		return fmt.Errorf("Reloaded scheme is of language %s, not %s", reloaded.SchemeDetails.LangCode, varnam.SchemeDetails.LangCode)
+	}
+
+	old := Varnam{vstConn: varnam.vstConn, mappedVST: varnam.mappedVST}
+
+	varnam.vstConn = reloaded.vstConn
+	varnam.mappedVST = reloaded.mappedVST
+	varnam.vstFileInfo = reloaded.vstFileInfo
+
+	reloaded.SchemeDetails.Path = varnam.SchemeDetails.Path
+	varnam.SchemeDetails = reloaded.SchemeDetails
+
+	varnam.LangRules.PatternLongestLength = reloaded.LangRules.PatternLongestLength
+	varnam.LangRules.Virama, _ = varnam.getVirama()
+
+	atomic.AddInt64(&varnam.schemeGeneration, 1)
+
+	old.closeVST()
+
+	if varnam.dictConn != nil {
+		err = varnam.loadSymbolOverrides()
+		if err != nil {
+			return err
+		}
+		return varnam.loadSymbolWeights()
+	}
+
+	return nil
+}
+
+// ReloadSchemeIfChanged reload the scheme if VST file has been
+// changed since it was loaded. Only the file is checked, it's cheap
+// enough to be called before every transliteration. Changes to the
+// base of a variant scheme are not found
+func (varnam *Varnam) ReloadSchemeIfChanged() (bool, error) {
+	if varnam.VSTPath == "" {
+		return false, nil
+	}
+
+	if statVST(varnam.VSTPath) == varnam.vstFileInfo {
+		return false, nil
+	}
+
+	err := varnam.ReloadScheme()
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
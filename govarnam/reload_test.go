@@ -0,0 +1,57 @@
+package govarnam
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestReloadScheme(t *testing.T) {
+	vst, err := os.ReadFile(getVarnamInstance("ml").VSTPath)
+	checkError(err)
+
+	vstPath := path.Join(testTempDir, "reload-ml.vst")
+	checkError(os.WriteFile(vstPath, vst, 0644))
+
+	varnam, err := Init(vstPath, path.Join(testTempDir, "reload-ml.vst.learnings"))
+	checkError(err)
+	defer varnam.Close()
+
+	checkError(varnam.OverrideSymbol(Symbol{Pattern: "zha", Value1: "ള"}))
+
+	ctx := context.Background()
+	session := varnam.NewSession()
+	assertEqual(t, session.Append(ctx, "qqq").GreedyTokenized[0].Word != "ക്യൂ", true)
+
+	reloaded, err := varnam.ReloadSchemeIfChanged()
+	checkError(err)
+	assertEqual(t, reloaded, false)
+
+	// Updated scheme is put in place of the old one
+	newPath := path.Join(testTempDir, "reload-ml-new.vst")
+	checkError(os.WriteFile(newPath, vst, 0644))
+
+	vm, err := VMInit(newPath)
+	checkError(err)
+	checkError(vm.VMCreateToken("qqq", "ക്യൂ", "", "", "", VARNAM_SYMBOL_OTHER, VARNAM_MATCH_EXACT, 0, 0, false))
+	vm.Close()
+
+	checkError(os.Rename(newPath, vstPath))
+
+	reloaded, err = varnam.ReloadSchemeIfChanged()
+	checkError(err)
+	assertEqual(t, reloaded, true)
+
+	assertEqual(t, varnam.TransliterateGreedyTokenized("qqq")[0].Word, "ക്യൂ")
+	assertEqual(t, session.Transliterate(ctx).GreedyTokenized[0].Word, "ക്യൂ")
+
+	// Dictionary & overrides are kept
+	assertEqual(t, varnam.TransliterateGreedyTokenized("pazham")[0].Word, "പളം")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+	assertEqual(t, varnam.TransliterateAdvanced("malayalam").ExactWords[0].Word, "മലയാളം")
+
+	embedded := Varnam{}
+	assertEqual(t, embedded.ReloadScheme() != nil, true)
+}
@@ -0,0 +1,230 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"container/list"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type resultCacheKey struct {
+	word              string
+	domains           string
+	restrictToDomains bool
+	indicDigits       int
+	acronym           int
+}
+
+// Learnings, symbols & config a cached result was made with
+type resultGeneration struct {
+	dictionary int64
+	scheme     int64
+	config     resultConfig
+}
+
+// Config of Varnam that results depend on
+type resultConfig struct {
+	dictionarySuggestionsLimit        int
+	patternDictionarySuggestionsLimit int
+	tokenizerSuggestionsLimit         int
+	tokenizerSuggestionsAlways        bool
+	tokenizerSuggestionsThreshold     int
+	tokenizerSuggestionsOnThreshold   int
+	tokenizerWeightThreshold          float64
+	dictionaryMinInputLength          int
+	dictionaryMatchExact              bool
+	keepDuplicateSuggestions          bool
+	scriptBoundary                    int
+	controlCharacters                 ControlCharacters
+	hasControlCharacters              bool
+	inputMethod                       int
+	joinerPolicy                      int
+	caseInsensitive                   bool
+	noGrantha                         bool
+	fuzzyMatching                     bool
+	suggestCorrections                bool
+
+	// Identities of these, see configIdentity
+	typoModel               interface{}
+	collator                interface{}
+	ranker                  interface{}
+	suggestionFilters       interface{}
+	patternWordPartializers interface{}
+}
+
+type referenceIdentity struct {
+	kind    reflect.Type
+	pointer uintptr
+	length  int
+}
+
+// Comparable value that stays the same as long as value isn't
+// replaced. Funcs, slices & maps are compared by what they point to.
+// Values that can't be compared are never the same
+func configIdentity(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Type().Comparable() {
+		return value
+	}
+
+	switch v.Kind() {
+	case reflect.Func, reflect.Map:
+		return referenceIdentity{v.Type(), v.Pointer(), 0}
+	case reflect.Slice:
+		return referenceIdentity{v.Type(), v.Pointer(), v.Len()}
+	}
+	return new(int)
+}
+
+type resultCacheEntry struct {
+	key        resultCacheKey
+	generation resultGeneration
+	result     TransliterationResult
+}
+
+// Recent results of Transliterate & friends, least recently
+// used is dropped when full. See Varnam.ResultCacheSize
+type resultCache struct {
+	mutex   sync.Mutex
+	entries map[resultCacheKey]*list.Element
+	order   *list.List
+}
+
+func makeResultCacheKey(word string, opts TransliterateOptions) resultCacheKey {
+	key := resultCacheKey{
+		word:              word,
+		domains:           strings.Join(opts.Domains, "\x00"),
+		restrictToDomains: opts.RestrictToDomains,
+		acronym:           opts.Acronym,
+		indicDigits:       -1,
+	}
+	if opts.IndicDigits != nil && *opts.IndicDigits {
+		key.indicDigits = 1
+	} else if opts.IndicDigits != nil {
+		key.indicDigits = 0
+	}
+	return key
+}
+
+// Cached result, if it was made with the current learnings & symbols
+func (cache *resultCache) get(key resultCacheKey, generation resultGeneration) (TransliterationResult, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, found := cache.entries[key]
+	if !found {
+		return TransliterationResult{}, false
+	}
+
+	entry := element.Value.(*resultCacheEntry)
+	if entry.generation != generation {
+		cache.order.Remove(element)
+		delete(cache.entries, key)
+		return TransliterationResult{}, false
+	}
+
+	cache.order.MoveToFront(element)
+	return cloneTransliterationResult(entry.result), true
+}
+
+func (cache *resultCache) set(key resultCacheKey, generation resultGeneration, result TransliterationResult, size int) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cache.entries == nil {
+		cache.entries = make(map[resultCacheKey]*list.Element)
+		cache.order = list.New()
+	}
+
+	entry := &resultCacheEntry{key, generation, cloneTransliterationResult(result)}
+
+	if element, found := cache.entries[key]; found {
+		element.Value = entry
+		cache.order.MoveToFront(element)
+	} else {
+		cache.entries[key] = cache.order.PushFront(entry)
+	}
+
+	for cache.order.Len() > size {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*resultCacheEntry).key)
+	}
+}
+
+func (cache *resultCache) clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.entries = nil
+	cache.order = nil
+}
+
+// Whether the result of transliteration can be cached
+func (varnam *Varnam) cacheableResult(opts TransliterateOptions) bool {
+	// Quick results depend on how fast lookups were,
+	// diagnostics on when it was made
+	return varnam.ResultCacheSize > 0 && !opts.Quick && !varnam.CollectDiagnostics
+}
+
+func (varnam *Varnam) resultGeneration() resultGeneration {
+	return resultGeneration{
+		dictionary: atomic.LoadInt64(&varnam.dictGeneration),
+		scheme:     atomic.LoadInt64(&varnam.schemeGeneration),
+		config:     varnam.resultConfig(),
+	}
+}
+
+func (varnam *Varnam) resultConfig() resultConfig {
+	config := resultConfig{
+		dictionarySuggestionsLimit:        varnam.DictionarySuggestionsLimit,
+		patternDictionarySuggestionsLimit: varnam.PatternDictionarySuggestionsLimit,
+		tokenizerSuggestionsLimit:         varnam.TokenizerSuggestionsLimit,
+		tokenizerSuggestionsAlways:        varnam.TokenizerSuggestionsAlways,
+		tokenizerSuggestionsThreshold:     varnam.TokenizerSuggestionsThreshold,
+		tokenizerSuggestionsOnThreshold:   varnam.TokenizerSuggestionsOnThreshold,
+		tokenizerWeightThreshold:          varnam.TokenizerWeightThreshold,
+		dictionaryMinInputLength:          varnam.DictionaryMinInputLength,
+		dictionaryMatchExact:              varnam.DictionaryMatchExact,
+		keepDuplicateSuggestions:          varnam.KeepDuplicateSuggestions,
+		scriptBoundary:                    varnam.ScriptBoundary,
+		inputMethod:                       varnam.InputMethod,
+		joinerPolicy:                      varnam.JoinerPolicy,
+		caseInsensitive:                   varnam.CaseInsensitive,
+		noGrantha:                         varnam.NoGrantha,
+		fuzzyMatching:                     varnam.FuzzyMatching,
+		suggestCorrections:                varnam.SuggestCorrections,
+		typoModel:                         configIdentity(varnam.TypoModel),
+		collator:                          configIdentity(varnam.Collator),
+		ranker:                            configIdentity(varnam.Ranker),
+		suggestionFilters:                 configIdentity(varnam.SuggestionFilters),
+		patternWordPartializers:           configIdentity(varnam.PatternWordPartializers),
+	}
+	if varnam.ControlCharacters != nil {
+		config.controlCharacters = *varnam.ControlCharacters
+		config.hasControlCharacters = true
+	}
+	return config
+}
+
+// Learnings have changed, cached results are stale
+func (varnam *Varnam) dictionaryChanged() {
+	atomic.AddInt64(&varnam.dictGeneration, 1)
+}
+
+// ClearResultCache drop all cached results. Results made with
+// other learnings, symbols or config aren't given anyway
+func (varnam *Varnam) ClearResultCache() {
+	varnam.resultCache.clear()
+}
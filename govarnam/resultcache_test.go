@@ -0,0 +1,75 @@
+package govarnam
+
+import (
+	"context"
+	"path"
+	"testing"
+)
+
+func TestMLResultCache(t *testing.T) {
+	varnam, err := Init(getVarnamInstance("ml").VSTPath, path.Join(testTempDir, "result-cache-ml.vst.learnings"))
+	checkError(err)
+	defer varnam.Close()
+
+	varnam.ResultCacheSize = 2
+
+	ctx := context.Background()
+	cached := func(word string, opts TransliterateOptions) bool {
+		_, found := varnam.resultCache.get(makeResultCacheKey(word, opts), varnam.resultGeneration())
+		return found
+	}
+
+	first := varnam.TransliterateAdvanced("thrissur")
+	assertEqual(t, cached("thrissur", TransliterateOptions{}), true)
+
+	// Changing given result doesn't change the cached one
+	first.TokenizerSuggestions[0].Word = "changed"
+	assertEqual(t, varnam.TransliterateAdvanced("thrissur").TokenizerSuggestions[0].Word != "changed", true)
+
+	// Options are of the key
+	digits := true
+	assertEqual(t, cached("thrissur", TransliterateOptions{IndicDigits: &digits}), false)
+
+	// Quick results aren't cached
+	varnam.TransliterateQuick(ctx, "kochi")
+	assertEqual(t, cached("kochi", TransliterateOptions{Quick: true}), false)
+
+	// Least recently used is dropped
+	varnam.Transliterate("kochi")
+	varnam.Transliterate("thrissur")
+	varnam.Transliterate("kannur")
+	assertEqual(t, cached("kochi", TransliterateOptions{}), false)
+	assertEqual(t, cached("thrissur", TransliterateOptions{}), true)
+
+	// Learnt word is given after learning
+	assertEqual(t, varnam.Transliterate("malayalam")[0].Word != "മലയാളം", true)
+	checkError(varnam.Train("malayalam", "മലയാളം"))
+	assertEqual(t, cached("thrissur", TransliterateOptions{}), false)
+	assertEqual(t, varnam.Transliterate("malayalam")[0].Word, "മലയാളം")
+
+	checkError(varnam.Unlearn("മലയാളം"))
+	assertEqual(t, varnam.Transliterate("malayalam")[0].Word != "മലയാളം", true)
+
+	varnam.ClearResultCache()
+	assertEqual(t, cached("malayalam", TransliterateOptions{}), false)
+
+	// Results made with other config aren't given
+	varnam.Transliterate("thrissur")
+	varnam.TokenizerSuggestionsLimit++
+	assertEqual(t, cached("thrissur", TransliterateOptions{}), false)
+
+	varnam.Transliterate("thrissur")
+	varnam.Ranker = NewRecencyBlendedRanker()
+	assertEqual(t, cached("thrissur", TransliterateOptions{}), false)
+
+	varnam.Transliterate("thrissur")
+	assertEqual(t, cached("thrissur", TransliterateOptions{}), true)
+	varnam.RegisterSuggestionFilter(func(word string, sugs []Suggestion) []Suggestion {
+		return sugs[:1]
+	})
+	assertEqual(t, cached("thrissur", TransliterateOptions{}), false)
+	assertEqual(t, len(varnam.Transliterate("thrissur")), 1)
+
+	varnam.SuggestionFilters = nil
+	assertEqual(t, len(varnam.Transliterate("thrissur")) > 1, true)
+}
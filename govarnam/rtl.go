@@ -0,0 +1,241 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Scripts written from right to left
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Arabic,
+	unicode.Hebrew,
+	unicode.Syriac,
+	unicode.Thaana,
+}
+
+// IsRTL whether the language of scheme is written right to left.
+// Text direction of suggestions should be set with this
+func (varnam *Varnam) IsRTL() bool {
+	script, ok := languageScripts[varnam.SchemeDetails.LangCode]
+	if !ok {
+		return false
+	}
+
+	for _, rtlScript := range rtlScripts {
+		if script == rtlScript {
+			return true
+		}
+	}
+	return false
+}
+
+// Marks that only change how text is displayed
+var directionMarks = []string{LRM, RLM, LRI, RLI, FSI, PDI}
+
+func removeDirectionMarks(word string) string {
+	for _, mark := range directionMarks {
+		word = strings.Replace(word, mark, "", -1)
+	}
+	return word
+}
+
+// Characters that aren't in the language's script (Latin passed
+// through by tokenizer etc.) are reordered by bidi algorithm with
+// the RTL characters around them. Eg: "کتابgo2" is shown as
+// "2goکتاب" in some renderers. Runs of such characters are
+// wrapped in LRI & PDI so that they're kept as they're typed
+func (varnam *Varnam) isolateLTRRuns(word string) string {
+	word = removeDirectionMarks(word)
+
+	var (
+		result    strings.Builder
+		run       []rune
+		runIsLTR  bool
+		hasNative bool
+		native    bool
+	)
+
+	flush := func() {
+		if runIsLTR {
+			result.WriteString(LRI + string(run) + PDI)
+		} else {
+			result.WriteString(string(run))
+		}
+		run = nil
+		runIsLTR = false
+	}
+
+	for _, char := range word {
+		native = varnam.isNativeChar(char, native)
+
+		if native || unicode.Is(unicode.Mn, char) {
+			hasNative = hasNative || native
+			flush()
+			result.WriteRune(char)
+			continue
+		}
+
+		run = append(run, char)
+		if unicode.IsLetter(char) || unicode.IsDigit(char) {
+			runIsLTR = true
+		}
+	}
+
+	if !hasNative {
+		// Nothing to isolate from
+		return word
+	}
+
+	flush()
+
+	return result.String()
+}
+
+func (varnam *Varnam) isolateLTRRunsInSuggestions(sugs []Suggestion) []Suggestion {
+	if !varnam.IsRTL() {
+		return sugs
+	}
+	for i := range sugs {
+		sugs[i].Word = varnam.isolateLTRRuns(sugs[i].Word)
+	}
+	return sugs
+}
+
+func (varnam *Varnam) isolateLTRRunsInResult(result *TransliterationResult) {
+	if !varnam.IsRTL() {
+		return
+	}
+	for _, sugs := range [][]Suggestion{
+		result.ExactWords,
+		result.ExactMatches,
+		result.DictionarySuggestions,
+		result.PatternDictionarySuggestions,
+		result.TokenizerSuggestions,
+		result.GreedyTokenized,
+		result.FuzzySuggestions,
+		result.Corrections,
+	} {
+		varnam.isolateLTRRunsInSuggestions(sugs)
+	}
+}
+
+// Contextual forms of Arabic letters (Arabic Presentation Forms).
+// Renderers choose the form of a letter by its position, text
+// should have only the nominal letters. Text copied from PDFs
+// often has these
+var arabicPresentationForms = []struct {
+	first   rune
+	last    rune
+	nominal string
+}{
+	{0xFB50, 0xFB51, "\u0671"},       // ٱ
+	{0xFB52, 0xFB55, "\u067B"},       // ٻ
+	{0xFB56, 0xFB59, "\u067E"},       // پ
+	{0xFB5A, 0xFB5D, "\u0680"},       // ڀ
+	{0xFB5E, 0xFB61, "\u067A"},       // ٺ
+	{0xFB62, 0xFB65, "\u067F"},       // ٿ
+	{0xFB66, 0xFB69, "\u0679"},       // ٹ
+	{0xFB6A, 0xFB6D, "\u06A4"},       // ڤ
+	{0xFB6E, 0xFB71, "\u06A6"},       // ڦ
+	{0xFB72, 0xFB75, "\u0684"},       // ڄ
+	{0xFB76, 0xFB79, "\u0683"},       // ڃ
+	{0xFB7A, 0xFB7D, "\u0686"},       // چ
+	{0xFB7E, 0xFB81, "\u0687"},       // ڇ
+	{0xFB82, 0xFB83, "\u068D"},       // ڍ
+	{0xFB84, 0xFB85, "\u068C"},       // ڌ
+	{0xFB86, 0xFB87, "\u068E"},       // ڎ
+	{0xFB88, 0xFB89, "\u0688"},       // ڈ
+	{0xFB8A, 0xFB8B, "\u0698"},       // ژ
+	{0xFB8C, 0xFB8D, "\u0691"},       // ڑ
+	{0xFB8E, 0xFB91, "\u06A9"},       // ک
+	{0xFB92, 0xFB95, "\u06AF"},       // گ
+	{0xFB96, 0xFB99, "\u06B3"},       // ڳ
+	{0xFB9A, 0xFB9D, "\u06B1"},       // ڱ
+	{0xFB9E, 0xFB9F, "\u06BA"},       // ں
+	{0xFBA0, 0xFBA3, "\u06BB"},       // ڻ
+	{0xFBA4, 0xFBA5, "\u06C0"},       // ۀ
+	{0xFBA6, 0xFBA9, "\u06C1"},       // ہ
+	{0xFBAA, 0xFBAD, "\u06BE"},       // ھ
+	{0xFBAE, 0xFBAF, "\u06D2"},       // ے
+	{0xFBB0, 0xFBB1, "\u06D3"},       // ۓ
+	{0xFBD3, 0xFBD6, "\u06AD"},       // ڭ
+	{0xFBD7, 0xFBD8, "\u06C7"},       // ۇ
+	{0xFBD9, 0xFBDA, "\u06C6"},       // ۆ
+	{0xFBDB, 0xFBDC, "\u06C8"},       // ۈ
+	{0xFBDD, 0xFBDD, "\u0677"},       // ٷ
+	{0xFBDE, 0xFBDF, "\u06CB"},       // ۋ
+	{0xFBE0, 0xFBE1, "\u06C5"},       // ۅ
+	{0xFBE2, 0xFBE3, "\u06C9"},       // ۉ
+	{0xFBE4, 0xFBE7, "\u06D0"},       // ې
+	{0xFBE8, 0xFBE9, "\u0649"},       // ى
+	{0xFBFC, 0xFBFF, "\u06CC"},       // ی
+	{0xFE80, 0xFE80, "\u0621"},       // ء
+	{0xFE81, 0xFE82, "\u0622"},       // آ
+	{0xFE83, 0xFE84, "\u0623"},       // أ
+	{0xFE85, 0xFE86, "\u0624"},       // ؤ
+	{0xFE87, 0xFE88, "\u0625"},       // إ
+	{0xFE89, 0xFE8C, "\u0626"},       // ئ
+	{0xFE8D, 0xFE8E, "\u0627"},       // ا
+	{0xFE8F, 0xFE92, "\u0628"},       // ب
+	{0xFE93, 0xFE94, "\u0629"},       // ة
+	{0xFE95, 0xFE98, "\u062A"},       // ت
+	{0xFE99, 0xFE9C, "\u062B"},       // ث
+	{0xFE9D, 0xFEA0, "\u062C"},       // ج
+	{0xFEA1, 0xFEA4, "\u062D"},       // ح
+	{0xFEA5, 0xFEA8, "\u062E"},       // خ
+	{0xFEA9, 0xFEAA, "\u062F"},       // د
+	{0xFEAB, 0xFEAC, "\u0630"},       // ذ
+	{0xFEAD, 0xFEAE, "\u0631"},       // ر
+	{0xFEAF, 0xFEB0, "\u0632"},       // ز
+	{0xFEB1, 0xFEB4, "\u0633"},       // س
+	{0xFEB5, 0xFEB8, "\u0634"},       // ش
+	{0xFEB9, 0xFEBC, "\u0635"},       // ص
+	{0xFEBD, 0xFEC0, "\u0636"},       // ض
+	{0xFEC1, 0xFEC4, "\u0637"},       // ط
+	{0xFEC5, 0xFEC8, "\u0638"},       // ظ
+	{0xFEC9, 0xFECC, "\u0639"},       // ع
+	{0xFECD, 0xFED0, "\u063A"},       // غ
+	{0xFED1, 0xFED4, "\u0641"},       // ف
+	{0xFED5, 0xFED8, "\u0642"},       // ق
+	{0xFED9, 0xFEDC, "\u0643"},       // ك
+	{0xFEDD, 0xFEE0, "\u0644"},       // ل
+	{0xFEE1, 0xFEE4, "\u0645"},       // م
+	{0xFEE5, 0xFEE8, "\u0646"},       // ن
+	{0xFEE9, 0xFEEC, "\u0647"},       // ه
+	{0xFEED, 0xFEEE, "\u0648"},       // و
+	{0xFEEF, 0xFEF0, "\u0649"},       // ى
+	{0xFEF1, 0xFEF4, "\u064A"},       // ي
+	{0xFEF5, 0xFEF6, "\u0644\u0622"}, // لآ
+	{0xFEF7, 0xFEF8, "\u0644\u0623"}, // لأ
+	{0xFEF9, 0xFEFA, "\u0644\u0625"}, // لإ
+	{0xFEFB, 0xFEFC, "\u0644\u0627"}, // لا
+}
+
+// Replace contextual forms of Arabic letters with nominal letters
+func toNominalArabicForms(word string) string {
+	var result strings.Builder
+
+	for _, char := range word {
+		replaced := false
+		if char >= 0xFB50 {
+			for _, forms := range arabicPresentationForms {
+				if char >= forms.first && char <= forms.last {
+					result.WriteString(forms.nominal)
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced {
+			result.WriteRune(char)
+		}
+	}
+
+	return result.String()
+}
@@ -0,0 +1,48 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestIsRTL(t *testing.T) {
+	varnam := Varnam{}
+
+	varnam.SchemeDetails.LangCode = "ur"
+	assertEqual(t, varnam.IsRTL(), true)
+
+	varnam.SchemeDetails.LangCode = "ml"
+	assertEqual(t, varnam.IsRTL(), false)
+
+	varnam.SchemeDetails.LangCode = ""
+	assertEqual(t, varnam.IsRTL(), false)
+}
+
+func TestIsolateLTRRuns(t *testing.T) {
+	varnam := Varnam{}
+	varnam.SchemeDetails.LangCode = "ur"
+
+	assertEqual(t, varnam.isolateLTRRuns("کتابgo2"), "کتاب"+LRI+"go2"+PDI)
+	assertEqual(t, varnam.isolateLTRRuns("A4کتاب"), LRI+"A4"+PDI+"کتاب")
+
+	// Punctuation takes the direction of text around it
+	assertEqual(t, varnam.isolateLTRRuns("کتاب?"), "کتاب?")
+
+	// Nothing to isolate from
+	assertEqual(t, varnam.isolateLTRRuns("book"), "book")
+
+	// Already isolated
+	assertEqual(t, varnam.isolateLTRRuns("کتاب"+LRI+"go"+PDI), "کتاب"+LRI+"go"+PDI)
+}
+
+func TestURSanitization(t *testing.T) {
+	varnam := Varnam{}
+	varnam.SchemeDetails.LangCode = "ur"
+
+	// Initial, medial & final forms of ک ت ا ب
+	assertEqual(t, varnam.sanitizeWord("ﮐﺘﺎﺏ"), "کتاب")
+
+	// Lam alef ligature
+	assertEqual(t, varnam.sanitizeWord("ﻻ"), "لا")
+
+	assertEqual(t, varnam.sanitizeWord(RLM+"کتاب"+LRI+"go"+PDI), "کتابgo")
+}
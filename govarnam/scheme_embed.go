@@ -0,0 +1,298 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+)
+
+// EmbeddedScheme a VST compiled into Go source.
+// Made with cmd/vst2go, see its usage for go:generate
+type EmbeddedScheme struct {
+	SchemeDetails SchemeDetails
+	Symbols       []Symbol
+	StemRules     []StemRule
+}
+
+// StemRule words ending with OldEnding are learnt with a stem ending
+// with NewEnding instead, unless they end with one of Exceptions
+type StemRule struct {
+	OldEnding  string
+	NewEnding  string
+	Exceptions []string
+}
+
+// InitEmbedded Initialize varnam from a scheme compiled into the binary.
+// No VST file is needed. Dictionary will be created if it doesn't exist
+func InitEmbedded(scheme EmbeddedScheme, dictPath string) (*Varnam, error) {
+	varnam := Varnam{}
+
+	err := varnam.InitEmbeddedVST(scheme)
+	if err != nil {
+		return nil, err
+	}
+	err = varnam.InitDict(dictPath)
+	if err != nil {
+		return nil, err
+	}
+
+	varnam.setDefaultConfig()
+
+	return &varnam, nil
+}
+
+// InitEmbeddedVST load an embedded scheme into an in-memory VST
+func (varnam *Varnam) InitEmbeddedVST(scheme EmbeddedScheme) error {
+	if len(scheme.Symbols) == 0 {
+		return fmt.Errorf("embedded scheme has no symbols")
+	}
+
+	var err error
+	varnam.vstConn, err = openDB("file::memory:?_case_sensitive_like=on")
+	if err != nil {
+		return err
+	}
+
+	// Every connection to :memory: is a new empty database.
+	// Stick to one connection so that the symbols stay.
+	varnam.vstConn.SetMaxOpenConns(1)
+	varnam.vstConn.SetConnMaxLifetime(0)
+
+	err = varnam.vmEnsureSchemaExists()
+	if err != nil {
+		return err
+	}
+
+	err = varnam.vmLoadEmbeddedScheme(scheme)
+	if err != nil {
+		return err
+	}
+
+	err = varnam.setPatternLongestLength()
+	if err != nil {
+		return err
+	}
+
+	varnam.vstConn.Exec("PRAGMA TEMP_STORE=2;")
+
+	varnam.VSTPath = ""
+	varnam.setSchemeInfo()
+
+	return varnam.checkSchemeCompatibility()
+}
+
+func (varnam *Varnam) vmLoadEmbeddedScheme(scheme EmbeddedScheme) error {
+	tx, err := varnam.vstConn.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare("INSERT INTO symbols (id, type, pattern, value1, value2, value3, tag, match_type, priority, accept_condition, flags, weight) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, s := range scheme.Symbols {
+		_, err = stmt.Exec(s.Identifier, s.Type, s.Pattern, s.Value1, s.Value2, s.Value3, s.Tag, s.MatchType, s.Priority, s.AcceptCondition, s.Flags, s.Weight)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to load symbol %s => %s: %s", s.Pattern, s.Value1, err.Error())
+		}
+	}
+
+	for _, rule := range scheme.StemRules {
+		_, err = tx.Exec("INSERT INTO stemrules (old_ending, new_ending) VALUES (?, ?)", rule.OldEnding, rule.NewEnding)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to load stem rule %s => %s: %s", rule.OldEnding, rule.NewEnding, err.Error())
+		}
+
+		for _, exception := range rule.Exceptions {
+			_, err = tx.Exec("INSERT INTO stem_exceptions (stem, exception) VALUES (?, ?)", rule.OldEnding, exception)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	isStable := "0"
+	if scheme.SchemeDetails.IsStable {
+		isStable = "1"
+	}
+
+	endOfWordLookup := "0"
+	if scheme.SchemeDetails.EndOfWordLookup {
+		endOfWordLookup = "1"
+	}
+
+	metadata := map[string]string{
+		VARNAM_METADATA_SCHEME_LANGUAGE_CODE: scheme.SchemeDetails.LangCode,
+		VARNAM_METADATA_SCHEME_IDENTIFIER:    scheme.SchemeDetails.Identifier,
+		VARNAM_METADATA_SCHEME_DISPLAY_NAME:  scheme.SchemeDetails.DisplayName,
+		VARNAM_METADATA_SCHEME_AUTHOR:        scheme.SchemeDetails.Author,
+		VARNAM_METADATA_SCHEME_COMPILED_DATE: scheme.SchemeDetails.CompiledDate,
+		VARNAM_METADATA_SCHEME_STABLE:        isStable,
+
+		VARNAM_METADATA_SCHEME_NATIVE_DISPLAY_NAME: scheme.SchemeDetails.NativeDisplayName,
+		VARNAM_METADATA_SCHEME_VERSION:             scheme.SchemeDetails.Version,
+		VARNAM_METADATA_SCHEME_MIN_LIBRARY_VERSION: scheme.SchemeDetails.MinLibraryVersion,
+		VARNAM_METADATA_SCHEME_BASE:                scheme.SchemeDetails.Base,
+
+		VARNAM_METADATA_SCHEME_END_OF_WORD_LOOKUP: endOfWordLookup,
+	}
+
+	for key, value := range metadata {
+		_, err = tx.Exec("INSERT OR REPLACE INTO metadata (key, value) VALUES (?, ?)", key, value)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// InitFromBytes Initialize varnam from the contents of a VST file,
+// Eg: a VST embedded with go:embed. If seedDict is not nil and there's
+// no dictionary at dictPath, seedDict is put there as the dictionary
+func InitFromBytes(vst []byte, dictPath string, seedDict []byte) (*Varnam, error) {
+	varnam := Varnam{}
+
+	err := varnam.InitVSTFromBytes(vst)
+	if err != nil {
+		return nil, err
+	}
+
+	if seedDict != nil && !fileExists(dictPath) {
+		err = os.MkdirAll(path.Dir(dictPath), 0750)
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.WriteFile(dictPath, seedDict, 0640)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = varnam.InitDict(dictPath)
+	if err != nil {
+		return nil, err
+	}
+
+	varnam.setDefaultConfig()
+
+	return &varnam, nil
+}
+
+// InitFromFS Initialize varnam from VST at vstPath in fsys, Eg: an
+// embed.FS. seedDictPath is the dictionary in fsys to start with if
+// there's none at dictPath. Leave it empty to start with an empty one
+func InitFromFS(fsys fs.FS, vstPath string, dictPath string, seedDictPath string) (*Varnam, error) {
+	vst, err := fs.ReadFile(fsys, vstPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var seedDict []byte
+	if seedDictPath != "" {
+		seedDict, err = fs.ReadFile(fsys, seedDictPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return InitFromBytes(vst, dictPath, seedDict)
+}
+
+// InitVSTFromBytes load contents of a VST file into an in-memory VST.
+// SQLite can only open files, so it's written to a temporary file first
+func (varnam *Varnam) InitVSTFromBytes(vst []byte) error {
+	file, err := os.CreateTemp("", "varnam-*.vst")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(vst)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	source := Varnam{}
+	err = source.InitVST(file.Name())
+	if err != nil {
+		return err
+	}
+	defer source.closeVST()
+
+	symbols, err := source.GetAllSymbols()
+	if err != nil {
+		return err
+	}
+
+	rules, err := source.GetAllStemRules()
+	if err != nil {
+		return err
+	}
+
+	return varnam.InitEmbeddedVST(EmbeddedScheme{source.SchemeDetails, symbols, rules})
+}
+
+// GetAllSymbols get every symbol in VST, ordered by ID
+func (varnam *Varnam) GetAllSymbols() ([]Symbol, error) {
+	if varnam.mappedVST != nil {
+		return varnam.mappedVST.allSymbols(), nil
+	}
+
+	rows, err := varnam.vstConn.Query("SELECT id, type, pattern, value1, IFNULL(value2, ''), IFNULL(value3, ''), IFNULL(tag, ''), match_type, IFNULL(priority, 0), IFNULL(accept_condition, 0), IFNULL(flags, 0), IFNULL(weight, 0) FROM symbols ORDER BY id ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []Symbol
+
+	for rows.Next() {
+		var item Symbol
+		err := rows.Scan(&item.Identifier, &item.Type, &item.Pattern, &item.Value1, &item.Value2, &item.Value3, &item.Tag, &item.MatchType, &item.Priority, &item.AcceptCondition, &item.Flags, &item.Weight)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+
+	return results, rows.Err()
+}
+
+// GetAllStemRules get every stem rule in VST, in the order they're tried
+func (varnam *Varnam) GetAllStemRules() ([]StemRule, error) {
+	rules, err := varnam.getStemRules()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []StemRule
+
+	for _, rule := range rules.rules {
+		results = append(results, StemRule{rule.oldEnding, rule.newEnding, rules.exceptions[rule.oldEnding]})
+	}
+
+	return results, nil
+}
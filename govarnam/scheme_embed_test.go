@@ -0,0 +1,122 @@
+package govarnam
+
+import (
+	"os"
+	"path"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEmbeddedScheme(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	symbols, err := varnam.GetAllSymbols()
+	checkError(err)
+
+	scheme := EmbeddedScheme{varnam.SchemeDetails, symbols, nil}
+
+	embedded, err := InitEmbedded(scheme, path.Join(testTempDir, "embedded.vst.learnings"))
+	checkError(err)
+	defer embedded.Close()
+
+	assertEqual(t, embedded.SchemeDetails, varnam.SchemeDetails)
+	assertEqual(t, embedded.LangRules.PatternLongestLength, varnam.LangRules.PatternLongestLength)
+	assertEqual(t, embedded.LangRules.Virama, varnam.LangRules.Virama)
+
+	for _, word := range []string{"namaskaaram", "malayalam", "thuthuru"} {
+		assertEqual(
+			t,
+			embedded.TransliterateGreedyTokenized(word)[0].Word,
+			varnam.TransliterateGreedyTokenized(word)[0].Word,
+		)
+	}
+
+	_, err = InitEmbedded(EmbeddedScheme{}, path.Join(testTempDir, "embedded.vst.learnings"))
+	assertEqual(t, err != nil, true)
+}
+
+func TestEmbeddedSchemeStemRules(t *testing.T) {
+	vst, err := os.ReadFile(getVarnamInstance("ml").VSTPath)
+	checkError(err)
+
+	vstPath := path.Join(testTempDir, "embedded-stem-ml.vst")
+	checkError(os.WriteFile(vstPath, vst, 0644))
+
+	vm, err := VMInit(vstPath)
+	checkError(err)
+	checkError(vm.VMCreateStemRule("ത്തിൽ", "ം"))
+	checkError(vm.VMCreateStemRule("ിൽ", "്"))
+	checkError(vm.VMCreateStemException("ിൽ", "ത്തിൽ"))
+	vm.Close()
+
+	vst, err = os.ReadFile(vstPath)
+	checkError(err)
+
+	fromBytes, err := InitFromBytes(vst, path.Join(testTempDir, "embedded-stem-bytes.vst.learnings"), nil)
+	checkError(err)
+	defer fromBytes.Close()
+
+	symbols, err := fromBytes.GetAllSymbols()
+	checkError(err)
+	rules, err := fromBytes.GetAllStemRules()
+	checkError(err)
+	assertEqual(t, len(rules), 2)
+
+	embedded, err := InitEmbedded(EmbeddedScheme{fromBytes.SchemeDetails, symbols, rules}, path.Join(testTempDir, "embedded-stem.vst.learnings"))
+	checkError(err)
+	defer embedded.Close()
+
+	for _, word := range []string{"മലയാളത്തിൽ", "വീട്ടിൽ"} {
+		want, err := fromBytes.Stems(word)
+		checkError(err)
+		got, err := embedded.Stems(word)
+		checkError(err)
+
+		assertEqual(t, len(want), 1)
+		assertEqual(t, len(got), 1)
+		assertEqual(t, got[0], want[0])
+	}
+}
+
+func TestInitFromFS(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	vst, err := os.ReadFile(varnam.VSTPath)
+	checkError(err)
+
+	// A dictionary to ship with the binary
+	seedPath := path.Join(testTempDir, "seed.vst.learnings")
+	seed, err := InitFromBytes(vst, seedPath, nil)
+	checkError(err)
+	checkError(seed.Learn("മലയാളം", 0))
+	seed.Close()
+
+	seedDict, err := os.ReadFile(seedPath)
+	checkError(err)
+
+	fsys := fstest.MapFS{
+		"schemes/ml.vst":           {Data: vst},
+		"schemes/ml.vst.learnings": {Data: seedDict},
+	}
+
+	dictPath := path.Join(testTempDir, "fs", "ml.vst.learnings")
+	embedded, err := InitFromFS(fsys, "schemes/ml.vst", dictPath, "schemes/ml.vst.learnings")
+	checkError(err)
+
+	assertEqual(t, embedded.VSTPath, "")
+	assertEqual(t, embedded.SchemeDetails, varnam.SchemeDetails)
+	assertEqual(t, embedded.TransliterateGreedyTokenized("namaskaaram")[0].Word, varnam.TransliterateGreedyTokenized("namaskaaram")[0].Word)
+	assertEqual(t, embedded.TransliterateAdvanced("malayalam").ExactWords[0].Word, "മലയാളം")
+
+	// Seed is used only when there's no dictionary
+	checkError(embedded.Unlearn("മലയാളം"))
+	embedded.Close()
+
+	embedded, err = InitFromFS(fsys, "schemes/ml.vst", dictPath, "schemes/ml.vst.learnings")
+	checkError(err)
+	defer embedded.Close()
+	assertEqual(t, len(embedded.TransliterateAdvanced("malayalam").ExactWords), 0)
+
+	_, err = InitFromFS(fsys, "schemes/missing.vst", dictPath, "")
+	assertEqual(t, err != nil, true)
+}
@@ -0,0 +1,186 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SchemeIssue a problem found in scheme by ValidateScheme
+type SchemeIssue struct {
+	// VARNAM_ISSUE_ERROR or VARNAM_ISSUE_WARNING
+	Severity int
+
+	// One of VARNAM_ISSUE_*
+	Kind int
+
+	// Symbol having the problem
+	Symbol Symbol
+
+	Message string
+}
+
+func (issue SchemeIssue) String() string {
+	severity := "error"
+	if issue.Severity == VARNAM_ISSUE_WARNING {
+		severity = "warning"
+	}
+	return fmt.Sprintf("%s: symbol %d %q => %q: %s", severity, issue.Symbol.Identifier, issue.Symbol.Pattern, issue.Symbol.Value1, issue.Message)
+}
+
+// ValidateScheme check the scheme for duplicate patterns,
+// unreachable symbols, weight inconsistencies & invalid unicode
+// sequences in values. Issues are ordered by symbol. A scheme
+// having issues of VARNAM_ISSUE_ERROR shouldn't be accepted
+func (varnam *Varnam) ValidateScheme() ([]SchemeIssue, error) {
+	symbols, err := varnam.GetAllSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	// Scheme might not have one
+	virama, _ := varnam.getVirama()
+
+	var issues []SchemeIssue
+
+	add := func(severity int, kind int, symbol Symbol, format string, a ...interface{}) {
+		issues = append(issues, SchemeIssue{severity, kind, symbol, fmt.Sprintf(format, a...)})
+	}
+
+	byPattern := map[string][]Symbol{}
+	for _, symbol := range symbols {
+		byPattern[symbol.Pattern] = append(byPattern[symbol.Pattern], symbol)
+	}
+
+	for _, symbol := range symbols {
+		if symbol.Pattern == "" {
+			add(VARNAM_ISSUE_ERROR, VARNAM_ISSUE_UNREACHABLE, symbol, "pattern is empty")
+		} else if strings.IndexFunc(symbol.Pattern, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) != -1 {
+			// Input is split into words at spaces before tokenizing
+			add(VARNAM_ISSUE_ERROR, VARNAM_ISSUE_UNREACHABLE, symbol, "pattern has space or control characters, it can't be typed")
+		}
+
+		if symbol.MatchType != VARNAM_MATCH_EXACT && symbol.MatchType != VARNAM_MATCH_POSSIBILITY {
+			add(VARNAM_ISSUE_ERROR, VARNAM_ISSUE_UNREACHABLE, symbol, "match type %d is not VARNAM_MATCH_EXACT or VARNAM_MATCH_POSSIBILITY", symbol.MatchType)
+		}
+
+		if symbol.AcceptCondition < VARNAM_TOKEN_ACCEPT_ALL || symbol.AcceptCondition > VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH {
+			add(VARNAM_ISSUE_ERROR, VARNAM_ISSUE_UNREACHABLE, symbol, "accept condition %d is not one of VARNAM_TOKEN_ACCEPT_*", symbol.AcceptCondition)
+		}
+
+		if symbol.Weight < 0 {
+			add(VARNAM_ISSUE_ERROR, VARNAM_ISSUE_WEIGHT, symbol, "weight %d is negative", symbol.Weight)
+		}
+
+		for i, value := range []string{symbol.Value1, symbol.Value2, symbol.Value3} {
+			if message := checkUnicodeSequence(value, virama); message != "" {
+				add(VARNAM_ISSUE_ERROR, VARNAM_ISSUE_INVALID_UNICODE, symbol, "value%d %s", i+1, message)
+			}
+		}
+
+		if symbol.Value1 == "" {
+			add(VARNAM_ISSUE_ERROR, VARNAM_ISSUE_INVALID_UNICODE, symbol, "value1 is empty")
+		} else if r, _ := utf8.DecodeRuneInString(symbol.Value1); isCombiningMark(r) && !symbolCanBeSign(symbol.Type) {
+			add(VARNAM_ISSUE_WARNING, VARNAM_ISSUE_INVALID_UNICODE, symbol, "value1 starts with a combining mark U+%04X", r)
+		}
+
+		// Value2 of inherent vowel is itself
+		if symbol.Type == VARNAM_SYMBOL_VOWEL && symbol.Value2 != "" && symbol.Value2 != symbol.Value1 {
+			if r, _ := utf8.DecodeRuneInString(symbol.Value2); !isCombiningMark(r) {
+				add(VARNAM_ISSUE_WARNING, VARNAM_ISSUE_INVALID_UNICODE, symbol, "vowel sign %q is not a combining mark", symbol.Value2)
+			}
+		}
+
+		for _, other := range byPattern[symbol.Pattern] {
+			if other.Identifier == symbol.Identifier || !acceptConditionsOverlap(symbol.AcceptCondition, other.AcceptCondition) {
+				continue
+			}
+
+			// Eg: "n" => "ൻ" at end & "n" => "ന്" elsewhere is fine
+			if other.AcceptCondition == symbol.AcceptCondition && other.Identifier < symbol.Identifier {
+				if other.MatchType == symbol.MatchType && other.Value1 == symbol.Value1 && other.Value2 == symbol.Value2 {
+					add(VARNAM_ISSUE_ERROR, VARNAM_ISSUE_DUPLICATE_PATTERN, symbol, "same as symbol %d", other.Identifier)
+					break
+				}
+
+				if other.MatchType == VARNAM_MATCH_EXACT && symbol.MatchType == VARNAM_MATCH_EXACT && other.Value1 != symbol.Value1 {
+					add(VARNAM_ISSUE_WARNING, VARNAM_ISSUE_DUPLICATE_PATTERN, symbol, "symbol %d is also an exact match of the pattern, %q", other.Identifier, other.Value1)
+				}
+			}
+
+			if other.MatchType == VARNAM_MATCH_EXACT && symbol.MatchType == VARNAM_MATCH_POSSIBILITY && symbol.Weight > other.Weight {
+				add(VARNAM_ISSUE_WARNING, VARNAM_ISSUE_WEIGHT, symbol, "possibility match has more weight (%d) than exact match, symbol %d (%d)", symbol.Weight, other.Identifier, other.Weight)
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Symbol.Identifier < issues[j].Symbol.Identifier
+	})
+
+	return issues, nil
+}
+
+// Whether both conditions can be true for a token
+func acceptConditionsOverlap(a int, b int) bool {
+	return a == VARNAM_TOKEN_ACCEPT_ALL || b == VARNAM_TOKEN_ACCEPT_ALL || a == b
+}
+
+func isCombiningMark(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Mc)
+}
+
+// Symbols whose value is a sign added to the letter before
+func symbolCanBeSign(symbolType int) bool {
+	switch symbolType {
+	case VARNAM_SYMBOL_VIRAMA, VARNAM_SYMBOL_ANUSVARA, VARNAM_SYMBOL_VISARGA, VARNAM_SYMBOL_OTHER:
+		return true
+	}
+	return false
+}
+
+// Problem with value as a unicode string. Empty if none
+func checkUnicodeSequence(value string, virama string) string {
+	if !utf8.ValidString(value) {
+		return "is not valid UTF-8"
+	}
+
+	var prev rune
+	for _, r := range value {
+		if r == utf8.RuneError {
+			return "has replacement character U+FFFD"
+		}
+
+		if unicode.IsControl(r) {
+			return fmt.Sprintf("has control character U+%04X", r)
+		}
+
+		if !unicode.IsPrint(r) && r != '\u200c' && r != '\u200d' {
+			return fmt.Sprintf("has non printable character U+%04X", r)
+		}
+
+		if isCombiningMark(r) {
+			// Eg: ാാ
+			if r == prev {
+				return fmt.Sprintf("has U+%04X twice", r)
+			}
+
+			// Eg: ്ാ
+			if virama != "" && string(prev) == virama {
+				return fmt.Sprintf("has combining mark U+%04X after virama", r)
+			}
+		}
+
+		prev = r
+	}
+
+	return ""
+}
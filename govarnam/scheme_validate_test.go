@@ -0,0 +1,53 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestValidateScheme(t *testing.T) {
+	issues, err := getVarnamInstance("ml").ValidateScheme()
+	checkError(err)
+	assertEqual(t, len(issues), 0)
+
+	symbol := func(id int, pattern string, value1 string, matchType int, weight int) Symbol {
+		return Symbol{Identifier: id, Type: VARNAM_SYMBOL_CONSONANT, Pattern: pattern, Value1: value1, MatchType: matchType, Weight: weight}
+	}
+
+	varnam := Varnam{}
+	checkError(varnam.InitEmbeddedVST(EmbeddedScheme{
+		SchemeDetails: SchemeDetails{Identifier: "invalid", LangCode: "ml"},
+		Symbols: []Symbol{
+			{Identifier: 1, Type: VARNAM_SYMBOL_VIRAMA, Pattern: "~", Value1: "്", MatchType: VARNAM_MATCH_EXACT},
+			symbol(2, "ka", "ക", VARNAM_MATCH_EXACT, 100),
+			symbol(3, "ka", "ക", VARNAM_MATCH_EXACT, 100),
+			symbol(4, "ka", "ഖ", VARNAM_MATCH_EXACT, 100),
+			symbol(5, "ka", "ഗ", VARNAM_MATCH_POSSIBILITY, 200),
+			symbol(6, "k a", "ക", VARNAM_MATCH_EXACT, 100),
+			symbol(7, "kaa", "ക്ാ", VARNAM_MATCH_EXACT, 100),
+			symbol(8, "kaaa", "കാാ", VARNAM_MATCH_EXACT, -1),
+			symbol(9, "ki", "ി", VARNAM_MATCH_EXACT, 100),
+			symbol(10, "kha", "ഖ", 5, 100),
+		},
+	}))
+	defer varnam.Close()
+
+	issues, err = varnam.ValidateScheme()
+	checkError(err)
+
+	kinds := map[int][]int{}
+	for _, issue := range issues {
+		kinds[issue.Symbol.Identifier] = append(kinds[issue.Symbol.Identifier], issue.Kind)
+	}
+
+	assertEqual(t, len(kinds[1]), 0)
+	assertEqual(t, len(kinds[2]), 0)
+	assertEqual(t, kinds[3][0], VARNAM_ISSUE_DUPLICATE_PATTERN)
+	assertEqual(t, issues[0].Severity, VARNAM_ISSUE_ERROR)
+	assertEqual(t, kinds[4][0], VARNAM_ISSUE_DUPLICATE_PATTERN)
+	assertEqual(t, kinds[5][0], VARNAM_ISSUE_WEIGHT)
+	assertEqual(t, kinds[6][0], VARNAM_ISSUE_UNREACHABLE)
+	assertEqual(t, kinds[7][0], VARNAM_ISSUE_INVALID_UNICODE)
+	assertEqual(t, len(kinds[8]), 2)
+	assertEqual(t, kinds[9][0], VARNAM_ISSUE_INVALID_UNICODE)
+	assertEqual(t, kinds[10][0], VARNAM_ISSUE_UNREACHABLE)
+}
@@ -0,0 +1,154 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"fmt"
+	"path"
+)
+
+// A variant scheme has only the symbols it changes from its base
+// scheme. Eg: a "Malayalam classic" scheme differing from "ml" in
+// a few mappings. When the variant is loaded, its symbols are put
+// over the symbols of the base into an in-memory VST. Symbols of
+// the variant replace all symbols of the base for the same pattern.
+
+// Find VST of base scheme. A base next to the variant is preferred
+func (varnam *Varnam) findBaseVSTPath(base string) (string, error) {
+	if varnam.VSTPath != "" {
+		basePath := path.Join(path.Dir(varnam.VSTPath), base+".vst")
+		if fileExists(basePath) && basePath != varnam.VSTPath {
+			return basePath, nil
+		}
+	}
+
+	return findVSTPath(base)
+}
+
+// Load base scheme and put the symbols of this variant over it.
+// bases are the schemes being loaded, to find loops
+func (varnam *Varnam) resolveBaseScheme(bases map[string]bool) error {
+	base := varnam.SchemeDetails.Base
+
+	if bases[base] {
+		return fmt.Errorf("Scheme %s is a base of itself", base)
+	}
+	bases[varnam.SchemeDetails.Identifier] = true
+
+	basePath, err := varnam.findBaseVSTPath(base)
+	if err != nil {
+		return err
+	}
+
+	baseVarnam := Varnam{}
+	err = baseVarnam.initVST(basePath, bases)
+	if err != nil {
+		return fmt.Errorf("Couldn't load base scheme %s: %s", base, err.Error())
+	}
+	defer baseVarnam.closeVST()
+
+	if baseVarnam.SchemeDetails.LangCode != varnam.SchemeDetails.LangCode {
+		return fmt.Errorf("Base scheme %s is of language %s, not %s", base, baseVarnam.SchemeDetails.LangCode, varnam.SchemeDetails.LangCode)
+	}
+
+	baseSymbols, err := baseVarnam.GetAllSymbols()
+	if err != nil {
+		return err
+	}
+
+	variantSymbols, err := varnam.GetAllSymbols()
+	if err != nil {
+		return err
+	}
+
+	baseStemRules, err := baseVarnam.getStemRules()
+	if err != nil {
+		return err
+	}
+
+	variantStemRules, err := varnam.getStemRules()
+	if err != nil {
+		return err
+	}
+
+	variantPatterns := map[string]bool{}
+	for _, symbol := range variantSymbols {
+		variantPatterns[symbol.Pattern] = true
+	}
+
+	var symbols []Symbol
+	for _, symbol := range baseSymbols {
+		if !variantPatterns[symbol.Pattern] {
+			symbols = append(symbols, symbol)
+		}
+	}
+	symbols = append(symbols, variantSymbols...)
+
+	for i := range symbols {
+		symbols[i].Identifier = i + 1
+	}
+
+	details := varnam.SchemeDetails
+
+	err = varnam.vstConn.Close()
+	if err != nil {
+		return err
+	}
+
+	varnam.vstConn, err = openDB("file::memory:?_case_sensitive_like=on")
+	if err != nil {
+		return err
+	}
+
+	// Every connection to :memory: is a new empty database.
+	// Stick to one connection so that the symbols stay.
+	varnam.vstConn.SetMaxOpenConns(1)
+	varnam.vstConn.SetConnMaxLifetime(0)
+
+	err = varnam.vmEnsureSchemaExists()
+	if err != nil {
+		return err
+	}
+
+	err = varnam.vmLoadEmbeddedScheme(EmbeddedScheme{SchemeDetails: details, Symbols: symbols})
+	if err != nil {
+		return err
+	}
+
+	return varnam.vmLoadStemRules(baseStemRules, variantStemRules)
+}
+
+// Stem rules of base and variant. A rule of variant replaces
+// the rule of base having the same ending, with its exceptions
+func (varnam *Varnam) vmLoadStemRules(base *stemRules, variant *stemRules) error {
+	overridden := map[string]bool{}
+	for _, rule := range variant.rules {
+		overridden[rule.oldEnding] = true
+	}
+
+	for _, rules := range []*stemRules{base, variant} {
+		for _, rule := range rules.rules {
+			if rules == base && overridden[rule.oldEnding] {
+				continue
+			}
+
+			_, err := varnam.vstConn.Exec("INSERT INTO stemrules (old_ending, new_ending) VALUES (?, ?)", rule.oldEnding, rule.newEnding)
+			if err != nil {
+				return err
+			}
+
+			for _, exception := range rules.exceptions[rule.oldEnding] {
+				_, err = varnam.vstConn.Exec("INSERT INTO stem_exceptions (stem, exception) VALUES (?, ?)", rule.oldEnding, exception)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
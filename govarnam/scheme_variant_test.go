@@ -0,0 +1,88 @@
+package govarnam
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func makeVariantVST(vstPath string, id string, base string) *Varnam {
+	os.Remove(vstPath)
+
+	vm, err := VMInit(vstPath)
+	checkError(err)
+
+	vm.VSTMakerConfig.UseDeadConsonants = false
+
+	checkError(vm.VMSetSchemeDetails(SchemeDetails{
+		Identifier:  id,
+		LangCode:    "ml",
+		DisplayName: "Malayalam Classic",
+		Base:        base,
+	}))
+
+	return vm
+}
+
+func TestSchemeVariant(t *testing.T) {
+	vstPath := path.Join(testTempDir, "ml-classic.vst")
+
+	vm := makeVariantVST(vstPath, "ml-classic", "ml")
+	checkError(vm.VMCreateToken("zha", "ള", "", "", "", VARNAM_SYMBOL_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false))
+	checkError(vm.VMCreateToken("zh", "ള്", "", "", "", VARNAM_SYMBOL_DEAD_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false))
+	checkError(vm.VMCreateStemRule("ത്തിൽ", "ം"))
+	vm.Close()
+
+	varnam, err := Init(vstPath, path.Join(testTempDir, "ml-classic.vst.learnings"))
+	checkError(err)
+	defer varnam.Close()
+
+	assertEqual(t, varnam.SchemeDetails.Identifier, "ml-classic")
+	assertEqual(t, varnam.SchemeDetails.Base, "ml")
+	assertEqual(t, varnam.VSTPath, vstPath)
+
+	// Symbols of variant replace those of base
+	assertEqual(t, varnam.TransliterateGreedyTokenized("pazham")[0].Word, "പളം")
+
+	// Rest are from base
+	assertEqual(t, varnam.TransliterateGreedyTokenized("mala")[0].Word, "മല")
+
+	stems, err := varnam.Stems("മലയാളത്തിൽ")
+	checkError(err)
+	assertEqual(t, len(stems), 1)
+	assertEqual(t, stems[0], "മലയാളം")
+
+	base := getVarnamInstance("ml")
+	baseSymbols, err := base.GetAllSymbols()
+	checkError(err)
+	symbols, err := varnam.GetAllSymbols()
+	checkError(err)
+	assertEqual(t, len(symbols) > len(baseSymbols)/2, true)
+
+	// Base is left as is
+	assertEqual(t, base.TransliterateGreedyTokenized("pazham")[0].Word, "പഴം")
+}
+
+func TestSchemeVariantErrors(t *testing.T) {
+	aPath := path.Join(testTempDir, "variant-a.vst")
+	bPath := path.Join(testTempDir, "variant-b.vst")
+
+	// Base of each other
+	vm := makeVariantVST(aPath, "variant-a", "variant-b")
+	checkError(vm.VMCreateToken("a", "അ", "", "", "", VARNAM_SYMBOL_VOWEL, VARNAM_MATCH_EXACT, 0, 0, false))
+	vm.Close()
+
+	vm = makeVariantVST(bPath, "variant-b", "variant-a")
+	checkError(vm.VMCreateToken("b", "ബ്", "", "", "", VARNAM_SYMBOL_DEAD_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false))
+	vm.Close()
+
+	varnam := Varnam{}
+	assertEqual(t, varnam.InitVST(aPath) != nil, true)
+
+	vm = makeVariantVST(aPath, "variant-a", "variant-missing")
+	checkError(vm.VMCreateToken("a", "അ", "", "", "", VARNAM_SYMBOL_VOWEL, VARNAM_MATCH_EXACT, 0, 0, false))
+	vm.Close()
+
+	varnam = Varnam{}
+	assertEqual(t, varnam.InitVST(aPath) != nil, true)
+}
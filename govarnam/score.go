@@ -0,0 +1,83 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+// Scores are in 0 to 1. They're meant to be compared across
+// categories, unlike weights which are in different units :
+//
+//   - Dictionary words score 0.5 to 1. A word that's not learnt
+//     by the user (from a corpus) is 0.5. Learnt words go up
+//     towards 1 as they're learnt more times
+//   - Tokenizer made words score up to 0.5, relative to the
+//     best tokenizer made word for the same input
+
+// Learnt words reach 0.75 when learnt this many times
+const dictionaryScoreHalfLife = 4
+
+func dictionaryScore(sug Suggestion) float64 {
+	if sug.LearnedOn == 0 {
+		return 0.5
+	}
+
+	// Weight starts at VARNAM_LEARNT_WORD_MIN_WEIGHT and goes up by one
+	// on every learn. Some suggestions have a bit more added when they
+	// are made from a partial match, that's counted as learnt more
+	timesLearnt := float64(sug.Weight - VARNAM_LEARNT_WORD_MIN_WEIGHT + 1)
+	if timesLearnt < 1 {
+		timesLearnt = 1
+	}
+
+	return 0.5 + 0.5*timesLearnt/(timesLearnt+dictionaryScoreHalfLife)
+}
+
+func scoreDictionarySuggestions(sugs []Suggestion) []Suggestion {
+	for i := range sugs {
+		sugs[i].Score = dictionaryScore(sugs[i])
+	}
+	return sugs
+}
+
+func scoreTokenizerSuggestions(sugs []Suggestion) []Suggestion {
+	maxWeight := 0
+	for _, sug := range sugs {
+		if sug.Weight > maxWeight {
+			maxWeight = sug.Weight
+		}
+	}
+
+	for i := range sugs {
+		if maxWeight == 0 {
+			sugs[i].Score = 0.5
+		} else {
+			sugs[i].Score = 0.5 * float64(sugs[i].Weight) / float64(maxWeight)
+		}
+	}
+	return sugs
+}
+
+// Drop tokenizer suggestions whose weight is less than
+// threshold times the weight of the best one
+func removeLowWeightedSuggestions(sugs []Suggestion, threshold float64) []Suggestion {
+	if threshold <= 0 {
+		return sugs
+	}
+
+	maxWeight := 0
+	for _, sug := range sugs {
+		if sug.Weight > maxWeight {
+			maxWeight = sug.Weight
+		}
+	}
+
+	var results []Suggestion
+	for _, sug := range sugs {
+		if float64(sug.Weight) >= threshold*float64(maxWeight) {
+			results = append(results, sug)
+		}
+	}
+	return results
+}
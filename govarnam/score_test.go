@@ -0,0 +1,32 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestMLSuggestionScore(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.Learn("മലയാളം", 0))
+	defer varnam.Unlearn("മലയാളം")
+
+	result := varnam.TransliterateAdvanced("malayalam")
+
+	learnt := result.ExactWords[0]
+	assertEqual(t, learnt.Word, "മലയാളം")
+	assertEqual(t, learnt.Score > 0.5 && learnt.Score < 1, true)
+
+	// Best tokenizer word gets the max tokenizer score
+	assertEqual(t, result.TokenizerSuggestions[0].Score, 0.5)
+
+	for _, sug := range result.Ranked() {
+		assertEqual(t, sug.Score >= 0 && sug.Score <= 1, true)
+	}
+
+	// Learning more increases score
+	checkError(varnam.Learn("മലയാളം", 0))
+	assertEqual(t, varnam.TransliterateAdvanced("malayalam").ExactWords[0].Score > learnt.Score, true)
+
+	// Corpus words
+	assertEqual(t, dictionaryScore(Suggestion{"മലയാളം", VARNAM_LEARNT_WORD_MIN_WEIGHT, 0, 0}), 0.5)
+}
@@ -0,0 +1,115 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"unicode"
+)
+
+// ScriptSegment a run of characters in the input that are
+// either all in the language's script or all not
+type ScriptSegment struct {
+	Text string
+
+	// Position of the first character (rune) in input
+	Position int
+
+	// Whether Text is in the language's native script.
+	// Such segments are kept as is while transliterating
+	Native bool
+}
+
+// Unicode script of each language
+var languageScripts = map[string]*unicode.RangeTable{
+	"as": unicode.Bengali,
+	"bn": unicode.Bengali,
+	"gu": unicode.Gujarati,
+	"hi": unicode.Devanagari,
+	"kn": unicode.Kannada,
+	"ml": unicode.Malayalam,
+	"mr": unicode.Devanagari,
+	"ne": unicode.Devanagari,
+	"or": unicode.Oriya,
+	"pa": unicode.Gurmukhi,
+	"sa": unicode.Devanagari,
+	"ta": unicode.Tamil,
+	"te": unicode.Telugu,
+	"ur": unicode.Arabic,
+}
+
+// Whether char is in the language's script. Joiners and
+// combining marks of no script (like Vedic accents) belong
+// to the script of the character before them
+func (varnam *Varnam) isNativeChar(char rune, afterNative bool) bool {
+	if string(char) == ZWJ || string(char) == ZWNJ || unicode.Is(unicode.Inherited, char) {
+		return afterNative
+	}
+
+	script, ok := languageScripts[varnam.SchemeDetails.LangCode]
+	if !ok {
+		return false
+	}
+	return unicode.Is(script, char)
+}
+
+// SegmentByScript split input where it switches between the
+// language's script and anything else (Latin etc.).
+// Eg: "naമസ്കാരmenthuNt" => "na", "മസ്കാര", "menthuNt"
+func (varnam *Varnam) SegmentByScript(input string) []ScriptSegment {
+	var segments []ScriptSegment
+
+	runes := []rune(input)
+	start := 0
+	native := false
+
+	for i, char := range runes {
+		charNative := varnam.isNativeChar(char, native)
+
+		if i > start && charNative != native {
+			segments = append(segments, ScriptSegment{string(runes[start:i]), start, native})
+			start = i
+		}
+		native = charNative
+	}
+
+	if start < len(runes) {
+		segments = append(segments, ScriptSegment{string(runes[start:]), start, native})
+	}
+
+	return segments
+}
+
+// Whether input has both native script and other characters
+func isMixedScript(segments []ScriptSegment) bool {
+	return len(segments) > 1
+}
+
+// Tokenize each non-native segment as a word of its own.
+// Native segments are added as character tokens
+func (varnam *Varnam) tokenizeSegments(ctx context.Context, segments []ScriptSegment, matchType int, partial bool) *[]Token {
+	var results []Token
+
+	for i, segment := range segments {
+		if segment.Native {
+			for j, char := range []rune(segment.Text) {
+				results = append(results, Token{VARNAM_TOKEN_CHAR, []Symbol{}, segment.Position + j, string(char)})
+			}
+			continue
+		}
+
+		// Only the first segment can be the continuation of a word
+		tokens := varnam.tokenizeWord(ctx, segment.Text, matchType, partial && i == 0)
+
+		for _, token := range *tokens {
+			token.position += segment.Position
+			results = append(results, token)
+		}
+	}
+
+	return &results
+}
@@ -0,0 +1,47 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestMLSegmentByScript(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	segments := varnam.SegmentByScript("naമസ്കാരmenthuNt")
+	assertEqual(t, len(segments), 3)
+	assertEqual(t, segments[0], ScriptSegment{"na", 0, false})
+	assertEqual(t, segments[1], ScriptSegment{"മസ്കാര", 2, true})
+	assertEqual(t, segments[2], ScriptSegment{"menthuNt", 8, false})
+
+	// Joiner stays with the native character before it
+	segments = varnam.SegmentByScript("താഴ്‌vara")
+	assertEqual(t, segments[0].Text, "താഴ്‌")
+	assertEqual(t, segments[1].Position, 5)
+
+	assertEqual(t, len(varnam.SegmentByScript("malayalam")), 1)
+	assertEqual(t, len(varnam.SegmentByScript("")), 0)
+}
+
+func TestMLScriptBoundary(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	defer func() {
+		varnam.ScriptBoundary = VARNAM_SCRIPT_BOUNDARY_JOIN
+	}()
+
+	result := varnam.TransliterateAdvanced("naമസ്കാരmenthuNt")
+	assertEqual(t, len(result.Segments), 3)
+	assertEqual(t, result.Segments[1].Native, true)
+	assertEqual(t, len(varnam.TransliterateAdvanced("malayalam").Segments), 0)
+
+	// Latin after native text continues the word
+	assertEqual(t, varnam.TransliterateGreedyTokenized("മn")[0].Word, "മൻ")
+
+	varnam.ScriptBoundary = VARNAM_SCRIPT_BOUNDARY_SPLIT
+
+	// Each latin run is a word of its own
+	assertEqual(t, varnam.TransliterateGreedyTokenized("മn")[0].Word, "മ"+varnam.TransliterateGreedyTokenized("n")[0].Word)
+
+	greedy := varnam.TransliterateAdvanced("naമസ്കാരmenthuNt").GreedyTokenized[0].Word
+	assertEqual(t, greedy, varnam.TransliterateGreedyTokenized("na")[0].Word+"മസ്കാര"+varnam.TransliterateGreedyTokenized("menthuNt")[0].Word)
+}
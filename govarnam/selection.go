@@ -0,0 +1,88 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Selection how many times a word was chosen for a pattern
+type Selection struct {
+	Pattern      string
+	Word         string
+	Count        int
+	LastSelected int
+}
+
+// ReportSelected tell that the user chose word from the
+// suggestions shown for pattern. The word's confidence is
+// increased and the selection is counted
+func (varnam *Varnam) ReportSelected(pattern string, word string) error {
+	defer varnam.dictionaryChanged()
+
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return fmt.Errorf("Pattern can't be empty")
+	}
+
+	err := varnam.Learn(word, 0)
+	if err != nil {
+		return err
+	}
+
+	wordInfo, err := varnam.getWordInfo(varnam.learntForm(word))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFunc()
+
+	_, err = varnam.dictWriteConn.ExecContext(
+		ctx,
+		`INSERT INTO selections(pattern, word_id, count, last_selected) VALUES (?, ?, 1, strftime('%s', 'now'))
+		ON CONFLICT(pattern, word_id) DO UPDATE SET count = count + 1, last_selected = excluded.last_selected`,
+		pattern,
+		wordInfo.id,
+	)
+
+	return err
+}
+
+// GetSelections get words chosen for a pattern, most chosen first
+func (varnam *Varnam) GetSelections(ctx context.Context, pattern string) ([]Selection, error) {
+	var result []Selection
+
+	select {
+	case <-ctx.Done():
+		return result, nil
+	default:
+		rows, err := varnam.dictConn.QueryContext(
+			ctx,
+			`SELECT s.pattern, w.word, s.count, s.last_selected FROM selections s
+			LEFT JOIN words w ON w.id = s.word_id
+			WHERE s.pattern = ?
+			ORDER BY s.count DESC, s.last_selected DESC`,
+			pattern,
+		)
+		if err != nil {
+			return result, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item Selection
+			rows.Scan(&item.Pattern, &item.Word, &item.Count, &item.LastSelected)
+			result = append(result, item)
+		}
+
+		return result, rows.Err()
+	}
+}
@@ -0,0 +1,42 @@
+package govarnam
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMLReportSelected(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	checkError(varnam.ReportSelected("malappuram", "മലപ്പുറം"))
+
+	wordInfo, err := varnam.getWordInfo("മലപ്പുറം")
+	checkError(err)
+	assertEqual(t, wordInfo.weight, VARNAM_LEARNT_WORD_MIN_WEIGHT)
+
+	checkError(varnam.ReportSelected("Malappuram", "മലപ്പുറം"))
+	checkError(varnam.ReportSelected("malappuram", "മലപ്പുറം"))
+	checkError(varnam.ReportSelected("malappuram", "വയനാട്"))
+
+	wordInfo, err = varnam.getWordInfo("മലപ്പുറം")
+	checkError(err)
+	assertEqual(t, wordInfo.weight, VARNAM_LEARNT_WORD_MIN_WEIGHT+2)
+
+	selections, err := varnam.GetSelections(context.Background(), "malappuram")
+	checkError(err)
+	assertEqual(t, len(selections), 2)
+	assertEqual(t, selections[0].Word, "മലപ്പുറം")
+	assertEqual(t, selections[0].Count, 3)
+	assertEqual(t, selections[1].Word, "വയനാട്")
+	assertEqual(t, selections[1].Count, 1)
+
+	assertEqual(t, varnam.ReportSelected("", "മലപ്പുറം") != nil, true)
+
+	// Statistics go away with the word
+	checkError(varnam.Unlearn("മലപ്പുറം"))
+	checkError(varnam.Unlearn("വയനാട്"))
+
+	selections, err = varnam.GetSelections(context.Background(), "malappuram")
+	checkError(err)
+	assertEqual(t, len(selections), 0)
+}
@@ -0,0 +1,153 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// Characters that end a word in a sentence. These are kept as is
+const sentencePunctuation = ".,;:!?\"()[]{}"
+
+type sentencePart struct {
+	text   string
+	isWord bool
+}
+
+// Split a sentence into words and the whitespace & punctuation
+// between them. Joining the parts gives back the sentence.
+func splitSentence(sentence string) []sentencePart {
+	return splitWords(sentence, sentencePunctuation)
+}
+
+// splitSentence with the characters in punctuation ending words
+func splitWords(sentence string, punctuation string) []sentencePart {
+	var parts []sentencePart
+
+	var current strings.Builder
+	currentIsWord := false
+
+	for _, char := range sentence {
+		isWord := !unicode.IsSpace(char) && !strings.ContainsRune(punctuation, char)
+
+		if current.Len() > 0 && isWord != currentIsWord {
+			parts = append(parts, sentencePart{current.String(), currentIsWord})
+			current.Reset()
+		}
+
+		current.WriteRune(char)
+		currentIsWord = isWord
+	}
+
+	if current.Len() > 0 {
+		parts = append(parts, sentencePart{current.String(), currentIsWord})
+	}
+
+	return parts
+}
+
+// TransliterateSentence transliterate every word in a sentence and
+// join them back with the top suggestion of each word. Whitespace and
+// punctuation are kept as is. Words without any suggestion
+// (non-language words) are also kept as is.
+func (varnam *Varnam) TransliterateSentence(ctx context.Context, sentence string) (string, error) {
+	output, _, err := varnam.TransliterateDocument(ctx, sentence)
+	return output, err
+}
+
+// ConvertedWord a word of a document and what it was converted to
+type ConvertedWord struct {
+	Input  string
+	Output string
+
+	// Line the word is in, starting from 1
+	Line int
+
+	// Whether Output is a word in dictionary for Input, rather than
+	// a guess of the tokenizer. Words without any latin letters
+	// (numbers, words already in native script) are confident too
+	Confident bool
+
+	// All suggestions, for picking another one
+	Suggestions []Suggestion
+}
+
+// Whether text has any letter that could be transliterated
+func hasLatinLetter(text string) bool {
+	for _, char := range text {
+		if char < unicode.MaxASCII && unicode.IsLetter(char) {
+			return true
+		}
+	}
+	return false
+}
+
+// TransliterateDocument transliterate text of a document like
+// TransliterateSentence, and also give each word with whether a
+// confident match was found for it, to report the ones that need to
+// be checked.
+func (varnam *Varnam) TransliterateDocument(ctx context.Context, text string) (string, []ConvertedWord, error) {
+	return varnam.convertParts(ctx, splitSentence(text))
+}
+
+// Transliterate word parts & join all parts back
+func (varnam *Varnam) convertParts(ctx context.Context, parts []sentencePart) (string, []ConvertedWord, error) {
+	var (
+		words     []string
+		converted []ConvertedWord
+	)
+
+	line := 1
+	for _, part := range parts {
+		if part.isWord {
+			words = append(words, part.text)
+			converted = append(converted, ConvertedWord{Input: part.text, Output: part.text, Line: line})
+		} else {
+			line += strings.Count(part.text, "\n")
+		}
+	}
+
+	varnam.transliterateBatch(ctx, words, func(i int, sugs []Suggestion, result TransliterationResult) {
+		word := &converted[i]
+		word.Suggestions = sugs
+
+		if !hasLatinLetter(word.Input) {
+			word.Confident = true
+		}
+
+		if len(sugs) == 0 {
+			return
+		}
+		word.Output = sugs[0].Word
+
+		for _, exact := range result.ExactWords {
+			if exact.Word == word.Output {
+				word.Confident = true
+				break
+			}
+		}
+	})
+	if ctx.Err() != nil {
+		return "", nil, ctx.Err()
+	}
+
+	var output strings.Builder
+	i := 0
+
+	for _, part := range parts {
+		if part.isWord {
+			output.WriteString(converted[i].Output)
+			i++
+		} else {
+			output.WriteString(part.text)
+		}
+	}
+
+	return output.String(), converted, nil
+}
@@ -0,0 +1,135 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+type symbolCacheKey struct {
+	pattern         string
+	matchType       int
+	acceptCondition int
+	caseInsensitive bool
+	noGrantha       bool
+}
+
+// VST lookups made for a session. VST doesn't change,
+// so these are valid for as long as the session lives
+type symbolCache struct {
+	mutex   sync.RWMutex
+	symbols map[symbolCacheKey][]Symbol
+}
+
+type symbolCacheContextKey struct{}
+
+func newSymbolCache() *symbolCache {
+	return &symbolCache{symbols: make(map[symbolCacheKey][]Symbol)}
+}
+
+func getSymbolCache(ctx context.Context) *symbolCache {
+	cache, _ := ctx.Value(symbolCacheContextKey{}).(*symbolCache)
+	return cache
+}
+
+func (cache *symbolCache) get(key symbolCacheKey) ([]Symbol, bool) {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	symbols, found := cache.symbols[key]
+	return symbols, found
+}
+
+func (cache *symbolCache) set(key symbolCacheKey, symbols []Symbol) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.symbols[key] = symbols
+}
+
+// Session transliterate a word as it's being typed, one or
+// a few characters at a time. Tokenizing is the costliest part
+// and is mostly the same on every keystroke, the VST lookups done
+// for earlier input are reused instead of doing them again.
+// Dictionary is always looked up fresh since learnings can change
+// in between. A session is not safe for concurrent use
+type Session struct {
+	varnam *Varnam
+	input  []rune
+	cache  *symbolCache
+
+	// Scheme generation the cache is of
+	generation int64
+
+	Options TransliterateOptions
+}
+
+// NewSession start a new transliteration session
+func (varnam *Varnam) NewSession() *Session {
+	session := &Session{varnam: varnam}
+	session.Reset()
+	return session
+}
+
+// Reset clear the input to start a new word
+func (session *Session) Reset() {
+	session.input = nil
+	session.cache = newSymbolCache()
+	session.generation = atomic.LoadInt64(&session.varnam.schemeGeneration)
+}
+
+// Input what has been typed so far
+func (session *Session) Input() string {
+	return string(session.input)
+}
+
+// Append characters to the input and transliterate it
+func (session *Session) Append(ctx context.Context, chars string) TransliterationResult {
+	session.input = append(session.input, []rune(chars)...)
+	return session.Transliterate(ctx)
+}
+
+// Backspace remove the last character of input and transliterate it
+func (session *Session) Backspace(ctx context.Context) TransliterationResult {
+	if len(session.input) > 0 {
+		session.input = session.input[:len(session.input)-1]
+	}
+	return session.Transliterate(ctx)
+}
+
+// Transliterate the current input
+func (session *Session) Transliterate(ctx context.Context) TransliterationResult {
+	return session.transliterate(ctx, session.Options)
+}
+
+func (session *Session) transliterate(ctx context.Context, opts TransliterateOptions) TransliterationResult {
+	if len(session.input) == 0 {
+		return TransliterationResult{}
+	}
+
+	// Cached symbols are of the scheme before reload
+	if generation := atomic.LoadInt64(&session.varnam.schemeGeneration); session.generation != generation {
+		session.cache = newSymbolCache()
+		session.generation = generation
+	}
+
+	ctx = context.WithValue(ctx, symbolCacheContextKey{}, session.cache)
+
+	_, result := session.varnam.transliterateStaged(ctx, string(session.input), opts, nil)
+	return result
+}
+
+// TransliterateQuick the current input in quick mode, for
+// calling on every keystroke. Call Transliterate when the
+// user pauses for all suggestions. See TransliterateOptions.Quick
+func (session *Session) TransliterateQuick(ctx context.Context) TransliterationResult {
+	opts := session.Options
+	opts.Quick = true
+	return session.transliterate(ctx, opts)
+}
@@ -0,0 +1,41 @@
+package govarnam
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMLSession(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+	ctx := context.Background()
+
+	checkError(varnam.Learn("മലയാളം", 0))
+	defer varnam.Unlearn("മലയാളം")
+
+	session := varnam.NewSession()
+
+	var result TransliterationResult
+	for _, ch := range "malayalam" {
+		result = session.Append(ctx, string(ch))
+		assertEqual(t, reflect.DeepEqual(result, varnam.TransliterateAdvanced(session.Input())), true)
+	}
+	assertEqual(t, session.Input(), "malayalam")
+	assertEqual(t, result.ExactWords[0].Word, "മലയാളം")
+
+	result = session.Backspace(ctx)
+	assertEqual(t, session.Input(), "malayala")
+	assertEqual(t, reflect.DeepEqual(result, varnam.TransliterateAdvanced("malayala")), true)
+
+	// Earlier VST lookups are reused
+	varnam.CollectDiagnostics = true
+	defer func() { varnam.CollectDiagnostics = false }()
+
+	withSession := session.Append(ctx, "m").Diagnostics.Queries
+	withoutSession := varnam.TransliterateAdvanced("malayalam").Diagnostics.Queries
+	assertEqual(t, withSession < withoutSession, true)
+
+	session.Reset()
+	assertEqual(t, session.Input(), "")
+	assertEqual(t, len(session.Backspace(ctx).Ranked()), 0)
+}
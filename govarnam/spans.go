@@ -0,0 +1,138 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"strings"
+)
+
+// Span a part of input and the part of a suggestion made from it.
+// Offsets are in bytes, end is exclusive. Eg: for "mala" => "മല",
+// "ma" (0 - 2) => "മ" (0 - 3) and "la" (2 - 4) => "ല" (3 - 6)
+type Span struct {
+	InputStart  int
+	InputEnd    int
+	OutputStart int
+	OutputEnd   int
+
+	// Output is from a learnt word and not made by tokenizing
+	// this part of input. Eg: the rest of a dictionary suggestion
+	Learned bool
+}
+
+// Values a token can be written as in a word
+func (varnam *Varnam) tokenValues(token Token) []string {
+	if token.tokenType != VARNAM_TOKEN_SYMBOL {
+		return []string{token.character}
+	}
+
+	var values []string
+	seen := map[string]bool{"": true}
+
+	for _, symbol := range token.symbols {
+		for _, value := range []string{symbol.Value1, symbol.Value2, varnam.applyJoinerPolicy(symbol.Value1), varnam.applyJoinerPolicy(symbol.Value2)} {
+			if !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// SuggestionSpans find which parts of input made which parts of a
+// suggested word, for underlining the matched part in editors.
+// Input is tokenized again and matched against word. Parts of word
+// that can't be made from input are given as a Learned span
+func (varnam *Varnam) SuggestionSpans(ctx context.Context, input string, word string) []Span {
+	var spans []Span
+
+	select {
+	case <-ctx.Done():
+		return spans
+	default:
+	}
+
+	tokens := *varnam.tokenizeWord(ctx, input, VARNAM_MATCH_ALL, false)
+
+	// Byte offset of each character of input
+	var offsets []int
+	for i := range input {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(input))
+
+	// Input part of each token. Token position is of its last character
+	inputStarts := make([]int, len(tokens))
+	inputEnds := make([]int, len(tokens))
+	for i, token := range tokens {
+		end := token.position + 1
+		if end >= len(offsets) {
+			end = len(offsets) - 1
+		}
+		if i > 0 {
+			inputStarts[i] = inputEnds[i-1]
+		}
+		inputEnds[i] = offsets[end]
+	}
+
+	var (
+		best       []Span
+		bestTokens = -1
+	)
+
+	// Token index & output offset that didn't lead to a full match
+	failed := map[[2]int]bool{}
+
+	var walk func(i int, outputPos int) bool
+	walk = func(i int, outputPos int) bool {
+		if i > bestTokens {
+			bestTokens = i
+			best = append([]Span{}, spans...)
+		}
+
+		if i == len(tokens) {
+			return outputPos == len(word)
+		}
+
+		if failed[[2]int{i, outputPos}] {
+			return false
+		}
+
+		for _, value := range varnam.tokenValues(tokens[i]) {
+			if !strings.HasPrefix(word[outputPos:], value) {
+				continue
+			}
+
+			spans = append(spans, Span{inputStarts[i], inputEnds[i], outputPos, outputPos + len(value), false})
+			if walk(i+1, outputPos+len(value)) {
+				return true
+			}
+			spans = spans[:len(spans)-1]
+		}
+
+		failed[[2]int{i, outputPos}] = true
+		return false
+	}
+
+	if walk(0, 0) {
+		return spans
+	}
+
+	inputEnd, outputEnd := 0, 0
+	if len(best) > 0 {
+		inputEnd = best[len(best)-1].InputEnd
+		outputEnd = best[len(best)-1].OutputEnd
+	}
+
+	if inputEnd < len(input) || outputEnd < len(word) {
+		best = append(best, Span{inputEnd, len(input), outputEnd, len(word), true})
+	}
+
+	return best
+}
@@ -0,0 +1,39 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMLSuggestionSpans(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+	ctx := context.Background()
+
+	spans := varnam.SuggestionSpans(ctx, "mala", "മല")
+	assertEqual(t, len(spans), 2)
+	assertEqual(t, spans[0], Span{0, 2, 0, 3, false})
+	assertEqual(t, spans[1], Span{2, 4, 3, 6, false})
+
+	// yaa is a single symbol in the scheme
+	spans = varnam.SuggestionSpans(ctx, "malayaaLam", "മലയാളം")
+	assertEqual(t, len(spans), 5)
+	assertEqual(t, spans[2], Span{4, 7, 6, 12, false})
+	assertEqual(t, spans[3], Span{7, 9, 12, 15, false})
+	assertEqual(t, spans[4].OutputEnd, len("മലയാളം"))
+
+	// Rest of a dictionary suggestion
+	spans = varnam.SuggestionSpans(ctx, "mala", "മലയാളം")
+	assertEqual(t, len(spans), 3)
+	assertEqual(t, spans[2], Span{4, 4, 6, len("മലയാളം"), true})
+
+	// Nothing in common
+	spans = varnam.SuggestionSpans(ctx, "mala", "കട")
+	assertEqual(t, len(spans), 1)
+	assertEqual(t, spans[0], Span{0, 4, 0, len("കട"), true})
+}
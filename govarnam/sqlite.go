@@ -0,0 +1,23 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+// Limit IDs of sqlite3_limit()
+const (
+	sqliteLimitExprDepth      = 3
+	sqliteLimitVariableNumber = 9
+)
+
+// Limits are read from the first connection. A connection
+// can't be kept around to read them later, database/sql
+// closes connections in its pool when it wants to
+// (a cancelled query for example)
+var sqlite3Limits = make(map[int]int)
+
+func getSQLiteLimit(id int) int {
+	return sqlite3Limits[id]
+}
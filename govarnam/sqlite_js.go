@@ -0,0 +1,352 @@
+//go:build js
+// +build js
+
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+In browsers, SQLite is sql.js (SQLite compiled to WebAssembly with
+FTS5 enabled). Databases are sql.js Database objects kept in
+globalThis.govarnamDatabases by name, the path given to Init &
+InitDict is that name. A name not in it gets a new empty database,
+made with the sql.js module at globalThis.govarnamSQL. See
+cmd/varnamwasm for loading a VST & learnings into it.
+
+sql.js runs statements synchronously, so this is a plain
+database/sql driver calling it. Statements are prepared for each
+run and freed after, because sql.js frees all prepared statements
+when a database is exported to be saved.
+*/
+
+import (
+	"context"
+	sql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// Database paths are names of sql.js databases
+const dbPathIsName = true
+
+var registerDriverOnce sync.Once
+
+func openDB(path string) (*sql.DB, error) {
+	registerDriverOnce.Do(func() {
+		sql.Register("sqlite3", &sqljsDriver{})
+
+		// sql.js is built with SQLite's default limits
+		sqlite3Limits[sqliteLimitVariableNumber] = 999
+		sqlite3Limits[sqliteLimitExprDepth] = 1000
+	})
+
+	return sql.Open("sqlite3", path)
+}
+
+// sql.js has no read only mode. Statements run one by one anyway
+func openReadOnlyDB(path string) (*sql.DB, error) {
+	return openDB(path)
+}
+
+type sqljsDriver struct{}
+
+type sqljsConn struct {
+	db js.Value
+}
+
+type sqljsStmt struct {
+	conn  *sqljsConn
+	query string
+}
+
+type sqljsRows struct {
+	stmt    js.Value
+	columns []string
+}
+
+type sqljsTx struct {
+	conn *sqljsConn
+}
+
+type sqljsResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+// Calls a sql.js method, JS exceptions are given as errors
+func callJS(value js.Value, method string, args ...interface{}) (result js.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if jsErr, ok := r.(js.Error); ok {
+				err = errors.New(jsErr.Get("message").String())
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	return value.Call(method, args...), nil
+}
+
+// Open name is the database's name in govarnamDatabases. Query
+// parameters (file:name?_case_sensitive_like=on) are as in go-sqlite3
+func (d *sqljsDriver) Open(name string) (driver.Conn, error) {
+	name = strings.TrimPrefix(name, "file:")
+
+	var params string
+	if i := strings.Index(name, "?"); i != -1 {
+		name, params = name[:i], name[i+1:]
+	}
+
+	databases := js.Global().Get("govarnamDatabases")
+	if databases.IsUndefined() {
+		databases = js.Global().Get("Object").New()
+		js.Global().Set("govarnamDatabases", databases)
+	}
+
+	db := databases.Get(name)
+	if db.IsUndefined() {
+		sqlJS := js.Global().Get("govarnamSQL")
+		if sqlJS.IsUndefined() {
+			return nil, fmt.Errorf("sql.js isn't loaded, can't open %q", name)
+		}
+		db = sqlJS.Get("Database").New()
+		databases.Set(name, db)
+	}
+
+	conn := &sqljsConn{db}
+
+	for _, param := range strings.Split(params, "&") {
+		if param == "_case_sensitive_like=on" || param == "_case_sensitive_like=1" {
+			if _, err := callJS(db, "run", "PRAGMA case_sensitive_like=ON"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return conn, nil
+}
+
+func (conn *sqljsConn) Prepare(query string) (driver.Stmt, error) {
+	return &sqljsStmt{conn, query}, nil
+}
+
+// Close the database is shared by all connections to it & kept open
+func (conn *sqljsConn) Close() error {
+	return nil
+}
+
+func (conn *sqljsConn) Begin() (driver.Tx, error) {
+	if _, err := callJS(conn.db, "run", "BEGIN"); err != nil {
+		return nil, err
+	}
+	return &sqljsTx{conn}, nil
+}
+
+func (tx *sqljsTx) Commit() error {
+	_, err := callJS(tx.conn.db, "run", "COMMIT")
+	return err
+}
+
+func (tx *sqljsTx) Rollback() error {
+	_, err := callJS(tx.conn.db, "run", "ROLLBACK")
+	return err
+}
+
+func toJSValue(value driver.Value) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return js.Null()
+	case int64:
+		return float64(v)
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case []byte:
+		array := js.Global().Get("Uint8Array").New(len(v))
+		js.CopyBytesToJS(array, v)
+		return array
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999999-07:00")
+	default:
+		return v
+	}
+}
+
+func fromJSValue(value js.Value) driver.Value {
+	switch value.Type() {
+	case js.TypeNull, js.TypeUndefined:
+		return nil
+	case js.TypeNumber:
+		number := value.Float()
+		if number == math.Trunc(number) && math.Abs(number) < 1<<53 {
+			return int64(number)
+		}
+		return number
+	case js.TypeString:
+		return value.String()
+	default:
+		if value.InstanceOf(js.Global().Get("Uint8Array")) {
+			data := make([]byte, value.Length())
+			js.CopyBytesToGo(data, value)
+			return data
+		}
+		return value.String()
+	}
+}
+
+func (stmt *sqljsStmt) prepare(args []driver.Value) (js.Value, error) {
+	jsStmt, err := callJS(stmt.conn.db, "prepare", stmt.query)
+	if err != nil {
+		return js.Undefined(), err
+	}
+
+	if len(args) > 0 {
+		values := make([]interface{}, len(args))
+		for i, arg := range args {
+			values[i] = toJSValue(arg)
+		}
+
+		if _, err := callJS(jsStmt, "bind", values); err != nil {
+			jsStmt.Call("free")
+			return js.Undefined(), err
+		}
+	}
+
+	return jsStmt, nil
+}
+
+func (stmt *sqljsStmt) Close() error {
+	return nil
+}
+
+// NumInput sql.js checks the argument count
+func (stmt *sqljsStmt) NumInput() int {
+	return -1
+}
+
+func (stmt *sqljsStmt) Exec(args []driver.Value) (driver.Result, error) {
+	db := stmt.conn.db
+
+	if len(args) == 0 {
+		// Can be many statements (migrations)
+		if _, err := callJS(db, "exec", stmt.query); err != nil {
+			return nil, err
+		}
+	} else {
+		jsStmt, err := stmt.prepare(args)
+		if err != nil {
+			return nil, err
+		}
+		_, err = callJS(jsStmt, "step")
+		jsStmt.Call("free")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := sqljsResult{rowsAffected: int64(db.Call("getRowsModified").Int())}
+
+	lastID, err := callJS(db, "exec", "SELECT last_insert_rowid()")
+	if err == nil && lastID.Length() > 0 {
+		result.lastInsertID = int64(lastID.Index(0).Get("values").Index(0).Index(0).Float())
+	}
+
+	return result, nil
+}
+
+func (stmt *sqljsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	jsStmt, err := stmt.prepare(args)
+	if err != nil {
+		return nil, err
+	}
+
+	names := jsStmt.Call("getColumnNames")
+	columns := make([]string, names.Length())
+	for i := range columns {
+		columns[i] = names.Index(i).String()
+	}
+
+	return &sqljsRows{jsStmt, columns}, nil
+}
+
+func (conn *sqljsConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return (&sqljsStmt{conn, query}).Exec(values)
+}
+
+func (conn *sqljsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return (&sqljsStmt{conn, query}).Query(values)
+}
+
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, fmt.Errorf("Named arguments are not supported (%s)", arg.Name)
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
+
+func (rows *sqljsRows) Columns() []string {
+	return rows.columns
+}
+
+func (rows *sqljsRows) Close() error {
+	if !rows.stmt.IsUndefined() {
+		rows.stmt.Call("free")
+		rows.stmt = js.Undefined()
+	}
+	return nil
+}
+
+func (rows *sqljsRows) Next(dest []driver.Value) error {
+	if rows.stmt.IsUndefined() {
+		return io.EOF
+	}
+
+	hasRow, err := callJS(rows.stmt, "step")
+	if err != nil {
+		return err
+	}
+	if !hasRow.Bool() {
+		rows.Close()
+		return io.EOF
+	}
+
+	row := rows.stmt.Call("get")
+	for i := range dest {
+		dest[i] = fromJSValue(row.Index(i))
+	}
+	return nil
+}
+
+func (result sqljsResult) LastInsertId() (int64, error) {
+	return result.lastInsertID, nil
+}
+
+func (result sqljsResult) RowsAffected() (int64, error) {
+	return result.rowsAffected, nil
+}
@@ -0,0 +1,51 @@
+//go:build !js
+// +build !js
+
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	sql "database/sql"
+	"sync"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Database paths are file paths
+const dbPathIsName = false
+
+var (
+	registerDriverOnce sync.Once
+	readLimitsOnce     sync.Once
+)
+
+func openDB(path string) (*sql.DB, error) {
+	registerDriverOnce.Do(func() {
+		sql.Register("sqlite3_with_limit", &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				readLimitsOnce.Do(func() {
+					for _, id := range []int{sqliteLimitVariableNumber, sqliteLimitExprDepth} {
+						sqlite3Limits[id] = conn.GetLimit(id)
+					}
+				})
+				return nil
+			},
+		})
+	})
+
+	conn, err := sql.Open("sqlite3_with_limit", path)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Connections to path can't write
+func openReadOnlyDB(path string) (*sql.DB, error) {
+	return openDB(path + "?_query_only=1")
+}
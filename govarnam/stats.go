@@ -0,0 +1,139 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// LearningDay number of learns and trains done in a day
+type LearningDay struct {
+	Day      time.Time
+	Learns   int
+	Trains   int
+	NewWords int
+}
+
+// BoostedWord a word and how many times it was learnt
+type BoostedWord struct {
+	Word   string
+	Learns int
+}
+
+// LearningStatistics result of LearningStats
+type LearningStatistics struct {
+	// Oldest day first. Days without any learning are left out
+	Days []LearningDay
+
+	// Most learnt words in the period, at most VARNAM_LEARNING_STATS_TOP_WORDS
+	TopWords []BoostedWord
+}
+
+func learningDay(t time.Time) int64 {
+	unix := t.Unix()
+	return unix - unix%86400
+}
+
+// Count learns & trains of words on a day
+func (varnam *Varnam) logLearning(words []string, day int64, learns int, trains int) error {
+	// 3 extra variables for day, learns & trains
+	wordsPerQuery := getSQLiteLimit(sqliteLimitVariableNumber) - 3
+
+	for len(words) > 0 {
+		lastIndex := int(math.Min(float64(wordsPerQuery), float64(len(words))))
+
+		args := []interface{}{day, learns, trains}
+		for _, word := range words[0:lastIndex] {
+			args = append(args, word)
+		}
+
+		query := fmt.Sprintf(
+			`INSERT INTO learning_log(word_id, day, learns, trains) SELECT id, ?, ?, ? FROM words WHERE word IN (%s)
+			ON CONFLICT(word_id, day) DO UPDATE SET learns = learns + excluded.learns, trains = trains + excluded.trains`,
+			strings.Repeat(", ?", lastIndex)[2:],
+		)
+
+		_, err := varnam.dictWriteConn.Exec(query, args...)
+		if err != nil {
+			return err
+		}
+
+		words = words[lastIndex:]
+	}
+
+	return nil
+}
+
+// LearningStats get per day learn & train counts and the
+// most learnt words from since till now. Days are in UTC
+func (varnam *Varnam) LearningStats(ctx context.Context, since time.Time) (LearningStatistics, error) {
+	var result LearningStatistics
+
+	select {
+	case <-ctx.Done():
+		return result, nil
+	default:
+		// A word is new on the day it was first logged
+		rows, err := varnam.dictConn.QueryContext(
+			ctx,
+			`SELECT l.day, SUM(l.learns), SUM(l.trains),
+				SUM(l.day = (SELECT MIN(f.day) FROM learning_log f WHERE f.word_id = l.word_id))
+			FROM learning_log l
+			WHERE l.day >= ?
+			GROUP BY l.day
+			ORDER BY l.day`,
+			learningDay(since),
+		)
+		if err != nil {
+			return result, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				day  int64
+				item LearningDay
+			)
+			rows.Scan(&day, &item.Learns, &item.Trains, &item.NewWords)
+			item.Day = time.Unix(day, 0).UTC()
+			result.Days = append(result.Days, item)
+		}
+
+		if err = rows.Err(); err != nil {
+			return result, err
+		}
+
+		rows, err = varnam.dictConn.QueryContext(
+			ctx,
+			`SELECT w.word, SUM(l.learns) AS total FROM learning_log l
+			LEFT JOIN words w ON w.id = l.word_id
+			WHERE l.day >= ?
+			GROUP BY l.word_id
+			HAVING total > 0
+			ORDER BY total DESC, MAX(l.day) DESC
+			LIMIT ?`,
+			learningDay(since),
+			VARNAM_LEARNING_STATS_TOP_WORDS,
+		)
+		if err != nil {
+			return result, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item BoostedWord
+			rows.Scan(&item.Word, &item.Learns)
+			result.TopWords = append(result.TopWords, item)
+		}
+
+		return result, rows.Err()
+	}
+}
@@ -0,0 +1,53 @@
+package govarnam
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMLLearningStats(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	now := time.Now()
+	lastWeek := now.Add(-7 * 24 * time.Hour)
+
+	checkError(varnam.LearnAt("ആലപ്പുഴ", 0, lastWeek))
+	checkError(varnam.Learn("ആലപ്പുഴ", 0))
+	checkError(varnam.Learn("ആലപ്പുഴ", 0))
+	checkError(varnam.Train("ernakulam", "എറണാകുളം"))
+
+	_, err := varnam.LearnMany([]WordInfo{{0, "പാലക്കാട്", 0, 0}, {0, "ആലപ്പുഴ", 0, 0}})
+	checkError(err)
+
+	stats, err := varnam.LearningStats(context.Background(), lastWeek)
+	checkError(err)
+
+	assertEqual(t, len(stats.Days), 2)
+	assertEqual(t, stats.Days[0].Day, time.Unix(learningDay(lastWeek), 0).UTC())
+	assertEqual(t, stats.Days[0].Learns, 1)
+	assertEqual(t, stats.Days[0].NewWords, 1)
+
+	// Train learns the word too
+	assertEqual(t, stats.Days[1].Learns, 5)
+	assertEqual(t, stats.Days[1].Trains, 1)
+	assertEqual(t, stats.Days[1].NewWords, 2)
+
+	assertEqual(t, stats.TopWords[0], BoostedWord{"ആലപ്പുഴ", 4})
+	assertEqual(t, len(stats.TopWords), 3)
+
+	// Only today
+	stats, err = varnam.LearningStats(context.Background(), now)
+	checkError(err)
+
+	assertEqual(t, len(stats.Days), 1)
+	assertEqual(t, stats.TopWords[0], BoostedWord{"ആലപ്പുഴ", 3})
+
+	for _, word := range []string{"ആലപ്പുഴ", "എറണാകുളം", "പാലക്കാട്"} {
+		checkError(varnam.Unlearn(word))
+	}
+
+	stats, err = varnam.LearningStats(context.Background(), lastWeek)
+	checkError(err)
+	assertEqual(t, len(stats.Days), 0)
+}
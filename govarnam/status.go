@@ -0,0 +1,94 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+)
+
+// InstanceStatus whether an instance is usable, for health checks
+// & monitoring of servers
+type InstanceStatus struct {
+	SchemeID string
+
+	// VST is open & symbols can be looked up
+	SchemeLoaded bool
+	SchemeError  string `json:",omitempty"`
+
+	// Learnings can be read
+	DictionaryReachable bool
+	DictionaryError     string `json:",omitempty"`
+
+	// Bytes in the write-ahead log of learnings, not yet
+	// checkpointed into the learnings file
+	WALSize int64
+
+	// VST lookups reused from the cache of sessions & batches
+	SymbolCacheHits   int64
+	SymbolCacheMisses int64
+}
+
+// Healthy whether both scheme & learnings are usable
+func (status InstanceStatus) Healthy() bool {
+	return status.SchemeLoaded && status.DictionaryReachable
+}
+
+// SymbolCacheHitRate fraction of cacheable VST lookups
+// that were cached. 0 if none were made
+func (status InstanceStatus) SymbolCacheHitRate() float64 {
+	total := status.SymbolCacheHits + status.SymbolCacheMisses
+	if total == 0 {
+		return 0
+	}
+	return float64(status.SymbolCacheHits) / float64(total)
+}
+
+// SymbolCacheStats number of VST lookups reused from the cache of
+// sessions & batches, and of those that weren't in it
+func (varnam *Varnam) SymbolCacheStats() (int64, int64) {
+	return atomic.LoadInt64(&varnam.symbolCacheHits), atomic.LoadInt64(&varnam.symbolCacheMisses)
+}
+
+// Status check whether scheme & learnings are usable
+func (varnam *Varnam) Status(ctx context.Context) InstanceStatus {
+	status := InstanceStatus{SchemeID: varnam.SchemeDetails.Identifier}
+	status.SymbolCacheHits, status.SymbolCacheMisses = varnam.SymbolCacheStats()
+
+	var err error
+	if varnam.mappedVST != nil {
+		status.SchemeLoaded = true
+	} else if varnam.vstConn == nil {
+		err = errors.New("VST isn't open")
+	} else {
+		var count int
+		err = varnam.vstConn.QueryRowContext(ctx, "SELECT COUNT(*) FROM (SELECT 1 FROM symbols LIMIT 1)").Scan(&count)
+		status.SchemeLoaded = err == nil
+	}
+	if err != nil {
+		status.SchemeError = err.Error()
+	}
+
+	if varnam.dictConn == nil {
+		err = errors.New("Learnings aren't open")
+	} else {
+		var count int
+		err = varnam.dictConn.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master").Scan(&count)
+		status.DictionaryReachable = err == nil
+	}
+	if err != nil {
+		status.DictionaryError = err.Error()
+	}
+
+	if info, err := os.Stat(varnam.DictPath + "-wal"); err == nil {
+		status.WALSize = info.Size()
+	}
+
+	return status
+}
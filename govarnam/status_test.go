@@ -0,0 +1,56 @@
+package govarnam
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestMLStatus(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	before := varnam.Status(context.Background())
+	assertEqual(t, before.Healthy(), true)
+	assertEqual(t, before.SchemeID, "ml")
+
+	session := varnam.NewSession()
+	session.Append(context.Background(), "mala")
+	session.Append(context.Background(), "y")
+
+	// Back to "mala", looked up before
+	session.Backspace(context.Background())
+
+	status := varnam.Status(context.Background())
+	assertEqual(t, status.SymbolCacheHits > before.SymbolCacheHits, true)
+	assertEqual(t, status.SymbolCacheMisses > before.SymbolCacheMisses, true)
+	assertEqual(t, status.SymbolCacheHitRate() > 0, true)
+}
+
+func TestMLCheckpoint(t *testing.T) {
+	varnam, err := Init(getVarnamInstance("ml").VSTPath, path.Join(testTempDir, "checkpoint-ml.vst.learnings"))
+	checkError(err)
+
+	ctx := context.Background()
+
+	checkError(varnam.Learn("കൊല്ലം", 0))
+	assertEqual(t, varnam.Status(ctx).WALSize > 0, true)
+
+	checkError(varnam.Checkpoint())
+	assertEqual(t, varnam.Status(ctx).WALSize, int64(0))
+
+	// Learnings are in the file after close
+	checkError(varnam.Learn("തിരുവനന്തപുരം", 0))
+	checkError(varnam.Close())
+
+	info, err := os.Stat(varnam.DictPath + "-wal")
+	assertEqual(t, os.IsNotExist(err) || info.Size() == 0, true)
+
+	reopened, err := Init(varnam.VSTPath, varnam.DictPath)
+	checkError(err)
+	defer reopened.Close()
+
+	words, err := reopened.GetRecentlyLearntWords(ctx, 0, 10)
+	checkError(err)
+	assertEqual(t, len(words), 2)
+}
@@ -0,0 +1,127 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"strings"
+)
+
+// Stem rules are from VST. A rule replaces a word ending, Eg:
+// ത്തിൽ => ം makes മലയാളം from മലയാളത്തിൽ. A stem exception
+// stops a rule for words having an ending, Eg: rule ിൽ => ്
+// with exception ത്തിൽ won't be applied on മലയാളത്തിൽ.
+type stemRules struct {
+	// Longest ending first
+	rules []stemRule
+
+	// Endings for which a rule is not applied, by rule's old ending
+	exceptions map[string][]string
+}
+
+type stemRule struct {
+	oldEnding string
+	newEnding string
+}
+
+func (varnam *Varnam) getStemRules() (*stemRules, error) {
+	if varnam.mappedVST != nil {
+		return varnam.mappedVST.getStemRules(), nil
+	}
+
+	result := stemRules{exceptions: map[string][]string{}}
+
+	rows, err := varnam.vstConn.Query("SELECT old_ending, new_ending FROM stemrules ORDER BY LENGTH(old_ending) DESC, id ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rule stemRule
+		err = rows.Scan(&rule.oldEnding, &rule.newEnding)
+		if err != nil {
+			return nil, err
+		}
+		result.rules = append(result.rules, rule)
+	}
+
+	if len(result.rules) == 0 {
+		return &result, rows.Err()
+	}
+
+	exceptionRows, err := varnam.vstConn.Query("SELECT stem, exception FROM stem_exceptions")
+	if err != nil {
+		return nil, err
+	}
+	defer exceptionRows.Close()
+
+	for exceptionRows.Next() {
+		var oldEnding, exception string
+		err = exceptionRows.Scan(&oldEnding, &exception)
+		if err != nil {
+			return nil, err
+		}
+		result.exceptions[oldEnding] = append(result.exceptions[oldEnding], exception)
+	}
+
+	return &result, exceptionRows.Err()
+}
+
+// Apply the first rule that can be applied on word.
+// Empty string if none can be
+func (rules *stemRules) apply(word string) string {
+	for _, rule := range rules.rules {
+		if len(word) <= len(rule.oldEnding) || !strings.HasSuffix(word, rule.oldEnding) {
+			continue
+		}
+
+		excepted := false
+		for _, exception := range rules.exceptions[rule.oldEnding] {
+			if strings.HasSuffix(word, exception) {
+				excepted = true
+				break
+			}
+		}
+
+		if !excepted {
+			return strings.TrimSuffix(word, rule.oldEnding) + rule.newEnding
+		}
+	}
+
+	return ""
+}
+
+// Stems of word by applying rules one after the other
+func (rules *stemRules) stems(word string) []string {
+	var stems []string
+
+	seen := map[string]bool{word: true}
+
+	for {
+		word = rules.apply(word)
+		if word == "" || seen[word] {
+			break
+		}
+
+		seen[word] = true
+		stems = append(stems, word)
+	}
+
+	return stems
+}
+
+// Stems get the stems of a word as per stem rules of the scheme.
+// Each stem is made from the one before it. These are learnt
+// along with the word
+func (varnam *Varnam) Stems(word string) ([]string, error) {
+	rules, err := varnam.getStemRules()
+	if err != nil {
+		return nil, err
+	}
+
+	return rules.stems(varnam.sanitizeWord(word)), nil
+}
@@ -0,0 +1,75 @@
+package govarnam
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func TestStemRules(t *testing.T) {
+	rules := stemRules{
+		rules: []stemRule{
+			{"ത്തിൽ", "ം"},
+			{"ിൽ", "്"},
+			{"ന്റെ", ""},
+		},
+		exceptions: map[string][]string{
+			"ിൽ": {"ത്തിൽ"},
+		},
+	}
+
+	stems := rules.stems("മലയാളത്തിൽ")
+	assertEqual(t, len(stems), 1)
+	assertEqual(t, stems[0], "മലയാളം")
+
+	// Exception stops ിൽ => ് for ത്തിൽ
+	rules.rules = rules.rules[1:]
+	assertEqual(t, len(rules.stems("മലയാളത്തിൽ")), 0)
+
+	stems = rules.stems("വീട്ടിൽ")
+	assertEqual(t, len(stems), 1)
+	assertEqual(t, stems[0], "വീട്ട്")
+
+	// Whole word is not an ending
+	assertEqual(t, len(rules.stems("ന്റെ")), 0)
+}
+
+func TestMLLearnStems(t *testing.T) {
+	vst, err := os.ReadFile(getVarnamInstance("ml").VSTPath)
+	checkError(err)
+
+	vstPath := path.Join(testTempDir, "stem-ml.vst")
+	checkError(os.WriteFile(vstPath, vst, 0644))
+
+	vm, err := VMInit(vstPath)
+	checkError(err)
+	checkError(vm.VMCreateStemRule("ത്തിൽ", "ം"))
+	checkError(vm.VMCreateStemRule("ിൽ", "്"))
+	checkError(vm.VMCreateStemException("ിൽ", "ത്തിൽ"))
+	assertEqual(t, vm.VMCreateStemRule("ത്തിൽ", "ം") != nil, true)
+	vm.Close()
+
+	varnam, err := Init(vstPath, path.Join(testTempDir, "stem-ml.vst.learnings"))
+	checkError(err)
+	defer varnam.Close()
+
+	stems, err := varnam.Stems("മലയാളത്തിൽ")
+	checkError(err)
+	assertEqual(t, len(stems), 1)
+	assertEqual(t, stems[0], "മലയാളം")
+
+	checkError(varnam.Learn("മലയാളത്തിൽ", 0))
+
+	info, err := varnam.getWordInfo("മലയാളം")
+	checkError(err)
+	assertEqual(t, info.weight, VARNAM_LEARNT_WORD_MIN_WEIGHT)
+
+	_, err = varnam.LearnMany([]WordInfo{{0, "വീട്ടിൽ", 0, 0}})
+	checkError(err)
+
+	_, err = varnam.getWordInfo("വീട്ട്")
+	checkError(err)
+
+	sugs := varnam.TransliterateAdvanced("malayaa").DictionarySuggestions
+	assertEqual(t, len(sugs) > 1, true)
+}
@@ -0,0 +1,83 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"strings"
+)
+
+// Split a dialogue line into words & the rest. Formatting tags
+// (<i>, <v Speaker>, <00:01.000>), {\an8} style overrides and
+// character references aren't words
+func splitSubtitleText(text string) []sentencePart {
+	var parts []sentencePart
+
+	textStart := 0
+	for i := 0; i < len(text); i++ {
+		var end int
+
+		switch {
+		case text[i] == '<' && i+1 < len(text) && text[i+1] != ' ':
+			end = strings.IndexByte(text[i:], '>')
+		case text[i] == '{' && i+1 < len(text) && text[i+1] == '\\':
+			end = strings.IndexByte(text[i:], '}')
+		default:
+			continue
+		}
+		if end == -1 {
+			continue
+		}
+		end += i + 1
+
+		parts = append(parts, splitHTMLText(text[textStart:i])...)
+		parts = append(parts, sentencePart{text[i:end], false})
+		textStart = end
+		i = end - 1
+	}
+
+	return append(parts, splitHTMLText(text[textStart:])...)
+}
+
+// Split SRT or WebVTT subtitles into words of dialogue lines & the
+// rest. Cue numbers, identifiers, timings and blocks without timings
+// (WEBVTT header, NOTE, STYLE) are kept. Joining the parts gives
+// back the subtitles
+func splitSubtitles(subtitles string) []sentencePart {
+	var parts []sentencePart
+
+	inDialogue := false
+
+	for _, line := range strings.SplitAfter(subtitles, "\n") {
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			// Blocks are separated by blank lines
+			inDialogue = false
+		case inDialogue:
+			parts = append(parts, splitSubtitleText(line)...)
+			continue
+		case strings.Contains(line, "-->"):
+			// Dialogue follows timing
+			inDialogue = true
+		}
+
+		parts = append(parts, sentencePart{line, false})
+	}
+
+	return parts
+}
+
+// TransliterateSubtitles transliterate dialogue in SRT or WebVTT
+// subtitles like TransliterateDocument. Cue numbers, timings,
+// formatting tags and other blocks are kept as is.
+func (varnam *Varnam) TransliterateSubtitles(ctx context.Context, subtitles string) (string, []ConvertedWord, error) {
+	return varnam.convertParts(ctx, splitSubtitles(subtitles))
+}
@@ -0,0 +1,48 @@
+package govarnam
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSplitSubtitles(t *testing.T) {
+	srt := "1\r\n00:00:01,000 --> 00:00:02,500\r\n<i>pani</i> nanni\r\n{\\an8}- vazhi &amp; 10\r\n\r\n" +
+		"2\n00:00:03,000 --> 00:00:04,000\nente\n"
+
+	vtt := "WEBVTT - pani\n\nNOTE nanni\nvazhi\n\nSTYLE\n::cue { color: red }\n\n" +
+		"intro\n00:01.000 --> 00:02.000 align:start\n<v Amma>pani <00:01.500>nanni</v>\n"
+
+	for input, expected := range map[string]string{
+		srt: "pani nanni - vazhi 10 ente",
+		vtt: "pani nanni",
+	} {
+		var words []string
+		var joined strings.Builder
+
+		for _, part := range splitSubtitles(input) {
+			if part.isWord {
+				words = append(words, part.text)
+			}
+			joined.WriteString(part.text)
+		}
+
+		assertEqual(t, joined.String(), input)
+		assertEqual(t, strings.Join(words, " "), expected)
+	}
+}
+
+func TestMLTransliterateSubtitles(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	top := func(word string) string {
+		return varnam.Transliterate(word)[0].Word
+	}
+
+	output, words, err := varnam.TransliterateSubtitles(context.Background(), "1\n00:00:01,000 --> 00:00:02,000\n<i>pani</i>\n\n2\n00:00:03,000 --> 00:00:04,000\nnanni\n")
+	checkError(err)
+	assertEqual(t, output, "1\n00:00:01,000 --> 00:00:02,000\n<i>"+top("pani")+"</i>\n\n2\n00:00:03,000 --> 00:00:04,000\n"+top("nanni")+"\n")
+
+	assertEqual(t, len(words), 2)
+	assertEqual(t, words[1].Line, 7)
+}
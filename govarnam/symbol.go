@@ -11,9 +11,9 @@ import (
 	sql "database/sql"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
-
-	"github.com/mattn/go-sqlite3"
+	"sync/atomic"
 )
 
 // Symbol result from VST
@@ -40,27 +40,17 @@ type Token struct {
 	character string // Non language character
 }
 
-var sqlite3Conn *sqlite3.SQLiteConn
-
-func openDB(path string) (*sql.DB, error) {
-	if sqlite3Conn == nil {
-		sql.Register("sqlite3_with_limit", &sqlite3.SQLiteDriver{
-			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
-				sqlite3Conn = conn
-				return nil
-			},
-		})
-	}
+// InitVST initialize. If the scheme is a variant,
+// its base scheme is loaded too
+func (varnam *Varnam) InitVST(vstPath string) error {
+	return varnam.initVST(vstPath, map[string]bool{})
+}
 
-	conn, err := sql.Open("sqlite3_with_limit", path)
-	if err != nil {
-		return nil, err
+func (varnam *Varnam) initVST(vstPath string, bases map[string]bool) error {
+	if isMappedVST(vstPath) {
+		return varnam.initMappedVST(vstPath)
 	}
-	return conn, nil
-}
 
-// InitVST initialize
-func (varnam *Varnam) InitVST(vstPath string) error {
 	var err error
 	varnam.vstConn, err = openDB(vstPath + "?_case_sensitive_like=on")
 
@@ -77,8 +67,30 @@ func (varnam *Varnam) InitVST(vstPath string) error {
 	varnam.vstConn.Exec("PRAGMA LOCKING_MODE=EXCLUSIVE;")
 
 	varnam.VSTPath = vstPath
+	varnam.vstFileInfo = statVST(vstPath)
 	varnam.setSchemeInfo()
 
+	if varnam.SchemeDetails.Base != "" {
+		err = varnam.resolveBaseScheme(bases)
+		if err != nil {
+			varnam.vstConn.Close()
+			return err
+		}
+
+		err = varnam.setPatternLongestLength()
+		if err != nil {
+			return err
+		}
+
+		varnam.vstConn.Exec("PRAGMA TEMP_STORE=2;")
+	}
+
+	err = varnam.checkSchemeCompatibility()
+	if err != nil {
+		varnam.vstConn.Close()
+		return err
+	}
+
 	return nil
 }
 
@@ -107,19 +119,14 @@ func (varnam *Varnam) setPatternLongestLength() error {
 }
 
 func (varnam *Varnam) setSchemeInfo() {
-	rows, err := varnam.vstConn.Query("SELECT * FROM metadata")
+	metadata, err := varnam.getVSTMetadata()
 
 	if err != nil {
 		log.Print(err)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var (
-			key   string
-			value string
-		)
-		rows.Scan(&key, &value)
+	for _, pair := range metadata {
+		key, value := pair[0], pair[1]
 		if key == "scheme-id" {
 			varnam.SchemeDetails.Identifier = value
 		} else if key == "lang-code" {
@@ -136,10 +143,45 @@ func (varnam *Varnam) setSchemeInfo() {
 			} else {
 				varnam.SchemeDetails.IsStable = false
 			}
+		} else if key == "scheme-native-display-name" {
+			varnam.SchemeDetails.NativeDisplayName = value
+		} else if key == "scheme-version" {
+			varnam.SchemeDetails.Version = value
+		} else if key == "scheme-min-library-version" {
+			varnam.SchemeDetails.MinLibraryVersion = value
+		} else if key == "scheme-base" {
+			varnam.SchemeDetails.Base = value
+		} else if key == "scheme-end-of-word-lookup" {
+			varnam.SchemeDetails.EndOfWordLookup = value == "1"
 		}
 	}
 }
 
+// Check whether this version of library can load the scheme.
+// Skipped if library version is not known (development builds)
+func (varnam *Varnam) checkSchemeCompatibility() error {
+	minVersion := varnam.SchemeDetails.MinLibraryVersion
+	if minVersion == "" {
+		return nil
+	}
+
+	libraryVersion, err := parseVersion(VersionString)
+	if err != nil {
+		return nil
+	}
+
+	schemeNeeds, err := parseVersion(minVersion)
+	if err != nil {
+		return fmt.Errorf("invalid minimum library version %q in scheme", minVersion)
+	}
+
+	if compareVersions(libraryVersion, schemeNeeds) < 0 {
+		return fmt.Errorf("scheme %s needs govarnam %s or newer, this is %s", varnam.SchemeDetails.Identifier, minVersion, VersionString)
+	}
+
+	return nil
+}
+
 func (varnam *Varnam) searchPattern(ctx context.Context, ch string, matchType int, acceptCondition int) []Symbol {
 	var (
 		rows    *sql.Rows
@@ -151,10 +193,21 @@ func (varnam *Varnam) searchPattern(ctx context.Context, ch string, matchType in
 	case <-ctx.Done():
 		return results
 	default:
+		if varnam.mappedVST != nil {
+			results = varnam.mappedSearchPattern(ctx, ch, matchType, acceptCondition)
+			results = varnam.applyValueSymbolWeights(results)
+			return varnam.applyValueSymbolOverrides(results, ch, matchType, acceptCondition)
+		}
+
+		queryCtx, cancel := varnam.watchdogContext(ctx)
+		defer cancel()
+		defer varnam.watchdogCheck(queryCtx, ctx, ch)
+
+		countQuery(ctx)
 		if matchType == VARNAM_MATCH_ALL {
-			rows, err = varnam.vstConn.QueryContext(ctx, "SELECT * FROM symbols WHERE (value1 = ? OR value2 = ?) AND (accept_condition = 0 OR accept_condition = ?) ORDER BY match_type ASC, weight DESC, priority DESC", ch, ch, acceptCondition)
+			rows, err = varnam.vstConn.QueryContext(queryCtx, "SELECT * FROM symbols WHERE (value1 = ? OR value2 = ?) AND (accept_condition = 0 OR accept_condition = ?) ORDER BY match_type ASC, weight DESC, priority DESC", ch, ch, acceptCondition)
 		} else {
-			rows, err = varnam.vstConn.QueryContext(ctx, "SELECT * FROM symbols WHERE (value1 = ? OR value2 = ?) AND match_type = ? AND (accept_condition = 0 OR accept_condition = ?)", ch, ch, matchType, acceptCondition)
+			rows, err = varnam.vstConn.QueryContext(queryCtx, "SELECT * FROM symbols WHERE (value1 = ? OR value2 = ?) AND match_type = ? AND (accept_condition = 0 OR accept_condition = ?)", ch, ch, matchType, acceptCondition)
 		}
 
 		if err != nil {
@@ -174,7 +227,9 @@ func (varnam *Varnam) searchPattern(ctx context.Context, ch string, matchType in
 			log.Print(err)
 		}
 
-		return results
+		results = varnam.applyValueSymbolWeights(results)
+
+		return varnam.applyValueSymbolOverrides(results, ch, matchType, acceptCondition)
 	}
 }
 
@@ -184,23 +239,46 @@ func (varnam *Varnam) findLongestPatternMatchSymbols(ctx context.Context, patter
 		query      string
 		results    []Symbol
 		patternINs string
-		vals       []interface{}
+
+		// matchType, acceptCondition & a prefix for each rune
+		vals = make([]interface{}, 0, len(pattern)+2)
+
+		// Prefixes are sliced out of this, not converted one by one
+		patternString = string(pattern)
 	)
 
-	if matchType != VARNAM_MATCH_ALL {
-		vals = append(vals, matchType)
+	cache := getSymbolCache(ctx)
+	cacheKey := symbolCacheKey{patternString, matchType, acceptCondition, varnam.CaseInsensitive, varnam.noGrantha()}
+
+	if cache != nil {
+		if symbols, found := cache.get(cacheKey); found {
+			atomic.AddInt64(&varnam.symbolCacheHits, 1)
+			return symbols
+		}
+		atomic.AddInt64(&varnam.symbolCacheMisses, 1)
+	}
+
+	queryMatchType := matchType
+	if varnam.noGrantha() {
+		// Possibility matches may replace the removed Grantha ones
+		queryMatchType = VARNAM_MATCH_ALL
+	}
+
+	if queryMatchType != VARNAM_MATCH_ALL {
+		vals = append(vals, queryMatchType)
 	}
 
 	vals = append(vals, acceptCondition)
-	vals = append(vals, string(pattern[0]))
 
-	for i := range pattern {
-		if i == 0 {
-			continue
+	// i is at the byte after each rune
+	for i := range patternString {
+		if i > 0 {
+			vals = append(vals, patternString[:i])
 		}
-		patternINs += ", ?"
-		vals = append(vals, string(pattern[0:i+1]))
 	}
+	vals = append(vals, patternString)
+
+	patternINs = strings.Repeat(", ?", len(pattern)-1)
 
 	if varnam.Debug {
 		// The query will be made like :
@@ -214,13 +292,36 @@ func (varnam *Varnam) findLongestPatternMatchSymbols(ctx context.Context, patter
 	case <-ctx.Done():
 		return results
 	default:
-		if matchType == VARNAM_MATCH_ALL {
-			query = "SELECT * FROM `symbols` WHERE (accept_condition = 0 OR accept_condition = ?) AND pattern IN (? " + patternINs + ") ORDER BY LENGTH(pattern) DESC, match_type ASC, weight DESC, priority DESC"
+		if varnam.mappedVST != nil {
+			results = varnam.mappedFindLongestPatternMatchSymbols(ctx, pattern, queryMatchType, acceptCondition)
+			results = varnam.adjustPatternMatchSymbols(results, pattern, matchType, acceptCondition)
+			if cache != nil {
+				cache.set(cacheKey, results)
+			}
+			return results
+		}
+
+		patternColumn := "pattern"
+		orderBy := "LENGTH(pattern) DESC"
+
+		if varnam.CaseInsensitive {
+			// e will match both e & E, the one with more weight comes first
+			patternColumn = "pattern COLLATE NOCASE"
+			orderBy += ", weight DESC"
+		}
+
+		if queryMatchType == VARNAM_MATCH_ALL {
+			query = "SELECT * FROM `symbols` WHERE (accept_condition = 0 OR accept_condition = ?) AND " + patternColumn + " IN (? " + patternINs + ") ORDER BY LENGTH(pattern) DESC, match_type ASC, weight DESC, priority DESC"
 		} else {
-			query = "SELECT * FROM `symbols` WHERE match_type = ? AND (accept_condition = 0 OR accept_condition = ?) AND pattern IN (? " + patternINs + ") ORDER BY LENGTH(pattern) DESC"
+			query = "SELECT * FROM `symbols` WHERE match_type = ? AND (accept_condition = 0 OR accept_condition = ?) AND " + patternColumn + " IN (? " + patternINs + ") ORDER BY " + orderBy
 		}
 
-		rows, err := varnam.vstConn.QueryContext(ctx, query, vals...)
+		queryCtx, cancel := varnam.watchdogContext(ctx)
+		defer cancel()
+		defer varnam.watchdogCheck(queryCtx, ctx, patternString)
+
+		countQuery(ctx)
+		rows, err := varnam.vstConn.QueryContext(queryCtx, query, vals...)
 
 		if err != nil {
 			log.Print(err)
@@ -234,15 +335,71 @@ func (varnam *Varnam) findLongestPatternMatchSymbols(ctx context.Context, patter
 			results = append(results, item)
 		}
 
+		results = varnam.adjustPatternMatchSymbols(results, pattern, matchType, acceptCondition)
+
 		err = rows.Err()
 		if err != nil {
 			log.Print(err)
+		} else if cache != nil && queryCtx.Err() == nil {
+			// Interrupted lookups may have missed symbols
+			cache.set(cacheKey, results)
 		}
 
 		return results
 	}
 }
 
+// Apply user's weights & overrides on symbols found for pattern
+func (varnam *Varnam) adjustPatternMatchSymbols(results []Symbol, pattern []rune, matchType int, acceptCondition int) []Symbol {
+	if varnam.noGrantha() {
+		results = removeGranthaSymbols(results, matchType)
+	}
+
+	results = varnam.applyPatternSymbolWeights(results)
+	results = varnam.applySymbolOverrides(results, pattern, matchType, acceptCondition)
+
+	if varnam.CaseInsensitive {
+		results = preferExactCase(results, pattern, matchType)
+	}
+
+	return results
+}
+
+// Symbols of a case insensitive search whose pattern is in the
+// same case as typed are preferred. The ones in other case are
+// made possibility matches (removed if only exact matches are
+// asked) if a symbol in the same case exists for that pattern.
+// If not, they are used as they are.
+func preferExactCase(symbols []Symbol, typed []rune, matchType int) []Symbol {
+	exactCaseExists := map[int]bool{}
+	for _, symbol := range symbols {
+		length := len([]rune(symbol.Pattern))
+		if length <= len(typed) && symbol.Pattern == string(typed[:length]) {
+			exactCaseExists[length] = true
+		}
+	}
+
+	var exactCase, otherCase []Symbol
+	for _, symbol := range symbols {
+		length := len([]rune(symbol.Pattern))
+		if !exactCaseExists[length] || symbol.Pattern == string(typed[:length]) {
+			exactCase = append(exactCase, symbol)
+		} else if matchType != VARNAM_MATCH_EXACT {
+			symbol.MatchType = VARNAM_MATCH_POSSIBILITY
+			otherCase = append(otherCase, symbol)
+		}
+	}
+
+	results := append(exactCase, otherCase...)
+
+	// Longest pattern should still come first
+	sort.SliceStable(results, func(i, j int) bool {
+		return len(results[i].Pattern) > len(results[j].Pattern)
+	})
+
+	return results
+}
+
 // Convert a string into Tokens for later processing
 func (varnam *Varnam) tokenizeWord(ctx context.Context, word string, matchType int, partial bool) *[]Token {
 	var results []Token
@@ -251,17 +408,45 @@ func (varnam *Varnam) tokenizeWord(ctx context.Context, word string, matchType i
 	case <-ctx.Done():
 		return &results
 	default:
+		if varnam.InputMethod == VARNAM_INPUT_METHOD_INSCRIPT {
+			return varnam.tokenizeInscript(ctx, word)
+		}
+
+		if varnam.ScriptBoundary == VARNAM_SCRIPT_BOUNDARY_SPLIT {
+			if segments := varnam.SegmentByScript(word); isMixedScript(segments) {
+				return varnam.tokenizeSegments(ctx, segments, matchType, partial)
+			}
+		}
+
 		runes := []rune(word)
 
+		// A token is of at least one character
+		results = make([]Token, 0, len(runes))
+
+		controls := varnam.ControlCharacters
+		longest := varnam.patternLongestLength()
+
 		i := 0
 		for i < len(runes) {
-			end := i + varnam.LangRules.PatternLongestLength
+			if controls != nil {
+				if token, length, ok := controls.tokenize(runes, i); ok {
+					results = append(results, token)
+					i += length
+					continue
+				}
+			}
+
+			end := i + longest
 			if len(runes) < end {
 				end = len(runes)
 			}
 			// Get characters after 'i'th position
 			sequence := runes[i:end]
 
+			if controls != nil {
+				sequence = controls.cut(sequence)
+			}
+
 			acceptCondition := VARNAM_TOKEN_ACCEPT_IF_IN_BETWEEN
 
 			if len(results) == 0 && !partial {
@@ -273,6 +458,10 @@ func (varnam *Varnam) tokenizeWord(ctx context.Context, word string, matchType i
 
 			matches := varnam.findLongestPatternMatchSymbols(ctx, sequence, matchType, acceptCondition)
 
+			if controls != nil {
+				matches = removeJoinerSymbols(matches)
+			}
+
 			if len(matches) == 0 {
 				// No matches, add a character token
 				// Note that we just add 1 character, and move on
@@ -281,7 +470,7 @@ func (varnam *Varnam) tokenizeWord(ctx context.Context, word string, matchType i
 
 				i++
 			} else {
-				if matches[0].Type == VARNAM_SYMBOL_NUMBER && !varnam.LangRules.IndicDigits {
+				if matches[0].Type == VARNAM_SYMBOL_NUMBER && !varnam.useIndicDigits(ctx) {
 					// Skip numbers
 					// Note that we just add 1 character, and move on
 					token := Token{VARNAM_TOKEN_CHAR, []Symbol{}, i, string(sequence[:1])}
@@ -289,22 +478,29 @@ func (varnam *Varnam) tokenizeWord(ctx context.Context, word string, matchType i
 
 					i += len(matches[0].Pattern)
 				} else {
-					// Add matches
-					var refinedMatches []Symbol
-					longestPatternLength := 0
+					// Add matches. Sort is by length of pattern, so
+					// we will get length from the first one. Matches
+					// of that length are the first ones, they're cut
+					// like in removeLessWeightedSymbols
+					longestPatternLength := len(matches[0].Pattern)
 
+					kept := 0
 					for _, match := range matches {
-						if longestPatternLength == 0 {
-							// Sort is by length of pattern, so we will get length from first iterations.
-							longestPatternLength = len(match.Pattern)
-							refinedMatches = append(refinedMatches, match)
-						} else {
-							if len(match.Pattern) != longestPatternLength {
-								break
-							}
-							refinedMatches = append(refinedMatches, match)
+						if len(match.Pattern) != longestPatternLength {
+							break
 						}
+						kept++
+					}
+					refinedMatches := matches[:kept:kept]
+
+					if varnam.SchemeDetails.EndOfWordLookup && acceptCondition != VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH && longestPatternLength > 1 && i+longestPatternLength == len(runes) {
+						// Last token is of more than one character,
+						// symbols for end of word are also applicable.
+						// A word of a single letter is left as it is,
+						// it's mostly the first keystroke
+						refinedMatches = varnam.findEndOfWordSymbols(ctx, sequence, longestPatternLength, matchType, refinedMatches)
 					}
+
 					i += longestPatternLength
 
 					token := Token{VARNAM_TOKEN_SYMBOL, refinedMatches, i - 1, string(refinedMatches[0].Pattern)}
@@ -316,7 +512,30 @@ func (varnam *Varnam) tokenizeWord(ctx context.Context, word string, matchType i
 	}
 }
 
-// Tokenize end part of a word and append it to results
+// Symbols for the last token of a word, found by looking up
+// the token's pattern again with accept condition for word end.
+// Symbols only for the end of word come first, symbols only
+// for the middle of word are removed
+func (varnam *Varnam) findEndOfWordSymbols(ctx context.Context, sequence []rune, patternLength int, matchType int, matches []Symbol) []Symbol {
+	var endMatches []Symbol
+	for _, match := range varnam.findLongestPatternMatchSymbols(ctx, sequence[:patternLength], matchType, VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH) {
+		if len(match.Pattern) == patternLength && match.AcceptCondition == VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH {
+			endMatches = append(endMatches, match)
+		}
+	}
+
+	if len(endMatches) == 0 {
+		return matches
+	}
+
+	for _, match := range matches {
+		if match.AcceptCondition != VARNAM_TOKEN_ACCEPT_IF_IN_BETWEEN {
+			endMatches = append(endMatches, match)
+		}
+	}
+	return endMatches
+}
+
 func (varnam *Varnam) tokenizeRestOfWord(ctx context.Context, word string, sugs []Suggestion, limit int) []Suggestion {
 	var results []Suggestion
 
@@ -468,21 +687,24 @@ func getSymbolWeight(symbol Symbol) int {
 	return symbol.Weight
 }
 
-// Removes less weighted symbols
+// Removes less weighted symbols. Symbols kept are the first ones,
+// symbols slices are cut instead of being copied. They may be of
+// a symbol cache, capacity is cut too so that appends don't write to it
 func removeLessWeightedSymbols(tokens []Token) []Token {
 	for i := range tokens {
-		var reducedSymbols []Symbol
-		for _, symbol := range tokens[i].symbols {
+		symbols := tokens[i].symbols
+
+		kept := 0
+		for _, symbol := range symbols {
 			// TODO should 0 be fixed for all languages ?
 			// Because this may differ according to data source
 			// from where symbol frequency was found out
-			if getSymbolWeight(symbol) == 0 && len(reducedSymbols) > 0 {
+			if getSymbolWeight(symbol) == 0 && kept > 0 {
 				break
 			}
-			reducedSymbols = append(reducedSymbols, symbol)
+			kept++
 		}
-		tokens[i].symbols = nil
-		tokens[i].symbols = reducedSymbols
+		tokens[i].symbols = symbols[:kept:kept]
 	}
 	return tokens
 }
@@ -492,21 +714,23 @@ func removeNonExactTokens(tokens []Token) []Token {
 	// Remove non-exact symbols
 	for i, token := range tokens {
 		if token.tokenType == VARNAM_TOKEN_SYMBOL {
-			var reducedSymbols []Symbol
+			// Exact symbols come first, they're kept by cutting
+			// like in removeLessWeightedSymbols
+			kept := 0
 			for _, symbol := range token.symbols {
 				if symbol.MatchType == VARNAM_MATCH_EXACT {
-					reducedSymbols = append(reducedSymbols, symbol)
+					kept++
 				} else {
-					if len(reducedSymbols) == 0 {
+					if kept == 0 {
 						// No exact matches, so add the first possibility match
-						reducedSymbols = append(reducedSymbols, symbol)
+						kept = 1
 					}
 					// If a possibility result, then rest of them will also be same
 					// so save time by skipping rest
 					break
 				}
 			}
-			tokens[i].symbols = reducedSymbols
+			tokens[i].symbols = token.symbols[:kept:kept]
 		}
 	}
 	return tokens
@@ -592,7 +816,12 @@ func (varnam *Varnam) SearchSymbolTable(ctx context.Context, searchCriteria Symb
 	case <-ctx.Done():
 		return results, nil
 	default:
+		if varnam.mappedVST != nil {
+			return varnam.mappedSearchSymbolTable(ctx, searchCriteria), nil
+		}
+
 		query, values := varnam.makeSearchSymbolQuery("SELECT * FROM symbols", searchCriteria)
+		countQuery(ctx)
 		rows, err := varnam.vstConn.QueryContext(ctx, query, values...)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,176 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// User's weights of scheme symbols, loaded from learnings
+type symbolWeights struct {
+	mutex sync.RWMutex
+
+	// Weights by pattern & value1
+	weights map[symbolWeightKey]int
+}
+
+type symbolWeightKey struct {
+	pattern string
+	value1  string
+}
+
+func (varnam *Varnam) loadSymbolWeights() error {
+	symbols, err := varnam.GetSymbolWeights()
+	if err != nil {
+		return err
+	}
+
+	weights := map[symbolWeightKey]int{}
+	for _, symbol := range symbols {
+		weights[symbolWeightKey{symbol.Pattern, symbol.Value1}] = symbol.Weight
+	}
+
+	// Symbols cached by sessions have the old weights
+	atomic.AddInt64(&varnam.schemeGeneration, 1)
+
+	if varnam.symbolWeights == nil {
+		varnam.symbolWeights = &symbolWeights{weights: weights}
+		return nil
+	}
+
+	varnam.symbolWeights.mutex.Lock()
+	varnam.symbolWeights.weights = weights
+	varnam.symbolWeights.mutex.Unlock()
+
+	return nil
+}
+
+// SetSymbolWeight change weight of the scheme's symbol for
+// pattern giving value1. More weight makes the tokenizer prefer
+// the symbol over others of the same pattern, Eg: make "la" give
+// "ള" before "ല". Weights are kept in learnings
+func (varnam *Varnam) SetSymbolWeight(pattern string, value1 string, weight int) error {
+	if weight < 0 {
+		return fmt.Errorf("weight can't be negative")
+	}
+
+	search := NewSearchSymbol()
+	search.Pattern = pattern
+	search.Value1 = value1
+
+	results, err := varnam.SearchSymbolTable(context.Background(), search)
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		return fmt.Errorf("Scheme has no symbol %s => %s", pattern, value1)
+	}
+
+	_, err = varnam.dictWriteConn.Exec(
+		"INSERT OR REPLACE INTO symbol_weights (scheme_id, pattern, value1, weight) VALUES (?, ?, ?, ?)",
+		varnam.SchemeDetails.Identifier,
+		pattern,
+		value1,
+		weight,
+	)
+	if err != nil {
+		return err
+	}
+
+	return varnam.loadSymbolWeights()
+}
+
+// ResetSymbolWeight use the scheme's weight for the symbol again
+func (varnam *Varnam) ResetSymbolWeight(pattern string, value1 string) error {
+	result, err := varnam.dictWriteConn.Exec("DELETE FROM symbol_weights WHERE scheme_id = ? AND pattern = ? AND value1 = ?", varnam.SchemeDetails.Identifier, pattern, value1)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("Weight of symbol is not changed")
+	}
+
+	return varnam.loadSymbolWeights()
+}
+
+// GetSymbolWeights get the symbols whose weight has been changed.
+// Only Pattern, Value1 & Weight are set
+func (varnam *Varnam) GetSymbolWeights() ([]Symbol, error) {
+	var results []Symbol
+
+	rows, err := varnam.dictConn.Query("SELECT pattern, value1, weight FROM symbol_weights WHERE scheme_id = ? ORDER BY pattern, value1", varnam.SchemeDetails.Identifier)
+	if err != nil {
+		return results, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item Symbol
+		rows.Scan(&item.Pattern, &item.Value1, &item.Weight)
+		results = append(results, item)
+	}
+
+	return results, rows.Err()
+}
+
+// Put user's weights on symbols. Gives whether a weight was changed
+func (varnam *Varnam) applySymbolWeights(symbols []Symbol) bool {
+	sw := varnam.symbolWeights
+	if sw == nil {
+		return false
+	}
+
+	sw.mutex.RLock()
+	defer sw.mutex.RUnlock()
+
+	if len(sw.weights) == 0 {
+		return false
+	}
+
+	changed := false
+	for i, symbol := range symbols {
+		if weight, found := sw.weights[symbolWeightKey{symbol.Pattern, symbol.Value1}]; found {
+			symbols[i].Weight = weight
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// Put user's weights on symbols found for a pattern
+func (varnam *Varnam) applyPatternSymbolWeights(symbols []Symbol) []Symbol {
+	if varnam.applySymbolWeights(symbols) {
+		sortSymbols(symbols)
+	}
+	return symbols
+}
+
+// Put user's weights on symbols found for a value
+func (varnam *Varnam) applyValueSymbolWeights(symbols []Symbol) []Symbol {
+	if varnam.applySymbolWeights(symbols) {
+		// Exact matches first, like in searchPattern
+		sort.SliceStable(symbols, func(i, j int) bool {
+			if symbols[i].MatchType != symbols[j].MatchType {
+				return symbols[i].MatchType < symbols[j].MatchType
+			}
+			return symbols[i].Weight > symbols[j].Weight
+		})
+	}
+	return symbols
+}
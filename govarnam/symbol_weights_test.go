@@ -0,0 +1,38 @@
+package govarnam
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMLSymbolWeights(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	session := varnam.NewSession()
+	assertEqual(t, session.Append(context.Background(), "la").TokenizerSuggestions[0].Word, "ല")
+
+	checkError(varnam.SetSymbolWeight("la", "ള", 300))
+	defer varnam.ResetSymbolWeight("la", "ള")
+
+	assertEqual(t, varnam.TransliterateAdvanced("la").TokenizerSuggestions[0].Word, "ള")
+	assertEqual(t, varnam.TransliterateAdvanced("pala").TokenizerSuggestions[0].Word, "പള")
+	assertEqual(t, session.Transliterate(context.Background()).TokenizerSuggestions[0].Word, "ള")
+
+	weights, err := varnam.GetSymbolWeights()
+	checkError(err)
+	assertEqual(t, len(weights), 1)
+	assertEqual(t, weights[0].Weight, 300)
+
+	// Weights are kept in learnings
+	reopened, err := Init(varnam.VSTPath, varnam.DictPath)
+	checkError(err)
+	assertEqual(t, reopened.TransliterateAdvanced("la").TokenizerSuggestions[0].Word, "ള")
+	reopened.Close()
+
+	assertEqual(t, varnam.SetSymbolWeight("la", "ക", 300) != nil, true)
+	assertEqual(t, varnam.SetSymbolWeight("la", "ള", -1) != nil, true)
+
+	checkError(varnam.ResetSymbolWeight("la", "ള"))
+	assertEqual(t, varnam.TransliterateAdvanced("la").TokenizerSuggestions[0].Word, "ല")
+	assertEqual(t, varnam.ResetSymbolWeight("la", "ള") != nil, true)
+}
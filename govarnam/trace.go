@@ -0,0 +1,90 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import "context"
+
+// Tracer starts spans around stages of transliteration & learning,
+// for tracing slow requests end to end. An OpenTelemetry tracer
+// can be adapted to this, govarnam doesn't depend on it.
+// Spans are children of the span in the caller's context
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, TraceSpan)
+}
+
+// TraceSpan a stage being traced
+type TraceSpan interface {
+	// Like varnam.scheme, varnam.input_length & varnam.suggestions
+	SetAttribute(key string, value interface{})
+
+	// err is nil if the stage succeeded. Cancelled stages end with ctx.Err()
+	End(err error)
+}
+
+// Names of trace spans
+const (
+	TraceTransliterate     = "varnam.transliterate"
+	TraceTokenize          = "varnam.tokenize"
+	TraceTokenizer         = "varnam.tokenizer"
+	TraceDictionary        = "varnam.dictionary"
+	TracePatternDictionary = "varnam.pattern_dictionary"
+	TraceLearn             = "varnam.learn"
+	TraceTrain             = "varnam.train"
+	TraceUnlearn           = "varnam.unlearn"
+)
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, interface{}) {}
+func (noopSpan) End(error)                        {}
+
+// Start a span if Varnam.Tracer is set
+func (varnam *Varnam) startSpan(ctx context.Context, name string) (context.Context, TraceSpan) {
+	if varnam.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	ctx, span := varnam.Tracer.Start(ctx, name)
+	span.SetAttribute("varnam.scheme", varnam.SchemeDetails.Identifier)
+	return ctx, span
+}
+
+// LearnContext same as Learn, traced as a child of ctx's span
+func (varnam *Varnam) LearnContext(ctx context.Context, word string, weight int) error {
+	_, span := varnam.startSpan(ctx, TraceLearn)
+	err := varnam.Learn(word, weight)
+	span.End(err)
+	return err
+}
+
+// TrainContext same as Train, traced as a child of ctx's span
+func (varnam *Varnam) TrainContext(ctx context.Context, pattern string, word string) error {
+	_, span := varnam.startSpan(ctx, TraceTrain)
+	err := varnam.Train(pattern, word)
+	span.End(err)
+	return err
+}
+
+// UnlearnContext same as Unlearn, traced as a child of ctx's span
+func (varnam *Varnam) UnlearnContext(ctx context.Context, word string) error {
+	_, span := varnam.startSpan(ctx, TraceUnlearn)
+	err := varnam.Unlearn(word)
+	span.End(err)
+	return err
+}
+
+// Number of suggestions in all categories of result
+func countSuggestions(result TransliterationResult) int {
+	return len(result.ExactWords) +
+		len(result.ExactMatches) +
+		len(result.DictionarySuggestions) +
+		len(result.PatternDictionarySuggestions) +
+		len(result.TokenizerSuggestions) +
+		len(result.GreedyTokenized) +
+		len(result.FuzzySuggestions) +
+		len(result.Corrections)
+}
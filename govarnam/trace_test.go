@@ -0,0 +1,111 @@
+package govarnam
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type parentSpanKey struct{}
+
+type recordedSpan struct {
+	name       string
+	parent     string
+	attributes map[string]interface{}
+	ended      bool
+	err        error
+}
+
+// Records spans with the name of their parent
+type recordingTracer struct {
+	mutex sync.Mutex
+	spans []*recordedSpan
+}
+
+func (tracer *recordingTracer) Start(ctx context.Context, name string) (context.Context, TraceSpan) {
+	parent, _ := ctx.Value(parentSpanKey{}).(string)
+	span := &recordedSpan{name: name, parent: parent, attributes: map[string]interface{}{}}
+
+	tracer.mutex.Lock()
+	tracer.spans = append(tracer.spans, span)
+	tracer.mutex.Unlock()
+
+	return context.WithValue(ctx, parentSpanKey{}, name), &recordingSpan{tracer, span}
+}
+
+func (tracer *recordingTracer) find(name string) *recordedSpan {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+
+	for _, span := range tracer.spans {
+		if span.name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	span   *recordedSpan
+}
+
+func (s *recordingSpan) SetAttribute(key string, value interface{}) {
+	s.tracer.mutex.Lock()
+	defer s.tracer.mutex.Unlock()
+	s.span.attributes[key] = value
+}
+
+func (s *recordingSpan) End(err error) {
+	s.tracer.mutex.Lock()
+	defer s.tracer.mutex.Unlock()
+	s.span.ended = true
+	s.span.err = err
+}
+
+func TestMLTracer(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	tracer := &recordingTracer{}
+	varnam.Tracer = tracer
+	defer func() {
+		varnam.Tracer = nil
+	}()
+
+	ctx := context.WithValue(context.Background(), parentSpanKey{}, "request")
+
+	varnam.TransliterateAdvancedWithOptions(ctx, "kollam", TransliterateOptions{})
+
+	transliterate := tracer.find(TraceTransliterate)
+	if transliterate == nil {
+		t.Fatal("Expected a transliterate span")
+	}
+	assertEqual(t, transliterate.parent, "request")
+	assertEqual(t, transliterate.ended, true)
+	assertEqual(t, transliterate.attributes["varnam.scheme"], "ml")
+	assertEqual(t, transliterate.attributes["varnam.input_length"], 6)
+
+	for _, name := range []string{TraceTokenize, TraceDictionary, TracePatternDictionary} {
+		span := tracer.find(name)
+		if span == nil {
+			t.Errorf("Expected a %s span", name)
+			continue
+		}
+		assertEqual(t, span.parent, TraceTransliterate)
+		assertEqual(t, span.ended, true)
+	}
+
+	err := varnam.LearnContext(ctx, "കൊല്ലം", 0)
+	checkError(err)
+
+	learn := tracer.find(TraceLearn)
+	if learn == nil {
+		t.Fatal("Expected a learn span")
+	}
+	assertEqual(t, learn.parent, "request")
+	assertEqual(t, learn.err, nil)
+
+	err = varnam.UnlearnContext(ctx, "")
+	assertEqual(t, errors.Is(tracer.find(TraceUnlearn).err, err), true)
+}
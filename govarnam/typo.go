@@ -0,0 +1,88 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import "unicode"
+
+// TypoSubstitute a character that may have been meant
+type TypoSubstitute struct {
+	Char rune
+
+	// 0 - 1. How likely it is that Char was meant
+	Likelihood float64
+}
+
+// TypoModel tells which characters could've been meant when a
+// character was typed. Used by fuzzy matching for substitutions.
+// See Varnam.TypoModel
+type TypoModel interface {
+	Substitutes(char rune) []TypoSubstitute
+}
+
+// KeyboardTypoModel typos made by hitting a neighbouring key
+type KeyboardTypoModel struct {
+	neighbours map[rune][]rune
+
+	// Likelihood of every neighbouring key
+	Likelihood float64
+}
+
+// NewKeyboardTypoModel make a typo model for a keyboard layout.
+// rows are the letter rows from top, each row being half a key
+// to the right of the row above like in a normal keyboard
+func NewKeyboardTypoModel(rows []string, likelihood float64) *KeyboardTypoModel {
+	model := &KeyboardTypoModel{map[rune][]rune{}, likelihood}
+
+	keys := make([][]rune, len(rows))
+	for i, row := range rows {
+		keys[i] = []rune(row)
+	}
+
+	at := func(row int, col int) (rune, bool) {
+		if row < 0 || row >= len(keys) || col < 0 || col >= len(keys[row]) {
+			return 0, false
+		}
+		return keys[row][col], true
+	}
+
+	for row := range keys {
+		for col, key := range keys[row] {
+			for _, pos := range [][2]int{
+				{row, col - 1}, {row, col + 1},
+				{row - 1, col}, {row - 1, col + 1},
+				{row + 1, col - 1}, {row + 1, col},
+			} {
+				if neighbour, ok := at(pos[0], pos[1]); ok {
+					model.neighbours[key] = append(model.neighbours[key], neighbour)
+				}
+			}
+		}
+	}
+
+	return model
+}
+
+// NewQWERTYTypoModel typo model for QWERTY keyboards
+func NewQWERTYTypoModel() *KeyboardTypoModel {
+	return NewKeyboardTypoModel([]string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}, VARNAM_TYPO_NEIGHBOUR_KEY_LIKELIHOOD)
+}
+
+// Substitutes keys around char. Case of char is kept
+func (model *KeyboardTypoModel) Substitutes(char rune) []TypoSubstitute {
+	var substitutes []TypoSubstitute
+
+	upper := unicode.IsUpper(char)
+
+	for _, neighbour := range model.neighbours[unicode.ToLower(char)] {
+		if upper {
+			neighbour = unicode.ToUpper(neighbour)
+		}
+		substitutes = append(substitutes, TypoSubstitute{neighbour, model.Likelihood})
+	}
+
+	return substitutes
+}
@@ -0,0 +1,49 @@
+package govarnam
+
+import (
+	"testing"
+)
+
+func TestQWERTYTypoModel(t *testing.T) {
+	model := NewQWERTYTypoModel()
+
+	chars := func(substitutes []TypoSubstitute) string {
+		var result []rune
+		for _, substitute := range substitutes {
+			result = append(result, substitute.Char)
+		}
+		return string(result)
+	}
+
+	assertEqual(t, chars(model.Substitutes('s')), "adwezx")
+	assertEqual(t, chars(model.Substitutes('q')), "wa")
+	assertEqual(t, chars(model.Substitutes('m')), "njk")
+	assertEqual(t, chars(model.Substitutes('S')), "ADWEZX")
+	assertEqual(t, len(model.Substitutes('1')), 0)
+	assertEqual(t, model.Substitutes('s')[0].Likelihood, VARNAM_TYPO_NEIGHBOUR_KEY_LIKELIHOOD)
+}
+
+func TestMLFuzzyTypoModel(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	err := varnam.Learn("മലയാളം", 0)
+	checkError(err)
+
+	varnam.FuzzyMatching = true
+
+	defer func() {
+		varnam.FuzzyMatching = false
+		varnam.TypoModel = NewQWERTYTypoModel()
+		varnam.Unlearn("മലയാളം")
+	}()
+
+	// s is next to a
+	sugs := varnam.TransliterateAdvanced("malayslam").FuzzySuggestions
+	assertEqual(t, sugs[0].Word, "മലയാളം")
+
+	// Substitutions are less likely than a missed vowel
+	assertEqual(t, sugs[0].Score < varnam.TransliterateAdvanced("malayalm").FuzzySuggestions[0].Score, true)
+
+	varnam.TypoModel = nil
+	assertEqual(t, len(varnam.TransliterateAdvanced("malayslam").FuzzySuggestions), 0)
+}
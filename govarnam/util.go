@@ -1,7 +1,10 @@
 package govarnam
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -44,3 +47,44 @@ func dirExists(loc string) bool {
 	}
 	return info.IsDir()
 }
+
+// Parse a version like v1.9.0 or 1.9
+func parseVersion(version string) ([]int, error) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return nil, fmt.Errorf("version is empty")
+	}
+
+	var parts []int
+	for _, part := range strings.Split(version, ".") {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+		parts = append(parts, number)
+	}
+
+	return parts, nil
+}
+
+// Compare versions from parseVersion. Missing parts are 0.
+// -1 if a is older than b, 1 if newer, 0 if same
+func compareVersions(a []int, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		partA, partB := 0, 0
+		if i < len(a) {
+			partA = a[i]
+		}
+		if i < len(b) {
+			partB = b[i]
+		}
+
+		if partA < partB {
+			return -1
+		}
+		if partA > partB {
+			return 1
+		}
+	}
+	return 0
+}
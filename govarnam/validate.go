@@ -0,0 +1,97 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError why a word or pattern can't be learnt as given
+type ValidationError struct {
+	Word string
+
+	// The offending character and its position (in characters)
+	// in the sanitized word. Position is -1 if it's not about a character
+	Character string
+	Position  int
+
+	Reason string
+}
+
+func (err *ValidationError) Error() string {
+	if err.Position == -1 {
+		return err.Reason
+	}
+	return fmt.Sprintf("%s: '%s' at position %d of %s", err.Reason, err.Character, err.Position, err.Word)
+}
+
+// Checks done by Learn. Gives the word as it would be stored
+func (varnam *Varnam) validateLearn(word string) (string, error) {
+	conjuncts := varnam.splitWordByConjunct(varnam.sanitizeWord(word))
+
+	if len(conjuncts) == 0 {
+		return "", &ValidationError{word, "", -1, "Nothing to learn"}
+	}
+
+	if len(conjuncts) == 1 {
+		return "", &ValidationError{word, "", -1, "Can't learn a single conjunct"}
+	}
+
+	// reconstruct word
+	word = strings.Join(conjuncts, "")
+
+	blacklisted, err := varnam.getBlacklisted(context.Background(), []string{word})
+	if err != nil {
+		return "", err
+	}
+	if blacklisted[word] {
+		return "", &ValidationError{word, "", -1, fmt.Sprintf("%s is blacklisted", word)}
+	}
+
+	return word, nil
+}
+
+// CanLearn check if a word can be learnt without learning it.
+// Unlike Learn, which silently leaves them out, characters that
+// won't be part of the learnt word are also reported
+func (varnam *Varnam) CanLearn(word string) error {
+	_, err := varnam.validateLearn(word)
+	if err != nil {
+		return err
+	}
+
+	word = varnam.sanitizeWord(word)
+
+	position := 0
+	for _, token := range varnam.splitTextByConjunct(context.Background(), word) {
+		if token.tokenType != VARNAM_TOKEN_SYMBOL {
+			return &ValidationError{word, token.character, position, "Not a language character"}
+		}
+
+		for _, symbol := range token.symbols {
+			if symbol.Type == VARNAM_SYMBOL_NUMBER || symbol.Type == VARNAM_SYMBOL_PERIOD || symbol.Type == VARNAM_SYMBOL_SYMBOL {
+				return &ValidationError{word, token.character, position, "Numbers and symbols are not learnt"}
+			}
+		}
+
+		position += utf8.RuneCountInString(token.character)
+	}
+
+	return nil
+}
+
+// ValidateTrain check if a pattern => word can be trained without training it
+func (varnam *Varnam) ValidateTrain(pattern string, word string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return &ValidationError{word, "", -1, "Pattern can't be empty"}
+	}
+
+	return varnam.CanLearn(word)
+}
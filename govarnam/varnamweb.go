@@ -0,0 +1,186 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// varnamWebWord is an item in the per-word JSON export of
+// Varnam web editor and the tools around it :
+//
+//	[
+//	  {"word": "മലയാളം", "confidence": 3, "patterns": ["malayalam", "malayaalam"]},
+//	  ...
+//	]
+//
+// The list can also be inside a {"words": [...]} object.
+// Some exports use weight instead of confidence and
+// a single pattern instead of patterns.
+type varnamWebWord struct {
+	Word       string   `json:"word"`
+	Confidence int      `json:"confidence"`
+	Weight     int      `json:"weight"`
+	Pattern    string   `json:"pattern"`
+	Patterns   []string `json:"patterns"`
+}
+
+// Move decoder to the start of word list
+func varnamWebSeekWords(decoder *json.Decoder) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if token == json.Delim('[') {
+		return nil
+	}
+
+	if token != json.Delim('{') {
+		return fmt.Errorf("expected a list of words")
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		if key == "words" {
+			token, err = decoder.Token()
+			if err != nil {
+				return err
+			}
+			if token != json.Delim('[') {
+				return fmt.Errorf("expected a list of words")
+			}
+			return nil
+		}
+
+		// Skip the value of other keys
+		var skip json.RawMessage
+		err = decoder.Decode(&skip)
+		if err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("no words in file")
+}
+
+// ImportFromVarnamWeb learn words and train patterns from a JSON export
+// of Varnam web editor. Words are learnt with LearnMany and their
+// patterns are trained, so existing words get a weight bump.
+// Weights less than VARNAM_LEARNT_WORD_MIN_WEIGHT are taken
+// as libvarnam style confidence starting from 1.
+func (varnam *Varnam) ImportFromVarnamWeb(filePath string) (LearnStatus, error) {
+	defer varnam.dictionaryChanged()
+
+	learnStatus := LearnStatus{0, 0}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return learnStatus, err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+
+	err = varnamWebSeekWords(decoder)
+	if err != nil {
+		return learnStatus, fmt.Errorf("Parsing JSON failed, err: %s", err.Error())
+	}
+
+	limitVariableNumber := getSQLiteLimit(sqliteLimitVariableNumber)
+
+	// We have 2 fields per item, word and weight
+	insertsPerTransaction := int(float64(limitVariableNumber) / 2)
+
+	var (
+		words    []WordInfo
+		patterns [][2]string
+	)
+
+	learnBatch := func() error {
+		learnStatusBatch, err := varnam.LearnMany(words)
+		if err != nil {
+			return err
+		}
+
+		learnStatus.TotalWords += learnStatusBatch.TotalWords
+		learnStatus.FailedWords += learnStatusBatch.FailedWords
+
+		words = nil
+		return nil
+	}
+
+	for decoder.More() {
+		var item varnamWebWord
+
+		err := decoder.Decode(&item)
+		if err != nil {
+			return learnStatus, fmt.Errorf("Parsing JSON failed, err: %s", err.Error())
+		}
+
+		weight := item.Weight
+		if weight == 0 {
+			weight = item.Confidence
+		}
+		if weight > 0 && weight < VARNAM_LEARNT_WORD_MIN_WEIGHT {
+			weight += VARNAM_LEARNT_WORD_MIN_WEIGHT - 1
+		}
+
+		words = append(words, WordInfo{0, item.Word, weight, 0})
+
+		if item.Pattern != "" {
+			item.Patterns = append(item.Patterns, item.Pattern)
+		}
+
+		for _, pattern := range item.Patterns {
+			patterns = append(patterns, [2]string{pattern, item.Word})
+		}
+
+		if len(words) == insertsPerTransaction {
+			err = learnBatch()
+			if err != nil {
+				return learnStatus, err
+			}
+		}
+	}
+
+	if len(words) > 0 {
+		err = learnBatch()
+		if err != nil {
+			return learnStatus, err
+		}
+	}
+
+	// Not using Train() because it will learn the word again
+	stmt, err := varnam.dictWriteConn.Prepare("INSERT OR IGNORE INTO patterns(pattern, word_id) SELECT ?, id FROM words WHERE word = ?")
+	if err != nil {
+		return learnStatus, err
+	}
+	defer stmt.Close()
+
+	for _, item := range patterns {
+		word := varnam.sanitizeWord(item[1])
+
+		result, err := stmt.Exec(item[0], word)
+		if err != nil {
+			return learnStatus, err
+		}
+
+		// Word may not have been learnt
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			varnam.runTrainHooks(item[0], word)
+		}
+	}
+
+	return learnStatus, nil
+}
@@ -0,0 +1,59 @@
+package govarnam
+
+import (
+	"os"
+	"path"
+	"testing"
+)
+
+func TestMLImportFromVarnamWeb(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	filePath := path.Join(testTempDir, "varnamweb.json")
+
+	content := `{
+		"language": "ml",
+		"words": [
+			{"word": "കോഴിക്കോട്", "confidence": 3, "patterns": ["calicut", "kozhikode"]},
+			{"word": "ഇടുക്കി", "weight": 40, "pattern": "idukki"},
+			{"word": "ക"}
+		]
+	}`
+	checkError(os.WriteFile(filePath, []byte(content), 0644))
+
+	learnStatus, err := varnam.ImportFromVarnamWeb(filePath)
+	checkError(err)
+	assertEqual(t, learnStatus.TotalWords, 3)
+
+	// Single conjunct can't be learnt
+	assertEqual(t, learnStatus.FailedWords, 1)
+
+	wordInfo, err := varnam.getWordInfo("കോഴിക്കോട്")
+	checkError(err)
+	assertEqual(t, wordInfo.weight, VARNAM_LEARNT_WORD_MIN_WEIGHT+2)
+
+	var count int
+	varnam.dictConn.QueryRow("SELECT COUNT(*) FROM patterns WHERE word_id = ?", wordInfo.id).Scan(&count)
+	assertEqual(t, count, 2)
+
+	wordInfo, err = varnam.getWordInfo("ഇടുക്കി")
+	checkError(err)
+	assertEqual(t, wordInfo.weight, 40)
+
+	// Top level list
+	checkError(os.WriteFile(filePath, []byte(`[{"word": "ഇടുക്കി"}]`), 0644))
+
+	learnStatus, err = varnam.ImportFromVarnamWeb(filePath)
+	checkError(err)
+	assertEqual(t, learnStatus.TotalWords, 1)
+
+	wordInfo, _ = varnam.getWordInfo("ഇടുക്കി")
+	assertEqual(t, wordInfo.weight, 41)
+
+	checkError(os.WriteFile(filePath, []byte(`"invalid"`), 0644))
+	_, err = varnam.ImportFromVarnamWeb(filePath)
+	assertEqual(t, err != nil, true)
+
+	checkError(varnam.Unlearn("കോഴിക്കോട്"))
+	checkError(varnam.Unlearn("ഇടുക്കി"))
+}
@@ -15,7 +15,6 @@ import (
 
 // VM, vm = Vst Maker
 // Ported from libvarnam. Some are not ported:
-// * stem rules
 // * symbols flag setting
 
 // VMInit init
@@ -130,6 +129,10 @@ func (varnam *Varnam) vmDiscardChanges() error {
 
 // VMCreateToken Create Token
 func (varnam *Varnam) VMCreateToken(pattern string, value1 string, value2 string, value3 string, tag string, symbolType int, matchType int, priority int, acceptCondition int, buffered bool) error {
+	if varnam.mappedVST != nil {
+		return errMappedVSTReadOnly
+	}
+
 	if pattern == "" || value1 == "" {
 		return fmt.Errorf("pattern or value1 is empty")
 	}
@@ -281,6 +284,10 @@ func (varnam *Varnam) vmAlreadyPersisted(pattern string, value1 string, matchTyp
 
 // VMDeleteToken Removes a token from VST
 func (varnam *Varnam) VMDeleteToken(searchCriteria Symbol) error {
+	if varnam.mappedVST != nil {
+		return errMappedVSTReadOnly
+	}
+
 	query, values := varnam.makeSearchSymbolQuery("DELETE FROM symbols", searchCriteria)
 	_, err := varnam.vstConn.Exec(query, values...)
 	if err != nil {
@@ -290,6 +297,62 @@ func (varnam *Varnam) VMDeleteToken(searchCriteria Symbol) error {
 	return nil
 }
 
+// VMSetWeight set weight of tokens matching searchCriteria.
+// Weight orders VARNAM_MATCH_POSSIBILITY tokens of the same pattern
+func (varnam *Varnam) VMSetWeight(searchCriteria Symbol, weight int) error {
+	if varnam.mappedVST != nil {
+		return errMappedVSTReadOnly
+	}
+
+	query, values := varnam.makeSearchSymbolQuery("UPDATE symbols SET weight = ?", searchCriteria)
+	_, err := varnam.vstConn.Exec(query, append([]interface{}{weight}, values...)...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// VMCreateStemRule make words ending with oldEnding learn a stem
+// ending with newEnding. Eg: ത്തിൽ => ം for മലയാളത്തിൽ => മലയാളം
+func (varnam *Varnam) VMCreateStemRule(oldEnding string, newEnding string) error {
+	if varnam.mappedVST != nil {
+		return errMappedVSTReadOnly
+	}
+
+	if oldEnding == "" {
+		return fmt.Errorf("old ending is empty")
+	}
+
+	var count int
+	err := varnam.vstConn.QueryRow("SELECT COUNT(*) FROM stemrules WHERE old_ending = ?", oldEnding).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count != 0 {
+		return fmt.Errorf("there is already a stem rule for '%s'. Duplicate entries are not allowed", oldEnding)
+	}
+
+	_, err = varnam.vstConn.Exec("INSERT INTO stemrules (old_ending, new_ending) VALUES (?, ?)", oldEnding, newEnding)
+	return err
+}
+
+// VMCreateStemException don't apply the stem rule of
+// oldEnding on words ending with exception
+func (varnam *Varnam) VMCreateStemException(oldEnding string, exception string) error {
+	if varnam.mappedVST != nil {
+		return errMappedVSTReadOnly
+	}
+
+	if oldEnding == "" || exception == "" {
+		return fmt.Errorf("old ending or exception is empty")
+	}
+
+	_, err := varnam.vstConn.Exec("INSERT INTO stem_exceptions (stem, exception) VALUES (?, ?)", oldEnding, exception)
+	return err
+}
+
 // Makes a prefix tree. This fills up the flags column
 // TODO incomplete
 func (varnam *Varnam) vmMakePrefixTree() error {
@@ -341,15 +404,30 @@ func (varnam *Varnam) vmAddMetadata(key string, value string) error {
 
 // VMSetSchemeDetails set scheme details
 func (varnam *Varnam) VMSetSchemeDetails(sd SchemeDetails) error {
+	if varnam.mappedVST != nil {
+		return errMappedVSTReadOnly
+	}
+
 	if len(sd.LangCode) != 2 {
 		return fmt.Errorf("language code should be one of ISO 639-1 two letter codes")
 	}
 
+	if sd.MinLibraryVersion != "" {
+		if _, err := parseVersion(sd.MinLibraryVersion); err != nil {
+			return err
+		}
+	}
+
 	isStable := "1"
 	if !sd.IsStable {
 		isStable = "0"
 	}
 
+	endOfWordLookup := "0"
+	if sd.EndOfWordLookup {
+		endOfWordLookup = "1"
+	}
+
 	type item struct {
 		name  string
 		key   string
@@ -363,6 +441,11 @@ func (varnam *Varnam) VMSetSchemeDetails(sd SchemeDetails) error {
 		{"author", VARNAM_METADATA_SCHEME_AUTHOR, sd.Author},
 		{"compiled date", VARNAM_METADATA_SCHEME_COMPILED_DATE, sd.CompiledDate},
 		{"stable", VARNAM_METADATA_SCHEME_STABLE, isStable},
+		{"native display name", VARNAM_METADATA_SCHEME_NATIVE_DISPLAY_NAME, sd.NativeDisplayName},
+		{"version", VARNAM_METADATA_SCHEME_VERSION, sd.Version},
+		{"minimum library version", VARNAM_METADATA_SCHEME_MIN_LIBRARY_VERSION, sd.MinLibraryVersion},
+		{"base scheme", VARNAM_METADATA_SCHEME_BASE, sd.Base},
+		{"end of word lookup", VARNAM_METADATA_SCHEME_END_OF_WORD_LOOKUP, endOfWordLookup},
 	}
 
 	for _, o := range items {
@@ -2,6 +2,7 @@ package govarnam
 
 import (
 	"context"
+	"os"
 	"path"
 	"strings"
 	"testing"
@@ -232,3 +233,57 @@ func TestPrefixTree(t *testing.T) {
 	// varnam, err := initTestVM()
 	// checkError(err)
 }
+
+func TestSchemeMetadata(t *testing.T) {
+	vstPath := path.Join(testTempDir, "metadata.vst")
+
+	makeVST := func(minLibraryVersion string) {
+		os.Remove(vstPath)
+
+		vm, err := VMInit(vstPath)
+		checkError(err)
+		checkError(vm.VMSetSchemeDetails(SchemeDetails{
+			Identifier:        "metadata",
+			LangCode:          "ml",
+			DisplayName:       "Malayalam",
+			NativeDisplayName: "മലയാളം",
+			Version:           "1.2",
+			MinLibraryVersion: minLibraryVersion,
+		}))
+		checkError(vm.VMCreateToken("ka", "ക", "", "", "", VARNAM_SYMBOL_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false))
+		vm.Close()
+	}
+
+	makeVST("1.9")
+
+	varnam := Varnam{}
+	checkError(varnam.InitVST(vstPath))
+	assertEqual(t, varnam.SchemeDetails.NativeDisplayName, "മലയാളം")
+	assertEqual(t, varnam.SchemeDetails.Version, "1.2")
+	assertEqual(t, varnam.SchemeDetails.MinLibraryVersion, "1.9")
+	varnam.Close()
+
+	prevVersion := VersionString
+	defer func() {
+		VersionString = prevVersion
+	}()
+
+	VersionString = "v1.9.0"
+	varnam = Varnam{}
+	checkError(varnam.InitVST(vstPath))
+	varnam.Close()
+
+	VersionString = "1.8.5"
+	varnam = Varnam{}
+	assertEqual(t, varnam.InitVST(vstPath) != nil, true)
+
+	// Development builds load everything
+	VersionString = "latest"
+	varnam = Varnam{}
+	checkError(varnam.InitVST(vstPath))
+	varnam.Close()
+
+	vm, err := initTestVM()
+	checkError(err)
+	assertEqual(t, vm.VMSetSchemeDetails(SchemeDetails{LangCode: "ml", MinLibraryVersion: "one"}) != nil, true)
+}
@@ -0,0 +1,827 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"unicode/utf8"
+)
+
+// A mapped VST (VST v2) has the same symbols, metadata & stem rules
+// as a SQLite VST but is laid out to be mmapped and read as is. Only
+// the header is checked at init, pages of the file are read by the OS
+// when a lookup touches them. Apps loading many languages start faster
+// and use less memory. It's read only, made from a loaded scheme with
+// WriteMappedVST. All numbers are little endian uint32 (int32 for
+// symbol fields), strings are (offset, length) into the file :
+//
+//	header          magic, version, counts & offsets of sections below
+//	symbols         fixed size records, ordered by ID
+//	pattern index   symbol indexes, ordered by pattern
+//	folded index    symbol indexes, ordered by ASCII lowercase pattern
+//	value index     (symbol index, 1 or 2), ordered by value1/value2
+//	metadata        (key, value) strings
+//	stem rules      (old ending, new ending) strings, longest first
+//	stem exceptions (old ending, exception) strings
+//	string data
+
+var mappedVSTMagic = []byte("VARNAMVS")
+
+var errMappedVSTReadOnly = fmt.Errorf("mapped VST can't be changed, change the SQLite VST & make it again")
+
+const (
+	mappedVSTVersion    = 2
+	mappedVSTHeaderSize = 64
+
+	// 7 int32 fields & 5 strings
+	mappedVSTSymbolSize = 7*4 + 5*8
+)
+
+// Header fields after magic, in order
+const (
+	mappedHeaderVersion = iota
+	mappedHeaderSymbolCount
+	mappedHeaderSymbolsOffset
+	mappedHeaderPatternIndexOffset
+	mappedHeaderFoldedIndexOffset
+	mappedHeaderValueIndexCount
+	mappedHeaderValueIndexOffset
+	mappedHeaderMetadataCount
+	mappedHeaderMetadataOffset
+	mappedHeaderStemRuleCount
+	mappedHeaderStemRulesOffset
+	mappedHeaderStemExceptionCount
+	mappedHeaderStemExceptionsOffset
+	mappedHeaderPatternLongestLength
+)
+
+type mappedVST struct {
+	data []byte
+
+	symbolCount        int
+	symbols            int
+	patternIndex       int
+	foldedIndex        int
+	valueIndexCount    int
+	valueIndex         int
+	metadataCount      int
+	metadata           int
+	stemRuleCount      int
+	stemRules          int
+	stemExceptionCount int
+	stemExceptions     int
+
+	patternLongestLength int
+}
+
+// Whether file at path is a mapped VST
+func isMappedVST(vstPath string) bool {
+	file, err := os.Open(vstPath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	magic := make([]byte, len(mappedVSTMagic))
+	_, err = file.Read(magic)
+	return err == nil && bytes.Equal(magic, mappedVSTMagic)
+}
+
+func openMappedVST(vstPath string) (*mappedVST, error) {
+	data, err := mmapFile(vstPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, err := parseMappedVST(data)
+	if err != nil {
+		munmapFile(data)
+		return nil, fmt.Errorf("%s: %s", vstPath, err.Error())
+	}
+
+	return mapped, nil
+}
+
+// Check the header & that sections are within the file.
+// Nothing else is read
+func parseMappedVST(data []byte) (*mappedVST, error) {
+	if len(data) < mappedVSTHeaderSize || !bytes.Equal(data[:len(mappedVSTMagic)], mappedVSTMagic) {
+		return nil, fmt.Errorf("not a mapped VST")
+	}
+
+	header := func(field int) int {
+		return int(binary.LittleEndian.Uint32(data[len(mappedVSTMagic)+field*4:]))
+	}
+
+	if header(mappedHeaderVersion) != mappedVSTVersion {
+		return nil, fmt.Errorf("mapped VST version %d is not supported", header(mappedHeaderVersion))
+	}
+
+	mapped := &mappedVST{
+		data:                 data,
+		symbolCount:          header(mappedHeaderSymbolCount),
+		symbols:              header(mappedHeaderSymbolsOffset),
+		patternIndex:         header(mappedHeaderPatternIndexOffset),
+		foldedIndex:          header(mappedHeaderFoldedIndexOffset),
+		valueIndexCount:      header(mappedHeaderValueIndexCount),
+		valueIndex:           header(mappedHeaderValueIndexOffset),
+		metadataCount:        header(mappedHeaderMetadataCount),
+		metadata:             header(mappedHeaderMetadataOffset),
+		stemRuleCount:        header(mappedHeaderStemRuleCount),
+		stemRules:            header(mappedHeaderStemRulesOffset),
+		stemExceptionCount:   header(mappedHeaderStemExceptionCount),
+		stemExceptions:       header(mappedHeaderStemExceptionsOffset),
+		patternLongestLength: header(mappedHeaderPatternLongestLength),
+	}
+
+	sections := []struct {
+		offset, count, size int
+	}{
+		{mapped.symbols, mapped.symbolCount, mappedVSTSymbolSize},
+		{mapped.patternIndex, mapped.symbolCount, 4},
+		{mapped.foldedIndex, mapped.symbolCount, 4},
+		{mapped.valueIndex, mapped.valueIndexCount, 8},
+		{mapped.metadata, mapped.metadataCount, 16},
+		{mapped.stemRules, mapped.stemRuleCount, 16},
+		{mapped.stemExceptions, mapped.stemExceptionCount, 16},
+	}
+	for _, section := range sections {
+		if section.offset < mappedVSTHeaderSize || section.offset+section.count*section.size > len(data) {
+			return nil, fmt.Errorf("mapped VST is corrupt")
+		}
+	}
+
+	if mapped.symbolCount == 0 || mapped.patternLongestLength == 0 {
+		return nil, fmt.Errorf("mapped VST has no symbols")
+	}
+
+	return mapped, nil
+}
+
+func (mapped *mappedVST) close() error {
+	data := mapped.data
+	mapped.data = nil
+	return munmapFile(data)
+}
+
+func (mapped *mappedVST) uint32At(offset int) int {
+	return int(binary.LittleEndian.Uint32(mapped.data[offset:]))
+}
+
+// String at offset, as bytes in the file
+func (mapped *mappedVST) bytesAt(offset int) []byte {
+	start := mapped.uint32At(offset)
+	end := start + mapped.uint32At(offset+4)
+	if start > end || end > len(mapped.data) {
+		return nil
+	}
+	return mapped.data[start:end]
+}
+
+func (mapped *mappedVST) stringAt(offset int) string {
+	return string(mapped.bytesAt(offset))
+}
+
+func (mapped *mappedVST) symbolOffset(index int) int {
+	return mapped.symbols + index*mappedVSTSymbolSize
+}
+
+func (mapped *mappedVST) symbolPattern(index int) []byte {
+	return mapped.bytesAt(mapped.symbolOffset(index) + 7*4)
+}
+
+func (mapped *mappedVST) symbol(index int) Symbol {
+	offset := mapped.symbolOffset(index)
+
+	field := func(i int) int {
+		return int(int32(binary.LittleEndian.Uint32(mapped.data[offset+i*4:])))
+	}
+	str := func(i int) string {
+		return mapped.stringAt(offset + 7*4 + i*8)
+	}
+
+	return Symbol{
+		Identifier:      field(0),
+		Type:            field(1),
+		MatchType:       field(2),
+		Weight:          field(3),
+		Priority:        field(4),
+		AcceptCondition: field(5),
+		Flags:           field(6),
+		Pattern:         str(0),
+		Value1:          str(1),
+		Value2:          str(2),
+		Value3:          str(3),
+		Tag:             str(4),
+	}
+}
+
+// Symbol index at position of an index section
+func (mapped *mappedVST) indexAt(section int, position int) int {
+	index := mapped.uint32At(section + position*4)
+	if index >= mapped.symbolCount {
+		return 0
+	}
+	return index
+}
+
+// Symbols with exactly this pattern, ordered by ID. SQLite's
+// NOCASE is followed for caseInsensitive, only ASCII is folded
+func (mapped *mappedVST) symbolsByPattern(pattern string, caseInsensitive bool) []Symbol {
+	target := []byte(pattern)
+
+	section := mapped.patternIndex
+	compare := func(index int) int {
+		return bytes.Compare(mapped.symbolPattern(index), target)
+	}
+
+	if caseInsensitive {
+		section = mapped.foldedIndex
+		compare = func(index int) int {
+			return compareASCIIFolded(mapped.symbolPattern(index), target)
+		}
+	}
+
+	start := sort.Search(mapped.symbolCount, func(i int) bool {
+		return compare(mapped.indexAt(section, i)) >= 0
+	})
+
+	var results []Symbol
+	for i := start; i < mapped.symbolCount; i++ {
+		index := mapped.indexAt(section, i)
+		if compare(index) != 0 {
+			break
+		}
+		results = append(results, mapped.symbol(index))
+	}
+
+	if caseInsensitive {
+		// Folded index is ordered by pattern as it is within a folded pattern
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Identifier < results[j].Identifier
+		})
+	}
+
+	return results
+}
+
+// Symbols with value1 or value2 as value, ordered by ID
+func (mapped *mappedVST) symbolsByValue(value string) []Symbol {
+	entryValue := func(i int) []byte {
+		entry := mapped.valueIndex + i*8
+		return mapped.bytesAt(mapped.symbolOffset(mapped.indexAt(entry, 0)) + 7*4 + mapped.uint32At(entry+4)*8)
+	}
+
+	target := []byte(value)
+
+	start := sort.Search(mapped.valueIndexCount, func(i int) bool {
+		return bytes.Compare(entryValue(i), target) >= 0
+	})
+
+	var (
+		indexes []int
+		found   = map[int]bool{}
+	)
+	for i := start; i < mapped.valueIndexCount && bytes.Equal(entryValue(i), target); i++ {
+		index := mapped.indexAt(mapped.valueIndex+i*8, 0)
+		if !found[index] {
+			found[index] = true
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Ints(indexes)
+
+	results := make([]Symbol, len(indexes))
+	for i, index := range indexes {
+		results[i] = mapped.symbol(index)
+	}
+	return results
+}
+
+func (mapped *mappedVST) allSymbols() []Symbol {
+	results := make([]Symbol, mapped.symbolCount)
+	for i := range results {
+		results[i] = mapped.symbol(i)
+	}
+	return results
+}
+
+// Pairs of strings in a section
+func (mapped *mappedVST) stringPairs(section int, count int) [][2]string {
+	pairs := make([][2]string, count)
+	for i := range pairs {
+		pairs[i] = [2]string{mapped.stringAt(section + i*16), mapped.stringAt(section + i*16 + 8)}
+	}
+	return pairs
+}
+
+func (mapped *mappedVST) getMetadata() [][2]string {
+	return mapped.stringPairs(mapped.metadata, mapped.metadataCount)
+}
+
+func (mapped *mappedVST) getStemRules() *stemRules {
+	result := stemRules{exceptions: map[string][]string{}}
+
+	for _, pair := range mapped.stringPairs(mapped.stemRules, mapped.stemRuleCount) {
+		result.rules = append(result.rules, stemRule{pair[0], pair[1]})
+	}
+
+	for _, pair := range mapped.stringPairs(mapped.stemExceptions, mapped.stemExceptionCount) {
+		result.exceptions[pair[0]] = append(result.exceptions[pair[0]], pair[1])
+	}
+
+	return &result
+}
+
+func appendUint32(data []byte, n int) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(n))
+	return append(data, buf[:]...)
+}
+
+// Compare like SQLite's NOCASE collation
+func compareASCIIFolded(a []byte, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		x, y := foldASCII(a[i]), foldASCII(b[i])
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
+
+func foldASCII(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// Load a mapped VST
+func (varnam *Varnam) initMappedVST(vstPath string) error {
+	mapped, err := openMappedVST(vstPath)
+	if err != nil {
+		return err
+	}
+
+	varnam.mappedVST = mapped
+	varnam.LangRules.PatternLongestLength = mapped.patternLongestLength
+
+	varnam.VSTPath = vstPath
+	varnam.vstFileInfo = statVST(vstPath)
+	varnam.setSchemeInfo()
+
+	if varnam.SchemeDetails.Base != "" {
+		varnam.closeVST()
+		return fmt.Errorf("mapped VST can't be a variant, make it from the loaded variant")
+	}
+
+	err = varnam.checkSchemeCompatibility()
+	if err != nil {
+		varnam.closeVST()
+		return err
+	}
+
+	return nil
+}
+
+// Close VST, whichever format it is in
+func (varnam *Varnam) closeVST() error {
+	if varnam.mappedVST != nil {
+		mapped := varnam.mappedVST
+		varnam.mappedVST = nil
+		return mapped.close()
+	}
+	if varnam.vstConn != nil {
+		return varnam.vstConn.Close()
+	}
+	return nil
+}
+
+// Key & value pairs in metadata of VST
+func (varnam *Varnam) getVSTMetadata() ([][2]string, error) {
+	if varnam.mappedVST != nil {
+		return varnam.mappedVST.getMetadata(), nil
+	}
+
+	rows, err := varnam.vstConn.Query("SELECT key, value FROM metadata")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var metadata [][2]string
+	for rows.Next() {
+		var key, value string
+		err = rows.Scan(&key, &value)
+		if err != nil {
+			return nil, err
+		}
+		metadata = append(metadata, [2]string{key, value})
+	}
+
+	return metadata, rows.Err()
+}
+
+// WriteMappedVST write the loaded scheme as a mapped VST (VST v2)
+// to filePath. A variant scheme is written with the symbols of its
+// base, the mapped VST doesn't need the base to be loaded
+func (varnam *Varnam) WriteMappedVST(filePath string) error {
+	if fileExists(filePath) {
+		return fmt.Errorf("Output file already exists")
+	}
+
+	symbols, err := varnam.GetAllSymbols()
+	if err != nil {
+		return err
+	}
+	if len(symbols) == 0 {
+		return fmt.Errorf("scheme has no symbols")
+	}
+
+	metadata, err := varnam.getVSTMetadata()
+	if err != nil {
+		return err
+	}
+
+	rules, err := varnam.getStemRules()
+	if err != nil {
+		return err
+	}
+
+	data := buildMappedVST(symbols, metadata, rules)
+
+	// Written fully before it's in place, a half written
+	// file shouldn't be found by scheme ID
+	file, err := os.CreateTemp(path.Dir(filePath), ".vst-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err == nil {
+		// Temp files are only for the owner
+		err = file.Chmod(0644)
+	}
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	return os.Rename(file.Name(), filePath)
+}
+
+func buildMappedVST(symbols []Symbol, metadata [][2]string, rules *stemRules) []byte {
+	var (
+		sections []byte
+		strs     []byte
+	)
+
+	// Strings go after the sections, whose size is known.
+	// Offsets are fixed up once it's known where strings start
+	var stringRefs []int
+
+	putUint32 := func(n int) {
+		sections = appendUint32(sections, n)
+	}
+	putString := func(s string) {
+		stringRefs = append(stringRefs, len(sections))
+		putUint32(len(strs))
+		putUint32(len(s))
+		strs = append(strs, s...)
+	}
+
+	header := make([]int, mappedHeaderPatternLongestLength+1)
+	header[mappedHeaderVersion] = mappedVSTVersion
+	header[mappedHeaderSymbolCount] = len(symbols)
+
+	header[mappedHeaderSymbolsOffset] = len(sections)
+	longest := 0
+	for _, symbol := range symbols {
+		for _, n := range []int{symbol.Identifier, symbol.Type, symbol.MatchType, symbol.Weight, symbol.Priority, symbol.AcceptCondition, symbol.Flags} {
+			putUint32(n)
+		}
+		for _, s := range []string{symbol.Pattern, symbol.Value1, symbol.Value2, symbol.Value3, symbol.Tag} {
+			putString(s)
+		}
+
+		if length := utf8.RuneCountInString(symbol.Pattern); length > longest {
+			longest = length
+		}
+	}
+	header[mappedHeaderPatternLongestLength] = longest
+
+	putIndex := func(less func(a, b Symbol) bool) {
+		indexes := make([]int, len(symbols))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		sort.SliceStable(indexes, func(i, j int) bool {
+			return less(symbols[indexes[i]], symbols[indexes[j]])
+		})
+		for _, index := range indexes {
+			putUint32(index)
+		}
+	}
+
+	header[mappedHeaderPatternIndexOffset] = len(sections)
+	putIndex(func(a, b Symbol) bool {
+		return a.Pattern < b.Pattern
+	})
+
+	header[mappedHeaderFoldedIndexOffset] = len(sections)
+	putIndex(func(a, b Symbol) bool {
+		return compareASCIIFolded([]byte(a.Pattern), []byte(b.Pattern)) < 0
+	})
+
+	type valueEntry struct {
+		value  string
+		index  int
+		column int
+	}
+	var values []valueEntry
+	for i, symbol := range symbols {
+		values = append(values, valueEntry{symbol.Value1, i, 1})
+		if symbol.Value2 != "" {
+			values = append(values, valueEntry{symbol.Value2, i, 2})
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].value < values[j].value
+	})
+
+	header[mappedHeaderValueIndexCount] = len(values)
+	header[mappedHeaderValueIndexOffset] = len(sections)
+	for _, entry := range values {
+		putUint32(entry.index)
+		// Strings of a symbol record after value1 are value2...
+		putUint32(entry.column)
+	}
+
+	header[mappedHeaderMetadataCount] = 0
+	header[mappedHeaderMetadataOffset] = len(sections)
+	for _, pair := range metadata {
+		if pair[0] == VARNAM_METADATA_SCHEME_BASE {
+			// Symbols of base are in it already
+			continue
+		}
+		putString(pair[0])
+		putString(pair[1])
+		header[mappedHeaderMetadataCount]++
+	}
+
+	header[mappedHeaderStemRuleCount] = len(rules.rules)
+	header[mappedHeaderStemRulesOffset] = len(sections)
+	for _, rule := range rules.rules {
+		putString(rule.oldEnding)
+		putString(rule.newEnding)
+	}
+
+	var endings []string
+	for ending := range rules.exceptions {
+		endings = append(endings, ending)
+	}
+	sort.Strings(endings)
+
+	header[mappedHeaderStemExceptionsOffset] = len(sections)
+	for _, ending := range endings {
+		for _, exception := range rules.exceptions[ending] {
+			putString(ending)
+			putString(exception)
+			header[mappedHeaderStemExceptionCount]++
+		}
+	}
+
+	data := append([]byte{}, mappedVSTMagic...)
+	for _, field := range header {
+		data = appendUint32(data, field)
+	}
+	data = append(data, make([]byte, mappedVSTHeaderSize-len(data))...)
+
+	// Offsets in header are from start of file
+	for _, field := range []int{mappedHeaderSymbolsOffset, mappedHeaderPatternIndexOffset, mappedHeaderFoldedIndexOffset, mappedHeaderValueIndexOffset, mappedHeaderMetadataOffset, mappedHeaderStemRulesOffset, mappedHeaderStemExceptionsOffset} {
+		position := len(mappedVSTMagic) + field*4
+		binary.LittleEndian.PutUint32(data[position:], binary.LittleEndian.Uint32(data[position:])+mappedVSTHeaderSize)
+	}
+
+	stringsStart := mappedVSTHeaderSize + len(sections)
+	for _, ref := range stringRefs {
+		binary.LittleEndian.PutUint32(sections[ref:], binary.LittleEndian.Uint32(sections[ref:])+uint32(stringsStart))
+	}
+
+	data = append(data, sections...)
+	return append(data, strs...)
+}
+
+// Lookups on a mapped VST, same as the SQL queries on a SQLite VST
+
+func (varnam *Varnam) mappedSearchPattern(ctx context.Context, ch string, matchType int, acceptCondition int) []Symbol {
+	countQuery(ctx)
+
+	var results []Symbol
+	for _, symbol := range varnam.mappedVST.symbolsByValue(ch) {
+		if !symbolAccepts(symbol, acceptCondition) {
+			continue
+		}
+		if matchType != VARNAM_MATCH_ALL && symbol.MatchType != matchType {
+			continue
+		}
+		results = append(results, symbol)
+	}
+
+	if matchType == VARNAM_MATCH_ALL {
+		sort.SliceStable(results, func(i, j int) bool {
+			a, b := results[i], results[j]
+			if a.MatchType != b.MatchType {
+				return a.MatchType < b.MatchType
+			}
+			if a.Weight != b.Weight {
+				return a.Weight > b.Weight
+			}
+			return a.Priority > b.Priority
+		})
+	}
+
+	return results
+}
+
+func (varnam *Varnam) mappedFindLongestPatternMatchSymbols(ctx context.Context, pattern []rune, matchType int, acceptCondition int) []Symbol {
+	countQuery(ctx)
+
+	var results []Symbol
+	for i := range pattern {
+		for _, symbol := range varnam.mappedVST.symbolsByPattern(string(pattern[0:i+1]), varnam.CaseInsensitive) {
+			if !symbolAccepts(symbol, acceptCondition) {
+				continue
+			}
+			if matchType != VARNAM_MATCH_ALL && symbol.MatchType != matchType {
+				continue
+			}
+			results = append(results, symbol)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+
+		aLength, bLength := utf8.RuneCountInString(a.Pattern), utf8.RuneCountInString(b.Pattern)
+		if aLength != bLength {
+			return aLength > bLength
+		}
+
+		if matchType == VARNAM_MATCH_ALL {
+			if a.MatchType != b.MatchType {
+				return a.MatchType < b.MatchType
+			}
+			if a.Weight != b.Weight {
+				return a.Weight > b.Weight
+			}
+			return a.Priority > b.Priority
+		}
+
+		if varnam.CaseInsensitive {
+			return a.Weight > b.Weight
+		}
+		return false
+	})
+
+	return results
+}
+
+func (varnam *Varnam) mappedFindSymbolByPattern(ctx context.Context, pattern string, acceptCondition int) (Symbol, bool) {
+	countQuery(ctx)
+
+	var candidates []Symbol
+	for _, symbol := range varnam.mappedVST.symbolsByPattern(pattern, false) {
+		if symbolAccepts(symbol, acceptCondition) {
+			candidates = append(candidates, symbol)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return Symbol{}, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.MatchType != b.MatchType {
+			return a.MatchType < b.MatchType
+		}
+		if a.AcceptCondition != b.AcceptCondition {
+			return a.AcceptCondition > b.AcceptCondition
+		}
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		return a.Priority > b.Priority
+	})
+
+	return candidates[0], true
+}
+
+// Symbols matching the criteria of SearchSymbolTable
+func (varnam *Varnam) mappedSearchSymbolTable(ctx context.Context, searchCriteria Symbol) []Symbol {
+	countQuery(ctx)
+
+	var candidates []Symbol
+	if searchCriteria.Pattern != "" && !isLikeCriteria(searchCriteria.Pattern) {
+		candidates = varnam.mappedVST.symbolsByPattern(searchCriteria.Pattern, false)
+	} else {
+		candidates = varnam.mappedVST.allSymbols()
+	}
+
+	var results []Symbol
+	for _, symbol := range candidates {
+		if symbolMatchesCriteria(symbol, searchCriteria) {
+			results = append(results, symbol)
+		}
+	}
+	return results
+}
+
+func symbolAccepts(symbol Symbol, acceptCondition int) bool {
+	return symbol.AcceptCondition == 0 || symbol.AcceptCondition == acceptCondition
+}
+
+func isLikeCriteria(criteria string) bool {
+	return len(criteria) > 5 && criteria[0:5] == "LIKE "
+}
+
+// Same as the WHERE made by makeSearchSymbolQuery
+func symbolMatchesCriteria(symbol Symbol, criteria Symbol) bool {
+	ints := [][2]int{
+		{criteria.Identifier, symbol.Identifier},
+		{criteria.Type, symbol.Type},
+		{criteria.MatchType, symbol.MatchType},
+		{criteria.Weight, symbol.Weight},
+		{criteria.Priority, symbol.Priority},
+		{criteria.AcceptCondition, symbol.AcceptCondition},
+		{criteria.Flags, symbol.Flags},
+	}
+	for _, pair := range ints {
+		if pair[0] != STRUCT_INT_DEFAULT_VALUE && pair[0] != pair[1] {
+			return false
+		}
+	}
+
+	strs := [][2]string{
+		{criteria.Pattern, symbol.Pattern},
+		{criteria.Value1, symbol.Value1},
+		{criteria.Value2, symbol.Value2},
+		{criteria.Value3, symbol.Value3},
+		{criteria.Tag, symbol.Tag},
+	}
+	for _, pair := range strs {
+		if pair[0] == "" {
+			continue
+		}
+		if isLikeCriteria(pair[0]) {
+			if !matchLike([]rune(pair[0][5:]), []rune(pair[1])) {
+				return false
+			}
+		} else if pair[0] != pair[1] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SQL LIKE, case sensitive as VST connections are.
+// % is any number of characters, _ is one character
+func matchLike(pattern []rune, text []rune) bool {
+	if len(pattern) == 0 {
+		return len(text) == 0
+	}
+
+	switch pattern[0] {
+	case '%':
+		for i := 0; i <= len(text); i++ {
+			if matchLike(pattern[1:], text[i:]) {
+				return true
+			}
+		}
+		return false
+	case '_':
+		return len(text) > 0 && matchLike(pattern[1:], text[1:])
+	default:
+		return len(text) > 0 && text[0] == pattern[0] && matchLike(pattern[1:], text[1:])
+	}
+}
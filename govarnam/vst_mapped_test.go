@@ -0,0 +1,71 @@
+package govarnam
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestMappedVST(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	vstPath := path.Join(testTempDir, "mapped", "ml.vst")
+	checkError(os.MkdirAll(path.Dir(vstPath), 0750))
+	checkError(varnam.WriteMappedVST(vstPath))
+
+	assertEqual(t, varnam.WriteMappedVST(vstPath) != nil, true)
+
+	mapped, err := Init(vstPath, path.Join(testTempDir, "mapped", "ml.vst.learnings"))
+	checkError(err)
+	defer mapped.Close()
+
+	assertEqual(t, mapped.vstConn == nil, true)
+	assertEqual(t, mapped.SchemeDetails.Identifier, varnam.SchemeDetails.Identifier)
+	assertEqual(t, mapped.SchemeDetails.LangCode, varnam.SchemeDetails.LangCode)
+	assertEqual(t, mapped.LangRules.PatternLongestLength, varnam.LangRules.PatternLongestLength)
+	assertEqual(t, mapped.LangRules.Virama, varnam.LangRules.Virama)
+
+	symbols, err := varnam.GetAllSymbols()
+	checkError(err)
+	mappedSymbols, err := mapped.GetAllSymbols()
+	checkError(err)
+	assertEqual(t, len(mappedSymbols), len(symbols))
+	assertEqual(t, mappedSymbols[len(symbols)-1], symbols[len(symbols)-1])
+
+	for _, word := range []string{"namaskaaram", "malayalam", "thuthuru", "pazham", "la"} {
+		expected := varnam.TransliterateAdvanced(word).TokenizerSuggestions
+		got := mapped.TransliterateAdvanced(word).TokenizerSuggestions
+
+		assertEqual(t, len(got), len(expected))
+		for i := range expected {
+			assertEqual(t, got[i].Word, expected[i].Word)
+			assertEqual(t, got[i].Weight, expected[i].Weight)
+		}
+	}
+
+	sugs, err := mapped.ReverseTransliterate("മലയാളം")
+	checkError(err)
+	assertEqual(t, len(sugs) > 0, true)
+
+	search := NewSearchSymbol()
+	search.Value1 = "LIKE ക%"
+	search.Type = VARNAM_SYMBOL_CONSONANT
+	expectedResults, err := varnam.SearchSymbolTable(context.Background(), search)
+	checkError(err)
+	results, err := mapped.SearchSymbolTable(context.Background(), search)
+	checkError(err)
+	assertEqual(t, len(results), len(expectedResults))
+
+	assertEqual(t, mapped.VMCreateToken("zz", "ക", "", "", "", VARNAM_SYMBOL_CONSONANT, VARNAM_MATCH_EXACT, 0, 0, false), errMappedVSTReadOnly)
+
+	// Only the header is checked at init
+	data, err := os.ReadFile(vstPath)
+	checkError(err)
+
+	corruptPath := path.Join(testTempDir, "mapped", "corrupt.vst")
+	checkError(os.WriteFile(corruptPath, data[:mappedVSTHeaderSize+10], 0644))
+
+	corrupt := Varnam{}
+	assertEqual(t, corrupt.InitVST(corruptPath) != nil, true)
+}
@@ -0,0 +1,30 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"log"
+)
+
+// Make a context for a single SQLite statement that gets done after
+// QueryTimeout. go-sqlite3 calls sqlite3_interrupt() on the running
+// statement when its context is done, so a pathological lookup is
+// stopped and whatever was found till then is used.
+func (varnam *Varnam) watchdogContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if varnam.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, varnam.QueryTimeout)
+}
+
+// Log if the statement was interrupted by watchdog and not by the caller
+func (varnam *Varnam) watchdogCheck(queryCtx context.Context, ctx context.Context, input interface{}) {
+	if queryCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		log.Printf("Lookup for %v took longer than %v, interrupted", input, varnam.QueryTimeout)
+	}
+}
@@ -0,0 +1,36 @@
+package govarnam
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWatchdog(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	prevTimeout := varnam.QueryTimeout
+	defer func() {
+		varnam.QueryTimeout = prevTimeout
+	}()
+
+	varnam.QueryTimeout = 50 * time.Millisecond
+
+	ctx := context.Background()
+	queryCtx, cancel := varnam.watchdogContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+
+	// Never ending query
+	var count int
+	err := varnam.dictConn.QueryRowContext(queryCtx, "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c) SELECT COUNT(*) FROM c").Scan(&count)
+
+	assertEqual(t, err != nil, true)
+	assertEqual(t, time.Since(start) < 5*time.Second, true)
+	assertEqual(t, queryCtx.Err(), context.DeadlineExceeded)
+
+	// Every lookup timing out gives empty results, not a hang
+	varnam.QueryTimeout = time.Nanosecond
+	varnam.TransliterateAdvanced("malayalam")
+}
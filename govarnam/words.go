@@ -0,0 +1,176 @@
+package govarnam
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	sql "database/sql"
+	"fmt"
+)
+
+// DictionaryStatistics counts of what's in learnings
+type DictionaryStatistics struct {
+	Words            int
+	Patterns         int
+	PinnedWords      int
+	BlacklistedWords int
+	Domains          int
+
+	// Unix timestamps of first & last learnt word. 0 if none
+	FirstLearnedOn int
+	LastLearnedOn  int
+}
+
+// WordDetails everything learnings has about a word
+type WordDetails struct {
+	Word      string
+	Weight    int
+	LearnedOn int
+	Pinned    bool
+
+	// Patterns the word was trained with
+	Patterns []string
+
+	Domains []string
+
+	// Patterns the word was chosen for from suggestions
+	Selections []Selection
+
+	// Times learnt, from the learning log
+	Learns int
+	Trains int
+}
+
+// DictionaryStats count words, patterns etc. in learnings
+func (varnam *Varnam) DictionaryStats(ctx context.Context) (DictionaryStatistics, error) {
+	var stats DictionaryStatistics
+
+	err := varnam.dictConn.QueryRowContext(
+		ctx,
+		`SELECT
+			(SELECT COUNT(*) FROM words),
+			(SELECT COUNT(*) FROM patterns),
+			(SELECT COUNT(*) FROM words WHERE pinned = 1),
+			(SELECT COUNT(*) FROM blacklist),
+			(SELECT COUNT(DISTINCT domain) FROM word_domains),
+			(SELECT IFNULL(MIN(learned_on), 0) FROM words WHERE learned_on > 0),
+			(SELECT IFNULL(MAX(learned_on), 0) FROM words)`,
+	).Scan(
+		&stats.Words,
+		&stats.Patterns,
+		&stats.PinnedWords,
+		&stats.BlacklistedWords,
+		&stats.Domains,
+		&stats.FirstLearnedOn,
+		&stats.LastLearnedOn,
+	)
+
+	return stats, err
+}
+
+// GetMostLearntWords get words with the most weight, i.e. learnt
+// the most times
+func (varnam *Varnam) GetMostLearntWords(ctx context.Context, offset int, limit int) ([]Suggestion, error) {
+	var result []Suggestion
+
+	rows, err := varnam.dictConn.QueryContext(
+		ctx,
+		"SELECT word, weight, IFNULL(learned_on, 0) FROM words ORDER BY weight DESC, learned_on DESC LIMIT ?, ?",
+		offset,
+		limit,
+	)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item Suggestion
+		rows.Scan(&item.Word, &item.Weight, &item.LearnedOn)
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// queryStrings run a query giving one string column
+func (varnam *Varnam) queryStrings(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	var result []string
+
+	rows, err := varnam.dictConn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item string
+		rows.Scan(&item)
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+// GetWordDetails get everything learnings has about a word
+func (varnam *Varnam) GetWordDetails(ctx context.Context, word string) (WordDetails, error) {
+	details := WordDetails{Word: varnam.learntForm(word)}
+
+	var (
+		id     int
+		pinned int
+	)
+
+	err := varnam.dictConn.QueryRowContext(
+		ctx,
+		"SELECT id, weight, IFNULL(learned_on, 0), pinned FROM words WHERE word = ?",
+		details.Word,
+	).Scan(&id, &details.Weight, &details.LearnedOn, &pinned)
+	if err == sql.ErrNoRows {
+		return details, fmt.Errorf("Word doesn't exist")
+	} else if err != nil {
+		return details, err
+	}
+	details.Pinned = pinned == 1
+
+	details.Patterns, err = varnam.queryStrings(ctx, "SELECT pattern FROM patterns WHERE word_id = ? ORDER BY pattern", id)
+	if err != nil {
+		return details, err
+	}
+
+	details.Domains, err = varnam.queryStrings(ctx, "SELECT domain FROM word_domains WHERE word_id = ? ORDER BY domain", id)
+	if err != nil {
+		return details, err
+	}
+
+	rows, err := varnam.dictConn.QueryContext(
+		ctx,
+		"SELECT pattern, count, last_selected FROM selections WHERE word_id = ? ORDER BY count DESC, last_selected DESC",
+		id,
+	)
+	if err != nil {
+		return details, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := Selection{Word: details.Word}
+		rows.Scan(&item.Pattern, &item.Count, &item.LastSelected)
+		details.Selections = append(details.Selections, item)
+	}
+	if err = rows.Err(); err != nil {
+		return details, err
+	}
+
+	err = varnam.dictConn.QueryRowContext(
+		ctx,
+		"SELECT IFNULL(SUM(learns), 0), IFNULL(SUM(trains), 0) FROM learning_log WHERE word_id = ?",
+		id,
+	).Scan(&details.Learns, &details.Trains)
+
+	return details, err
+}
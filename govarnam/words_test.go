@@ -0,0 +1,68 @@
+package govarnam
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMLDictionaryStats(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+	ctx := context.Background()
+
+	before, err := varnam.DictionaryStats(ctx)
+	checkError(err)
+
+	checkError(varnam.Train("kozhikkode", "കോഴിക്കോട്"))
+	checkError(varnam.Pin("കോഴിക്കോട്"))
+	checkError(varnam.LearnInDomain("കോട്ടയം", 0, "places-stats-test"))
+
+	after, err := varnam.DictionaryStats(ctx)
+	checkError(err)
+
+	assertEqual(t, after.Words, before.Words+2)
+	assertEqual(t, after.Patterns, before.Patterns+1)
+	assertEqual(t, after.PinnedWords, before.PinnedWords+1)
+	assertEqual(t, after.Domains, before.Domains+1)
+	assertEqual(t, after.LastLearnedOn > 0, true)
+	assertEqual(t, after.FirstLearnedOn <= after.LastLearnedOn, true)
+
+	top, err := varnam.GetMostLearntWords(ctx, 0, 10)
+	checkError(err)
+	assertEqual(t, len(top) > 0, true)
+	for i := 1; i < len(top); i++ {
+		assertEqual(t, top[i-1].Weight >= top[i].Weight, true)
+	}
+
+	checkError(varnam.Unlearn("കോഴിക്കോട്"))
+	checkError(varnam.Unlearn("കോട്ടയം"))
+}
+
+func TestMLGetWordDetails(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+	ctx := context.Background()
+
+	checkError(varnam.Train("kollam", "കൊല്ലം"))
+	checkError(varnam.Learn("കൊല്ലം", 0))
+	checkError(varnam.ReportSelected("kolam", "കൊല്ലം"))
+	checkError(varnam.LearnInDomain("കൊല്ലം", 0, "places"))
+
+	details, err := varnam.GetWordDetails(ctx, "കൊല്ലം")
+	checkError(err)
+
+	assertEqual(t, details.Word, varnam.learntForm("കൊല്ലം"))
+	assertEqual(t, details.Pinned, false)
+	assertEqual(t, len(details.Patterns), 1)
+	assertEqual(t, details.Patterns[0], "kollam")
+	assertEqual(t, len(details.Domains), 1)
+	assertEqual(t, details.Domains[0], "places")
+	assertEqual(t, len(details.Selections), 1)
+	assertEqual(t, details.Selections[0].Pattern, "kolam")
+	assertEqual(t, details.Trains, 1)
+	assertEqual(t, details.Learns > 1, true)
+	assertEqual(t, details.LearnedOn > 0, true)
+
+	_, err = varnam.GetWordDetails(ctx, "ഇല്ലാത്തവാക്ക്")
+	assertEqual(t, err != nil, true)
+
+	checkError(varnam.Unlearn("കൊല്ലം"))
+}
@@ -0,0 +1,149 @@
+package govarnamgo
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+// #cgo pkg-config: govarnam
+// #include "libgovarnam.h"
+// #include "stdlib.h"
+import "C"
+
+import (
+	"unsafe"
+)
+
+// Composition state of an IME while a word is being typed.
+// See govarnam.Composition
+type Composition struct {
+	handle *VarnamHandle
+	id     C.int
+}
+
+// NewComposition start composing a word. pageSize is the number of
+// candidates shown at a time, 0 shows all. Free it after use
+func (handle *VarnamHandle) NewComposition(pageSize int) (*Composition, error) {
+	var id C.int
+
+	err := handle.checkError(C.varnam_composition_new(handle.connectionID, C.int(pageSize), &id))
+	if err != nil {
+		return nil, err
+	}
+	return &Composition{handle, id}, nil
+}
+
+// Free the composition
+func (composition *Composition) Free() error {
+	return composition.handle.checkError(C.varnam_composition_free(composition.id))
+}
+
+// Append characters typed & find candidates
+func (composition *Composition) Append(chars string) error {
+	cChars := C.CString(chars)
+	defer C.free(unsafe.Pointer(cChars))
+
+	return composition.handle.checkError(C.varnam_composition_append(composition.id, cChars))
+}
+
+// Backspace remove the last character typed & find candidates
+func (composition *Composition) Backspace() error {
+	return composition.handle.checkError(C.varnam_composition_backspace(composition.id))
+}
+
+// Reset clear everything to start a new word
+func (composition *Composition) Reset() error {
+	return composition.handle.checkError(C.varnam_composition_reset(composition.id))
+}
+
+// IsEmpty whether nothing is being composed
+func (composition *Composition) IsEmpty() bool {
+	return C.varnam_composition_is_empty(composition.id) == 1
+}
+
+func (composition *Composition) getString(get func(**C.char) C.int) (string, error) {
+	var cStr *C.char
+
+	err := composition.handle.checkError(get(&cStr))
+	if cStr == nil {
+		return "", err
+	}
+
+	str := C.GoString(cStr)
+	C.free(unsafe.Pointer(cStr))
+	return str, err
+}
+
+// Input what has been typed so far
+func (composition *Composition) Input() (string, error) {
+	return composition.getString(func(output **C.char) C.int {
+		return C.varnam_composition_get_input(composition.id, output)
+	})
+}
+
+// Preedit text to show inline where the user is typing
+func (composition *Composition) Preedit() (string, error) {
+	return composition.getString(func(output **C.char) C.int {
+		return C.varnam_composition_get_preedit(composition.id, output)
+	})
+}
+
+// Page candidates in the current page & index of the highlighted one in it
+func (composition *Composition) Page() ([]Suggestion, int, error) {
+	var (
+		resultPointer *C.varray
+		cursor        C.int
+	)
+
+	err := composition.handle.checkError(C.varnam_composition_get_page(composition.id, &resultPointer, &cursor))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var page []Suggestion
+	i := 0
+	for i < int(C.varray_length(resultPointer)) {
+		cSug := (*C.Suggestion)(C.varray_get(resultPointer, C.int(i)))
+		page = append(page, makeSuggestion(cSug))
+		i++
+	}
+	C.destroySuggestionsArray(resultPointer)
+
+	return page, int(cursor), nil
+}
+
+// MoveCursor highlight a candidate before (negative) or after
+func (composition *Composition) MoveCursor(by int) error {
+	return composition.handle.checkError(C.varnam_composition_move_cursor(composition.id, C.int(by)))
+}
+
+// NextPage highlight the first candidate of next page
+func (composition *Composition) NextPage() error {
+	return composition.handle.checkError(C.varnam_composition_next_page(composition.id))
+}
+
+// PreviousPage highlight the first candidate of previous page
+func (composition *Composition) PreviousPage() error {
+	return composition.handle.checkError(C.varnam_composition_previous_page(composition.id))
+}
+
+// Select highlight candidate at index of all candidates
+func (composition *Composition) Select(index int) error {
+	return composition.handle.checkError(C.varnam_composition_select(composition.id, C.int(index)))
+}
+
+// Commit the highlighted candidate. Returns the text to insert,
+// even if learning it fails
+func (composition *Composition) Commit() (string, error) {
+	return composition.getString(func(output **C.char) C.int {
+		return C.varnam_composition_commit(composition.id, output)
+	})
+}
+
+// CommitInput commit what was typed as is
+func (composition *Composition) CommitInput() (string, error) {
+	return composition.getString(func(output **C.char) C.int {
+		return C.varnam_composition_commit_input(composition.id, output)
+	})
+}
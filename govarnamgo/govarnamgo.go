@@ -17,6 +17,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 	"unsafe"
 )
 
@@ -40,6 +41,7 @@ type Suggestion struct {
 	Word      string
 	Weight    int
 	LearnedOn int
+	Score     float64
 }
 
 // TransliterationResult result
@@ -60,6 +62,20 @@ type SchemeDetails struct {
 	Author       string
 	CompiledDate string
 	IsStable     bool
+
+	// Location of VST. Set by GetAllSchemeDetails
+	Path string
+
+	// Name of the language in its own script
+	NativeDisplayName string
+
+	Version string
+
+	// Oldest govarnam version the scheme works with
+	MinLibraryVersion string
+
+	// Identifier of the scheme this is a variant of
+	Base string
 }
 
 // LearnStatus output of bulk learn
@@ -84,6 +100,16 @@ type Symbol struct {
 	Flags           int
 }
 
+// SchemeIssue a problem found in scheme by ValidateScheme
+type SchemeIssue struct {
+	// 1 for error, 2 for warning
+	Severity int
+
+	Kind    int
+	Symbol  Symbol
+	Message string
+}
+
 var contextOperationCount = C.int(0)
 
 func makeContextOperation() C.int {
@@ -99,6 +125,7 @@ func makeSuggestion(cSug *C.struct_Suggestion_t) Suggestion {
 	sug.Word = C.GoString(cSug.Word)
 	sug.Weight = int(cSug.Weight)
 	sug.LearnedOn = int(cSug.LearnedOn)
+	sug.Score = float64(cSug.Score)
 
 	return sug
 }
@@ -209,6 +236,11 @@ func GetBuild() string {
 	return C.GoString(C.varnam_get_build())
 }
 
+// GetABIVersion get version of the C structs used by the library
+func GetABIVersion() int {
+	return int(C.varnam_get_abi_version())
+}
+
 // Init Initialize
 func Init(vstLoc string, dictLoc string) (*VarnamHandle, error) {
 	handleID := C.int(0)
@@ -418,6 +450,35 @@ func (handle *VarnamHandle) TransliterateGreedyTokenized(word string) []Suggesti
 	return result
 }
 
+// TransliterateQuick transliterate within a small time budget.
+// For calling on every keystroke
+func (handle *VarnamHandle) TransliterateQuick(word string) []Suggestion {
+	var result []Suggestion
+
+	var resultPointer *C.varray
+
+	cWord := C.CString(word)
+	defer C.free(unsafe.Pointer(cWord))
+
+	code := C.varnam_transliterate_quick(handle.connectionID, cWord, &resultPointer)
+	if code != C.VARNAM_SUCCESS {
+		log.Print(handle.GetLastError())
+		return result
+	}
+
+	i := 0
+	for i < int(C.varray_length(resultPointer)) {
+		cSug := (*C.Suggestion)(C.varray_get(resultPointer, C.int(i)))
+		sug := makeSuggestion(cSug)
+		result = append(result, sug)
+		i++
+	}
+
+	go C.destroySuggestionsArray(resultPointer)
+
+	return result
+}
+
 // ReverseTransliterate reverse transilterate
 func (handle *VarnamHandle) ReverseTransliterate(word string) ([]Suggestion, error) {
 	var sugs []Suggestion
@@ -446,6 +507,177 @@ func (handle *VarnamHandle) ReverseTransliterate(word string) ([]Suggestion, err
 	return sugs, nil
 }
 
+// SetControlCharacters set characters that act as joiner, non-joiner
+// & escape instead of the scheme's joiner patterns. Give all empty
+// to use the scheme's patterns again
+func (handle *VarnamHandle) SetControlCharacters(joiner string, nonJoiner string, escape string) error {
+	cJoiner := C.CString(joiner)
+	defer C.free(unsafe.Pointer(cJoiner))
+
+	cNonJoiner := C.CString(nonJoiner)
+	defer C.free(unsafe.Pointer(cNonJoiner))
+
+	cEscape := C.CString(escape)
+	defer C.free(unsafe.Pointer(cEscape))
+
+	err := C.varnam_set_control_characters(handle.connectionID, cJoiner, cNonJoiner, cEscape)
+	return handle.checkError(err)
+}
+
+// RomanizeISO15919 write text in latin as per ISO 15919
+func (handle *VarnamHandle) RomanizeISO15919(text string) (string, error) {
+	cText := C.CString(text)
+	defer C.free(unsafe.Pointer(cText))
+
+	var cOutput *C.char
+
+	code := C.varnam_romanize_iso15919(handle.connectionID, cText, &cOutput)
+	if code != C.VARNAM_SUCCESS {
+		return "", &VarnamError{
+			ErrorCode: int(code),
+			Message:   handle.GetLastError(),
+		}
+	}
+	defer C.free(unsafe.Pointer(cOutput))
+
+	return C.GoString(cOutput), nil
+}
+
+// DumpVST all symbols of the scheme in a readable form
+func (handle *VarnamHandle) DumpVST() (string, error) {
+	var cOutput *C.char
+
+	code := C.varnam_dump_vst(handle.connectionID, &cOutput)
+	if code != C.VARNAM_SUCCESS {
+		return "", &VarnamError{
+			ErrorCode: int(code),
+			Message:   handle.GetLastError(),
+		}
+	}
+	defer C.free(unsafe.Pointer(cOutput))
+
+	return C.GoString(cOutput), nil
+}
+
+// ValidateScheme check the scheme for duplicate patterns, unreachable
+// symbols, weight inconsistencies & invalid unicode sequences
+func (handle *VarnamHandle) ValidateScheme() ([]SchemeIssue, error) {
+	var resultPointer *C.varray
+	defer C.destroySchemeIssueArray(unsafe.Pointer(resultPointer))
+
+	code := C.varnam_validate_scheme(handle.connectionID, &resultPointer)
+	if code != C.VARNAM_SUCCESS {
+		return nil, &VarnamError{
+			ErrorCode: int(code),
+			Message:   handle.GetLastError(),
+		}
+	}
+
+	var issues []SchemeIssue
+
+	i := 0
+	for i < int(C.varray_length(resultPointer)) {
+		cIssue := (*C.SchemeIssue)(C.varray_get(resultPointer, C.int(i)))
+
+		issues = append(issues, SchemeIssue{
+			int(cIssue.Severity),
+			int(cIssue.Kind),
+			makeGoSymbol(cIssue.Symbol),
+			C.GoString(cIssue.Message),
+		})
+		i++
+	}
+
+	return issues, nil
+}
+
+// DumpTokens how input is tokenized and the symbols each part can be
+func (handle *VarnamHandle) DumpTokens(input string) (string, error) {
+	cInput := C.CString(input)
+	defer C.free(unsafe.Pointer(cInput))
+
+	var cOutput *C.char
+
+	code := C.varnam_dump_tokens(handle.connectionID, cInput, &cOutput)
+	if code != C.VARNAM_SUCCESS {
+		return "", &VarnamError{
+			ErrorCode: int(code),
+			Message:   handle.GetLastError(),
+		}
+	}
+	defer C.free(unsafe.Pointer(cOutput))
+
+	return C.GoString(cOutput), nil
+}
+
+// RunGoldTests check if inputs in a gold TSV file give the expected
+// word as top suggestion. Gives counts and a report with diffs of failures
+func (handle *VarnamHandle) RunGoldTests(filePath string) (int, int, string, error) {
+	cFilePath := C.CString(filePath)
+	defer C.free(unsafe.Pointer(cFilePath))
+
+	var (
+		passed  C.int
+		failed  C.int
+		cOutput *C.char
+	)
+
+	code := C.varnam_run_gold_tests(handle.connectionID, cFilePath, &passed, &failed, &cOutput)
+	if code != C.VARNAM_SUCCESS {
+		return 0, 0, "", &VarnamError{
+			ErrorCode: int(code),
+			Message:   handle.GetLastError(),
+		}
+	}
+	defer C.free(unsafe.Pointer(cOutput))
+
+	return int(passed), int(failed), C.GoString(cOutput), nil
+}
+
+// TransliterateSentence transliterate all words in a sentence
+func (handle *VarnamHandle) TransliterateSentence(sentence string) (string, error) {
+	cSentence := C.CString(sentence)
+	defer C.free(unsafe.Pointer(cSentence))
+
+	var cOutput *C.char
+
+	code := C.varnam_transliterate_sentence(handle.connectionID, cSentence, &cOutput)
+	if code != C.VARNAM_SUCCESS {
+		return "", &VarnamError{
+			ErrorCode: int(code),
+			Message:   handle.GetLastError(),
+		}
+	}
+	defer C.free(unsafe.Pointer(cOutput))
+
+	return C.GoString(cOutput), nil
+}
+
+// TransliterateBetween convert text from the script of one scheme to another's
+func TransliterateBetween(fromSchemeID string, toSchemeID string, text string) (string, error) {
+	cFrom := C.CString(fromSchemeID)
+	defer C.free(unsafe.Pointer(cFrom))
+
+	cTo := C.CString(toSchemeID)
+	defer C.free(unsafe.Pointer(cTo))
+
+	cText := C.CString(text)
+	defer C.free(unsafe.Pointer(cText))
+
+	var cOutput *C.char
+
+	code := C.varnam_transliterate_between(cFrom, cTo, cText, &cOutput)
+	if code != C.VARNAM_SUCCESS {
+		return "", &VarnamError{
+			ErrorCode: int(code),
+			Message:   fmt.Sprintf("couldn't transliterate from %s to %s", fromSchemeID, toSchemeID),
+		}
+	}
+	defer C.free(unsafe.Pointer(cOutput))
+
+	return C.GoString(cOutput), nil
+}
+
 // Train train a pattern => word
 func (handle *VarnamHandle) Train(pattern string, word string) error {
 	cPattern := C.CString(pattern)
@@ -459,6 +691,54 @@ func (handle *VarnamHandle) Train(pattern string, word string) error {
 	return handle.checkError(err)
 }
 
+// ReportSelected tell that the user chose word for pattern
+func (handle *VarnamHandle) ReportSelected(pattern string, word string) error {
+	cPattern := C.CString(pattern)
+	cWord := C.CString(word)
+
+	err := C.varnam_report_selected(handle.connectionID, cPattern, cWord)
+
+	C.free(unsafe.Pointer(cPattern))
+	C.free(unsafe.Pointer(cWord))
+
+	return handle.checkError(err)
+}
+
+// Touch mark a learnt word as used now without changing its weight
+func (handle *VarnamHandle) Touch(word string) error {
+	cWord := C.CString(word)
+
+	err := C.varnam_touch(handle.connectionID, cWord)
+
+	C.free(unsafe.Pointer(cWord))
+
+	return handle.checkError(err)
+}
+
+// CanLearn check if a word can be learnt without learning it
+func (handle *VarnamHandle) CanLearn(word string) error {
+	cWord := C.CString(word)
+
+	err := C.varnam_can_learn(handle.connectionID, cWord)
+
+	C.free(unsafe.Pointer(cWord))
+
+	return handle.checkError(err)
+}
+
+// ValidateTrain check if a pattern => word can be trained without training it
+func (handle *VarnamHandle) ValidateTrain(pattern string, word string) error {
+	cPattern := C.CString(pattern)
+	cWord := C.CString(word)
+
+	err := C.varnam_validate_train(handle.connectionID, cPattern, cWord)
+
+	C.free(unsafe.Pointer(cPattern))
+	C.free(unsafe.Pointer(cWord))
+
+	return handle.checkError(err)
+}
+
 // Learn a word
 func (handle *VarnamHandle) Learn(word string, weight int) error {
 	cWord := C.CString(word)
@@ -470,6 +750,58 @@ func (handle *VarnamHandle) Learn(word string, weight int) error {
 	return handle.checkError(err)
 }
 
+// LearnInDomain learn a word and put it in a domain
+func (handle *VarnamHandle) LearnInDomain(word string, weight int, domain string) error {
+	cWord := C.CString(word)
+	cDomain := C.CString(domain)
+
+	err := C.varnam_learn_in_domain(handle.connectionID, cWord, C.int(weight), cDomain)
+
+	C.free(unsafe.Pointer(cWord))
+	C.free(unsafe.Pointer(cDomain))
+
+	return handle.checkError(err)
+}
+
+// TrainInDomain train a pattern => word and put the word in a domain
+func (handle *VarnamHandle) TrainInDomain(pattern string, word string, domain string) error {
+	cPattern := C.CString(pattern)
+	cWord := C.CString(word)
+	cDomain := C.CString(domain)
+
+	err := C.varnam_train_in_domain(handle.connectionID, cPattern, cWord, cDomain)
+
+	C.free(unsafe.Pointer(cPattern))
+	C.free(unsafe.Pointer(cWord))
+	C.free(unsafe.Pointer(cDomain))
+
+	return handle.checkError(err)
+}
+
+// RemoveFromDomain take a word out of a domain
+func (handle *VarnamHandle) RemoveFromDomain(word string, domain string) error {
+	cWord := C.CString(word)
+	cDomain := C.CString(domain)
+
+	err := C.varnam_remove_from_domain(handle.connectionID, cWord, cDomain)
+
+	C.free(unsafe.Pointer(cWord))
+	C.free(unsafe.Pointer(cDomain))
+
+	return handle.checkError(err)
+}
+
+// LearnAt learn a word with the given learnt time
+func (handle *VarnamHandle) LearnAt(word string, weight int, learnedOn time.Time) error {
+	cWord := C.CString(word)
+
+	err := C.varnam_learn_at(handle.connectionID, cWord, C.int(weight), C.longlong(learnedOn.Unix()))
+
+	C.free(unsafe.Pointer(cWord))
+
+	return handle.checkError(err)
+}
+
 // Unlearn a word
 func (handle *VarnamHandle) Unlearn(word string) error {
 	cWord := C.CString(word)
@@ -481,6 +813,123 @@ func (handle *VarnamHandle) Unlearn(word string) error {
 	return handle.checkError(err)
 }
 
+// Blacklist a word so that it's never suggested or learnt
+func (handle *VarnamHandle) Blacklist(word string) error {
+	cWord := C.CString(word)
+
+	err := C.varnam_blacklist(handle.connectionID, cWord)
+
+	C.free(unsafe.Pointer(cWord))
+
+	return handle.checkError(err)
+}
+
+// RemoveFromBlacklist allow a blacklisted word again
+func (handle *VarnamHandle) RemoveFromBlacklist(word string) error {
+	cWord := C.CString(word)
+
+	err := C.varnam_remove_from_blacklist(handle.connectionID, cWord)
+
+	C.free(unsafe.Pointer(cWord))
+
+	return handle.checkError(err)
+}
+
+// Pin a learnt word to the top of suggestions
+func (handle *VarnamHandle) Pin(word string) error {
+	cWord := C.CString(word)
+
+	err := C.varnam_pin(handle.connectionID, cWord)
+
+	C.free(unsafe.Pointer(cWord))
+
+	return handle.checkError(err)
+}
+
+// Unpin a pinned word
+func (handle *VarnamHandle) Unpin(word string) error {
+	cWord := C.CString(word)
+
+	err := C.varnam_unpin(handle.connectionID, cWord)
+
+	C.free(unsafe.Pointer(cWord))
+
+	return handle.checkError(err)
+}
+
+// OverrideSymbol make a pattern give the user's own value instead of
+// what the scheme gives. Type & MatchType are guessed if not set
+func (handle *VarnamHandle) OverrideSymbol(symbol Symbol) error {
+	Pattern := C.CString(symbol.Pattern)
+	Value1 := C.CString(symbol.Value1)
+	Value2 := C.CString(symbol.Value2)
+	Value3 := C.CString(symbol.Value3)
+	Tag := C.CString(symbol.Tag)
+
+	defer C.free(unsafe.Pointer(Pattern))
+	defer C.free(unsafe.Pointer(Value1))
+	defer C.free(unsafe.Pointer(Value2))
+	defer C.free(unsafe.Pointer(Value3))
+	defer C.free(unsafe.Pointer(Tag))
+
+	cSymbol := C.makeSymbol(C.int(symbol.Identifier), C.int(symbol.Type), C.int(symbol.MatchType), Pattern, Value1, Value2, Value3, Tag, C.int(symbol.Weight), C.int(symbol.Priority), C.int(symbol.AcceptCondition), C.int(symbol.Flags))
+	defer C.free(unsafe.Pointer(cSymbol))
+
+	err := C.varnam_override_symbol(handle.connectionID, *cSymbol)
+
+	return handle.checkError(err)
+}
+
+// RemoveSymbolOverride make pattern give what the scheme gives again
+func (handle *VarnamHandle) RemoveSymbolOverride(pattern string) error {
+	cPattern := C.CString(pattern)
+
+	err := C.varnam_remove_symbol_override(handle.connectionID, cPattern)
+
+	C.free(unsafe.Pointer(cPattern))
+
+	return handle.checkError(err)
+}
+
+// SetSymbolWeight change weight of the scheme's symbol for pattern
+// giving value1. More weight makes the tokenizer prefer it
+func (handle *VarnamHandle) SetSymbolWeight(pattern string, value1 string, weight int) error {
+	cPattern := C.CString(pattern)
+	cValue1 := C.CString(value1)
+
+	err := C.varnam_set_symbol_weight(handle.connectionID, cPattern, cValue1, C.int(weight))
+
+	C.free(unsafe.Pointer(cPattern))
+	C.free(unsafe.Pointer(cValue1))
+
+	return handle.checkError(err)
+}
+
+// ResetSymbolWeight use the scheme's weight for the symbol again
+func (handle *VarnamHandle) ResetSymbolWeight(pattern string, value1 string) error {
+	cPattern := C.CString(pattern)
+	cValue1 := C.CString(value1)
+
+	err := C.varnam_reset_symbol_weight(handle.connectionID, cPattern, cValue1)
+
+	C.free(unsafe.Pointer(cPattern))
+	C.free(unsafe.Pointer(cValue1))
+
+	return handle.checkError(err)
+}
+
+// ReloadScheme load the VST again without closing the dictionary
+func (handle *VarnamHandle) ReloadScheme() error {
+	err := C.varnam_reload_scheme(handle.connectionID)
+	return handle.checkError(err)
+}
+
+// ReloadSchemeIfChanged reload the scheme if VST file has changed
+func (handle *VarnamHandle) ReloadSchemeIfChanged() error {
+	err := C.varnam_reload_scheme_if_changed(handle.connectionID)
+	return handle.checkError(err)
+}
+
 // LearnFromFile learn words from a file
 func (handle *VarnamHandle) LearnFromFile(filePath string) (LearnStatus, error) {
 	var learnStatus LearnStatus
@@ -540,6 +989,25 @@ func (handle *VarnamHandle) Export(filePath string, wordsPerFile int) error {
 	return handle.checkError(err)
 }
 
+// ExportToHunspell export learnt words as basePath.dic & basePath.aff
+func (handle *VarnamHandle) ExportToHunspell(basePath string) error {
+	cBasePath := C.CString(basePath)
+	defer C.free(unsafe.Pointer(cBasePath))
+
+	err := C.varnam_export_to_hunspell(handle.connectionID, cBasePath)
+	return handle.checkError(err)
+}
+
+// WriteMappedVST write the scheme as a mapped VST, which
+// is mmapped at init instead of being read fully
+func (handle *VarnamHandle) WriteMappedVST(filePath string) error {
+	cFilePath := C.CString(filePath)
+	defer C.free(unsafe.Pointer(cFilePath))
+
+	err := C.varnam_write_mapped_vst(handle.connectionID, cFilePath)
+	return handle.checkError(err)
+}
+
 // Import learnigns to a file
 func (handle *VarnamHandle) Import(filePath string) error {
 	cFilePath := C.CString(filePath)
@@ -547,6 +1015,85 @@ func (handle *VarnamHandle) Import(filePath string) error {
 	return handle.checkError(err)
 }
 
+// ImportFromLibvarnam import words and trained patterns from a libvarnam learnings DB
+func (handle *VarnamHandle) ImportFromLibvarnam(filePath string) (LearnStatus, error) {
+	var learnStatus LearnStatus
+
+	cFilePath := C.CString(filePath)
+	defer C.free(unsafe.Pointer(cFilePath))
+
+	var resultPointer *C.LearnStatus
+
+	code := C.varnam_import_from_libvarnam(handle.connectionID, cFilePath, &resultPointer)
+	if code != C.VARNAM_SUCCESS {
+		return learnStatus, &VarnamError{
+			ErrorCode: int(code),
+			Message:   handle.GetLastError(),
+		}
+	}
+
+	learnStatus = LearnStatus{
+		int((*resultPointer).TotalWords),
+		int((*resultPointer).FailedWords),
+	}
+
+	return learnStatus, nil
+}
+
+// ImportFromHunspell import words in a hunspell dictionary.
+// affPath can be empty
+func (handle *VarnamHandle) ImportFromHunspell(dicPath string, affPath string) (LearnStatus, error) {
+	var learnStatus LearnStatus
+
+	cDicPath := C.CString(dicPath)
+	defer C.free(unsafe.Pointer(cDicPath))
+
+	cAffPath := C.CString(affPath)
+	defer C.free(unsafe.Pointer(cAffPath))
+
+	var resultPointer *C.LearnStatus
+
+	code := C.varnam_import_from_hunspell(handle.connectionID, cDicPath, cAffPath, &resultPointer)
+	if code != C.VARNAM_SUCCESS {
+		return learnStatus, &VarnamError{
+			ErrorCode: int(code),
+			Message:   handle.GetLastError(),
+		}
+	}
+
+	learnStatus = LearnStatus{
+		int((*resultPointer).TotalWords),
+		int((*resultPointer).FailedWords),
+	}
+
+	return learnStatus, nil
+}
+
+// ImportFromVarnamWeb learn words and patterns from a Varnam web editor JSON export
+func (handle *VarnamHandle) ImportFromVarnamWeb(filePath string) (LearnStatus, error) {
+	var learnStatus LearnStatus
+
+	cFilePath := C.CString(filePath)
+	defer C.free(unsafe.Pointer(cFilePath))
+
+	var resultPointer *C.LearnStatus
+
+	code := C.varnam_import_from_varnam_web(handle.connectionID, cFilePath, &resultPointer)
+	if code != C.VARNAM_SUCCESS {
+		return learnStatus, &VarnamError{
+			ErrorCode: int(code),
+			Message:   handle.GetLastError(),
+		}
+	}
+
+	learnStatus = LearnStatus{
+		int((*resultPointer).TotalWords),
+		int((*resultPointer).FailedWords),
+	}
+
+	return learnStatus, nil
+}
+
 // GetRecentlyLearntWords get recently learn words
 func (handle *VarnamHandle) GetRecentlyLearntWords(ctx context.Context, offset int, limit int) ([]Suggestion, error) {
 	var result []Suggestion
@@ -580,6 +1127,44 @@ func (handle *VarnamHandle) GetRecentlyLearntWords(ctx context.Context, offset i
 	}
 }
 
+// GetRandomLearnedWords get a random sample of learnt words
+func (handle *VarnamHandle) GetRandomLearnedWords(ctx context.Context, limit int, preferLessUsed bool) ([]Suggestion, error) {
+	var result []Suggestion
+
+	operationID := makeContextOperation()
+
+	select {
+	case <-ctx.Done():
+		C.varnam_cancel(operationID)
+		return result, nil
+	default:
+		var resultPointer *C.varray
+
+		cPreferLessUsed := C.int(0)
+		if preferLessUsed {
+			cPreferLessUsed = C.int(1)
+		}
+
+		code := C.varnam_get_random_learned_words(handle.connectionID, operationID, C.int(limit), cPreferLessUsed, &resultPointer)
+		if code != C.VARNAM_SUCCESS {
+			return result, &VarnamError{
+				ErrorCode: int(code),
+				Message:   handle.GetLastError(),
+			}
+		}
+
+		i := 0
+		for i < int(C.varray_length(resultPointer)) {
+			cSug := (*C.Suggestion)(C.varray_get(resultPointer, C.int(i)))
+			sug := makeSuggestion(cSug)
+			result = append(result, sug)
+			i++
+		}
+
+		return result, nil
+	}
+}
+
 // GetSuggestions get suggestions for a word
 func (handle *VarnamHandle) GetSuggestions(ctx context.Context, word string) ([]Suggestion, error) {
 	var result []Suggestion
@@ -629,6 +1214,11 @@ func makeGoSchemeDetails(cSD *C.struct_SchemeDetails_t) SchemeDetails {
 		C.GoString(cSD.Author),
 		C.GoString(cSD.CompiledDate),
 		isStable,
+		C.GoString(cSD.Path),
+		C.GoString(cSD.NativeDisplayName),
+		C.GoString(cSD.Version),
+		C.GoString(cSD.MinLibraryVersion),
+		C.GoString(cSD.Base),
 	}
 }
 
@@ -637,6 +1227,11 @@ func (handle *VarnamHandle) GetSchemeDetails() SchemeDetails {
 	return makeGoSchemeDetails(C.varnam_get_scheme_details(handle.connectionID))
 }
 
+// IsRTL whether the language of scheme is written right to left
+func (handle *VarnamHandle) IsRTL() bool {
+	return C.varnam_is_rtl(handle.connectionID) == C.int(1)
+}
+
 // GetVSTPath Get path to VST of current handle
 func (handle *VarnamHandle) GetVSTPath() string {
 	cStr := C.varnam_get_vst_path(handle.connectionID)
@@ -720,6 +1315,14 @@ func GetVSTDir() string {
 	return C.GoString(cStr)
 }
 
+// AddVSTLookupDir look for VSTs in dir too
+func AddVSTLookupDir(dir string) {
+	cDir := C.CString(dir)
+	defer C.free(unsafe.Pointer(cDir))
+
+	C.varnam_add_vst_lookup_dir(cDir)
+}
+
 // GetAllSchemeDetails get all available scheme details. The bool is for error
 func GetAllSchemeDetails() ([]SchemeDetails, bool) {
 	cSchemeDetails := C.varnam_get_all_scheme_details()
@@ -97,3 +97,29 @@ func TestSearchSymbolTable(t *testing.T) {
 
 	assertEqual(t, result[0].Value1, "ല")
 }
+
+func TestComposition(t *testing.T) {
+	varnam := getVarnamInstance("ml")
+
+	composition, err := varnam.NewComposition(3)
+	checkError(err)
+	defer composition.Free()
+
+	checkError(composition.Append("mala"))
+	assertEqual(t, composition.IsEmpty(), false)
+
+	preedit, err := composition.Preedit()
+	checkError(err)
+	assertEqual(t, preedit, "മല")
+
+	checkError(composition.NextPage())
+	page, cursor, err := composition.Page()
+	checkError(err)
+	assertEqual(t, len(page), 3)
+	assertEqual(t, cursor, 0)
+
+	input, err := composition.CommitInput()
+	checkError(err)
+	assertEqual(t, input, "mala")
+	assertEqual(t, composition.IsEmpty(), true)
+}
@@ -61,13 +61,17 @@ func TestVersion(t *testing.T) {
 	assertEqual(t, GetVersion(), tagVersion)
 }
 
+func TestABIVersion(t *testing.T) {
+	assertEqual(t, GetABIVersion(), 1)
+}
+
 func tearDown() {
 	os.RemoveAll(testTempDir)
 }
 
 func TestMain(m *testing.M) {
 	var err error
-	testTempDir, err = os.TempDir("", "govarnam_test")
+	testTempDir, err = os.MkdirTemp("", "govarnam_test")
 	checkError(err)
 
 	setUp("ml")
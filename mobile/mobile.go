@@ -0,0 +1,255 @@
+/*
+Package mobile is govarnam for Android & iOS keyboard apps, made with
+gomobile :
+
+	gomobile bind -tags fts5 -target android -o govarnam.aar ./mobile
+	gomobile bind -tags fts5 -target ios -o Govarnam.xcframework ./mobile
+
+gomobile can only bind basic types, []byte & pointers to structs, so
+suggestions are given as Suggestions with Len & Get instead of a slice.
+
+# Where to keep files
+
+SQLite needs files on disk. Android assets & iOS bundle resources
+aren't, so VSTs shipped with the app are installed into a data
+directory with InstallVST on first run (and after updating them).
+Learnings are kept in the same directory :
+
+	<dataDir>/schemes/<schemeID>.vst
+	<dataDir>/learnings/<schemeID>.vst.learnings
+
+Use a directory private to the app that is kept across updates :
+
+	Android : context.getFilesDir() + "/varnam"
+	iOS     : Application Support directory + "/varnam"
+	          (exclude it from iCloud backup if learnings shouldn't sync)
+
+Don't use cache directories, the system can clear those. On Android,
+a keyboard (InputMethodService) runs in the app's own process so the
+settings activity & the keyboard see the same files. On iOS the keyboard
+extension runs in its own sandbox, use the App Group container
+(containerURL(forSecurityApplicationGroupIdentifier:)) for the data
+directory to share learnings with the containing app.
+*/
+package mobile
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+// Varnam an instance of govarnam
+type Varnam struct {
+	varnam *govarnam.Varnam
+}
+
+// Suggestion a transliterated word
+type Suggestion struct {
+	Word      string
+	Weight    int
+	LearnedOn int
+
+	// 0 - 1, see govarnam.Suggestion
+	Score float64
+}
+
+// Suggestions list of Suggestion
+type Suggestions struct {
+	sugs []govarnam.Suggestion
+}
+
+// SchemeDetails details of the scheme in use
+type SchemeDetails struct {
+	Identifier        string
+	LangCode          string
+	DisplayName       string
+	NativeDisplayName string
+	Author            string
+	CompiledDate      string
+	IsStable          bool
+	Version           string
+}
+
+// Len number of suggestions
+func (s *Suggestions) Len() int {
+	return len(s.sugs)
+}
+
+// Get suggestion at index i. nil if out of range
+func (s *Suggestions) Get(i int) *Suggestion {
+	if i < 0 || i >= len(s.sugs) {
+		return nil
+	}
+	sug := s.sugs[i]
+	return &Suggestion{sug.Word, sug.Weight, sug.LearnedOn, sug.Score}
+}
+
+// VSTPath where the VST of schemeID is kept in dataDir
+func VSTPath(dataDir string, schemeID string) string {
+	return filepath.Join(dataDir, "schemes", schemeID+".vst")
+}
+
+// LearningsPath where learnings of schemeID are kept in dataDir
+func LearningsPath(dataDir string, schemeID string) string {
+	return filepath.Join(dataDir, "learnings", schemeID+".vst.learnings")
+}
+
+// InstallVST write VST contents (read from app assets) to dataDir.
+// Replaces the existing one, close instances using it before this
+func InstallVST(dataDir string, schemeID string, vst []byte) error {
+	vstPath := VSTPath(dataDir, schemeID)
+
+	err := os.MkdirAll(filepath.Dir(vstPath), 0750)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename so that a crash
+	// midway doesn't leave a broken VST
+	temp, err := ioutil.TempFile(filepath.Dir(vstPath), schemeID+".vst.*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	_, err = temp.Write(vst)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), vstPath)
+}
+
+// IsVSTInstalled whether VST of schemeID is in dataDir
+func IsVSTInstalled(dataDir string, schemeID string) bool {
+	_, err := os.Stat(VSTPath(dataDir, schemeID))
+	return err == nil
+}
+
+// Init initialize from file paths
+func Init(vstPath string, learningsPath string) (*Varnam, error) {
+	varnam, err := govarnam.Init(vstPath, learningsPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Varnam{varnam}, nil
+}
+
+// InitFromDataDir initialize with VST installed in dataDir
+// by InstallVST. Learnings are kept in dataDir too
+func InitFromDataDir(dataDir string, schemeID string) (*Varnam, error) {
+	if !IsVSTInstalled(dataDir, schemeID) {
+		return nil, fmt.Errorf("VST for %q isn't installed in %s", schemeID, dataDir)
+	}
+	return Init(VSTPath(dataDir, schemeID), LearningsPath(dataDir, schemeID))
+}
+
+// Close the instance
+func (v *Varnam) Close() error {
+	return v.varnam.Close()
+}
+
+// GetSchemeDetails details of the scheme in use
+func (v *Varnam) GetSchemeDetails() *SchemeDetails {
+	sd := v.varnam.SchemeDetails
+	return &SchemeDetails{
+		Identifier:        sd.Identifier,
+		LangCode:          sd.LangCode,
+		DisplayName:       sd.DisplayName,
+		NativeDisplayName: sd.NativeDisplayName,
+		Author:            sd.Author,
+		CompiledDate:      sd.CompiledDate,
+		IsStable:          sd.IsStable,
+		Version:           sd.Version,
+	}
+}
+
+// SetDictionarySuggestionsLimit see govarnam.Varnam
+func (v *Varnam) SetDictionarySuggestionsLimit(limit int) {
+	v.varnam.DictionarySuggestionsLimit = limit
+}
+
+// SetPatternDictionarySuggestionsLimit see govarnam.Varnam
+func (v *Varnam) SetPatternDictionarySuggestionsLimit(limit int) {
+	v.varnam.PatternDictionarySuggestionsLimit = limit
+}
+
+// SetTokenizerSuggestionsLimit see govarnam.Varnam
+func (v *Varnam) SetTokenizerSuggestionsLimit(limit int) {
+	v.varnam.TokenizerSuggestionsLimit = limit
+}
+
+// SetIndicDigits output native digits for numbers
+func (v *Varnam) SetIndicDigits(enabled bool) {
+	v.varnam.LangRules.IndicDigits = enabled
+}
+
+// Transliterate get suggestions for word
+func (v *Varnam) Transliterate(word string) *Suggestions {
+	return &Suggestions{v.varnam.Transliterate(word)}
+}
+
+// TransliterateQuick like Transliterate but gives up on slow
+// lookups to keep typing responsive. See govarnam.Varnam.QuickBudget
+func (v *Varnam) TransliterateQuick(word string) *Suggestions {
+	return &Suggestions{v.varnam.TransliterateQuick(context.Background(), word)}
+}
+
+// TransliterateGreedyTokenized only tokenizer output, fastest
+func (v *Varnam) TransliterateGreedyTokenized(word string) *Suggestions {
+	return &Suggestions{v.varnam.TransliterateGreedyTokenized(word)}
+}
+
+// ReverseTransliterate get possible inputs of a word
+func (v *Varnam) ReverseTransliterate(word string) (*Suggestions, error) {
+	sugs, err := v.varnam.ReverseTransliterate(word)
+	if err != nil {
+		return nil, err
+	}
+	return &Suggestions{sugs}, nil
+}
+
+// Learn a word. weight 0 for default
+func (v *Varnam) Learn(word string, weight int) error {
+	return v.varnam.Learn(word, weight)
+}
+
+// Train a pattern to a word
+func (v *Varnam) Train(pattern string, word string) error {
+	return v.varnam.Train(pattern, word)
+}
+
+// Unlearn a word
+func (v *Varnam) Unlearn(word string) error {
+	return v.varnam.Unlearn(word)
+}
+
+// ReportSelected tell that the user picked word for input
+// so that it ranks higher next time
+func (v *Varnam) ReportSelected(input string, word string) error {
+	return v.varnam.ReportSelected(input, word)
+}
+
+// Export learnings to JSON files at filePath, for backups
+func (v *Varnam) Export(filePath string, wordsPerFile int) error {
+	return v.varnam.Export(filePath, wordsPerFile)
+}
+
+// Import learnings from a file made by Export
+func (v *Varnam) Import(filePath string) error {
+	return v.varnam.Import(filePath)
+}
@@ -0,0 +1,74 @@
+package mobile
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/varnamproject/govarnam/schemecompile"
+)
+
+// Data directory with the Hindi scheme in scheme-sources/ installed as "hi"
+func makeDataDir(t *testing.T) string {
+	dir := t.TempDir()
+	vstPath := filepath.Join(dir, "compiled.vst")
+
+	err := schemecompile.CompileFile(filepath.Join("..", "scheme-sources", "hi.toml"), vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vst, err := ioutil.ReadFile(vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataDir := filepath.Join(dir, "varnam")
+	if IsVSTInstalled(dataDir, "hi") {
+		t.Fatal("VST shouldn't be installed yet")
+	}
+	if err := InstallVST(dataDir, "hi", vst); err != nil {
+		t.Fatal(err)
+	}
+	if !IsVSTInstalled(dataDir, "hi") {
+		t.Fatal("VST should be installed")
+	}
+
+	return dataDir
+}
+
+func TestMobile(t *testing.T) {
+	dataDir := makeDataDir(t)
+
+	_, err := InitFromDataDir(dataDir, "ml")
+	if err == nil {
+		t.Error("Expected error for scheme not installed")
+	}
+
+	varnam, err := InitFromDataDir(dataDir, "hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer varnam.Close()
+
+	if varnam.GetSchemeDetails().LangCode != "hi" {
+		t.Errorf("Expected lang code hi, got %q", varnam.GetSchemeDetails().LangCode)
+	}
+
+	sugs := varnam.TransliterateGreedyTokenized("namaste")
+	if sugs.Len() == 0 || sugs.Get(0).Word != "नमस्ते" {
+		t.Errorf("Expected नमस्ते as first suggestion")
+	}
+	if sugs.Get(-1) != nil || sugs.Get(sugs.Len()) != nil {
+		t.Error("Expected nil for out of range index")
+	}
+
+	if err := varnam.Learn("नमस्ते", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	sugs = varnam.Transliterate("namaste")
+	if sugs.Len() == 0 || sugs.Get(0).Word != "नमस्ते" || sugs.Get(0).LearnedOn == 0 {
+		t.Errorf("Expected learnt नमस्ते as first suggestion")
+	}
+}
@@ -0,0 +1,314 @@
+package registry
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+Package registry downloads, verifies and installs scheme VSTs and
+prebuilt dictionaries from a registry. A registry is a JSON index
+served over HTTP(S) (or a file:// URL) :
+
+	{
+	  "packages": [
+	    {
+	      "id": "ml",
+	      "type": "scheme",
+	      "version": "1.0.0",
+	      "description": "Malayalam",
+	      "url": "schemes/ml.vst",
+	      "sha256": "9f86d08...",
+	      "size": 1048576
+	    },
+	    {
+	      "id": "ml-wikipedia",
+	      "type": "dictionary",
+	      "scheme": "ml",
+	      "url": "dictionaries/ml-wikipedia.vlf",
+	      "sha256": "60303ae..."
+	    }
+	  ]
+	}
+
+A relative url is relative to the index. Packages without sha256
+are not installed. Schemes are installed to govarnam.UserVSTDir() so
+that they're found by scheme ID. Dictionaries are files exported with
+Varnam.Export, installed to the "dictionaries" directory next to it.
+Import them into learnings with Varnam.Import.
+*/
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+// Package types
+const (
+	TypeScheme     = "scheme"
+	TypeDictionary = "dictionary"
+)
+
+// Package a scheme or dictionary in registry
+type Package struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+
+	// Scheme ID a dictionary is for
+	Scheme string `json:"scheme"`
+
+	URL string `json:"url"`
+
+	// Hex encoded SHA-256 of the file
+	SHA256 string `json:"sha256"`
+
+	// Size of file in bytes. 0 if unknown
+	Size int64 `json:"size"`
+}
+
+// Index list of packages in registry
+type Index struct {
+	Packages []Package `json:"packages"`
+}
+
+// Registry where packages are downloaded from
+// and where they're installed to
+type Registry struct {
+	// URL of the index
+	URL string
+
+	SchemesDir      string
+	DictionariesDir string
+
+	Client *http.Client
+}
+
+// DefaultURL registry URL set with VARNAM_REGISTRY_URL
+func DefaultURL() string {
+	return os.Getenv("VARNAM_REGISTRY_URL")
+}
+
+// New registry at url, installing to the user's directories
+func New(registryURL string) *Registry {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	transport.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+
+	return &Registry{
+		URL:             registryURL,
+		SchemesDir:      govarnam.UserVSTDir(),
+		DictionariesDir: filepath.Join(filepath.Dir(govarnam.UserVSTDir()), "dictionaries"),
+		Client:          &http.Client{Transport: transport},
+	}
+}
+
+func (registry *Registry) get(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := registry.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", fileURL, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// Index get the list of packages in registry
+func (registry *Registry) Index(ctx context.Context) (*Index, error) {
+	if registry.URL == "" {
+		return nil, fmt.Errorf("Registry URL is not set. Set VARNAM_REGISTRY_URL")
+	}
+
+	body, err := registry.get(ctx, registry.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var index Index
+	err = json.NewDecoder(body).Decode(&index)
+	if err != nil {
+		return nil, fmt.Errorf("Parsing registry index failed, err: %s", err.Error())
+	}
+
+	return &index, nil
+}
+
+// Find a package in registry
+func (registry *Registry) Find(ctx context.Context, id string) (Package, error) {
+	index, err := registry.Index(ctx)
+	if err != nil {
+		return Package{}, err
+	}
+
+	for _, pkg := range index.Packages {
+		if pkg.ID == id {
+			return pkg, nil
+		}
+	}
+
+	return Package{}, fmt.Errorf("Package %s not found in registry", id)
+}
+
+// Install a package from registry. Gives the installed path
+func (registry *Registry) Install(ctx context.Context, id string) (string, error) {
+	pkg, err := registry.Find(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return registry.InstallPackage(ctx, pkg)
+}
+
+// InstallPackage download, verify and install a package.
+// An installed version of the package is replaced only
+// if the new one is verified. Gives the installed path
+func (registry *Registry) InstallPackage(ctx context.Context, pkg Package) (string, error) {
+	// ID becomes the file name
+	if pkg.ID == "" || strings.ContainsAny(pkg.ID, `/\`) || strings.HasPrefix(pkg.ID, ".") {
+		return "", fmt.Errorf("Invalid package ID %q", pkg.ID)
+	}
+
+	if pkg.SHA256 == "" {
+		return "", fmt.Errorf("Package %s has no checksum", pkg.ID)
+	}
+
+	var dir, installPath string
+
+	switch pkg.Type {
+	case TypeScheme:
+		dir = registry.SchemesDir
+		installPath = filepath.Join(dir, pkg.ID+".vst")
+	case TypeDictionary:
+		dir = registry.DictionariesDir
+		installPath = filepath.Join(dir, pkg.ID+".vlf")
+	default:
+		return "", fmt.Errorf("Package %s is of unknown type %q", pkg.ID, pkg.Type)
+	}
+
+	fileURL, err := registry.resolve(pkg.URL)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(dir, 0750)
+	if err != nil {
+		return "", err
+	}
+
+	// Downloaded next to the installed path so that it can be renamed
+	file, err := os.CreateTemp(dir, ".download-"+pkg.ID+"-*")
+	if err != nil {
+		return "", err
+	}
+	tempPath := file.Name()
+	defer os.Remove(tempPath)
+
+	err = registry.download(ctx, fileURL, pkg, file)
+	closeErr := file.Close()
+	if err != nil {
+		return "", fmt.Errorf("Downloading %s failed: %s", pkg.ID, err.Error())
+	}
+	if closeErr != nil {
+		return "", closeErr
+	}
+
+	if pkg.Type == TypeScheme {
+		err = verifyScheme(tempPath, pkg.ID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = os.Rename(tempPath, installPath)
+	if err != nil {
+		return "", err
+	}
+
+	return installPath, nil
+}
+
+// Download to w and check size & checksum
+func (registry *Registry) download(ctx context.Context, fileURL string, pkg Package, w io.Writer) error {
+	body, err := registry.get(ctx, fileURL)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+
+	reader := io.Reader(body)
+	if pkg.Size > 0 {
+		// One more byte to know if it's bigger
+		reader = io.LimitReader(body, pkg.Size+1)
+	}
+
+	size, err := io.Copy(io.MultiWriter(w, hash), reader)
+	if err != nil {
+		return err
+	}
+
+	if pkg.Size > 0 && size != pkg.Size {
+		return fmt.Errorf("size mismatch, expected %d bytes", pkg.Size)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(checksum, pkg.SHA256) {
+		return fmt.Errorf("checksum mismatch, expected %s got %s", pkg.SHA256, checksum)
+	}
+
+	return nil
+}
+
+// URL of package file. Relative URLs are relative to index
+func (registry *Registry) resolve(fileURL string) (string, error) {
+	base, err := url.Parse(registry.URL)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := url.Parse(fileURL)
+	if err != nil {
+		return "", err
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
+
+// Check that the downloaded file is a VST of the scheme
+func verifyScheme(vstPath string, id string) error {
+	varnam := govarnam.Varnam{}
+
+	err := varnam.InitVST(vstPath)
+	if err != nil {
+		return fmt.Errorf("Package %s is not a valid VST: %s", id, err.Error())
+	}
+	defer varnam.Close()
+
+	if varnam.SchemeDetails.Identifier != id {
+		return fmt.Errorf("Package %s has VST of scheme %s", id, varnam.SchemeDetails.Identifier)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+func makeVST(t *testing.T, id string) []byte {
+	vstPath := filepath.Join(t.TempDir(), id+".vst")
+
+	vm, err := govarnam.VMInit(vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = vm.VMSetSchemeDetails(govarnam.SchemeDetails{Identifier: id, LangCode: "ml", DisplayName: "Test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = vm.VMCreateToken("a", "അ", "", "", "", govarnam.VARNAM_SYMBOL_VOWEL, govarnam.VARNAM_MATCH_EXACT, 0, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.Close()
+
+	vst, err := os.ReadFile(vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return vst
+}
+
+func checksum(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func TestInstall(t *testing.T) {
+	vst := makeVST(t, "test-ml")
+	dict := []byte(`{"words":[],"patterns":[]}`)
+
+	index := Index{Packages: []Package{
+		{ID: "test-ml", Type: TypeScheme, URL: "files/test-ml.vst", SHA256: checksum(vst), Size: int64(len(vst))},
+		{ID: "test-ml-corpus", Type: TypeDictionary, Scheme: "test-ml", URL: "files/corpus.vlf", SHA256: checksum(dict)},
+		{ID: "tampered", Type: TypeScheme, URL: "files/test-ml.vst", SHA256: checksum(dict)},
+		{ID: "wrong-id", Type: TypeScheme, URL: "files/test-ml.vst", SHA256: checksum(vst)},
+		{ID: "unverified", Type: TypeDictionary, URL: "files/corpus.vlf"},
+		{ID: "../escape", Type: TypeDictionary, URL: "files/corpus.vlf", SHA256: checksum(dict)},
+	}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/registry/index.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(index)
+	})
+	mux.HandleFunc("/registry/files/test-ml.vst", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(vst)
+	})
+	mux.HandleFunc("/registry/files/corpus.vlf", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(dict)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	registry := New(server.URL + "/registry/index.json")
+	registry.SchemesDir = filepath.Join(t.TempDir(), "schemes")
+	registry.DictionariesDir = filepath.Join(t.TempDir(), "dictionaries")
+
+	ctx := context.Background()
+
+	installed, err := registry.Install(ctx, "test-ml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installed != filepath.Join(registry.SchemesDir, "test-ml.vst") {
+		t.Errorf("Installed to %s", installed)
+	}
+
+	varnam := govarnam.Varnam{}
+	err = varnam.InitVST(installed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	varnam.Close()
+
+	installed, err = registry.Install(ctx, "test-ml-corpus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(installed)
+	if err != nil || string(data) != string(dict) {
+		t.Errorf("Dictionary not installed: %s %v", data, err)
+	}
+
+	failures := map[string]string{
+		"tampered":   "checksum mismatch",
+		"wrong-id":   "has VST of scheme test-ml",
+		"unverified": "has no checksum",
+		"../escape":  "Invalid package ID",
+		"missing":    "not found in registry",
+	}
+	for id, expected := range failures {
+		_, err = registry.Install(ctx, id)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q installing %s, got %v", expected, id, err)
+		}
+	}
+
+	// Failed installs leave nothing behind
+	files, _ := os.ReadDir(registry.SchemesDir)
+	if len(files) != 1 {
+		t.Errorf("Expected only test-ml.vst, got %v", files)
+	}
+
+	_, err = New("").Index(ctx)
+	if err == nil {
+		t.Errorf("Expected error without registry URL")
+	}
+}
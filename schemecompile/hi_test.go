@@ -0,0 +1,98 @@
+package schemecompile
+
+import "testing"
+
+func TestHIGreedyTokenizer(t *testing.T) {
+	varnam := compileScheme(t, "hi")
+
+	assertGreedy(t, varnam, "namaste", "नमस्ते")
+	assertGreedy(t, varnam, "namaskaar", "नमस्कार")
+	assertGreedy(t, varnam, "hindee", "हिन्दी")
+	assertGreedy(t, varnam, "dhanyavaad", "धन्यवाद")
+	assertGreedy(t, varnam, "pustak", "पुस्तक")
+	assertGreedy(t, varnam, "gyaan", "ज्ञान")
+	assertGreedy(t, varnam, "kshatriya", "क्षत्रिय")
+	assertGreedy(t, varnam, "hiMdee", "हिंदी")
+}
+
+func TestHIConjuncts(t *testing.T) {
+	varnam := compileScheme(t, "hi")
+
+	// Halant joins consonants
+	assertGreedy(t, varnam, "shabd", "शब्द")
+	assertGreedy(t, varnam, "prem", "प्रेम")
+	assertGreedy(t, varnam, "acchha", "अच्छ")
+	assertGreedy(t, varnam, "kRipaa", "कृपा")
+
+	sugs := varnam.TransliterateAdvanced("kya").TokenizerSuggestions
+	if sugs[0].Word != "क्य" || !hasSuggestion(sugs, "क्या") {
+		t.Errorf("kya: %v", sugs)
+	}
+}
+
+func TestHISchwa(t *testing.T) {
+	varnam := compileScheme(t, "hi")
+
+	// Last consonant of a word doesn't get a virama
+	assertGreedy(t, varnam, "kamal", "कमल")
+	assertGreedy(t, varnam, "bharat", "भरत")
+	assertGreedy(t, varnam, "khush", "खुश")
+	assertGreedy(t, varnam, "sundar", "सुन्दर")
+
+	sugs := varnam.TransliterateAdvanced("kamal").TokenizerSuggestions
+	if sugs[0].Word != "कमल" || !hasSuggestion(sugs, "कमल्") {
+		t.Errorf("kamal: %v", sugs)
+	}
+
+	// Final a is mostly आ
+	if !hasSuggestion(varnam.TransliterateAdvanced("kamala").TokenizerSuggestions, "कमला") {
+		t.Errorf("kamala doesn't suggest कमला")
+	}
+}
+
+func TestHINukta(t *testing.T) {
+	varnam := compileScheme(t, "hi")
+
+	// Consonant + nukta (U+093C)
+	assertGreedy(t, varnam, "zindagee", "ज़िन्दगी")
+	assertGreedy(t, varnam, "fasal", "फ़सल")
+	assertGreedy(t, varnam, "qilaa", "क़िला")
+	assertGreedy(t, varnam, "Khush", "ख़ुश")
+
+	// Nukta is often left out
+	if !hasSuggestion(varnam.TransliterateAdvanced("fasal").TokenizerSuggestions, "फसल") {
+		t.Errorf("fasal doesn't suggest फसल")
+	}
+}
+
+func TestHILearn(t *testing.T) {
+	varnam := compileScheme(t, "hi")
+
+	// Precomposed ज़ is learnt as ज + nukta
+	err := varnam.Learn("ज़िंदगी", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sugs := varnam.Transliterate("zindagi")
+	if sugs[0].Word != "ज़िंदगी" {
+		t.Errorf("zindagi: %v", sugs)
+	}
+
+	sugs, err = varnam.ReverseTransliterate("कमल")
+	if err != nil || !hasSuggestion(sugs, "kamal") {
+		t.Errorf("Reverse transliteration of कमल: %v %v", sugs, err)
+	}
+}
+
+func TestHIValidateScheme(t *testing.T) {
+	varnam := compileScheme(t, "hi")
+
+	issues, err := varnam.ValidateScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		t.Error(issue)
+	}
+}
@@ -0,0 +1,47 @@
+package schemecompile
+
+import "testing"
+
+func TestSAGreedyTokenizer(t *testing.T) {
+	varnam := compileScheme(t, "sa")
+
+	assertGreedy(t, varnam, "saMskRRitam", "संस्कृतम्")
+	assertGreedy(t, varnam, "rAmaH", "रामः")
+	assertGreedy(t, varnam, "dharmakShetre", "धर्मक्षेत्रे")
+	assertGreedy(t, varnam, "j~nAnam", "ज्ञानम्")
+	assertGreedy(t, varnam, "kRRiShNa", "कृष्ण")
+	assertGreedy(t, varnam, "agnimILe", "अग्निमीळे")
+
+	// Avagraha, Om & dandas
+	assertGreedy(t, varnam, "so.aham", "सोऽहम्")
+	assertGreedy(t, varnam, "OM", "ॐ")
+	assertGreedy(t, varnam, "namaH.", "नमः।")
+	assertGreedy(t, varnam, "namaH..", "नमः॥")
+}
+
+func TestSAVedicAccents(t *testing.T) {
+	varnam := compileScheme(t, "sa")
+
+	// Udātta, anudātta & dīrgha svarita
+	assertGreedy(t, varnam, `a\'gnimI\_Le`, "अ॑ग्निमी॒ळे")
+	assertGreedy(t, varnam, `puro\'hitam`, "पुरो॑हितम्")
+	assertGreedy(t, varnam, `ya\_j~nasya\'`, "य॒ज्ञस्य॑")
+	assertGreedy(t, varnam, `de\"vam`, "दे᳚वम्")
+
+	sugs, err := varnam.ReverseTransliterate("अ॑ग्निमी॒ळे")
+	if err != nil || !hasSuggestion(sugs, `a\'gnimI\_Le`) {
+		t.Errorf("Reverse transliteration of accented word: %v %v", sugs, err)
+	}
+}
+
+func TestSAValidateScheme(t *testing.T) {
+	varnam := compileScheme(t, "sa")
+
+	issues, err := varnam.ValidateScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		t.Error(issue)
+	}
+}
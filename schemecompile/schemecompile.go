@@ -0,0 +1,699 @@
+package schemecompile
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+Package schemecompile compiles a scheme written in TOML into
+the VST that govarnam loads. A scheme source looks like :
+
+	[scheme]
+	identifier = "ml"
+	lang_code = "ml"
+	display_name = "Malayalam"
+	native_display_name = "മലയാളം"
+	author = "Varnam Project"
+	version = "1.0.0"
+	# Oldest govarnam the scheme works with
+	min_library_version = "1.9.0"
+	stable = true
+	# Make "k" => "ക്" from "ka" => "ക"
+	dead_consonants = true
+	# Make "ki" => "കി" from "ka" => "ക" and "i" => "ി"
+	consonant_vowels = true
+
+	[[virama]]
+	pattern = "~"
+	value = "്"
+
+	[[vowels]]
+	pattern = ["aa", "A"]
+	value = "ആ"
+	sign = "ാ"
+
+	[[consonants]]
+	pattern = "La"
+	value = "ള"
+
+	[[consonants]]
+	pattern = "la"
+	value = "ള"
+	match = "possibility"
+	weight = 50
+
+Each [[section]] is a symbol. Sections are the symbol types :
+vowels, consonants, dead_consonants, consonant_vowels, conjuncts,
+numbers, symbols, anusvara, visarga, virama, others, non_joiner,
+joiner and period.
+
+A variant of a scheme has only the symbols it changes. Symbols of
+the base scheme are used for the other patterns when the variant
+is loaded. The base VST is looked for next to the variant first :
+
+	[scheme]
+	identifier = "ml-classic"
+	lang_code = "ml"
+	base = "ml"
+
+	[[consonants]]
+	pattern = "zha"
+	value = "ള"
+
+Languages like Hindi don't write the inherent vowel of the last
+consonant of a word, "kamal" is कमल and not कमल्. With this, "k" at
+the end of a word is also "क", preferred over the dead consonant :
+
+	schwa_deletion = true
+
+Symbols accepted only at the end of a word are used for the last
+token of a word when it's of one character. For them to be used
+for a longer last token too, like "sh" of "khush" (खुश) :
+
+	end_of_word_lookup = true
+
+Stem rules make the stem of a learnt word be learnt too :
+
+	[[stem_rules]]
+	ending = "ത്തിൽ"
+	new_ending = "ം"
+	# Rule is not applied on words ending with these
+	except = ["ുത്തിൽ"]
+
+Keys of a symbol :
+
+	pattern   string or array of strings, each is a token of its own
+	value     value1 of symbol
+	sign      value2 of symbol. Vowel sign for vowels
+	value3    value3 of symbol
+	tag       tag of symbol
+	match     "exact" (default) or "possibility"
+	accept    "all" (default), "starts", "between" or "ends"
+	priority  priority of symbol
+	weight    orders possibility matches of a pattern
+*/
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+// Weights given when not set in source. Same as in upstream schemes
+const (
+	defaultExactWeight       = 200
+	defaultPossibilityWeight = 100
+)
+
+var symbolTypes = map[string]int{
+	"vowels":           govarnam.VARNAM_SYMBOL_VOWEL,
+	"consonants":       govarnam.VARNAM_SYMBOL_CONSONANT,
+	"dead_consonants":  govarnam.VARNAM_SYMBOL_DEAD_CONSONANT,
+	"consonant_vowels": govarnam.VARNAM_SYMBOL_CONSONANT_VOWEL,
+	"conjuncts":        govarnam.VARNAM_SYMBOL_CONSONANT,
+	"numbers":          govarnam.VARNAM_SYMBOL_NUMBER,
+	"symbols":          govarnam.VARNAM_SYMBOL_SYMBOL,
+	"anusvara":         govarnam.VARNAM_SYMBOL_ANUSVARA,
+	"visarga":          govarnam.VARNAM_SYMBOL_VISARGA,
+	"virama":           govarnam.VARNAM_SYMBOL_VIRAMA,
+	"others":           govarnam.VARNAM_SYMBOL_OTHER,
+	"non_joiner":       govarnam.VARNAM_SYMBOL_NON_JOINER,
+	"joiner":           govarnam.VARNAM_SYMBOL_JOINER,
+	"period":           govarnam.VARNAM_SYMBOL_PERIOD,
+}
+
+var matchTypes = map[string]int{
+	"exact":       govarnam.VARNAM_MATCH_EXACT,
+	"possibility": govarnam.VARNAM_MATCH_POSSIBILITY,
+}
+
+var acceptConditions = map[string]int{
+	"all":     govarnam.VARNAM_TOKEN_ACCEPT_ALL,
+	"starts":  govarnam.VARNAM_TOKEN_ACCEPT_IF_STARTS_WITH,
+	"between": govarnam.VARNAM_TOKEN_ACCEPT_IF_IN_BETWEEN,
+	"ends":    govarnam.VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH,
+}
+
+// SymbolDef a symbol in scheme source
+type SymbolDef struct {
+	Patterns        []string
+	Type            int
+	Value1          string
+	Value2          string
+	Value3          string
+	Tag             string
+	MatchType       int
+	AcceptCondition int
+	Priority        int
+	Weight          int
+
+	// Line in source, for errors
+	Line int
+}
+
+// Scheme a parsed scheme source
+type Scheme struct {
+	Details govarnam.SchemeDetails
+
+	// Make dead consonants from consonants ending with 'a'
+	DeadConsonants bool
+
+	// Make a symbol for each consonant & vowel sign
+	ConsonantVowels bool
+
+	// Make consonants ending with 'a' without 'a' at word end
+	SchwaDeletion bool
+
+	Symbols []SymbolDef
+
+	StemRules []StemRuleDef
+}
+
+// StemRuleDef a stem rule in scheme source
+type StemRuleDef struct {
+	OldEnding string
+	NewEnding string
+
+	// Endings of words the rule is not applied on
+	Exceptions []string
+
+	// Line in source, for errors
+	Line int
+}
+
+// Parse read scheme source
+func Parse(r io.Reader) (*Scheme, error) {
+	tables, err := parseTOML(r)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := Scheme{}
+	foundScheme := false
+
+	for _, table := range tables {
+		if table.name == "" {
+			if len(table.values) > 0 {
+				return nil, fmt.Errorf("line %d: keys should be inside a table", table.line)
+			}
+			continue
+		}
+
+		if table.name == "scheme" {
+			foundScheme = true
+			err = parseSchemeTable(table, &scheme)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if table.name == "stem_rules" {
+			rule, err := parseStemRuleTable(table)
+			if err != nil {
+				return nil, err
+			}
+			scheme.StemRules = append(scheme.StemRules, rule)
+			continue
+		}
+
+		symbolType, found := symbolTypes[table.name]
+		if !found {
+			return nil, fmt.Errorf("line %d: unknown section %s", table.line, table.name)
+		}
+
+		symbol, err := parseSymbolTable(table, symbolType)
+		if err != nil {
+			return nil, err
+		}
+		scheme.Symbols = append(scheme.Symbols, symbol)
+	}
+
+	if !foundScheme {
+		return nil, fmt.Errorf("[scheme] section is missing")
+	}
+
+	return &scheme, nil
+}
+
+// ParseFile read scheme source from file
+func ParseFile(path string) (*Scheme, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+func parseSchemeTable(table tomlTable, scheme *Scheme) error {
+	for key, value := range table.values {
+		var err error
+
+		switch key {
+		case "identifier":
+			scheme.Details.Identifier, err = stringValue(key, value)
+		case "lang_code":
+			scheme.Details.LangCode, err = stringValue(key, value)
+		case "display_name":
+			scheme.Details.DisplayName, err = stringValue(key, value)
+		case "native_display_name":
+			scheme.Details.NativeDisplayName, err = stringValue(key, value)
+		case "version":
+			scheme.Details.Version, err = stringValue(key, value)
+		case "min_library_version":
+			scheme.Details.MinLibraryVersion, err = stringValue(key, value)
+		case "base":
+			scheme.Details.Base, err = stringValue(key, value)
+		case "author":
+			scheme.Details.Author, err = stringValue(key, value)
+		case "stable":
+			scheme.Details.IsStable, err = boolValue(key, value)
+		case "dead_consonants":
+			scheme.DeadConsonants, err = boolValue(key, value)
+		case "consonant_vowels":
+			scheme.ConsonantVowels, err = boolValue(key, value)
+		case "schwa_deletion":
+			scheme.SchwaDeletion, err = boolValue(key, value)
+		case "end_of_word_lookup":
+			scheme.Details.EndOfWordLookup, err = boolValue(key, value)
+		default:
+			err = fmt.Errorf("unknown key %s", key)
+		}
+
+		if err != nil {
+			return fmt.Errorf("[scheme] at line %d: %s", table.line, err.Error())
+		}
+	}
+
+	if scheme.Details.Identifier == "" {
+		return fmt.Errorf("[scheme] at line %d: identifier is missing", table.line)
+	}
+
+	if len(scheme.Details.LangCode) != 2 {
+		return fmt.Errorf("[scheme] at line %d: lang_code should be an ISO 639-1 two letter code", table.line)
+	}
+
+	return nil
+}
+
+func parseSymbolTable(table tomlTable, symbolType int) (SymbolDef, error) {
+	symbol := SymbolDef{
+		Type:            symbolType,
+		MatchType:       govarnam.VARNAM_MATCH_EXACT,
+		AcceptCondition: govarnam.VARNAM_TOKEN_ACCEPT_ALL,
+		Line:            table.line,
+	}
+
+	weightSet := false
+
+	for key, value := range table.values {
+		var err error
+
+		switch key {
+		case "pattern":
+			switch pattern := value.(type) {
+			case string:
+				symbol.Patterns = []string{pattern}
+			case []string:
+				symbol.Patterns = pattern
+			default:
+				err = fmt.Errorf("pattern should be a string or an array of strings")
+			}
+		case "value":
+			symbol.Value1, err = stringValue(key, value)
+		case "sign":
+			symbol.Value2, err = stringValue(key, value)
+		case "value3":
+			symbol.Value3, err = stringValue(key, value)
+		case "tag":
+			symbol.Tag, err = stringValue(key, value)
+		case "match":
+			symbol.MatchType, err = enumValue(key, value, matchTypes)
+		case "accept":
+			symbol.AcceptCondition, err = enumValue(key, value, acceptConditions)
+		case "priority":
+			symbol.Priority, err = intValue(key, value)
+		case "weight":
+			symbol.Weight, err = intValue(key, value)
+			weightSet = true
+		default:
+			err = fmt.Errorf("unknown key %s", key)
+		}
+
+		if err != nil {
+			return symbol, fmt.Errorf("[[%s]] at line %d: %s", table.name, table.line, err.Error())
+		}
+	}
+
+	if len(symbol.Patterns) == 0 {
+		return symbol, fmt.Errorf("[[%s]] at line %d: pattern is missing", table.name, table.line)
+	}
+
+	// Joiners get their value when compiled
+	if symbol.Value1 == "" && symbolType != govarnam.VARNAM_SYMBOL_NON_JOINER && symbolType != govarnam.VARNAM_SYMBOL_JOINER {
+		return symbol, fmt.Errorf("[[%s]] at line %d: value is missing", table.name, table.line)
+	}
+
+	if !weightSet {
+		if symbol.MatchType == govarnam.VARNAM_MATCH_POSSIBILITY {
+			symbol.Weight = defaultPossibilityWeight
+		} else {
+			symbol.Weight = defaultExactWeight
+		}
+	}
+
+	return symbol, nil
+}
+
+func parseStemRuleTable(table tomlTable) (StemRuleDef, error) {
+	rule := StemRuleDef{Line: table.line}
+
+	for key, value := range table.values {
+		var err error
+
+		switch key {
+		case "ending":
+			rule.OldEnding, err = stringValue(key, value)
+		case "new_ending":
+			rule.NewEnding, err = stringValue(key, value)
+		case "except":
+			exceptions, ok := value.([]string)
+			if !ok {
+				err = fmt.Errorf("except should be an array of strings")
+			}
+			rule.Exceptions = exceptions
+		default:
+			err = fmt.Errorf("unknown key %s", key)
+		}
+
+		if err != nil {
+			return rule, fmt.Errorf("[[%s]] at line %d: %s", table.name, table.line, err.Error())
+		}
+	}
+
+	if rule.OldEnding == "" {
+		return rule, fmt.Errorf("[[%s]] at line %d: ending is missing", table.name, table.line)
+	}
+
+	return rule, nil
+}
+
+func stringValue(key string, value interface{}) (string, error) {
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	return "", fmt.Errorf("%s should be a string", key)
+}
+
+func boolValue(key string, value interface{}) (bool, error) {
+	if b, ok := value.(bool); ok {
+		return b, nil
+	}
+	return false, fmt.Errorf("%s should be true or false", key)
+}
+
+func intValue(key string, value interface{}) (int, error) {
+	if i, ok := value.(int); ok {
+		return i, nil
+	}
+	return 0, fmt.Errorf("%s should be an integer", key)
+}
+
+func enumValue(key string, value interface{}, values map[string]int) (int, error) {
+	str, err := stringValue(key, value)
+	if err != nil {
+		return 0, err
+	}
+
+	if i, found := values[str]; found {
+		return i, nil
+	}
+
+	var names []string
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return 0, fmt.Errorf("%s should be one of %v", key, names)
+}
+
+// Compile make VST at vstPath from scheme.
+// An existing file at vstPath is replaced
+func Compile(scheme *Scheme, vstPath string) error {
+	err := os.Remove(vstPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	varnam, err := govarnam.VMInit(vstPath)
+	if err != nil {
+		return err
+	}
+	defer varnam.Close()
+
+	varnam.VSTMakerConfig.UseDeadConsonants = scheme.DeadConsonants
+
+	details := scheme.Details
+	if details.CompiledDate == "" {
+		details.CompiledDate = time.Now().Format(time.RFC3339)
+	}
+
+	err = varnam.VMSetSchemeDetails(details)
+	if err != nil {
+		return err
+	}
+
+	// Virama is needed to make dead consonants
+	symbols := append([]SymbolDef{}, scheme.Symbols...)
+	sort.SliceStable(symbols, func(i, j int) bool {
+		return symbols[i].Type == govarnam.VARNAM_SYMBOL_VIRAMA && symbols[j].Type != govarnam.VARNAM_SYMBOL_VIRAMA
+	})
+
+	virama := ""
+
+	for _, symbol := range symbols {
+		if symbol.Type == govarnam.VARNAM_SYMBOL_VIRAMA && virama == "" {
+			virama = symbol.Value1
+		}
+
+		for _, pattern := range symbol.Patterns {
+			if scheme.SchwaDeletion {
+				// Made first so that it comes before the dead
+				// consonant in lookups that aren't ordered by weight
+				err = createSchwaDeletedToken(varnam, symbol, pattern)
+				if err != nil {
+					return fmt.Errorf("line %d: %s => %s: %s", symbol.Line, pattern, symbol.Value1, err.Error())
+				}
+			}
+
+			err = createToken(varnam, symbol, pattern, virama)
+			if err != nil {
+				return fmt.Errorf("line %d: %s => %s: %s", symbol.Line, pattern, symbol.Value1, err.Error())
+			}
+		}
+	}
+
+	if scheme.ConsonantVowels {
+		err = createConsonantVowels(varnam, symbols)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, rule := range scheme.StemRules {
+		err = varnam.VMCreateStemRule(rule.OldEnding, rule.NewEnding)
+		if err != nil {
+			return fmt.Errorf("line %d: %s", rule.Line, err.Error())
+		}
+
+		for _, exception := range rule.Exceptions {
+			err = varnam.VMCreateStemException(rule.OldEnding, exception)
+			if err != nil {
+				return fmt.Errorf("line %d: %s", rule.Line, err.Error())
+			}
+		}
+	}
+
+	return varnam.VMFlushBuffer()
+}
+
+// CompileFile make VST at vstPath from scheme source file
+func CompileFile(sourcePath string, vstPath string) error {
+	scheme, err := ParseFile(sourcePath)
+	if err != nil {
+		return fmt.Errorf("%s: %s", sourcePath, err.Error())
+	}
+	return Compile(scheme, vstPath)
+}
+
+func createToken(varnam *govarnam.Varnam, symbol SymbolDef, pattern string, virama string) error {
+	value1 := symbol.Value1
+	if value1 == "" {
+		// VMCreateToken sets the joiner values
+		value1 = pattern
+	}
+
+	err := varnam.VMCreateToken(pattern, value1, symbol.Value2, symbol.Value3, symbol.Tag, symbol.Type, symbol.MatchType, symbol.Priority, symbol.AcceptCondition, true)
+	if err != nil {
+		return err
+	}
+
+	search := govarnam.NewSearchSymbol()
+	search.Pattern = pattern
+	search.MatchType = symbol.MatchType
+	search.AcceptCondition = symbol.AcceptCondition
+	if symbol.Value1 != "" {
+		search.Value1 = symbol.Value1
+	}
+
+	err = varnam.VMSetWeight(search, symbol.Weight)
+	if err != nil {
+		return err
+	}
+
+	// Dead consonant made from this, Eg: "k" => "ക്" from "ka" => "ക"
+	patternRunes := []rune(pattern)
+	if varnam.VSTMakerConfig.UseDeadConsonants && symbol.Type == govarnam.VARNAM_SYMBOL_CONSONANT && len(patternRunes) > 1 {
+		search.Pattern = string(patternRunes[:len(patternRunes)-1])
+		search.Value1 = symbol.Value1 + virama
+		search.Type = govarnam.VARNAM_SYMBOL_DEAD_CONSONANT
+
+		err = varnam.VMSetWeight(search, symbol.Weight)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Whether pattern ends with the inherent 'a', Eg: "ka" but not "kaa"
+func hasInherentVowel(pattern string) bool {
+	return len(pattern) > 1 && strings.HasSuffix(pattern, "a") && !strings.HasSuffix(pattern, "aa")
+}
+
+// Make "k" => "क" at the end of word from "ka" => "क"
+func createSchwaDeletedToken(varnam *govarnam.Varnam, symbol SymbolDef, pattern string) error {
+	if symbol.Type != govarnam.VARNAM_SYMBOL_CONSONANT || !hasInherentVowel(pattern) {
+		return nil
+	}
+
+	if symbol.AcceptCondition != govarnam.VARNAM_TOKEN_ACCEPT_ALL && symbol.AcceptCondition != govarnam.VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH {
+		return nil
+	}
+
+	schwaDeleted := SymbolDef{
+		Patterns:        []string{strings.TrimSuffix(pattern, "a")},
+		Type:            govarnam.VARNAM_SYMBOL_CONSONANT,
+		Value1:          symbol.Value1,
+		Value2:          symbol.Value2,
+		Value3:          symbol.Value3,
+		Tag:             symbol.Tag,
+		MatchType:       symbol.MatchType,
+		AcceptCondition: govarnam.VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH,
+		Priority:        symbol.Priority,
+		// More than the dead consonant of the same pattern
+		Weight: symbol.Weight + 1,
+	}
+
+	return createToken(varnam, schwaDeleted, schwaDeleted.Patterns[0], "")
+}
+
+// Make a symbol for each consonant & vowel sign pair,
+// Eg: "ki" => "कि" from "ka" => "क" and "i" => "ि".
+// Consonant vowels written in source and exact symbols
+// in source having the same pattern are kept instead
+func createConsonantVowels(varnam *govarnam.Varnam, symbols []SymbolDef) error {
+	type exactKey struct {
+		pattern         string
+		acceptCondition int
+	}
+
+	written := map[string]bool{}
+	exact := map[exactKey]bool{}
+	for _, symbol := range symbols {
+		for _, pattern := range symbol.Patterns {
+			if symbol.Type == govarnam.VARNAM_SYMBOL_CONSONANT_VOWEL {
+				written[pattern] = true
+			}
+			if symbol.MatchType == govarnam.VARNAM_MATCH_EXACT {
+				exact[exactKey{pattern, symbol.AcceptCondition}] = true
+			}
+		}
+	}
+
+	for _, consonant := range symbols {
+		if consonant.Type != govarnam.VARNAM_SYMBOL_CONSONANT {
+			continue
+		}
+
+		for _, consonantPattern := range consonant.Patterns {
+			if !hasInherentVowel(consonantPattern) {
+				continue
+			}
+
+			for _, vowel := range symbols {
+				if vowel.Type != govarnam.VARNAM_SYMBOL_VOWEL || vowel.Value2 == "" {
+					continue
+				}
+
+				acceptCondition := consonant.AcceptCondition
+				if acceptCondition == govarnam.VARNAM_TOKEN_ACCEPT_ALL {
+					acceptCondition = vowel.AcceptCondition
+				} else if vowel.AcceptCondition != govarnam.VARNAM_TOKEN_ACCEPT_ALL && vowel.AcceptCondition != acceptCondition {
+					continue
+				}
+
+				consonantVowel := SymbolDef{
+					Type:            govarnam.VARNAM_SYMBOL_CONSONANT_VOWEL,
+					Value1:          consonant.Value1 + vowel.Value2,
+					MatchType:       govarnam.VARNAM_MATCH_EXACT,
+					AcceptCondition: acceptCondition,
+					Weight:          defaultExactWeight,
+				}
+
+				if consonant.MatchType == govarnam.VARNAM_MATCH_POSSIBILITY || vowel.MatchType == govarnam.VARNAM_MATCH_POSSIBILITY {
+					consonantVowel.MatchType = govarnam.VARNAM_MATCH_POSSIBILITY
+					consonantVowel.Weight = defaultPossibilityWeight
+					for _, symbol := range []SymbolDef{consonant, vowel} {
+						if symbol.MatchType == govarnam.VARNAM_MATCH_POSSIBILITY && symbol.Weight < consonantVowel.Weight {
+							consonantVowel.Weight = symbol.Weight
+						}
+					}
+				}
+
+				for _, vowelPattern := range vowel.Patterns {
+					pattern := strings.TrimSuffix(consonantPattern, "a") + vowelPattern
+					if written[pattern] {
+						continue
+					}
+
+					if consonantVowel.MatchType == govarnam.VARNAM_MATCH_EXACT {
+						key := exactKey{pattern, consonantVowel.AcceptCondition}
+						if exact[key] {
+							continue
+						}
+						exact[key] = true
+					}
+
+					err := createToken(varnam, consonantVowel, pattern, "")
+					if err != nil {
+						return fmt.Errorf("line %d: %s => %s: %s", consonant.Line, pattern, consonantVowel.Value1, err.Error())
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package schemecompile
+
+import (
+	"context"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+const testSource = `
+[scheme]
+identifier = "test-ml"
+lang_code = "ml"
+display_name = "Malayalam Test"
+native_display_name = "മലയാളം"
+version = "0.1"
+author = 'Varnam'
+stable = true
+dead_consonants = true
+
+[[virama]]
+pattern = "~"
+value = "്"
+
+[[vowels]]
+pattern = ["aa", "A"] # Both are aa
+value = "ആ"
+sign = "ാ"
+
+[[consonants]]
+pattern = "ka"
+value = "ക"
+
+[[consonants]]
+pattern = "La"
+value = "ള"
+
+[[consonants]]
+pattern = "la"
+value = "ള"
+match = "possibility"
+weight = 50
+
+[[anusvara]]
+pattern = "m"
+value = "ം"
+accept = "ends"
+
+[[non_joiner]]
+pattern = "_"
+
+[[stem_rules]]
+ending = "ത്തിൽ"
+new_ending = "ം"
+
+[[stem_rules]]
+ending = "ിൽ"
+new_ending = "്"
+except = ["ത്തിൽ"]
+`
+
+func findSymbols(t *testing.T, varnam *govarnam.Varnam, pattern string) []govarnam.Symbol {
+	search := govarnam.NewSearchSymbol()
+	search.Pattern = pattern
+
+	symbols, err := varnam.SearchSymbolTable(context.Background(), search)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return symbols
+}
+
+func TestCompile(t *testing.T) {
+	scheme, err := Parse(strings.NewReader(testSource))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(scheme.Symbols) != 7 {
+		t.Fatalf("Expected 7 symbols, got %d", len(scheme.Symbols))
+	}
+
+	vstPath := path.Join(t.TempDir(), "test-ml.vst")
+	err = Compile(scheme, vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	varnam := govarnam.Varnam{}
+	err = varnam.InitVST(vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer varnam.Close()
+
+	if varnam.SchemeDetails.Identifier != "test-ml" || varnam.SchemeDetails.DisplayName != "Malayalam Test" || !varnam.SchemeDetails.IsStable || varnam.SchemeDetails.NativeDisplayName != "മലയാളം" || varnam.SchemeDetails.Version != "0.1" || varnam.SchemeDetails.EndOfWordLookup {
+		t.Errorf("Scheme details not set: %v", varnam.SchemeDetails)
+	}
+
+	for _, pattern := range []string{"aa", "A"} {
+		symbols := findSymbols(t, &varnam, pattern)
+		if len(symbols) != 1 || symbols[0].Value1 != "ആ" || symbols[0].Value2 != "ാ" {
+			t.Errorf("%s not compiled: %v", pattern, symbols)
+		}
+	}
+
+	symbols := findSymbols(t, &varnam, "la")
+	if len(symbols) != 1 || symbols[0].MatchType != govarnam.VARNAM_MATCH_POSSIBILITY || symbols[0].Weight != 50 {
+		t.Errorf("la not compiled: %v", symbols)
+	}
+
+	symbols = findSymbols(t, &varnam, "ka")
+	if len(symbols) != 1 || symbols[0].Weight != defaultExactWeight {
+		t.Errorf("ka not compiled: %v", symbols)
+	}
+
+	// Made from "ka"
+	symbols = findSymbols(t, &varnam, "k")
+	if len(symbols) != 1 || symbols[0].Value1 != "ക്" || symbols[0].Type != govarnam.VARNAM_SYMBOL_DEAD_CONSONANT {
+		t.Errorf("Dead consonant not made: %v", symbols)
+	}
+
+	symbols = findSymbols(t, &varnam, "m")
+	if len(symbols) != 1 || symbols[0].AcceptCondition != govarnam.VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH {
+		t.Errorf("m not compiled: %v", symbols)
+	}
+
+	symbols = findSymbols(t, &varnam, "_")
+	if len(symbols) != 1 || symbols[0].Value1 != govarnam.ZWNJ {
+		t.Errorf("Non joiner not compiled: %v", symbols)
+	}
+
+	stems, err := varnam.Stems("മലയാളത്തിൽ")
+	if err != nil || len(stems) != 1 || stems[0] != "മലയാളം" {
+		t.Errorf("Stem rules not compiled: %v %v", stems, err)
+	}
+
+	// Compiling again replaces the file
+	err = Compile(scheme, vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"[[vowels]]\npattern = \"a\"\nvalue = \"അ\"":                                  "[scheme] section is missing",
+		"[scheme]\nidentifier = \"x\"\nlang_code = \"ml\"\n[[vowel]]":                 "line 4: unknown section vowel",
+		"[scheme]\nidentifier = \"x\"\nlang_code = \"ml\"\n[[vowels]]\nvalue = \"അ\"": "at line 4: pattern is missing",
+		"[scheme]\nidentifier = \"x\"\nlang_code = \"ml\"\n[[vowels]]\nptn = \"a\"":   "unknown key ptn",
+		"[scheme]\nidentifier = \"x\"\nlang_code = \"malayalam\"":                     "lang_code should be",
+		"[scheme]\nidentifier = \"x\"\nlang_code = \"ml\nauthor = \"y\"":              "line 3: string is not closed",
+		"[scheme]\nidentifier = \"x\"\nlang_code = \"ml\"\n[[vowels]]\nmatch = \"x\"": "match should be one of [exact possibility]",
+	}
+
+	for source, expected := range cases {
+		_, err := Parse(strings.NewReader(source))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q for %q, got %v", expected, source, err)
+		}
+	}
+}
+
+const generatedSource = `
+[scheme]
+identifier = "test-hi"
+lang_code = "hi"
+dead_consonants = true
+consonant_vowels = true
+schwa_deletion = true
+end_of_word_lookup = true
+
+[[virama]]
+pattern = "~"
+value = "्"
+
+[[vowels]]
+pattern = "i"
+value = "इ"
+sign = "ि"
+
+[[consonants]]
+pattern = "ka"
+value = "क"
+`
+
+func TestCompileGeneratedSymbols(t *testing.T) {
+	scheme, err := Parse(strings.NewReader(generatedSource))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !scheme.ConsonantVowels || !scheme.SchwaDeletion || !scheme.Details.EndOfWordLookup {
+		t.Fatalf("Options not parsed: %v %v %v", scheme.ConsonantVowels, scheme.SchwaDeletion, scheme.Details.EndOfWordLookup)
+	}
+
+	vstPath := path.Join(t.TempDir(), "test-hi.vst")
+	err = Compile(scheme, vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	varnam := govarnam.Varnam{}
+	err = varnam.InitVST(vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer varnam.Close()
+
+	if !varnam.SchemeDetails.EndOfWordLookup {
+		t.Errorf("End of word lookup not set: %v", varnam.SchemeDetails)
+	}
+
+	symbols := findSymbols(t, &varnam, "ki")
+	if len(symbols) != 1 || symbols[0].Value1 != "कि" || symbols[0].Type != govarnam.VARNAM_SYMBOL_CONSONANT_VOWEL {
+		t.Errorf("Consonant vowel not made: %v", symbols)
+	}
+
+	// Dead consonant and the word ending one without virama
+	symbols = findSymbols(t, &varnam, "k")
+	if len(symbols) != 2 {
+		t.Fatalf("Expected 2 symbols for k, got %v", symbols)
+	}
+	for _, symbol := range symbols {
+		if symbol.AcceptCondition == govarnam.VARNAM_TOKEN_ACCEPT_IF_ENDS_WITH {
+			if symbol.Value1 != "क" {
+				t.Errorf("Schwa deleted symbol not made: %v", symbol)
+			}
+		} else if symbol.Value1 != "क्" {
+			t.Errorf("Dead consonant not made: %v", symbol)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package schemecompile
+
+import (
+	"path"
+	"testing"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+// Instance of a scheme shipped in scheme-sources/, compiled for the test
+func compileScheme(t *testing.T, lang string) *govarnam.Varnam {
+	dir := t.TempDir()
+	vstPath := path.Join(dir, lang+".vst")
+
+	err := CompileFile(path.Join("..", "scheme-sources", lang+".toml"), vstPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	varnam, err := govarnam.Init(vstPath, path.Join(dir, lang+".vst.learnings"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		varnam.Close()
+	})
+
+	return varnam
+}
+
+func assertGreedy(t *testing.T, varnam *govarnam.Varnam, input string, expected string) {
+	t.Helper()
+	if got := varnam.TransliterateAdvanced(input).GreedyTokenized[0].Word; got != expected {
+		t.Errorf("%s: expected %s, got %s", input, expected, got)
+	}
+}
+
+func hasSuggestion(sugs []govarnam.Suggestion, word string) bool {
+	for _, sug := range sugs {
+		if sug.Word == word {
+			return true
+		}
+	}
+	return false
+}
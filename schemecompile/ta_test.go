@@ -0,0 +1,82 @@
+package schemecompile
+
+import "testing"
+
+func TestTAGreedyTokenizer(t *testing.T) {
+	varnam := compileScheme(t, "ta")
+
+	assertGreedy(t, varnam, "thamizh", "தமிழ்")
+	assertGreedy(t, varnam, "vaNakkam", "வணக்கம்")
+	assertGreedy(t, varnam, "puththakam", "புத்தகம்")
+	assertGreedy(t, varnam, "kaalai", "காலை")
+
+	// ந at start, ன elsewhere
+	assertGreedy(t, varnam, "naan", "நான்")
+	assertGreedy(t, varnam, "avan", "அவன்")
+	assertGreedy(t, varnam, "nanRi", "நன்றி")
+}
+
+func TestTAGrantha(t *testing.T) {
+	varnam := compileScheme(t, "ta")
+
+	assertGreedy(t, varnam, "raajaa", "ராஜா")
+	assertGreedy(t, varnam, "vishayam", "விஷயம்")
+	assertGreedy(t, varnam, "mahaan", "மஹான்")
+	assertGreedy(t, varnam, "Saami", "ஸாமி")
+	assertGreedy(t, varnam, "kshEththiram", "க்ஷேத்திரம்")
+	assertGreedy(t, varnam, "sri", "ஸ்ரீ")
+
+	// Native letters are possibilities
+	if !hasSuggestion(varnam.TransliterateAdvanced("jalam").TokenizerSuggestions, "சலம்") {
+		t.Errorf("jalam doesn't suggest சலம்")
+	}
+
+	varnam.NoGrantha = true
+
+	assertGreedy(t, varnam, "raajaa", "ராசா")
+	assertGreedy(t, varnam, "vishayam", "விசயம்")
+	assertGreedy(t, varnam, "mahaan", "மகான்")
+	assertGreedy(t, varnam, "Saami", "சாமி")
+	assertGreedy(t, varnam, "kshEththiram", "க்சேத்திரம்")
+
+	// Native words are unaffected
+	assertGreedy(t, varnam, "thamizh", "தமிழ்")
+
+	for _, sug := range varnam.TransliterateAdvanced("jalam").TokenizerSuggestions {
+		if sug.Word == "ஜலம்" {
+			t.Errorf("jalam suggests %s", sug.Word)
+		}
+	}
+}
+
+func TestTAGranthaLearnt(t *testing.T) {
+	varnam := compileScheme(t, "ta")
+
+	err := varnam.Learn("ஜலம்", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sugs := varnam.Transliterate("jalam"); sugs[0].Word != "ஜலம்" {
+		t.Errorf("jalam: %v", sugs)
+	}
+
+	varnam.NoGrantha = true
+
+	sugs := varnam.Transliterate("jalam")
+	if len(sugs) == 0 || sugs[0].Word != "சலம்" || hasSuggestion(sugs, "ஜலம்") {
+		t.Errorf("jalam without Grantha: %v", sugs)
+	}
+}
+
+func TestTAValidateScheme(t *testing.T) {
+	varnam := compileScheme(t, "ta")
+
+	issues, err := varnam.ValidateScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		t.Error(issue)
+	}
+}
@@ -0,0 +1,237 @@
+package schemecompile
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Only the part of TOML needed for schemes is parsed :
+// [table], [[array of tables]], comments and key = value
+// where value is a string, integer, boolean or a
+// single line array of strings
+
+type tomlTable struct {
+	name   string
+	values map[string]interface{}
+
+	// Line where the table starts, for errors
+	line int
+}
+
+func parseTOML(r io.Reader) ([]tomlTable, error) {
+	var tables []tomlTable
+
+	// Keys before any table header
+	tables = append(tables, tomlTable{"", map[string]interface{}{}, 1})
+
+	// [table] names seen, they can't be repeated
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") {
+			end := strings.Index(line, "]]")
+			if end == -1 || !isTOMLComment(line[end+2:]) {
+				return nil, fmt.Errorf("line %d: invalid table header", lineNumber)
+			}
+			name := strings.TrimSpace(line[2:end])
+			if seen[name] {
+				return nil, fmt.Errorf("line %d: %s is already a table, can't be an array of tables", lineNumber, name)
+			}
+			tables = append(tables, tomlTable{name, map[string]interface{}{}, lineNumber})
+			continue
+		}
+
+		if line[0] == '[' {
+			end := strings.Index(line, "]")
+			if end == -1 || !isTOMLComment(line[end+1:]) {
+				return nil, fmt.Errorf("line %d: invalid table header", lineNumber)
+			}
+			name := strings.TrimSpace(line[1:end])
+			if seen[name] {
+				return nil, fmt.Errorf("line %d: table %s is defined more than once", lineNumber, name)
+			}
+			seen[name] = true
+			tables = append(tables, tomlTable{name, map[string]interface{}{}, lineNumber})
+			continue
+		}
+
+		equals := strings.Index(line, "=")
+		if equals == -1 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+
+		key := strings.TrimSpace(line[:equals])
+		if unquoted, err := strconv.Unquote(key); err == nil {
+			key = unquoted
+		}
+		if key == "" {
+			return nil, fmt.Errorf("line %d: key is empty", lineNumber)
+		}
+
+		value, rest, err := parseTOMLValue(strings.TrimSpace(line[equals+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNumber, err.Error())
+		}
+		if !isTOMLComment(rest) {
+			return nil, fmt.Errorf("line %d: unexpected %q after value", lineNumber, rest)
+		}
+
+		table := tables[len(tables)-1]
+		if _, exists := table.values[key]; exists {
+			return nil, fmt.Errorf("line %d: %s is defined more than once", lineNumber, key)
+		}
+		table.values[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// Whether s is empty or only a comment
+func isTOMLComment(s string) bool {
+	s = strings.TrimSpace(s)
+	return s == "" || s[0] == '#'
+}
+
+// Parse a value at the start of s. Returns the value and what's after it
+func parseTOMLValue(s string) (interface{}, string, error) {
+	if s == "" {
+		return nil, "", fmt.Errorf("value is empty")
+	}
+
+	switch s[0] {
+	case '"':
+		return parseTOMLBasicString(s)
+
+	case '\'':
+		end := strings.IndexByte(s[1:], '\'')
+		if end == -1 {
+			return nil, "", fmt.Errorf("string is not closed")
+		}
+		return s[1 : end+1], s[end+2:], nil
+
+	case '[':
+		var items []string
+
+		rest := strings.TrimSpace(s[1:])
+		for {
+			if rest == "" {
+				return nil, "", fmt.Errorf("array is not closed. Arrays should be in a single line")
+			}
+			if rest[0] == ']' {
+				return items, rest[1:], nil
+			}
+
+			value, after, err := parseTOMLValue(rest)
+			if err != nil {
+				return nil, "", err
+			}
+			item, ok := value.(string)
+			if !ok {
+				return nil, "", fmt.Errorf("arrays can only have strings")
+			}
+			items = append(items, item)
+
+			rest = strings.TrimSpace(after)
+			if strings.HasPrefix(rest, ",") {
+				rest = strings.TrimSpace(rest[1:])
+			} else if !strings.HasPrefix(rest, "]") {
+				return nil, "", fmt.Errorf("expected , or ] in array")
+			}
+		}
+	}
+
+	// Bare values go till whitespace, comma or end of array
+	end := strings.IndexAny(s, " \t,]#")
+	if end == -1 {
+		end = len(s)
+	}
+	bare := s[:end]
+
+	if bare == "true" || bare == "false" {
+		return bare == "true", s[end:], nil
+	}
+
+	number, err := strconv.ParseInt(strings.ReplaceAll(bare, "_", ""), 10, 64)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid value %q", bare)
+	}
+	return int(number), s[end:], nil
+}
+
+func parseTOMLBasicString(s string) (interface{}, string, error) {
+	var value strings.Builder
+
+	i := 1
+	for i < len(s) {
+		char := s[i]
+
+		if char == '"' {
+			return value.String(), s[i+1:], nil
+		}
+
+		if char != '\\' {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			value.WriteRune(r)
+			i += size
+			continue
+		}
+
+		if i+1 >= len(s) {
+			break
+		}
+
+		switch s[i+1] {
+		case '"', '\\':
+			value.WriteByte(s[i+1])
+			i += 2
+		case 'n':
+			value.WriteByte('\n')
+			i += 2
+		case 't':
+			value.WriteByte('\t')
+			i += 2
+		case 'u', 'U':
+			length := 4
+			if s[i+1] == 'U' {
+				length = 8
+			}
+			if i+2+length > len(s) {
+				return nil, "", fmt.Errorf("invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(s[i+2:i+2+length], 16, 32)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid unicode escape")
+			}
+			value.WriteRune(rune(code))
+			i += 2 + length
+		default:
+			return nil, "", fmt.Errorf("invalid escape \\%c", s[i+1])
+		}
+	}
+
+	return nil, "", fmt.Errorf("string is not closed")
+}
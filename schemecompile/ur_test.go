@@ -0,0 +1,70 @@
+package schemecompile
+
+import (
+	"testing"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+func TestURGreedyTokenizer(t *testing.T) {
+	varnam := compileScheme(t, "ur")
+
+	if !varnam.IsRTL() {
+		t.Error("Urdu is not RTL")
+	}
+
+	// Short vowels are not written
+	assertGreedy(t, varnam, "kitaab", "کتاب")
+	assertGreedy(t, varnam, "dil", "دل")
+	assertGreedy(t, varnam, "ham", "ہم")
+	assertGreedy(t, varnam, "pyaar", "پیار")
+
+	// Forms at start & end of word
+	assertGreedy(t, varnam, "aaj", "آج")
+	assertGreedy(t, varnam, "aap", "آپ")
+	assertGreedy(t, varnam, "urdu", "اردو")
+	assertGreedy(t, varnam, "zindagi", "زندگی")
+	assertGreedy(t, varnam, "hai", "ہے")
+	assertGreedy(t, varnam, "kaise", "کیسے")
+
+	// Word of a single token is also the end of word
+	assertGreedy(t, varnam, "ka", "کا")
+	assertGreedy(t, varnam, "ki", "کی")
+	assertGreedy(t, varnam, "ke", "کے")
+
+	assertGreedy(t, varnam, "Khaana", "کھانا")
+
+	sugs := varnam.TransliterateAdvanced("hain").TokenizerSuggestions
+	if !hasSuggestion(sugs, "ہیں") {
+		t.Errorf("hain: %v", sugs)
+	}
+}
+
+func TestURLatinPassthrough(t *testing.T) {
+	varnam := compileScheme(t, "ur")
+
+	// Isolated so that it's shown in typed order
+	assertGreedy(t, varnam, "kitaab2go", "کتاب"+govarnam.LRI+"2"+govarnam.PDI+"گو")
+
+	err := varnam.Learn("کتاب"+govarnam.LRI+"2"+govarnam.PDI, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sugs := varnam.Transliterate("kitaab2")
+	if sugs[0].Word != "کتاب"+govarnam.LRI+"2"+govarnam.PDI {
+		t.Errorf("kitaab2: %v", sugs)
+	}
+}
+
+func TestURValidateScheme(t *testing.T) {
+	varnam := compileScheme(t, "ur")
+
+	issues, err := varnam.ValidateScheme()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, issue := range issues {
+		t.Error(issue)
+	}
+}
@@ -0,0 +1,260 @@
+package varnamdbus
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+Package varnamdbus serves govarnam on D-Bus as org.varnam.Engine, so
+that desktop components share one instance & one learnings database.
+The interface is in org.varnam.Engine.xml :
+
+	conn, _ := dbus.ConnectSessionBus()
+	service := varnamdbus.NewService()
+	defer service.Close()
+	service.Serve(conn)
+
+	gdbus call --session --dest org.varnam.Engine --object-path /org/varnam/Engine --method org.varnam.Engine.Transliterate ml malayalam
+
+An instance is made for a scheme on its first call and is shared by
+all clients after that. LearnedWord is emitted for every word learnt
+with the instance.
+*/
+
+import (
+	_ "embed" // for org.varnam.Engine.xml
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+)
+
+// Name, object path & interface served on the bus
+const (
+	BusName       = "org.varnam.Engine"
+	ObjectPath    = dbus.ObjectPath("/org/varnam/Engine")
+	InterfaceName = "org.varnam.Engine"
+)
+
+//go:embed org.varnam.Engine.xml
+var introspectionXML string
+
+// Suggestion is (sixd) on the bus
+type Suggestion struct {
+	Word      string
+	Weight    int32
+	LearnedOn int64
+	Score     float64
+}
+
+// Service implements org.varnam.Engine
+type Service struct {
+	// Makes the instance for a scheme ID. govarnam.InitFromID if nil
+	Init func(schemeID string) (*govarnam.Varnam, error)
+
+	// Disables Learn, Train & Unlearn
+	ReadOnly bool
+
+	connMutex sync.Mutex
+	conn      *dbus.Conn
+
+	instances instances.Manager
+}
+
+// NewService make a Service
+func NewService() *Service {
+	return &Service{}
+}
+
+// Export serve the service at ObjectPath on conn
+func (service *Service) Export(conn *dbus.Conn) error {
+	service.connMutex.Lock()
+	service.conn = conn
+	service.connMutex.Unlock()
+
+	err := conn.ExportMethodTable(service.methods(), ObjectPath, InterfaceName)
+	if err != nil {
+		return err
+	}
+	return conn.Export(introspect.Introspectable(introspectionXML), ObjectPath, "org.freedesktop.DBus.Introspectable")
+}
+
+// Serve export the service and take BusName on conn
+func (service *Service) Serve(conn *dbus.Conn) error {
+	if err := service.Export(conn); err != nil {
+		return err
+	}
+
+	reply, err := conn.RequestName(BusName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		return err
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		return fmt.Errorf("%s is already taken", BusName)
+	}
+	return nil
+}
+
+// Close closes all instances made
+func (service *Service) Close() error {
+	return service.instances.Close()
+}
+
+func (service *Service) getInstance(schemeID string) (*govarnam.Varnam, *dbus.Error) {
+	if !instances.ValidSchemeID(schemeID) {
+		return nil, dbus.NewError("org.freedesktop.DBus.Error.InvalidArgs", []interface{}{fmt.Sprintf("Invalid scheme ID %q", schemeID)})
+	}
+
+	varnam, err := service.instances.Get(schemeID, service.open)
+	if err != nil {
+		return nil, dbus.MakeFailedError(err)
+	}
+	return varnam, nil
+}
+
+// Open the instance of a scheme, emitting LearnedWord on learning
+func (service *Service) open(schemeID string) (*govarnam.Varnam, error) {
+	init := service.Init
+	if init == nil {
+		init = govarnam.InitFromID
+	}
+
+	varnam, err := init(schemeID)
+	if err != nil {
+		return nil, err
+	}
+
+	service.connMutex.Lock()
+	conn := service.conn
+	service.connMutex.Unlock()
+
+	if conn != nil {
+		varnam.OnLearn(func(word string, weight int) {
+			conn.Emit(ObjectPath, InterfaceName+".LearnedWord", schemeID, word, int32(weight))
+		})
+	}
+
+	return varnam, nil
+}
+
+// Instance of a scheme for learning
+func (service *Service) getWritableInstance(schemeID string) (*govarnam.Varnam, *dbus.Error) {
+	if service.ReadOnly {
+		return nil, dbus.NewError("org.freedesktop.DBus.Error.AccessDenied", []interface{}{"Service is read only"})
+	}
+	return service.getInstance(schemeID)
+}
+
+// Words that can't be learnt are the client's fault
+func learnError(err error) *dbus.Error {
+	var validationErr *govarnam.ValidationError
+	if errors.As(err, &validationErr) {
+		return dbus.NewError("org.freedesktop.DBus.Error.InvalidArgs", []interface{}{err.Error()})
+	}
+	return dbus.MakeFailedError(err)
+}
+
+func toSuggestions(sugs []govarnam.Suggestion) []Suggestion {
+	result := make([]Suggestion, len(sugs))
+	for i, sug := range sugs {
+		result[i] = Suggestion{sug.Word, int32(sug.Weight), int64(sug.LearnedOn), sug.Score}
+	}
+	return result
+}
+
+// Methods of org.varnam.Engine, names as in the XML
+func (service *Service) methods() map[string]interface{} {
+	return map[string]interface{}{
+		"Transliterate":                service.transliterate,
+		"TransliterateGreedyTokenized": service.transliterateGreedyTokenized,
+		"ReverseTransliterate":         service.reverseTransliterate,
+		"Learn":                        service.learn,
+		"Train":                        service.train,
+		"Unlearn":                      service.unlearn,
+		"GetSchemes":                   service.getSchemes,
+	}
+}
+
+func (service *Service) transliterate(scheme string, word string) ([]Suggestion, *dbus.Error) {
+	varnam, dbusErr := service.getInstance(scheme)
+	if dbusErr != nil {
+		return nil, dbusErr
+	}
+	return toSuggestions(varnam.Transliterate(word)), nil
+}
+
+func (service *Service) transliterateGreedyTokenized(scheme string, word string) ([]Suggestion, *dbus.Error) {
+	varnam, dbusErr := service.getInstance(scheme)
+	if dbusErr != nil {
+		return nil, dbusErr
+	}
+	return toSuggestions(varnam.TransliterateGreedyTokenized(word)), nil
+}
+
+func (service *Service) reverseTransliterate(scheme string, word string) ([]Suggestion, *dbus.Error) {
+	varnam, dbusErr := service.getInstance(scheme)
+	if dbusErr != nil {
+		return nil, dbusErr
+	}
+
+	sugs, err := varnam.ReverseTransliterate(word)
+	if err != nil {
+		return nil, dbus.MakeFailedError(err)
+	}
+	return toSuggestions(sugs), nil
+}
+
+func (service *Service) learn(scheme string, word string, weight int32) *dbus.Error {
+	varnam, dbusErr := service.getWritableInstance(scheme)
+	if dbusErr != nil {
+		return dbusErr
+	}
+
+	if err := varnam.Learn(word, int(weight)); err != nil {
+		return learnError(err)
+	}
+	return nil
+}
+
+func (service *Service) train(scheme string, pattern string, word string) *dbus.Error {
+	varnam, dbusErr := service.getWritableInstance(scheme)
+	if dbusErr != nil {
+		return dbusErr
+	}
+
+	if err := varnam.Train(pattern, word); err != nil {
+		return learnError(err)
+	}
+	return nil
+}
+
+func (service *Service) unlearn(scheme string, word string) *dbus.Error {
+	varnam, dbusErr := service.getWritableInstance(scheme)
+	if dbusErr != nil {
+		return dbusErr
+	}
+
+	if err := varnam.Unlearn(word); err != nil {
+		return dbus.MakeFailedError(err)
+	}
+	return nil
+}
+
+func (service *Service) getSchemes() ([]string, *dbus.Error) {
+	schemes, err := govarnam.GetAllSchemeDetails()
+	if err != nil {
+		return nil, dbus.MakeFailedError(err)
+	}
+
+	ids := make([]string, len(schemes))
+	for i, scheme := range schemes {
+		ids[i] = scheme.Identifier
+	}
+	return ids, nil
+}
@@ -0,0 +1,176 @@
+package varnamdbus
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/testutil"
+)
+
+// Address of a private bus, stopped at the end of test
+func startBus(t *testing.T) string {
+	daemon, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not found")
+	}
+
+	dir := t.TempDir()
+	socketPath := filepath.Join(dir, "bus")
+	configPath := filepath.Join(dir, "bus.conf")
+
+	config := fmt.Sprintf(`<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:path=%s</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>`, socketPath)
+	if err := os.WriteFile(configPath, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(daemon, "--nofork", "--config-file="+configPath)
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(socketPath); err == nil {
+			return "unix:path=" + socketPath
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("Bus didn't start")
+	return ""
+}
+
+func connect(t *testing.T, address string) *dbus.Conn {
+	conn, err := dbus.Connect(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
+
+// Service with instances of testutil.Init, on a private bus
+func makeService(t *testing.T, readOnly bool) (dbus.BusObject, *dbus.Conn) {
+	address := startBus(t)
+
+	service := NewService()
+	service.ReadOnly = readOnly
+	service.Init = testutil.Init(t)
+	t.Cleanup(func() {
+		service.Close()
+	})
+
+	if err := service.Serve(connect(t, address)); err != nil {
+		t.Fatal(err)
+	}
+
+	client := connect(t, address)
+	return client.Object(BusName, ObjectPath), client
+}
+
+func TestDBus(t *testing.T) {
+	engine, client := makeService(t, false)
+
+	err := client.AddMatchSignal(dbus.WithMatchInterface(InterfaceName), dbus.WithMatchMember("LearnedWord"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signals := make(chan *dbus.Signal, 10)
+	client.Signal(signals)
+
+	var sugs []Suggestion
+	err = engine.Call(InterfaceName+".TransliterateGreedyTokenized", 0, "hi", "namaste").Store(&sugs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sugs) == 0 || sugs[0].Word != "नमस्ते" {
+		t.Errorf("Expected नमस्ते, got %v", sugs)
+	}
+
+	err = engine.Call(InterfaceName+".Learn", 0, "hi", "नमस्ते", int32(0)).Err
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case signal := <-signals:
+		if signal.Path != ObjectPath || len(signal.Body) != 3 ||
+			signal.Body[0] != "hi" || signal.Body[1] != "नमस्ते" || signal.Body[2] != int32(govarnam.VARNAM_LEARNT_WORD_MIN_WEIGHT) {
+			t.Errorf("Unexpected signal %+v", signal)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("LearnedWord wasn't emitted")
+	}
+
+	// Words that can't be learnt
+	err = engine.Call(InterfaceName+".Learn", 0, "hi", "hello", int32(0)).Err
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) || dbusErr.Name != "org.freedesktop.DBus.Error.InvalidArgs" {
+		t.Errorf("Expected InvalidArgs, got %v", err)
+	}
+
+	err = engine.Call(InterfaceName+".Train", 0, "hi", "nmste", "नमस्ते").Err
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = engine.Call(InterfaceName+".Transliterate", 0, "hi", "nmste").Store(&sugs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sugs) == 0 || sugs[0].Word != "नमस्ते" || sugs[0].LearnedOn == 0 {
+		t.Errorf("Expected trained नमस्ते, got %v", sugs)
+	}
+
+	err = engine.Call(InterfaceName+".Unlearn", 0, "hi", "नमस्ते").Err
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data string
+	err = engine.Call("org.freedesktop.DBus.Introspectable.Introspect", 0).Store(&data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(data, `<signal name="LearnedWord">`) {
+		t.Errorf("Expected interface in introspection, got %s", data)
+	}
+}
+
+func TestDBusErrors(t *testing.T) {
+	engine, _ := makeService(t, true)
+
+	calls := [][]interface{}{
+		{"Transliterate", "../hi", "a"},
+		{"Transliterate", "ml", "a"},
+		{"Learn", "hi", "नमस्ते", int32(0)},
+	}
+	for _, call := range calls {
+		if err := engine.Call(InterfaceName+"."+call[0].(string), 0, call[1:]...).Err; err == nil {
+			t.Errorf("Expected error for %v", call)
+		}
+	}
+}
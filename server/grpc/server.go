@@ -0,0 +1,327 @@
+package varnamgrpc
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+Package varnamgrpc serves govarnam over gRPC, so that applications in
+other languages and remote clients can share one instance. The API is
+in varnam.proto :
+
+	server := varnamgrpc.NewServer()
+	defer server.Close()
+
+	s := grpc.NewServer()
+	varnamgrpc.RegisterVarnamServer(s, server)
+	s.Serve(listener)
+
+An instance is made for a scheme on its first request and is shared by
+all clients after that. Learnings are exported & imported in the
+messages, clients never give paths on the server.
+
+Learnings of each user can be kept apart by setting Server.User, see
+UserFromMetadata. Instances of users are opened on their first request
+and the least recently used ones are closed when there are more than
+Server.MaxUserInstances.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative varnam.proto
+
+// Server implements VarnamServer
+type Server struct {
+	UnimplementedVarnamServer
+
+	// Makes the instance for a scheme ID. govarnam.InitFromID if nil
+	Init func(schemeID string) (*govarnam.Varnam, error)
+
+	// Disables Learn, Train, Unlearn & Import
+	ReadOnly bool
+
+	// Identifies the user of an RPC to keep learnings of each
+	// user apart. All RPCs share learnings if nil
+	User func(ctx context.Context) (string, error)
+
+	// Makes the instance of a user, used instead of Init when User is set.
+	// If nil, learnings of a user are kept in UsersDir/{userID}
+	InitForUser func(schemeID string, userID string) (*govarnam.Varnam, error)
+
+	// See InitForUser
+	UsersDir string
+
+	// Most user instances kept open. Least recently used ones not
+	// serving an RPC are closed after this. 0 for DefaultMaxUserInstances
+	MaxUserInstances int
+
+	instances instances.Manager
+}
+
+// NewServer make a Server
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Close closes all instances made
+func (server *Server) Close() error {
+	return server.instances.Close()
+}
+
+// Get the instance for schemeID, of the user making the RPC of ctx if
+// Server.User is set. release should be called when done with it
+func (server *Server) getInstance(ctx context.Context, schemeID string) (*govarnam.Varnam, func(), error) {
+	if !instances.ValidSchemeID(schemeID) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Invalid scheme ID %q", schemeID)
+	}
+
+	if server.User != nil {
+		userID, err := server.User(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		return server.getUserInstance(schemeID, userID)
+	}
+
+	varnam, err := server.instances.Get(schemeID, server.Init)
+	if err != nil {
+		return nil, nil, status.Error(codes.NotFound, err.Error())
+	}
+	return varnam, func() {}, nil
+}
+
+// Instance of a scheme for learning
+func (server *Server) getWritableInstance(ctx context.Context, schemeID string) (*govarnam.Varnam, func(), error) {
+	if server.ReadOnly {
+		return nil, nil, status.Error(codes.PermissionDenied, "Server is read only")
+	}
+	return server.getInstance(ctx, schemeID)
+}
+
+// Words that can't be learnt are the client's fault
+func learnError(err error) error {
+	var validationErr *govarnam.ValidationError
+	if errors.As(err, &validationErr) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func toSuggestions(sugs []govarnam.Suggestion) []*Suggestion {
+	result := make([]*Suggestion, len(sugs))
+	for i, sug := range sugs {
+		result[i] = &Suggestion{
+			Word:      sug.Word,
+			Weight:    int32(sug.Weight),
+			LearnedOn: int64(sug.LearnedOn),
+			Score:     sug.Score,
+		}
+	}
+	return result
+}
+
+func toResult(result govarnam.TransliterationResult) *TransliterationResult {
+	return &TransliterationResult{
+		ExactWords:                   toSuggestions(result.ExactWords),
+		ExactMatches:                 toSuggestions(result.ExactMatches),
+		DictionarySuggestions:        toSuggestions(result.DictionarySuggestions),
+		PatternDictionarySuggestions: toSuggestions(result.PatternDictionarySuggestions),
+		TokenizerSuggestions:         toSuggestions(result.TokenizerSuggestions),
+		GreedyTokenized:              toSuggestions(result.GreedyTokenized),
+	}
+}
+
+// Transliterate implements VarnamServer
+func (server *Server) Transliterate(ctx context.Context, req *TransliterateRequest) (*TransliterationResult, error) {
+	varnam, release, err := server.getInstance(ctx, req.SchemeId)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	result := varnam.TransliterateAdvancedWithOptions(ctx, req.Word, govarnam.TransliterateOptions{})
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	return toResult(result), nil
+}
+
+// TransliterateStream implements VarnamServer
+func (server *Server) TransliterateStream(req *TransliterateRequest, stream Varnam_TransliterateStreamServer) error {
+	varnam, release, err := server.getInstance(stream.Context(), req.SchemeId)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var sendErr error
+	varnam.TransliterateStaged(ctx, req.Word, func(stage govarnam.TransliterationStage, result govarnam.TransliterationResult) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&TransliterationBatch{
+			Stage:  TransliterationStage(stage),
+			Result: toResult(result),
+		})
+		if sendErr != nil {
+			cancel()
+		}
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+
+	// Client went away, COMPLETE wasn't sent
+	if err := stream.Context().Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+// Learn implements VarnamServer
+func (server *Server) Learn(ctx context.Context, req *LearnRequest) (*Empty, error) {
+	varnam, release, err := server.getWritableInstance(ctx, req.SchemeId)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := varnam.Learn(req.Word, int(req.Weight)); err != nil {
+		return nil, learnError(err)
+	}
+	return &Empty{}, nil
+}
+
+// Train implements VarnamServer
+func (server *Server) Train(ctx context.Context, req *TrainRequest) (*Empty, error) {
+	varnam, release, err := server.getWritableInstance(ctx, req.SchemeId)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := varnam.Train(req.Pattern, req.Word); err != nil {
+		return nil, learnError(err)
+	}
+	return &Empty{}, nil
+}
+
+// Unlearn implements VarnamServer
+func (server *Server) Unlearn(ctx context.Context, req *UnlearnRequest) (*Empty, error) {
+	varnam, release, err := server.getWritableInstance(ctx, req.SchemeId)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := varnam.Unlearn(req.Word); err != nil {
+		if errors.Is(err, govarnam.ErrNothingToUnlearn) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+	return &Empty{}, nil
+}
+
+// Export implements VarnamServer. Learnings are exported to a
+// temporary directory and sent file by file
+func (server *Server) Export(req *ExportRequest, stream Varnam_ExportServer) error {
+	varnam, release, err := server.getInstance(stream.Context(), req.SchemeId)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if req.WordsPerFile <= 0 {
+		return status.Error(codes.InvalidArgument, "Words per file should be more than 0")
+	}
+
+	dir, err := os.MkdirTemp("", "varnam-export")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, req.SchemeId)
+
+	err = varnam.ExportWithOptions(filePath, govarnam.ExportOptions{WordsPerFile: int(req.WordsPerFile)})
+	if err != nil {
+		return err
+	}
+
+	// Files are named filePath-1.vlf, filePath-2.vlf...
+	for page := 1; ; page++ {
+		data, err := os.ReadFile(fmt.Sprintf("%s-%d.vlf", filePath, page))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&LearningsFile{Data: data}); err != nil {
+			return err
+		}
+	}
+}
+
+// Import implements VarnamServer. Each file is imported as it comes
+func (server *Server) Import(stream Varnam_ImportServer) error {
+	var varnam *govarnam.Varnam
+
+	dir, err := os.MkdirTemp("", "varnam-import")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, "learnings.vlf")
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&Empty{})
+		}
+		if err != nil {
+			return err
+		}
+
+		if varnam == nil {
+			var release func()
+			varnam, release, err = server.getWritableInstance(stream.Context(), req.SchemeId)
+			if err != nil {
+				return err
+			}
+			defer release()
+		}
+
+		if err := os.WriteFile(filePath, req.GetFile().GetData(), 0600); err != nil {
+			return err
+		}
+
+		// Progress is printed to stdout if not given
+		err = varnam.ImportWithOptions(filePath, govarnam.ImportOptions{
+			Progress: func(done int, total int) {},
+		})
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package varnamgrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/varnamproject/govarnam/server/internal/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Server with instances of testutil.Init
+func makeServer(t *testing.T) *Server {
+	server := NewServer()
+	server.Init = testutil.Init(t)
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return server
+}
+
+// Client of server, over an in memory connection
+func connect(t *testing.T, server *Server) VarnamClient {
+	listener := bufconn.Listen(1 << 20)
+
+	s := grpc.NewServer()
+	RegisterVarnamServer(s, server)
+	go s.Serve(listener)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+
+	return NewVarnamClient(conn)
+}
+
+func TestGRPC(t *testing.T) {
+	client := connect(t, makeServer(t))
+	ctx := context.Background()
+
+	result, err := client.Transliterate(ctx, &TransliterateRequest{SchemeId: "hi", Word: "namaste"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.GreedyTokenized) == 0 || result.GreedyTokenized[0].Word != "नमस्ते" {
+		t.Errorf("Expected नमस्ते, got %v", result.GreedyTokenized)
+	}
+
+	if _, err := client.Learn(ctx, &LearnRequest{SchemeId: "hi", Word: "नमस्ते"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Train(ctx, &TrainRequest{SchemeId: "hi", Pattern: "nmste", Word: "नमस्ते"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = client.Transliterate(ctx, &TransliterateRequest{SchemeId: "hi", Word: "nmste"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ExactWords) == 0 || result.ExactWords[0].Word != "नमस्ते" || result.ExactWords[0].LearnedOn == 0 {
+		t.Errorf("Expected trained नमस्ते, got %v", result.ExactWords)
+	}
+
+	if _, err := client.Unlearn(ctx, &UnlearnRequest{SchemeId: "hi", Word: "नमस्ते"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = client.Transliterate(ctx, &TransliterateRequest{SchemeId: "hi", Word: "nmste"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ExactWords) != 0 {
+		t.Errorf("Expected unlearnt, got %v", result.ExactWords)
+	}
+}
+
+func TestGRPCStream(t *testing.T) {
+	client := connect(t, makeServer(t))
+	ctx := context.Background()
+
+	if _, err := client.Learn(ctx, &LearnRequest{SchemeId: "hi", Word: "नमस्ते"}); err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := client.TransliterateStream(ctx, &TransliterateRequest{SchemeId: "hi", Word: "namaste"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var batches []*TransliterationBatch
+	for {
+		batch, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		batches = append(batches, batch)
+	}
+
+	if len(batches) < 2 {
+		t.Fatalf("Expected a batch for each stage, got %v", batches)
+	}
+
+	last := batches[len(batches)-1]
+	if last.Stage != TransliterationStage_COMPLETE {
+		t.Errorf("Expected last batch to be COMPLETE, got %v", last.Stage)
+	}
+	if len(last.Result.ExactWords) == 0 || last.Result.ExactWords[0].Word != "नमस्ते" {
+		t.Errorf("Expected learnt नमस्ते in complete result, got %v", last.Result)
+	}
+	for _, batch := range batches[:len(batches)-1] {
+		if batch.Stage == TransliterationStage_COMPLETE {
+			t.Errorf("Expected COMPLETE only at the end, got %v", batches)
+		}
+	}
+}
+
+func TestGRPCExportImport(t *testing.T) {
+	client := connect(t, makeServer(t))
+	ctx := context.Background()
+
+	for _, word := range []string{"नमस्ते", "नमस्कार", "दोस्त"} {
+		if _, err := client.Learn(ctx, &LearnRequest{SchemeId: "hi", Word: word}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	export, err := client.Export(ctx, &ExportRequest{SchemeId: "hi", WordsPerFile: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var files []*LearningsFile
+	for {
+		file, err := export.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(files))
+	}
+
+	// Into another server
+	client = connect(t, makeServer(t))
+
+	imp, err := client.Import(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, file := range files {
+		req := &ImportRequest{File: file}
+		if i == 0 {
+			req.SchemeId = "hi"
+		}
+		if err := imp.Send(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := imp.CloseAndRecv(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.Transliterate(ctx, &TransliterateRequest{SchemeId: "hi", Word: "dost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.ExactWords) == 0 || result.ExactWords[0].Word != "दोस्त" {
+		t.Errorf("Expected imported दोस्त, got %v", result.ExactWords)
+	}
+}
+
+func TestGRPCErrors(t *testing.T) {
+	server := makeServer(t)
+	server.ReadOnly = true
+	client := connect(t, server)
+	ctx := context.Background()
+
+	_, err := client.Transliterate(ctx, &TransliterateRequest{SchemeId: "../hi", Word: "a"})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = client.Transliterate(ctx, &TransliterateRequest{SchemeId: "ml", Word: "a"})
+	assertCode(t, err, codes.NotFound)
+
+	_, err = client.Learn(ctx, &LearnRequest{SchemeId: "hi", Word: "नमस्ते"})
+	assertCode(t, err, codes.PermissionDenied)
+
+	imp, err := client.Import(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imp.Send(&ImportRequest{SchemeId: "hi", File: &LearningsFile{Data: []byte("{}")}})
+	_, err = imp.CloseAndRecv()
+	assertCode(t, err, codes.PermissionDenied)
+
+	server.ReadOnly = false
+
+	_, err = client.Learn(ctx, &LearnRequest{SchemeId: "hi", Word: "hello"})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = client.Unlearn(ctx, &UnlearnRequest{SchemeId: "hi", Word: "hello"})
+	assertCode(t, err, codes.NotFound)
+
+	export, err := client.Export(ctx, &ExportRequest{SchemeId: "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = export.Recv()
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func assertCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+
+	if status.Code(err) != code {
+		t.Errorf("Expected %v, got %v", code, err)
+	}
+}
@@ -0,0 +1,75 @@
+package varnamgrpc
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxUserInstances used when Server.MaxUserInstances is 0
+const DefaultMaxUserInstances = instances.DefaultMaxUserInstances
+
+// UserFromMetadata identify user by the value of metadata key, like
+// x-varnam-user. Only for servers behind a proxy that sets the
+// metadata after authenticating users, anyone can send any metadata
+func UserFromMetadata(key string) func(ctx context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		var userID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(key); len(values) != 0 {
+				userID = values[0]
+			}
+		}
+
+		if userID == "" {
+			return "", status.Errorf(codes.Unauthenticated, "%s metadata is required", key)
+		}
+		if !instances.ValidUserID(userID) {
+			return "", status.Errorf(codes.InvalidArgument, "Invalid user ID %q", userID)
+		}
+		return userID, nil
+	}
+}
+
+func (server *Server) initForUser(schemeID string, userID string) (*govarnam.Varnam, error) {
+	if server.InitForUser != nil {
+		return server.InitForUser(schemeID, userID)
+	}
+	if server.UsersDir == "" {
+		return nil, errors.New("UsersDir isn't set")
+	}
+	return govarnam.InitFromIDInLearningsDir(schemeID, filepath.Join(server.UsersDir, userID))
+}
+
+// Get the instance of userID for schemeID, opening it if needed.
+// release should be called when done with it
+func (server *Server) getUserInstance(schemeID string, userID string) (*govarnam.Varnam, func(), error) {
+	varnam, release, err := server.instances.GetForUser(schemeID, userID, server.initForUser)
+	if err != nil {
+		return nil, nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	server.instances.Evict(server.MaxUserInstances, nil)
+
+	return varnam, func() {
+		release()
+		server.instances.Evict(server.MaxUserInstances, nil)
+	}, nil
+}
+
+// OpenUserInstances number of user instances open now
+func (server *Server) OpenUserInstances() int {
+	return server.instances.OpenUserInstances()
+}
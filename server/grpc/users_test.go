@@ -0,0 +1,82 @@
+package varnamgrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/varnamproject/govarnam/server/internal/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// Server serving "hi" with learnings of each user apart
+func makeUsersServer(t *testing.T) *Server {
+	server := NewServer()
+	server.User = UserFromMetadata("x-varnam-user")
+	server.InitForUser = testutil.InitForUser(t)
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return server
+}
+
+func asUser(userID string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-varnam-user", userID)
+}
+
+func TestGRPCUserIsolation(t *testing.T) {
+	server := makeUsersServer(t)
+	client := connect(t, server)
+
+	if _, err := client.Learn(asUser("alice"), &LearnRequest{SchemeId: "hi", Word: "नमस्कार"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := client.Transliterate(asUser("alice"), &TransliterateRequest{SchemeId: "hi", Word: "namas"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DictionarySuggestions) == 0 || result.DictionarySuggestions[0].Word != "नमस्कार" {
+		t.Errorf("Expected learnt word in suggestions of alice, got %v", result.DictionarySuggestions)
+	}
+
+	result, err = client.Transliterate(asUser("bob"), &TransliterateRequest{SchemeId: "hi", Word: "namas"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DictionarySuggestions) != 0 {
+		t.Errorf("Expected no suggestions for bob, got %v", result.DictionarySuggestions)
+	}
+
+	_, err = client.Transliterate(context.Background(), &TransliterateRequest{SchemeId: "hi", Word: "namas"})
+	assertCode(t, err, codes.Unauthenticated)
+
+	_, err = client.Transliterate(asUser("../bob"), &TransliterateRequest{SchemeId: "hi", Word: "namas"})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestGRPCUserInstancesEviction(t *testing.T) {
+	server := makeUsersServer(t)
+	server.MaxUserInstances = 2
+	client := connect(t, server)
+
+	for _, user := range []string{"a", "b", "c"} {
+		if _, err := client.Learn(asUser(user), &LearnRequest{SchemeId: "hi", Word: "नमस्कार"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if open := server.OpenUserInstances(); open != 2 {
+		t.Errorf("Expected 2 open instances, got %d", open)
+	}
+
+	// Learnings of "a" are kept after its instance was closed
+	result, err := client.Transliterate(asUser("a"), &TransliterateRequest{SchemeId: "hi", Word: "namas"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.DictionarySuggestions) == 0 {
+		t.Errorf("Expected learnt word after reopening, got %v", result.DictionarySuggestions)
+	}
+}
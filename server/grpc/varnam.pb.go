@@ -0,0 +1,1075 @@
+// govarnam - An Indian language transliteration library
+// Copyright Subin Siby <mail at subinsb (.) com>, 2021
+// Licensed under AGPL-3.0-only. See LICENSE.txt
+
+// API for serving one govarnam instance over gRPC.
+// Messages follow the types in the govarnam package.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: varnam.proto
+
+package varnamgrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Same values as govarnam.TransliterationStage
+type TransliterationStage int32
+
+const (
+	TransliterationStage_UNKNOWN            TransliterationStage = 0
+	TransliterationStage_GREEDY_TOKENIZED   TransliterationStage = 1
+	TransliterationStage_DICTIONARY         TransliterationStage = 2
+	TransliterationStage_PATTERN_DICTIONARY TransliterationStage = 3
+	TransliterationStage_TOKENIZER          TransliterationStage = 4
+	TransliterationStage_COMPLETE           TransliterationStage = 5
+)
+
+// Enum value maps for TransliterationStage.
+var (
+	TransliterationStage_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "GREEDY_TOKENIZED",
+		2: "DICTIONARY",
+		3: "PATTERN_DICTIONARY",
+		4: "TOKENIZER",
+		5: "COMPLETE",
+	}
+	TransliterationStage_value = map[string]int32{
+		"UNKNOWN":            0,
+		"GREEDY_TOKENIZED":   1,
+		"DICTIONARY":         2,
+		"PATTERN_DICTIONARY": 3,
+		"TOKENIZER":          4,
+		"COMPLETE":           5,
+	}
+)
+
+func (x TransliterationStage) Enum() *TransliterationStage {
+	p := new(TransliterationStage)
+	*p = x
+	return p
+}
+
+func (x TransliterationStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransliterationStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_varnam_proto_enumTypes[0].Descriptor()
+}
+
+func (TransliterationStage) Type() protoreflect.EnumType {
+	return &file_varnam_proto_enumTypes[0]
+}
+
+func (x TransliterationStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransliterationStage.Descriptor instead.
+func (TransliterationStage) EnumDescriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{0}
+}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{0}
+}
+
+type Suggestion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word      string  `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Weight    int32   `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	LearnedOn int64   `protobuf:"varint,3,opt,name=learned_on,json=learnedOn,proto3" json:"learned_on,omitempty"`
+	Score     float64 `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Suggestion) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Suggestion) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Suggestion) GetLearnedOn() int64 {
+	if x != nil {
+		return x.LearnedOn
+	}
+	return 0
+}
+
+func (x *Suggestion) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type TransliterateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Scheme ID. The server can serve more than one
+	SchemeId string `protobuf:"bytes,1,opt,name=scheme_id,json=schemeId,proto3" json:"scheme_id,omitempty"`
+	Word     string `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *TransliterateRequest) Reset() {
+	*x = TransliterateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransliterateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransliterateRequest) ProtoMessage() {}
+
+func (x *TransliterateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransliterateRequest.ProtoReflect.Descriptor instead.
+func (*TransliterateRequest) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TransliterateRequest) GetSchemeId() string {
+	if x != nil {
+		return x.SchemeId
+	}
+	return ""
+}
+
+func (x *TransliterateRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+type TransliterationResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExactWords                   []*Suggestion `protobuf:"bytes,1,rep,name=exact_words,json=exactWords,proto3" json:"exact_words,omitempty"`
+	ExactMatches                 []*Suggestion `protobuf:"bytes,2,rep,name=exact_matches,json=exactMatches,proto3" json:"exact_matches,omitempty"`
+	DictionarySuggestions        []*Suggestion `protobuf:"bytes,3,rep,name=dictionary_suggestions,json=dictionarySuggestions,proto3" json:"dictionary_suggestions,omitempty"`
+	PatternDictionarySuggestions []*Suggestion `protobuf:"bytes,4,rep,name=pattern_dictionary_suggestions,json=patternDictionarySuggestions,proto3" json:"pattern_dictionary_suggestions,omitempty"`
+	TokenizerSuggestions         []*Suggestion `protobuf:"bytes,5,rep,name=tokenizer_suggestions,json=tokenizerSuggestions,proto3" json:"tokenizer_suggestions,omitempty"`
+	GreedyTokenized              []*Suggestion `protobuf:"bytes,6,rep,name=greedy_tokenized,json=greedyTokenized,proto3" json:"greedy_tokenized,omitempty"`
+}
+
+func (x *TransliterationResult) Reset() {
+	*x = TransliterationResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransliterationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransliterationResult) ProtoMessage() {}
+
+func (x *TransliterationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransliterationResult.ProtoReflect.Descriptor instead.
+func (*TransliterationResult) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransliterationResult) GetExactWords() []*Suggestion {
+	if x != nil {
+		return x.ExactWords
+	}
+	return nil
+}
+
+func (x *TransliterationResult) GetExactMatches() []*Suggestion {
+	if x != nil {
+		return x.ExactMatches
+	}
+	return nil
+}
+
+func (x *TransliterationResult) GetDictionarySuggestions() []*Suggestion {
+	if x != nil {
+		return x.DictionarySuggestions
+	}
+	return nil
+}
+
+func (x *TransliterationResult) GetPatternDictionarySuggestions() []*Suggestion {
+	if x != nil {
+		return x.PatternDictionarySuggestions
+	}
+	return nil
+}
+
+func (x *TransliterationResult) GetTokenizerSuggestions() []*Suggestion {
+	if x != nil {
+		return x.TokenizerSuggestions
+	}
+	return nil
+}
+
+func (x *TransliterationResult) GetGreedyTokenized() []*Suggestion {
+	if x != nil {
+		return x.GreedyTokenized
+	}
+	return nil
+}
+
+type TransliterationBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage  TransliterationStage   `protobuf:"varint,1,opt,name=stage,proto3,enum=varnam.TransliterationStage" json:"stage,omitempty"`
+	Result *TransliterationResult `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *TransliterationBatch) Reset() {
+	*x = TransliterationBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransliterationBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransliterationBatch) ProtoMessage() {}
+
+func (x *TransliterationBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransliterationBatch.ProtoReflect.Descriptor instead.
+func (*TransliterationBatch) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransliterationBatch) GetStage() TransliterationStage {
+	if x != nil {
+		return x.Stage
+	}
+	return TransliterationStage_UNKNOWN
+}
+
+func (x *TransliterationBatch) GetResult() *TransliterationResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type LearnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SchemeId string `protobuf:"bytes,1,opt,name=scheme_id,json=schemeId,proto3" json:"scheme_id,omitempty"`
+	Word     string `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+	Weight   int32  `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *LearnRequest) Reset() {
+	*x = LearnRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LearnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LearnRequest) ProtoMessage() {}
+
+func (x *LearnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LearnRequest.ProtoReflect.Descriptor instead.
+func (*LearnRequest) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LearnRequest) GetSchemeId() string {
+	if x != nil {
+		return x.SchemeId
+	}
+	return ""
+}
+
+func (x *LearnRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *LearnRequest) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type TrainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SchemeId string `protobuf:"bytes,1,opt,name=scheme_id,json=schemeId,proto3" json:"scheme_id,omitempty"`
+	Pattern  string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Word     string `protobuf:"bytes,3,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *TrainRequest) Reset() {
+	*x = TrainRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrainRequest) ProtoMessage() {}
+
+func (x *TrainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrainRequest.ProtoReflect.Descriptor instead.
+func (*TrainRequest) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TrainRequest) GetSchemeId() string {
+	if x != nil {
+		return x.SchemeId
+	}
+	return ""
+}
+
+func (x *TrainRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *TrainRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+type UnlearnRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SchemeId string `protobuf:"bytes,1,opt,name=scheme_id,json=schemeId,proto3" json:"scheme_id,omitempty"`
+	Word     string `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *UnlearnRequest) Reset() {
+	*x = UnlearnRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnlearnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlearnRequest) ProtoMessage() {}
+
+func (x *UnlearnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlearnRequest.ProtoReflect.Descriptor instead.
+func (*UnlearnRequest) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UnlearnRequest) GetSchemeId() string {
+	if x != nil {
+		return x.SchemeId
+	}
+	return ""
+}
+
+func (x *UnlearnRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+type ExportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SchemeId string `protobuf:"bytes,1,opt,name=scheme_id,json=schemeId,proto3" json:"scheme_id,omitempty"`
+	// Words in each LearningsFile. Keep messages under
+	// the client's max receive size (4 MB by default)
+	WordsPerFile int32 `protobuf:"varint,2,opt,name=words_per_file,json=wordsPerFile,proto3" json:"words_per_file,omitempty"`
+}
+
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRequest) ProtoMessage() {}
+
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ExportRequest) GetSchemeId() string {
+	if x != nil {
+		return x.SchemeId
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetWordsPerFile() int32 {
+	if x != nil {
+		return x.WordsPerFile
+	}
+	return 0
+}
+
+type LearningsFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *LearningsFile) Reset() {
+	*x = LearningsFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LearningsFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LearningsFile) ProtoMessage() {}
+
+func (x *LearningsFile) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LearningsFile.ProtoReflect.Descriptor instead.
+func (*LearningsFile) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LearningsFile) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SchemeId string         `protobuf:"bytes,1,opt,name=scheme_id,json=schemeId,proto3" json:"scheme_id,omitempty"`
+	File     *LearningsFile `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+}
+
+func (x *ImportRequest) Reset() {
+	*x = ImportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_varnam_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRequest) ProtoMessage() {}
+
+func (x *ImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_varnam_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRequest.ProtoReflect.Descriptor instead.
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return file_varnam_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ImportRequest) GetSchemeId() string {
+	if x != nil {
+		return x.SchemeId
+	}
+	return ""
+}
+
+func (x *ImportRequest) GetFile() *LearningsFile {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+var File_varnam_proto protoreflect.FileDescriptor
+
+var file_varnam_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x6d, 0x0a, 0x0a, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x61,
+	0x72, 0x6e, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c,
+	0x65, 0x61, 0x72, 0x6e, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x47,
+	0x0a, 0x14, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x22, 0xb2, 0x03, 0x0a, 0x15, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x33, 0x0a, 0x0b, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e,
+	0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x65, 0x78, 0x61, 0x63,
+	0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x37, 0x0a, 0x0d, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0c, 0x65, 0x78, 0x61, 0x63, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12,
+	0x49, 0x0a, 0x16, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x73, 0x75,
+	0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x15, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x53,
+	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x58, 0x0a, 0x1e, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x5f, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79,
+	0x5f, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x53, 0x75, 0x67, 0x67,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1c, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x44,
+	0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x72, 0x79, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x47, 0x0a, 0x15, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x69, 0x7a, 0x65,
+	0x72, 0x5f, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x53, 0x75, 0x67,
+	0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x14, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x69, 0x7a,
+	0x65, 0x72, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3d, 0x0a,
+	0x10, 0x67, 0x72, 0x65, 0x65, 0x64, 0x79, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x69, 0x7a, 0x65,
+	0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d,
+	0x2e, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x67, 0x72, 0x65,
+	0x65, 0x64, 0x79, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x69, 0x7a, 0x65, 0x64, 0x22, 0x81, 0x01, 0x0a,
+	0x14, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x32, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x61, 0x72, 0x6e,
+	0x61, 0x6d, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0x57, 0x0a, 0x0c, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x59, 0x0a, 0x0c, 0x54, 0x72, 0x61,
+	0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x77, 0x6f, 0x72, 0x64, 0x22, 0x41, 0x0a, 0x0e, 0x55, 0x6e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x52, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x5f, 0x70,
+	0x65, 0x72, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x77,
+	0x6f, 0x72, 0x64, 0x73, 0x50, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x23, 0x0a, 0x0d, 0x4c,
+	0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x57, 0x0a, 0x0d, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x29,
+	0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76,
+	0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x2a, 0x7e, 0x0a, 0x14, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x67,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x14,
+	0x0a, 0x10, 0x47, 0x52, 0x45, 0x45, 0x44, 0x59, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x49, 0x5a,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x41,
+	0x52, 0x59, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e, 0x5f,
+	0x44, 0x49, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09,
+	0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x49, 0x5a, 0x45, 0x52, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x43,
+	0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x05, 0x32, 0xa5, 0x03, 0x0a, 0x06, 0x56, 0x61,
+	0x72, 0x6e, 0x61, 0x6d, 0x12, 0x4c, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x53, 0x0a, 0x13, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1c, 0x2e, 0x76, 0x61, 0x72, 0x6e,
+	0x61, 0x6d, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x30, 0x01, 0x12, 0x2c, 0x0a, 0x05, 0x4c, 0x65, 0x61, 0x72, 0x6e,
+	0x12, 0x14, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x54, 0x72, 0x61, 0x69, 0x6e, 0x12, 0x14,
+	0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x54, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x30, 0x0a, 0x07, 0x55, 0x6e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x12, 0x16,
+	0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x55, 0x6e, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x15, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e,
+	0x4c, 0x65, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x30, 0x01, 0x12,
+	0x30, 0x0a, 0x06, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x15, 0x2e, 0x76, 0x61, 0x72, 0x6e,
+	0x61, 0x6d, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0d, 0x2e, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x28,
+	0x01, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2f, 0x67, 0x6f,
+	0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x67, 0x72,
+	0x70, 0x63, 0x3b, 0x76, 0x61, 0x72, 0x6e, 0x61, 0x6d, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_varnam_proto_rawDescOnce sync.Once
+	file_varnam_proto_rawDescData = file_varnam_proto_rawDesc
+)
+
+func file_varnam_proto_rawDescGZIP() []byte {
+	file_varnam_proto_rawDescOnce.Do(func() {
+		file_varnam_proto_rawDescData = protoimpl.X.CompressGZIP(file_varnam_proto_rawDescData)
+	})
+	return file_varnam_proto_rawDescData
+}
+
+var file_varnam_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_varnam_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_varnam_proto_goTypes = []interface{}{
+	(TransliterationStage)(0),     // 0: varnam.TransliterationStage
+	(*Empty)(nil),                 // 1: varnam.Empty
+	(*Suggestion)(nil),            // 2: varnam.Suggestion
+	(*TransliterateRequest)(nil),  // 3: varnam.TransliterateRequest
+	(*TransliterationResult)(nil), // 4: varnam.TransliterationResult
+	(*TransliterationBatch)(nil),  // 5: varnam.TransliterationBatch
+	(*LearnRequest)(nil),          // 6: varnam.LearnRequest
+	(*TrainRequest)(nil),          // 7: varnam.TrainRequest
+	(*UnlearnRequest)(nil),        // 8: varnam.UnlearnRequest
+	(*ExportRequest)(nil),         // 9: varnam.ExportRequest
+	(*LearningsFile)(nil),         // 10: varnam.LearningsFile
+	(*ImportRequest)(nil),         // 11: varnam.ImportRequest
+}
+var file_varnam_proto_depIdxs = []int32{
+	2,  // 0: varnam.TransliterationResult.exact_words:type_name -> varnam.Suggestion
+	2,  // 1: varnam.TransliterationResult.exact_matches:type_name -> varnam.Suggestion
+	2,  // 2: varnam.TransliterationResult.dictionary_suggestions:type_name -> varnam.Suggestion
+	2,  // 3: varnam.TransliterationResult.pattern_dictionary_suggestions:type_name -> varnam.Suggestion
+	2,  // 4: varnam.TransliterationResult.tokenizer_suggestions:type_name -> varnam.Suggestion
+	2,  // 5: varnam.TransliterationResult.greedy_tokenized:type_name -> varnam.Suggestion
+	0,  // 6: varnam.TransliterationBatch.stage:type_name -> varnam.TransliterationStage
+	4,  // 7: varnam.TransliterationBatch.result:type_name -> varnam.TransliterationResult
+	10, // 8: varnam.ImportRequest.file:type_name -> varnam.LearningsFile
+	3,  // 9: varnam.Varnam.Transliterate:input_type -> varnam.TransliterateRequest
+	3,  // 10: varnam.Varnam.TransliterateStream:input_type -> varnam.TransliterateRequest
+	6,  // 11: varnam.Varnam.Learn:input_type -> varnam.LearnRequest
+	7,  // 12: varnam.Varnam.Train:input_type -> varnam.TrainRequest
+	8,  // 13: varnam.Varnam.Unlearn:input_type -> varnam.UnlearnRequest
+	9,  // 14: varnam.Varnam.Export:input_type -> varnam.ExportRequest
+	11, // 15: varnam.Varnam.Import:input_type -> varnam.ImportRequest
+	4,  // 16: varnam.Varnam.Transliterate:output_type -> varnam.TransliterationResult
+	5,  // 17: varnam.Varnam.TransliterateStream:output_type -> varnam.TransliterationBatch
+	1,  // 18: varnam.Varnam.Learn:output_type -> varnam.Empty
+	1,  // 19: varnam.Varnam.Train:output_type -> varnam.Empty
+	1,  // 20: varnam.Varnam.Unlearn:output_type -> varnam.Empty
+	10, // 21: varnam.Varnam.Export:output_type -> varnam.LearningsFile
+	1,  // 22: varnam.Varnam.Import:output_type -> varnam.Empty
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_varnam_proto_init() }
+func file_varnam_proto_init() {
+	if File_varnam_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_varnam_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Suggestion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransliterateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransliterationResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransliterationBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LearnRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrainRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnlearnRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LearningsFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_varnam_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_varnam_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_varnam_proto_goTypes,
+		DependencyIndexes: file_varnam_proto_depIdxs,
+		EnumInfos:         file_varnam_proto_enumTypes,
+		MessageInfos:      file_varnam_proto_msgTypes,
+	}.Build()
+	File_varnam_proto = out.File
+	file_varnam_proto_rawDesc = nil
+	file_varnam_proto_goTypes = nil
+	file_varnam_proto_depIdxs = nil
+}
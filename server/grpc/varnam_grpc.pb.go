@@ -0,0 +1,420 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: varnam.proto
+
+package varnamgrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// VarnamClient is the client API for Varnam service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VarnamClient interface {
+	Transliterate(ctx context.Context, in *TransliterateRequest, opts ...grpc.CallOption) (*TransliterationResult, error)
+	// Sends the result collected so far each time a stage finishes.
+	// Same as govarnam's TransliterateStaged. The last one has stage COMPLETE
+	TransliterateStream(ctx context.Context, in *TransliterateRequest, opts ...grpc.CallOption) (Varnam_TransliterateStreamClient, error)
+	Learn(ctx context.Context, in *LearnRequest, opts ...grpc.CallOption) (*Empty, error)
+	Train(ctx context.Context, in *TrainRequest, opts ...grpc.CallOption) (*Empty, error)
+	Unlearn(ctx context.Context, in *UnlearnRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Learnings are sent in the messages, each has the content of
+	// a .vlf file made by govarnam's Export
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (Varnam_ExportClient, error)
+	// Only the first message need scheme_id. Same as govarnam's Import
+	Import(ctx context.Context, opts ...grpc.CallOption) (Varnam_ImportClient, error)
+}
+
+type varnamClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVarnamClient(cc grpc.ClientConnInterface) VarnamClient {
+	return &varnamClient{cc}
+}
+
+func (c *varnamClient) Transliterate(ctx context.Context, in *TransliterateRequest, opts ...grpc.CallOption) (*TransliterationResult, error) {
+	out := new(TransliterationResult)
+	err := c.cc.Invoke(ctx, "/varnam.Varnam/Transliterate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *varnamClient) TransliterateStream(ctx context.Context, in *TransliterateRequest, opts ...grpc.CallOption) (Varnam_TransliterateStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Varnam_ServiceDesc.Streams[0], "/varnam.Varnam/TransliterateStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &varnamTransliterateStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Varnam_TransliterateStreamClient interface {
+	Recv() (*TransliterationBatch, error)
+	grpc.ClientStream
+}
+
+type varnamTransliterateStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *varnamTransliterateStreamClient) Recv() (*TransliterationBatch, error) {
+	m := new(TransliterationBatch)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *varnamClient) Learn(ctx context.Context, in *LearnRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/varnam.Varnam/Learn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *varnamClient) Train(ctx context.Context, in *TrainRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/varnam.Varnam/Train", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *varnamClient) Unlearn(ctx context.Context, in *UnlearnRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/varnam.Varnam/Unlearn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *varnamClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (Varnam_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Varnam_ServiceDesc.Streams[1], "/varnam.Varnam/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &varnamExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Varnam_ExportClient interface {
+	Recv() (*LearningsFile, error)
+	grpc.ClientStream
+}
+
+type varnamExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *varnamExportClient) Recv() (*LearningsFile, error) {
+	m := new(LearningsFile)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *varnamClient) Import(ctx context.Context, opts ...grpc.CallOption) (Varnam_ImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Varnam_ServiceDesc.Streams[2], "/varnam.Varnam/Import", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &varnamImportClient{stream}
+	return x, nil
+}
+
+type Varnam_ImportClient interface {
+	Send(*ImportRequest) error
+	CloseAndRecv() (*Empty, error)
+	grpc.ClientStream
+}
+
+type varnamImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *varnamImportClient) Send(m *ImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *varnamImportClient) CloseAndRecv() (*Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// VarnamServer is the server API for Varnam service.
+// All implementations must embed UnimplementedVarnamServer
+// for forward compatibility
+type VarnamServer interface {
+	Transliterate(context.Context, *TransliterateRequest) (*TransliterationResult, error)
+	// Sends the result collected so far each time a stage finishes.
+	// Same as govarnam's TransliterateStaged. The last one has stage COMPLETE
+	TransliterateStream(*TransliterateRequest, Varnam_TransliterateStreamServer) error
+	Learn(context.Context, *LearnRequest) (*Empty, error)
+	Train(context.Context, *TrainRequest) (*Empty, error)
+	Unlearn(context.Context, *UnlearnRequest) (*Empty, error)
+	// Learnings are sent in the messages, each has the content of
+	// a .vlf file made by govarnam's Export
+	Export(*ExportRequest, Varnam_ExportServer) error
+	// Only the first message need scheme_id. Same as govarnam's Import
+	Import(Varnam_ImportServer) error
+	mustEmbedUnimplementedVarnamServer()
+}
+
+// UnimplementedVarnamServer must be embedded to have forward compatible implementations.
+type UnimplementedVarnamServer struct {
+}
+
+func (UnimplementedVarnamServer) Transliterate(context.Context, *TransliterateRequest) (*TransliterationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transliterate not implemented")
+}
+func (UnimplementedVarnamServer) TransliterateStream(*TransliterateRequest, Varnam_TransliterateStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method TransliterateStream not implemented")
+}
+func (UnimplementedVarnamServer) Learn(context.Context, *LearnRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Learn not implemented")
+}
+func (UnimplementedVarnamServer) Train(context.Context, *TrainRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Train not implemented")
+}
+func (UnimplementedVarnamServer) Unlearn(context.Context, *UnlearnRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unlearn not implemented")
+}
+func (UnimplementedVarnamServer) Export(*ExportRequest, Varnam_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedVarnamServer) Import(Varnam_ImportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
+func (UnimplementedVarnamServer) mustEmbedUnimplementedVarnamServer() {}
+
+// UnsafeVarnamServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VarnamServer will
+// result in compilation errors.
+type UnsafeVarnamServer interface {
+	mustEmbedUnimplementedVarnamServer()
+}
+
+func RegisterVarnamServer(s grpc.ServiceRegistrar, srv VarnamServer) {
+	s.RegisterService(&Varnam_ServiceDesc, srv)
+}
+
+func _Varnam_Transliterate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransliterateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VarnamServer).Transliterate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/varnam.Varnam/Transliterate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VarnamServer).Transliterate(ctx, req.(*TransliterateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Varnam_TransliterateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TransliterateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VarnamServer).TransliterateStream(m, &varnamTransliterateStreamServer{stream})
+}
+
+type Varnam_TransliterateStreamServer interface {
+	Send(*TransliterationBatch) error
+	grpc.ServerStream
+}
+
+type varnamTransliterateStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *varnamTransliterateStreamServer) Send(m *TransliterationBatch) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Varnam_Learn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LearnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VarnamServer).Learn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/varnam.Varnam/Learn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VarnamServer).Learn(ctx, req.(*LearnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Varnam_Train_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VarnamServer).Train(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/varnam.Varnam/Train",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VarnamServer).Train(ctx, req.(*TrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Varnam_Unlearn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlearnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VarnamServer).Unlearn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/varnam.Varnam/Unlearn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VarnamServer).Unlearn(ctx, req.(*UnlearnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Varnam_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VarnamServer).Export(m, &varnamExportServer{stream})
+}
+
+type Varnam_ExportServer interface {
+	Send(*LearningsFile) error
+	grpc.ServerStream
+}
+
+type varnamExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *varnamExportServer) Send(m *LearningsFile) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Varnam_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VarnamServer).Import(&varnamImportServer{stream})
+}
+
+type Varnam_ImportServer interface {
+	SendAndClose(*Empty) error
+	Recv() (*ImportRequest, error)
+	grpc.ServerStream
+}
+
+type varnamImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *varnamImportServer) SendAndClose(m *Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *varnamImportServer) Recv() (*ImportRequest, error) {
+	m := new(ImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Varnam_ServiceDesc is the grpc.ServiceDesc for Varnam service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Varnam_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "varnam.Varnam",
+	HandlerType: (*VarnamServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Transliterate",
+			Handler:    _Varnam_Transliterate_Handler,
+		},
+		{
+			MethodName: "Learn",
+			Handler:    _Varnam_Learn_Handler,
+		},
+		{
+			MethodName: "Train",
+			Handler:    _Varnam_Train_Handler,
+		},
+		{
+			MethodName: "Unlearn",
+			Handler:    _Varnam_Unlearn_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TransliterateStream",
+			Handler:       _Varnam_TransliterateStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _Varnam_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Import",
+			Handler:       _Varnam_Import_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "varnam.proto",
+}
@@ -0,0 +1,246 @@
+package instances
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+Package instances keeps govarnam instances of servers open. An
+instance is opened for a scheme on its first request and is shared by
+all clients after that. Instances of users are kept apart, and least
+recently used ones are closed to keep only some of them open.
+*/
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+// DefaultMaxUserInstances used when max given to Evict is 0
+const DefaultMaxUserInstances = 64
+
+// Scheme IDs are file names of VSTs, don't allow going out of VST dirs
+var schemeIDRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// User IDs are directory names of learnings, don't allow going out of users dir
+var userIDRegex = regexp.MustCompile(`^[a-zA-Z0-9_@-][a-zA-Z0-9_.@-]{0,127}$`)
+
+// ValidSchemeID whether schemeID can be used to look for a VST
+func ValidSchemeID(schemeID string) bool {
+	return schemeIDRegex.MatchString(schemeID)
+}
+
+// ValidUserID whether userID can be used as a directory of learnings
+func ValidUserID(userID string) bool {
+	return userIDRegex.MatchString(userID)
+}
+
+// Instance an open instance
+type Instance struct {
+	// Empty for instances shared by all users
+	UserID string
+	Varnam *govarnam.Varnam
+}
+
+// An instance of a user, for a scheme
+type userInstance struct {
+	key    string
+	userID string
+	varnam *govarnam.Varnam
+
+	// Requests using the instance now. Not closed till it's 0
+	users int
+
+	element *list.Element
+}
+
+// Manager opens instances and keeps them open till Close.
+// The zero value is ready to use
+type Manager struct {
+	mutex  sync.Mutex
+	shared map[string]*govarnam.Varnam
+
+	users map[string]*userInstance
+	lru   *list.List // of *userInstance, most recently used first
+}
+
+// Get the instance for schemeID shared by all, opening it with open
+// if it isn't. govarnam.InitFromID is used if open is nil
+func (manager *Manager) Get(schemeID string, open func(schemeID string) (*govarnam.Varnam, error)) (*govarnam.Varnam, error) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if varnam, found := manager.shared[schemeID]; found {
+		return varnam, nil
+	}
+
+	if open == nil {
+		open = govarnam.InitFromID
+	}
+
+	varnam, err := open(schemeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if manager.shared == nil {
+		manager.shared = map[string]*govarnam.Varnam{}
+	}
+	manager.shared[schemeID] = varnam
+
+	return varnam, nil
+}
+
+// GetForUser get the instance of userID for schemeID, opening it with
+// open if it isn't. release should be called when done with it, it's
+// not closed by Evict till then
+func (manager *Manager) GetForUser(schemeID string, userID string, open func(schemeID string, userID string) (*govarnam.Varnam, error)) (*govarnam.Varnam, func(), error) {
+	key := userID + "/" + schemeID
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	instance, found := manager.users[key]
+	if found {
+		manager.lru.MoveToFront(instance.element)
+	} else {
+		// Opened with the lock held so that two requests
+		// don't open the same learnings together
+		varnam, err := open(schemeID, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if manager.users == nil {
+			manager.users = map[string]*userInstance{}
+			manager.lru = list.New()
+		}
+
+		instance = &userInstance{key: key, userID: userID, varnam: varnam}
+		instance.element = manager.lru.PushFront(instance)
+		manager.users[key] = instance
+	}
+
+	instance.users++
+
+	release := func() {
+		manager.mutex.Lock()
+		defer manager.mutex.Unlock()
+
+		instance.users--
+	}
+
+	return instance.varnam, release, nil
+}
+
+// Evict close least recently used user instances not in use till there
+// are only max, DefaultMaxUserInstances if max is 0. closing is called
+// with each before it's closed if it isn't nil
+func (manager *Manager) Evict(max int, closing func(varnam *govarnam.Varnam)) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if manager.lru == nil {
+		return
+	}
+
+	if max <= 0 {
+		max = DefaultMaxUserInstances
+	}
+
+	element := manager.lru.Back()
+	for element != nil && len(manager.users) > max {
+		instance := element.Value.(*userInstance)
+		element = element.Prev()
+
+		if instance.users > 0 {
+			continue
+		}
+
+		if closing != nil {
+			closing(instance.varnam)
+		}
+		instance.varnam.Close()
+		manager.lru.Remove(instance.element)
+		delete(manager.users, instance.key)
+	}
+}
+
+// Open call f with instances open now. No instance is opened or
+// closed till it returns
+func (manager *Manager) Open(f func(instances []Instance)) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	f(manager.open())
+}
+
+// Acquire get instances open now, user instances aren't closed by
+// Evict till release is called
+func (manager *Manager) Acquire() ([]Instance, func()) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	var inUse []*userInstance
+	for _, instance := range manager.users {
+		instance.users++
+		inUse = append(inUse, instance)
+	}
+
+	release := func() {
+		manager.mutex.Lock()
+		defer manager.mutex.Unlock()
+
+		for _, instance := range inUse {
+			instance.users--
+		}
+	}
+
+	return manager.open(), release
+}
+
+// Instances open now. mutex should be held
+func (manager *Manager) open() []Instance {
+	var instances []Instance
+	for _, varnam := range manager.shared {
+		instances = append(instances, Instance{"", varnam})
+	}
+	for _, instance := range manager.users {
+		instances = append(instances, Instance{instance.userID, instance.varnam})
+	}
+	return instances
+}
+
+// OpenUserInstances number of user instances open now
+func (manager *Manager) OpenUserInstances() int {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return len(manager.users)
+}
+
+// Close closes all instances opened
+func (manager *Manager) Close() error {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	var firstErr error
+	for schemeID, varnam := range manager.shared {
+		if err := varnam.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(manager.shared, schemeID)
+	}
+	for key, instance := range manager.users {
+		if err := instance.varnam.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		manager.lru.Remove(instance.element)
+		delete(manager.users, key)
+	}
+	return firstErr
+}
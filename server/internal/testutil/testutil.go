@@ -0,0 +1,65 @@
+package testutil
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+Package testutil has fixtures shared by tests of servers. Servers in
+tests serve the Hindi scheme in scheme-sources/ as "hi" :
+
+	server := NewServer()
+	server.Init = testutil.Init(t)
+*/
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/schemecompile"
+)
+
+// Compile hi.toml into a directory removed at the end of test
+func compileHindi(t *testing.T) (dir string, vstPath string) {
+	t.Helper()
+
+	_, file, _, _ := runtime.Caller(0)
+	sourcePath := filepath.Join(filepath.Dir(file), "..", "..", "..", "scheme-sources", "hi.toml")
+
+	dir = t.TempDir()
+	vstPath = filepath.Join(dir, "hi.vst")
+
+	if err := schemecompile.CompileFile(sourcePath, vstPath); err != nil {
+		t.Fatal(err)
+	}
+	return dir, vstPath
+}
+
+// Init makes instances of "hi", with one learnings for all
+func Init(t *testing.T) func(schemeID string) (*govarnam.Varnam, error) {
+	dir, vstPath := compileHindi(t)
+
+	return func(schemeID string) (*govarnam.Varnam, error) {
+		if schemeID != "hi" {
+			return nil, errors.New("Couldn't find VST")
+		}
+		return govarnam.Init(vstPath, filepath.Join(dir, "hi.vst.learnings"))
+	}
+}
+
+// InitForUser makes instances of "hi", with learnings of each user apart
+func InitForUser(t *testing.T) func(schemeID string, userID string) (*govarnam.Varnam, error) {
+	dir, vstPath := compileHindi(t)
+
+	return func(schemeID string, userID string) (*govarnam.Varnam, error) {
+		if schemeID != "hi" {
+			return nil, errors.New("Couldn't find VST")
+		}
+		return govarnam.Init(vstPath, filepath.Join(dir, "users", userID, "hi.vst.learnings"))
+	}
+}
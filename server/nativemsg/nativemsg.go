@@ -0,0 +1,122 @@
+package nativemsg
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+Package nativemsg serves govarnam to browser extensions as a native
+messaging host of Chrome & Firefox. The browser starts the host and
+talks to it over stdin & stdout. Each message is JSON, prefixed by its
+length as a 32-bit unsigned integer in native byte order (little endian
+on the platforms browsers run on).
+
+Messages are Request & Response of package server/socket. Requests
+are answered in order :
+
+	{"id": 1, "method": "transliterate_sentence", "scheme": "ml", "text": "ente peru"}
+	{"id": 1, "result": "എന്റെ പെരു"}
+
+	{"id": 2, "method": "learn", "scheme": "ml", "word": "പേര്"}
+	{"id": 2, "result": true}
+*/
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/varnamproject/govarnam/server/socket"
+)
+
+// MaxResponseSize longest message browsers accept from a host
+const MaxResponseSize = 1 << 20
+
+// Longest request accepted. Browsers can send up to 4 GB, documents
+// to transliterate are a lot smaller
+const maxRequestSize = 1 << 24
+
+// errTooBig is given for a request longer than maxRequestSize.
+// It's skipped, so that the next one can be read
+var errTooBig = errors.New("Message is too big")
+
+// Read a message. io.EOF when the browser has closed stdin
+func readMessage(r io.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+		return nil, err
+	}
+
+	if length > maxRequestSize {
+		if _, err := io.CopyN(ioutil.Discard, r, int64(length)); err != nil {
+			return nil, err
+		}
+		return nil, errTooBig
+	}
+
+	message := make([]byte, length)
+	if _, err := io.ReadFull(r, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func writeMessage(w io.Writer, message []byte) error {
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(message))); err != nil {
+		return err
+	}
+	_, err := w.Write(message)
+	return err
+}
+
+// Serve answer requests read from r, writing responses to w. Returns
+// nil when r ends, browsers close stdin when the extension disconnects
+func Serve(ctx context.Context, server *socket.Server, r io.Reader, w io.Writer) error {
+	for {
+		message, err := readMessage(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil && err != errTooBig {
+			return err
+		}
+
+		var (
+			req  socket.Request
+			resp socket.Response
+		)
+		if err == errTooBig {
+			resp.Error = fmt.Sprintf("Request is longer than %d bytes", maxRequestSize)
+		} else if err := json.Unmarshal(message, &req); err != nil {
+			resp.Error = fmt.Sprintf("Invalid request: %s", err)
+		} else {
+			resp = server.Handle(ctx, req)
+		}
+
+		data, err := json.Marshal(resp)
+		if err != nil {
+			return err
+		}
+
+		// Browser would disconnect on getting it
+		if len(data) > MaxResponseSize {
+			data, err = json.Marshal(socket.Response{
+				ID:    req.ID,
+				Error: fmt.Sprintf("Response is longer than %d bytes, send smaller requests", MaxResponseSize),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := writeMessage(w, data); err != nil {
+			return err
+		}
+	}
+}
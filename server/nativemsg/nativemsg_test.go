@@ -0,0 +1,81 @@
+package nativemsg
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/varnamproject/govarnam/server/internal/testutil"
+	"github.com/varnamproject/govarnam/server/socket"
+)
+
+// Server with instances of testutil.Init
+func makeServer(t *testing.T) *socket.Server {
+	server := socket.NewServer()
+	server.Init = testutil.Init(t)
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return server
+}
+
+func TestServe(t *testing.T) {
+	server := makeServer(t)
+
+	var input bytes.Buffer
+	for _, message := range []string{
+		`{"id": 1, "method": "transliterate_sentence", "scheme": "hi", "text": "namaste dost"}`,
+		`{"id": 2, "method": "learn", "scheme": "hi", "word": "नमस्ते"}`,
+		`not json`,
+	} {
+		if err := writeMessage(&input, []byte(message)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Too big, skipped
+	binary.Write(&input, binary.LittleEndian, uint32(maxRequestSize+1))
+	input.Write(make([]byte, maxRequestSize+1))
+
+	writeMessage(&input, []byte(`{"id": 3, "method": "schemes"}`))
+
+	var output bytes.Buffer
+	if err := Serve(context.Background(), server, &input, &output); err != nil {
+		t.Fatal(err)
+	}
+
+	var responses []socket.Response
+	for output.Len() > 0 {
+		message, err := readMessage(&output)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var resp socket.Response
+		if err := json.Unmarshal(message, &resp); err != nil {
+			t.Fatal(err)
+		}
+		responses = append(responses, resp)
+	}
+
+	if len(responses) != 5 {
+		t.Fatalf("Expected 5 responses, got %d", len(responses))
+	}
+
+	if sentence, _ := responses[0].Result.(string); string(responses[0].ID) != "1" || !strings.HasPrefix(sentence, "नमस्ते ") {
+		t.Errorf("Expected sentence starting with नमस्ते, got %+v", responses[0])
+	}
+	if responses[1].Result != true {
+		t.Errorf("Expected learn to work, got %+v", responses[1])
+	}
+	if responses[2].Error == "" || responses[3].Error == "" {
+		t.Errorf("Expected errors for invalid & too big requests, got %+v %+v", responses[2], responses[3])
+	}
+	if string(responses[4].ID) != "3" {
+		t.Errorf("Expected requests after a too big one to be answered, got %+v", responses[4])
+	}
+}
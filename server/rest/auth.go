@@ -0,0 +1,57 @@
+package rest
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+)
+
+// APIKeyHeader header to send the API key in for write endpoints
+const APIKeyHeader = "X-API-Key"
+
+// APIKeys allow write requests having one of keys in the X-API-Key
+// header. For Handler.WriteAuth
+func APIKeys(keys ...string) func(r *http.Request) error {
+	// Hashes are compared so that comparison takes the same time
+	// whatever the length of the given key is
+	hashes := make([][sha256.Size]byte, len(keys))
+	for i, key := range keys {
+		hashes[i] = sha256.Sum256([]byte(key))
+	}
+
+	return func(r *http.Request) error {
+		given := r.Header.Get(APIKeyHeader)
+		if given == "" {
+			return &httpError{http.StatusUnauthorized, errors.New("API key is required, send it in " + APIKeyHeader + " header")}
+		}
+		givenHash := sha256.Sum256([]byte(given))
+
+		valid := 0
+		for _, hash := range hashes {
+			valid |= subtle.ConstantTimeCompare(givenHash[:], hash[:])
+		}
+
+		if valid != 1 {
+			return &httpError{http.StatusForbidden, errors.New("Invalid API key")}
+		}
+		return nil
+	}
+}
+
+// Check whether r can change learnings
+func (handler *Handler) authorizeWrite(r *http.Request) error {
+	if handler.ReadOnly {
+		return &httpError{http.StatusForbidden, errors.New("Server is read only")}
+	}
+	if handler.WriteAuth != nil {
+		return handler.WriteAuth(r)
+	}
+	return nil
+}
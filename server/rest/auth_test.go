@@ -0,0 +1,41 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWriteAuth(t *testing.T) {
+	handler := makeHandler(t)
+	handler.WriteAuth = APIKeys("key1", "key2")
+
+	body := `{"scheme": "hi", "word": "नमस्कार"}`
+
+	var errResp errorResponse
+	status := requestWithHeader(t, handler, http.MethodPost, "/learn", body, "", "", &errResp)
+	if status != http.StatusUnauthorized || errResp.Error == "" {
+		t.Errorf("Expected 401 without API key, got %d", status)
+	}
+
+	status = requestWithHeader(t, handler, http.MethodPost, "/train", body, APIKeyHeader, "wrong", &errResp)
+	if status != http.StatusForbidden {
+		t.Errorf("Expected 403 with wrong API key, got %d", status)
+	}
+
+	var success successResponse
+	status = requestWithHeader(t, handler, http.MethodPost, "/learn", body, APIKeyHeader, "key2", &success)
+	if status != http.StatusOK || !success.Success {
+		t.Errorf("Expected learning with API key to work, got %d", status)
+	}
+
+	status = requestWithHeader(t, handler, http.MethodPost, "/unlearn", body, APIKeyHeader, "key1", &success)
+	if status != http.StatusOK {
+		t.Errorf("Expected unlearning with API key to work, got %d", status)
+	}
+
+	// Transliteration stays public
+	status = requestWithHeader(t, handler, http.MethodGet, "/tl/hi/namaste", "", "", "", nil)
+	if status != http.StatusOK {
+		t.Errorf("Expected transliteration without API key to work, got %d", status)
+	}
+}
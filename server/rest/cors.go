@@ -0,0 +1,64 @@
+package rest
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Request headers allowed from browsers along with Handler.AllowedHeaders
+var defaultAllowedHeaders = []string{"Content-Type", "Authorization", APIKeyHeader}
+
+// How long browsers can cache a preflight response, in seconds
+const corsMaxAge = 600
+
+func (handler *Handler) isOriginAllowed(origin string) bool {
+	for _, allowed := range handler.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sets CORS headers for requests from allowed origins. Returns
+// true if r was a preflight request, which is answered then
+func (handler *Handler) cors(w http.ResponseWriter, r *http.Request) bool {
+	if len(handler.AllowedOrigins) == 0 {
+		return false
+	}
+
+	// Responses differ by origin, caches should know
+	w.Header().Add("Vary", "Origin")
+
+	origin := r.Header.Get("Origin")
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+	if origin == "" || !handler.isOriginAllowed(origin) {
+		if preflight {
+			// Without CORS headers the browser won't make the request
+			w.WriteHeader(http.StatusNoContent)
+		}
+		return preflight
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if !preflight {
+		return false
+	}
+
+	headers := append(append([]string{}, defaultAllowedHeaders...), handler.AllowedHeaders...)
+
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+	w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+	w.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+	w.WriteHeader(http.StatusNoContent)
+
+	return true
+}
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCORS(t *testing.T) {
+	handler := makeHandler(t)
+	handler.AllowedOrigins = []string{"https://example.com"}
+	handler.AllowedHeaders = []string{"X-Varnam-User"}
+
+	req := httptest.NewRequest(http.MethodOptions, "/learn", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 for preflight, got %d", rec.Code)
+	}
+	if rec.Header().Get("Access-Control-Allow-Origin") != "https://example.com" {
+		t.Errorf("Expected origin to be allowed, got %q", rec.Header().Get("Access-Control-Allow-Origin"))
+	}
+	if !strings.Contains(rec.Header().Get("Access-Control-Allow-Headers"), "X-Varnam-User") {
+		t.Errorf("Expected X-Varnam-User in allowed headers, got %q", rec.Header().Get("Access-Control-Allow-Headers"))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/tl/hi/namaste", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Access-Control-Allow-Origin") != "https://example.com" {
+		t.Errorf("Expected CORS header on response, got %d %q", rec.Code, rec.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/tl/hi/namaste", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected other origins to not be allowed")
+	}
+
+	handler.AllowedOrigins = []string{"*"}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get("Access-Control-Allow-Origin") != "https://evil.example" {
+		t.Errorf("Expected any origin to be allowed with *")
+	}
+}
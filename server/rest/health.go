@@ -0,0 +1,126 @@
+package rest
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+)
+
+// HealthResponse body of /healthz
+type HealthResponse struct {
+	// "ok" or "unavailable"
+	Status string   `json:"status"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// InstanceStatus status of an open instance in /status
+type InstanceStatus struct {
+	// Empty for instances shared by all users
+	User string `json:"user,omitempty"`
+
+	govarnam.InstanceStatus
+	SymbolCacheHitRate float64
+}
+
+// StatusResponse body of /status
+type StatusResponse struct {
+	Healthy       bool
+	StartedAt     time.Time
+	UptimeSeconds int64
+	Uptime        string
+
+	Instances        []InstanceStatus
+	MaxUserInstances int `json:",omitempty"`
+}
+
+// Instances open now, kept from being closed till release
+func (handler *Handler) openInstances() ([]instances.Instance, func()) {
+	open, release := handler.instances.Acquire()
+
+	return open, func() {
+		release()
+		handler.evictUserInstances()
+	}
+}
+
+// Liveness & readiness. Opens HealthCheckSchemes & checks
+// whether all open instances are usable
+func (handler *Handler) health(r *http.Request) (int, HealthResponse) {
+	response := HealthResponse{Status: "ok"}
+
+	// Schemes of user instances are opened on requests of users
+	if handler.User == nil {
+		for _, schemeID := range handler.HealthCheckSchemes {
+			if _, err := handler.getSharedInstance(schemeID); err != nil {
+				response.Errors = append(response.Errors, schemeID+": "+err.Error())
+			}
+		}
+	}
+
+	instances, release := handler.openInstances()
+	defer release()
+
+	for _, instance := range instances {
+		status := instance.Varnam.Status(r.Context())
+		if status.SchemeError != "" {
+			response.Errors = append(response.Errors, status.SchemeID+": "+status.SchemeError)
+		}
+		if status.DictionaryError != "" {
+			response.Errors = append(response.Errors, status.SchemeID+": "+status.DictionaryError)
+		}
+	}
+
+	if len(response.Errors) > 0 {
+		response.Status = "unavailable"
+		return http.StatusServiceUnavailable, response
+	}
+	return http.StatusOK, response
+}
+
+func (handler *Handler) status(r *http.Request) StatusResponse {
+	response := StatusResponse{
+		Healthy:   true,
+		StartedAt: handler.started,
+	}
+
+	if !handler.started.IsZero() {
+		uptime := time.Since(handler.started)
+		response.UptimeSeconds = int64(uptime.Seconds())
+		response.Uptime = uptime.Round(time.Second).String()
+	}
+
+	instances, release := handler.openInstances()
+	defer release()
+
+	for _, instance := range instances {
+		status := instance.Varnam.Status(r.Context())
+		response.Instances = append(response.Instances, InstanceStatus{instance.UserID, status, status.SymbolCacheHitRate()})
+		response.Healthy = response.Healthy && status.Healthy()
+	}
+
+	sort.Slice(response.Instances, func(i, j int) bool {
+		a, b := response.Instances[i], response.Instances[j]
+		if a.User != b.User {
+			return a.User < b.User
+		}
+		return a.SchemeID < b.SchemeID
+	})
+
+	if handler.User != nil {
+		response.MaxUserInstances = handler.MaxUserInstances
+		if response.MaxUserInstances <= 0 {
+			response.MaxUserInstances = DefaultMaxUserInstances
+		}
+	}
+
+	return response
+}
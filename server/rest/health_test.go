@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHealth(t *testing.T) {
+	handler := makeHandler(t)
+	handler.HealthCheckSchemes = []string{"hi"}
+
+	var health HealthResponse
+	status := request(t, handler, http.MethodGet, "/healthz", "", &health)
+	if status != http.StatusOK || health.Status != "ok" {
+		t.Errorf("Expected healthy, got %d %v", status, health)
+	}
+
+	var resp StatusResponse
+	status = request(t, handler, http.MethodGet, "/status", "", &resp)
+	if status != http.StatusOK || !resp.Healthy || resp.StartedAt.IsZero() {
+		t.Fatalf("Expected status, got %d %v", status, resp)
+	}
+	if len(resp.Instances) != 1 || resp.Instances[0].SchemeID != "hi" || !resp.Instances[0].DictionaryReachable {
+		t.Errorf("Expected status of hi instance, got %v", resp.Instances)
+	}
+
+	handler.HealthCheckSchemes = []string{"xx"}
+	health = HealthResponse{}
+	status = request(t, handler, http.MethodGet, "/healthz", "", &health)
+	if status != http.StatusServiceUnavailable || health.Status != "unavailable" || len(health.Errors) != 1 {
+		t.Errorf("Expected unavailable for unknown scheme, got %d %v", status, health)
+	}
+
+	// Status needs credentials of write endpoints
+	handler.WriteAuth = APIKeys("key")
+	status = request(t, handler, http.MethodGet, "/status", "", nil)
+	if status != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for status without API key, got %d", status)
+	}
+}
+
+func TestUsersStatus(t *testing.T) {
+	handler := makeUsersHandler(t)
+
+	requestWithHeader(t, handler, http.MethodGet, "/tl/hi/namaste", "", "X-Varnam-User", "alice", nil)
+
+	var resp StatusResponse
+	request(t, handler, http.MethodGet, "/status", "", &resp)
+	if len(resp.Instances) != 1 || resp.Instances[0].User != "alice" || resp.MaxUserInstances != DefaultMaxUserInstances {
+		t.Errorf("Expected status of alice's instance, got %v", resp)
+	}
+}
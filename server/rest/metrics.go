@@ -0,0 +1,332 @@
+package rest
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+)
+
+// Upper bounds of latency histogram buckets, in seconds
+var latencyBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}
+
+// Paths counted separately in varnam_http_requests_total.
+// Others are counted as "other" so that labels don't grow unbounded
+var metricsEndpoints = map[string]bool{
+	"tl": true, "rtl": true, "learn": true, "train": true, "unlearn": true,
+	"stream": true, "schemes": true, "healthz": true, "status": true,
+	"metrics": true, "openapi.yaml": true,
+}
+
+type histogram struct {
+	counts []uint64 // of each bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+func (h *histogram) observe(value float64) {
+	if h.counts == nil {
+		h.counts = make([]uint64, len(latencyBuckets))
+	}
+	for i, bound := range latencyBuckets {
+		if value <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+// Label values joined by this as map keys
+const labelSeparator = "\x00"
+
+// Metrics collected by a Handler, in Prometheus text format at /metrics
+type metrics struct {
+	mutex sync.Mutex
+
+	// endpoint, code
+	requests map[string]uint64
+
+	// scheme
+	transliterations map[string]*histogram
+	queries          map[string]uint64
+
+	// scheme, stage
+	stageDurations map[string]*histogram
+	suggestions    map[string]uint64
+
+	// scheme, action, result
+	learns map[string]uint64
+
+	// scheme. Cache hits & misses of closed instances
+	closedCacheHits   map[string]int64
+	closedCacheMisses map[string]int64
+}
+
+func (m *metrics) init() {
+	if m.requests == nil {
+		m.requests = map[string]uint64{}
+		m.transliterations = map[string]*histogram{}
+		m.queries = map[string]uint64{}
+		m.stageDurations = map[string]*histogram{}
+		m.suggestions = map[string]uint64{}
+		m.learns = map[string]uint64{}
+		m.closedCacheHits = map[string]int64{}
+		m.closedCacheMisses = map[string]int64{}
+	}
+}
+
+func observeIn(histograms map[string]*histogram, key string, value float64) {
+	h, found := histograms[key]
+	if !found {
+		h = &histogram{}
+		histograms[key] = h
+	}
+	h.observe(value)
+}
+
+func labelKey(values ...string) string {
+	return strings.Join(values, labelSeparator)
+}
+
+func (m *metrics) countRequest(path string, code int) {
+	endpoint := strings.SplitN(path, "/", 2)[0]
+	if !metricsEndpoints[endpoint] {
+		endpoint = "other"
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.init()
+
+	m.requests[labelKey(endpoint, strconv.Itoa(code))]++
+}
+
+func (m *metrics) observeTransliteration(scheme string, duration time.Duration, result govarnam.TransliterationResult) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.init()
+
+	observeIn(m.transliterations, scheme, duration.Seconds())
+
+	stages := map[string][]govarnam.Suggestion{
+		"exact_words":        result.ExactWords,
+		"exact_matches":      result.ExactMatches,
+		"dictionary":         result.DictionarySuggestions,
+		"pattern_dictionary": result.PatternDictionarySuggestions,
+		"tokenizer":          result.TokenizerSuggestions,
+		"greedy_tokenized":   result.GreedyTokenized,
+		"fuzzy":              result.FuzzySuggestions,
+		"corrections":        result.Corrections,
+	}
+	for stage, sugs := range stages {
+		m.suggestions[labelKey(scheme, stage)] += uint64(len(sugs))
+	}
+
+	if d := result.Diagnostics; d != nil {
+		m.queries[scheme] += uint64(d.Queries)
+
+		// Durations of stages that ran, from the start of transliteration
+		stageDurations := map[string]time.Duration{
+			"dictionary":         d.Dictionary,
+			"pattern_dictionary": d.PatternDictionary,
+			"greedy_tokenized":   d.GreedyTokenized,
+			"tokenizer":          d.Tokenizer,
+		}
+		for stage, duration := range stageDurations {
+			if duration > 0 {
+				observeIn(m.stageDurations, labelKey(scheme, stage), duration.Seconds())
+			}
+		}
+	}
+}
+
+func (m *metrics) countLearn(scheme string, action string, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.init()
+
+	m.learns[labelKey(scheme, action, result)]++
+}
+
+// Keep cache stats of an instance being closed
+func (m *metrics) instanceClosed(varnam *govarnam.Varnam) {
+	hits, misses := varnam.SymbolCacheStats()
+	scheme := varnam.SchemeDetails.Identifier
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.init()
+
+	m.closedCacheHits[scheme] += hits
+	m.closedCacheMisses[scheme] += misses
+}
+
+// Writes "name{labels} value" lines of values sorted by labels
+func writeSamples(w io.Writer, name string, labelNames []string, values map[string]string) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s%s %s\n", name, formatLabels(labelNames, strings.Split(key, labelSeparator)), values[key])
+	}
+}
+
+func formatLabels(names []string, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + strconv.Quote(values[i])
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func writeCounters(w io.Writer, name string, help string, labelNames []string, counters map[string]uint64) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+
+	values := map[string]string{}
+	for key, count := range counters {
+		values[key] = strconv.FormatUint(count, 10)
+	}
+	writeSamples(w, name, labelNames, values)
+}
+
+func writeHistograms(w io.Writer, name string, help string, labelNames []string, histograms map[string]*histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+
+	keys := make([]string, 0, len(histograms))
+	for key := range histograms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		h := histograms[key]
+		values := strings.Split(key, labelSeparator)
+
+		var cumulative uint64
+		for i, bound := range latencyBuckets {
+			cumulative += h.counts[i]
+			labels := formatLabels(append(labelNames, "le"), append(values, strconv.FormatFloat(bound, 'g', -1, 64)))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", name, labels, cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(append(labelNames, "le"), append(values, "+Inf")), h.count)
+		fmt.Fprintf(w, "%s_sum%s %g\n", name, formatLabels(labelNames, values), h.sum)
+		fmt.Fprintf(w, "%s_count%s %d\n", name, formatLabels(labelNames, values), h.count)
+	}
+}
+
+// Cache hits & misses of each scheme, of open & closed instances.
+// Also gives number of user instances open
+func (handler *Handler) symbolCacheCounts() (map[string]uint64, map[string]uint64, int) {
+	hits := map[string]uint64{}
+	misses := map[string]uint64{}
+	openUserInstances := 0
+
+	add := func(varnam *govarnam.Varnam) {
+		h, m := varnam.SymbolCacheStats()
+		hits[varnam.SchemeDetails.Identifier] += uint64(h)
+		misses[varnam.SchemeDetails.Identifier] += uint64(m)
+	}
+
+	// No instance is closed till this is done, so that one
+	// being closed now isn't counted as both open & closed
+	handler.instances.Open(func(open []instances.Instance) {
+		for _, instance := range open {
+			add(instance.Varnam)
+			if instance.UserID != "" {
+				openUserInstances++
+			}
+		}
+
+		handler.metrics.mutex.Lock()
+		defer handler.metrics.mutex.Unlock()
+
+		for scheme, count := range handler.metrics.closedCacheHits {
+			hits[scheme] += uint64(count)
+		}
+		for scheme, count := range handler.metrics.closedCacheMisses {
+			misses[scheme] += uint64(count)
+		}
+	})
+
+	return hits, misses, openUserInstances
+}
+
+// Serves metrics in Prometheus text format
+func (handler *Handler) serveMetrics(w http.ResponseWriter) {
+	m := &handler.metrics
+
+	hits, misses, openUserInstances := handler.symbolCacheCounts()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.init()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	writeCounters(w, "varnam_http_requests_total", "HTTP requests by endpoint and status code.", []string{"endpoint", "code"}, m.requests)
+	writeHistograms(w, "varnam_transliteration_duration_seconds", "Time taken to transliterate a word.", []string{"scheme"}, m.transliterations)
+	writeHistograms(w, "varnam_stage_duration_seconds", "Time from start of transliteration till a stage finished.", []string{"scheme", "stage"}, m.stageDurations)
+	writeCounters(w, "varnam_suggestions_total", "Suggestions given by each stage.", []string{"scheme", "stage"}, m.suggestions)
+	writeCounters(w, "varnam_queries_total", "VST and learnings database queries made for transliterations.", []string{"scheme"}, m.queries)
+	writeCounters(w, "varnam_learn_requests_total", "Learn, train and unlearn requests by result.", []string{"scheme", "action", "result"}, m.learns)
+
+	writeCounters(w, "varnam_symbol_cache_hits_total", "VST lookups reused from cache of sessions and batches.", []string{"scheme"}, hits)
+	writeCounters(w, "varnam_symbol_cache_misses_total", "VST lookups not found in cache of sessions and batches.", []string{"scheme"}, misses)
+
+	fmt.Fprintf(w, "# HELP varnam_user_instances Instances of users open now.\n# TYPE varnam_user_instances gauge\nvarnam_user_instances %d\n", openUserInstances)
+	fmt.Fprintf(w, "# HELP varnam_uptime_seconds Time since server started.\n# TYPE varnam_uptime_seconds gauge\nvarnam_uptime_seconds %d\n", int64(time.Since(handler.started).Seconds()))
+}
+
+// Records status code written, for varnam_http_requests_total
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(data []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.ResponseWriter.Write(data)
+}
+
+// Flush for event streams
+func (rec *statusRecorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,45 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	handler := makeHandler(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for /metrics when not enabled, got %d", rec.Code)
+	}
+
+	handler = makeHandler(t)
+	handler.Metrics = true
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tl/hi/namaste", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/learn", bytes.NewBufferString(`{"scheme": "hi", "word": "नमस्कार"}`)))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for /metrics, got %d", rec.Code)
+	}
+
+	body := rec.Body.String()
+	for _, expected := range []string{
+		`varnam_http_requests_total{endpoint="tl",code="200"} 1`,
+		`varnam_http_requests_total{endpoint="learn",code="200"} 1`,
+		`varnam_transliteration_duration_seconds_count{scheme="hi"} 1`,
+		`varnam_transliteration_duration_seconds_bucket{scheme="hi",le="+Inf"} 1`,
+		`varnam_learn_requests_total{scheme="hi",action="learn",result="ok"} 1`,
+		`# TYPE varnam_symbol_cache_hits_total counter`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected %q in metrics, got:\n%s", expected, body)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package rest
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit requests allowed in a second on average, with bursts of
+// up to Burst requests. Zero PerSecond is no limit
+type RateLimit struct {
+	PerSecond float64
+	Burst     int
+}
+
+// How often buckets of clients that aren't limited anymore are forgotten
+const rateLimitSweepInterval = time.Minute
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Take a token if there's one. Else gives how long till there's one
+func (bucket *tokenBucket) take(limit RateLimit, now time.Time) (bool, time.Duration) {
+	burst := math.Max(float64(limit.Burst), 1)
+
+	if bucket.last.IsZero() {
+		bucket.tokens = burst
+	} else {
+		bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.last).Seconds()*limit.PerSecond)
+	}
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - bucket.tokens) / limit.PerSecond * float64(time.Second))
+	return false, wait
+}
+
+type rateLimiter struct {
+	mutex sync.Mutex
+
+	global  tokenBucket
+	clients map[string]*tokenBucket
+	writes  map[string]*tokenBucket
+
+	lastSweep time.Time
+}
+
+// rateLimitError is given when a client is over the limit
+type rateLimitError struct {
+	retryAfter time.Duration
+}
+
+func (e *rateLimitError) Error() string {
+	return "Too many requests, retry after " + strconv.Itoa(e.retryAfterSeconds()) + " seconds"
+}
+
+func (e *rateLimitError) retryAfterSeconds() int {
+	return int(math.Ceil(e.retryAfter.Seconds()))
+}
+
+// Take a token from the bucket of key in buckets
+func takeFromBucket(buckets map[string]*tokenBucket, key string, limit RateLimit, now time.Time) error {
+	if limit.PerSecond <= 0 {
+		return nil
+	}
+
+	bucket, found := buckets[key]
+	if !found {
+		bucket = &tokenBucket{}
+		buckets[key] = bucket
+	}
+
+	if ok, wait := bucket.take(limit, now); !ok {
+		return &rateLimitError{wait}
+	}
+	return nil
+}
+
+// Forget buckets that would've been refilled fully by now,
+// they are same as new ones
+func (limiter *rateLimiter) sweep(handler *Handler, now time.Time) {
+	if now.Sub(limiter.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	limiter.lastSweep = now
+
+	sweep := func(buckets map[string]*tokenBucket, limit RateLimit) {
+		if limit.PerSecond <= 0 {
+			return
+		}
+		refillTime := time.Duration(math.Max(float64(limit.Burst), 1) / limit.PerSecond * float64(time.Second))
+		for key, bucket := range buckets {
+			if now.Sub(bucket.last) >= refillTime {
+				delete(buckets, key)
+			}
+		}
+	}
+	sweep(limiter.clients, handler.ClientRateLimit)
+	sweep(limiter.writes, handler.ClientWriteRateLimit)
+}
+
+// The client a request is from. Its IP address if
+// Handler.ClientID isn't set
+func (handler *Handler) clientOf(r *http.Request) string {
+	if handler.ClientID != nil {
+		return handler.ClientID(r)
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Check rate limits for r. write is whether r changes learnings
+func (handler *Handler) checkRateLimit(r *http.Request, write bool) error {
+	limiter := &handler.rateLimiter
+	now := time.Now()
+	client := handler.clientOf(r)
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	if limiter.clients == nil {
+		limiter.clients = map[string]*tokenBucket{}
+		limiter.writes = map[string]*tokenBucket{}
+	}
+
+	limiter.sweep(handler, now)
+
+	if write {
+		if err := takeFromBucket(limiter.writes, client, handler.ClientWriteRateLimit, now); err != nil {
+			return err
+		}
+	}
+
+	if err := takeFromBucket(limiter.clients, client, handler.ClientRateLimit, now); err != nil {
+		return err
+	}
+
+	if handler.GlobalRateLimit.PerSecond > 0 {
+		if ok, wait := limiter.global.take(handler.GlobalRateLimit, now); !ok {
+			return &rateLimitError{wait}
+		}
+	}
+
+	return nil
+}
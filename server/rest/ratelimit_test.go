@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	limit := RateLimit{PerSecond: 2, Burst: 3}
+	now := time.Now()
+
+	var bucket tokenBucket
+	for i := 0; i < 3; i++ {
+		if ok, _ := bucket.take(limit, now); !ok {
+			t.Fatalf("Expected burst of 3 to be allowed, failed at %d", i+1)
+		}
+	}
+
+	ok, wait := bucket.take(limit, now)
+	if ok || wait != 500*time.Millisecond {
+		t.Errorf("Expected to wait 500ms after burst, got %v %v", ok, wait)
+	}
+
+	if ok, _ := bucket.take(limit, now.Add(500*time.Millisecond)); !ok {
+		t.Errorf("Expected a token after 500ms")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	handler := makeHandler(t)
+	handler.ClientWriteRateLimit = RateLimit{PerSecond: 0.01, Burst: 1}
+
+	learn := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/learn", strings.NewReader(`{"scheme": "hi", "word": "नमस्कार"}`))
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := learn("192.0.2.1:1234"); rec.Code != http.StatusOK {
+		t.Fatalf("Expected first learn to work, got %d", rec.Code)
+	}
+
+	rec := learn("192.0.2.1:5678")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 for second learn, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "100" {
+		t.Errorf("Expected Retry-After 100, got %q", rec.Header().Get("Retry-After"))
+	}
+
+	if rec := learn("192.0.2.2:1234"); rec.Code != http.StatusOK {
+		t.Errorf("Expected learn from another client to work, got %d", rec.Code)
+	}
+
+	// Only writes are limited
+	req := httptest.NewRequest(http.MethodGet, "/tl/hi/namaste", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected transliteration to not be limited, got %d", rec.Code)
+	}
+
+	handler.GlobalRateLimit = RateLimit{PerSecond: 0.01, Burst: 1}
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 over global limit, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,77 @@
+package rest
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+)
+
+// Reload change settings of the handler while serving, without
+// closing instances & losing their caches. update is called when
+// no request is being served, it can change any exported field of
+// handler. Configure is called again on open instances after that,
+// and their cached results are dropped.
+// Instances of schemes left out of Schemes are kept open till Close
+func (handler *Handler) Reload(update func(handler *Handler) error) error {
+	handler.configMutex.Lock()
+	defer handler.configMutex.Unlock()
+
+	handler.mutex.Lock()
+	// Rate limits are read with only this held
+	handler.rateLimiter.mutex.Lock()
+	err := update(handler)
+	handler.rateLimiter.mutex.Unlock()
+	handler.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	handler.instances.Open(func(open []instances.Instance) {
+		for _, instance := range open {
+			handler.setUpInstance(instance.Varnam)
+
+			// Cached results were ranked & limited with old settings
+			instance.Varnam.ClearResultCache()
+		}
+	})
+
+	// MaxUserInstances may have gone down
+	handler.evictUserInstances()
+
+	return nil
+}
+
+// Whether schemeID is one of Handler.Schemes
+func (handler *Handler) schemeEnabled(schemeID string) bool {
+	if len(handler.Schemes) == 0 {
+		return true
+	}
+	for _, enabled := range handler.Schemes {
+		if enabled == schemeID {
+			return true
+		}
+	}
+	return false
+}
+
+// Details of schemes served
+func (handler *Handler) schemes() ([]govarnam.SchemeDetails, error) {
+	all, err := govarnam.GetAllSchemeDetails()
+	if err != nil || len(handler.Schemes) == 0 {
+		return all, err
+	}
+
+	schemes := []govarnam.SchemeDetails{}
+	for _, scheme := range all {
+		if handler.schemeEnabled(scheme.Identifier) {
+			schemes = append(schemes, scheme)
+		}
+	}
+	return schemes, nil
+}
@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+func TestReload(t *testing.T) {
+	handler := makeHandler(t)
+
+	limit := 3
+	handler.Configure = func(varnam *govarnam.Varnam) {
+		varnam.TokenizerSuggestionsLimit = limit
+	}
+
+	var result govarnam.TransliterationResult
+	if code := request(t, handler, http.MethodGet, "/tl/hi/namaste", "", &result); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+
+	varnam, err := handler.getSharedInstance("hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if varnam.TokenizerSuggestionsLimit != 3 {
+		t.Errorf("Expected Configure to be called on open, limit is %d", varnam.TokenizerSuggestionsLimit)
+	}
+
+	// Requests going on while reloading
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tl/hi/namaskaar", nil))
+			}
+		}()
+	}
+
+	err = handler.Reload(func(handler *Handler) error {
+		limit = 7
+		handler.Schemes = []string{"ml"}
+		handler.ClientRateLimit = RateLimit{PerSecond: 100, Burst: 100}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	if open, _ := handler.getSharedInstance("hi"); open != varnam {
+		t.Errorf("Expected instance to be kept open on reload")
+	}
+	if varnam.TokenizerSuggestionsLimit != 7 {
+		t.Errorf("Expected Configure to be called on reload, limit is %d", varnam.TokenizerSuggestionsLimit)
+	}
+
+	if code := request(t, handler, http.MethodGet, "/tl/hi/namaste", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for scheme not served, got %d", code)
+	}
+
+	err = handler.Reload(func(handler *Handler) error {
+		return errors.New("Invalid config")
+	})
+	if err == nil {
+		t.Errorf("Expected error of update to be given")
+	}
+}
+
+// Ranks the one with less weight first
+type lightestFirstRanker struct{}
+
+func (lightestFirstRanker) Less(a govarnam.RankCandidate, b govarnam.RankCandidate) bool {
+	return a.Weight < b.Weight
+}
+
+func TestReloadRanker(t *testing.T) {
+	handler := makeHandler(t)
+
+	var ranker govarnam.Ranker = govarnam.ConfidenceFirstRanker{}
+	handler.Configure = func(varnam *govarnam.Varnam) {
+		varnam.ResultCacheSize = 10
+		varnam.Ranker = ranker
+	}
+
+	varnam, err := handler.getSharedInstance("hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := varnam.Learn("नमस्ते", 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := varnam.Learn("नमस्कार", 20); err != nil {
+		t.Fatal(err)
+	}
+
+	words := func() []string {
+		var result govarnam.TransliterationResult
+		if code := request(t, handler, http.MethodGet, "/tl/hi/nam", "", &result); code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", code)
+		}
+
+		var words []string
+		for _, sug := range result.DictionarySuggestions {
+			words = append(words, sug.Word)
+		}
+		return words
+	}
+
+	if got := words(); len(got) != 2 || got[0] != "नमस्कार" {
+		t.Fatalf("Expected heavier नमस्कार first, got %v", got)
+	}
+
+	err = handler.Reload(func(handler *Handler) error {
+		ranker = lightestFirstRanker{}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Not the cached result ranked with the old ranker
+	if got := words(); len(got) != 2 || got[0] != "नमस्ते" {
+		t.Errorf("Expected lighter नमस्ते first after reload, got %v", got)
+	}
+}
@@ -0,0 +1,526 @@
+package rest
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+Package rest serves govarnam over HTTP with JSON, for web apps to use
+without bindings. Handler can be mounted in any net/http server :
+
+	http.Handle("/varnam/", http.StripPrefix("/varnam", rest.NewHandler()))
+
+Endpoints :
+
+	GET  /tl/{schemeID}/{word}   TransliterationResult
+	GET  /rtl/{schemeID}/{word}  Reverse transliteration, []Suggestion
+	POST /learn                  {"scheme": "ml", "word": "മലയാളം", "weight": 0}
+	POST /train                  {"scheme": "ml", "pattern": "malayalam", "word": "മലയാളം"}
+	POST /unlearn                {"scheme": "ml", "word": "മലയാളം"}
+	GET  /schemes                []SchemeDetails
+	GET  /healthz                Liveness & readiness, 503 if unhealthy
+	GET  /status                 Uptime & StatusResponse of open instances
+	GET  /metrics                Prometheus metrics, if Handler.Metrics is set
+	GET  /openapi.yaml           OpenAPI spec of the above
+
+	GET  /stream/{schemeID}              Server-Sent Events of suggestions
+	POST /stream/{schemeID}/{sessionID}  {"input": "namas"}
+
+Learnings of each user can be kept apart by setting Handler.User,
+see UserFromHeader & UserFromToken. Instances of users are opened on
+their first request and the least recently used ones are closed when
+there are more than Handler.MaxUserInstances.
+
+Set Handler.WriteAuth to require credentials like an API key
+(see APIKeys) for /learn, /train, /unlearn, /status & /metrics while keeping
+transliteration public.
+
+Browsers can call the API from pages of Handler.AllowedOrigins.
+
+Set Handler.Tracer to trace transliteration & learning of requests,
+see govarnam.Tracer.
+
+/tl takes "domain" (can be repeated) and "quick" query parameters, see
+govarnam.TransliterateOptions. Errors are given as {"error": "message"}.
+An instance is made for a scheme on its first request and is shared
+by all requests after that.
+
+/stream is for editors to show suggestions as the user types. The
+first event of the stream is "session" with data {"id": sessionID}.
+Post the input to the session on each keystroke, the server cancels
+transliteration of the previous input and sends "result" events with
+StreamEvent data for the new input, one for each stage of
+Varnam.TransliterateStaged.
+*/
+
+import (
+	_ "embed" // for openapi.yaml
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// Limit for POST bodies
+const maxBodySize = 1 << 20
+
+// Handler serves govarnam over HTTP
+type Handler struct {
+	// Makes the instance for a scheme ID. govarnam.InitFromID if nil
+	Init func(schemeID string) (*govarnam.Varnam, error)
+
+	// Disables /learn, /train & /unlearn. For public servers
+	ReadOnly bool
+
+	// Checks credentials of requests to /learn, /train & /unlearn,
+	// giving an error if not allowed. Transliteration stays public.
+	// See APIKeys. Anyone can write if nil
+	WriteAuth func(r *http.Request) error
+
+	// Origins of web pages allowed to call the API from browsers
+	// (CORS), like "https://example.com". "*" allows any
+	AllowedOrigins []string
+
+	// Limits of all requests together, requests of each client
+	// and learning, training & unlearning of each client. Requests
+	// over the limit are given 429. No limits by default
+	GlobalRateLimit      RateLimit
+	ClientRateLimit      RateLimit
+	ClientWriteRateLimit RateLimit
+
+	// Identifies the client of a request for rate limits. IP address
+	// of the request is used if nil. Set this when behind a proxy
+	ClientID func(r *http.Request) string
+
+	// Schemes /healthz opens & checks, so that a server isn't
+	// ready till these can be used. Only when User isn't set
+	HealthCheckSchemes []string
+
+	// Collect metrics of requests & transliterations, served at
+	// /metrics for Prometheus. Turns on Varnam.CollectDiagnostics
+	// of instances for stage durations
+	Metrics bool
+
+	// Set as Varnam.Tracer of instances, to trace transliteration &
+	// learning as children of the span in request's context. Wrap the
+	// handler with an OpenTelemetry HTTP middleware to start that span
+	Tracer govarnam.Tracer
+
+	// Request headers allowed from browsers in addition to
+	// Content-Type, Authorization & X-API-Key. Like the user header
+	// given to UserFromHeader
+	AllowedHeaders []string
+
+	// Schemes served, others get 404. All are if empty
+	Schemes []string
+
+	// Sets options of instances like Ranker & suggestion limits.
+	// Called when an instance is opened and on Reload
+	Configure func(varnam *govarnam.Varnam)
+
+	// Identifies the user of a request to keep learnings of each
+	// user apart. All requests share learnings if nil
+	User func(r *http.Request) (string, error)
+
+	// Makes the instance of a user, used instead of Init when User is set.
+	// If nil, learnings of a user are kept in UsersDir/{userID}
+	InitForUser func(schemeID string, userID string) (*govarnam.Varnam, error)
+
+	// See InitForUser
+	UsersDir string
+
+	// Most user instances kept open. Least recently used ones not
+	// serving a request are closed after this. 0 for DefaultMaxUserInstances
+	MaxUserInstances int
+
+	// Held for reading by requests, Reload changes settings with it held
+	configMutex sync.RWMutex
+
+	// Held by Reload while changing settings, for
+	// evicting user instances without configMutex
+	mutex sync.Mutex
+
+	instances instances.Manager
+
+	rateLimiter rateLimiter
+	metrics     metrics
+
+	started time.Time
+
+	sessionsMutex sync.Mutex
+	sessions      map[string]*streamSession
+}
+
+// LearnRequest body of /learn
+type LearnRequest struct {
+	Scheme string `json:"scheme"`
+	Word   string `json:"word"`
+	Weight int    `json:"weight"`
+}
+
+// TrainRequest body of /train
+type TrainRequest struct {
+	Scheme  string `json:"scheme"`
+	Pattern string `json:"pattern"`
+	Word    string `json:"word"`
+}
+
+// UnlearnRequest body of /unlearn
+type UnlearnRequest struct {
+	Scheme string `json:"scheme"`
+	Word   string `json:"word"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type successResponse struct {
+	Success bool `json:"success"`
+}
+
+// httpError is an error with the status code to give for it
+type httpError struct {
+	status int
+	err    error
+}
+
+func (e *httpError) Error() string {
+	return e.err.Error()
+}
+
+// NewHandler make a Handler
+func NewHandler() *Handler {
+	return &Handler{started: time.Now()}
+}
+
+// Close closes all instances made
+func (handler *Handler) Close() error {
+	return handler.instances.Close()
+}
+
+// Get the instance for schemeID, of the user making request r if
+// Handler.User is set. release should be called when done with it
+func (handler *Handler) getInstance(r *http.Request, schemeID string) (*govarnam.Varnam, func(), error) {
+	if !instances.ValidSchemeID(schemeID) {
+		return nil, nil, &httpError{http.StatusBadRequest, fmt.Errorf("Invalid scheme ID %q", schemeID)}
+	}
+	if !handler.schemeEnabled(schemeID) {
+		return nil, nil, &httpError{http.StatusNotFound, fmt.Errorf("Scheme %q isn't served", schemeID)}
+	}
+
+	if handler.User != nil {
+		userID, err := handler.User(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return handler.getUserInstance(schemeID, userID)
+	}
+
+	varnam, err := handler.getSharedInstance(schemeID)
+	return varnam, func() {}, err
+}
+
+// Instance for schemeID used by all requests
+func (handler *Handler) getSharedInstance(schemeID string) (*govarnam.Varnam, error) {
+	varnam, err := handler.instances.Get(schemeID, func(schemeID string) (*govarnam.Varnam, error) {
+		init := handler.Init
+		if init == nil {
+			init = govarnam.InitFromID
+		}
+
+		varnam, err := init(schemeID)
+		if err != nil {
+			return nil, err
+		}
+		handler.setUpInstance(varnam)
+
+		return varnam, nil
+	})
+	if err != nil {
+		return nil, &httpError{http.StatusNotFound, err}
+	}
+	return varnam, nil
+}
+
+// Set up an instance when it's opened and on Reload
+func (handler *Handler) setUpInstance(varnam *govarnam.Varnam) {
+	if handler.Metrics {
+		varnam.CollectDiagnostics = true
+	}
+	if handler.Tracer != nil {
+		varnam.Tracer = handler.Tracer
+	}
+	if handler.Configure != nil {
+		handler.Configure(varnam)
+	}
+}
+
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+
+	// Event streams let go of it once they are set up
+	handler.configMutex.RLock()
+	locked := true
+	unlock := func() {
+		if locked {
+			locked = false
+			handler.configMutex.RUnlock()
+		}
+	}
+	defer unlock()
+
+	if handler.Metrics {
+		rec := &statusRecorder{ResponseWriter: w}
+		w = rec
+		defer func() {
+			handler.metrics.countRequest(path, rec.status)
+		}()
+	}
+
+	if handler.cors(w, r) {
+		return
+	}
+
+	// Probes aren't rate limited
+	if path == "healthz" {
+		if allowMethod(w, r, http.MethodGet) {
+			status, response := handler.health(r)
+			writeJSON(w, status, response)
+		}
+		return
+	}
+
+	write := path == "learn" || path == "train" || path == "unlearn"
+	if err := handler.checkRateLimit(r, write); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+
+	switch {
+	case strings.HasPrefix(path, "tl/"):
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		result, err = handler.transliterate(r, strings.TrimPrefix(path, "tl/"))
+	case strings.HasPrefix(path, "stream/"):
+		handler.stream(w, r, strings.TrimPrefix(path, "stream/"), unlock)
+		return
+	case strings.HasPrefix(path, "rtl/"):
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		result, err = handler.reverseTransliterate(r, strings.TrimPrefix(path, "rtl/"))
+	case write:
+		if !allowMethod(w, r, http.MethodPost) {
+			return
+		}
+		if err = handler.authorizeWrite(r); err != nil {
+			break
+		}
+		result, err = handler.learn(r, path)
+	case path == "status":
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		if handler.WriteAuth != nil {
+			if err = handler.WriteAuth(r); err != nil {
+				break
+			}
+		}
+		result = handler.status(r)
+	case path == "metrics" && handler.Metrics:
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		if handler.WriteAuth != nil {
+			if err = handler.WriteAuth(r); err != nil {
+				break
+			}
+		}
+		handler.serveMetrics(w)
+		return
+	case path == "schemes":
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		result, err = handler.schemes()
+	case path == "openapi.yaml":
+		if !allowMethod(w, r, http.MethodGet) {
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(openAPISpec)
+		return
+	default:
+		err = &httpError{http.StatusNotFound, fmt.Errorf("Unknown path %q", r.URL.Path)}
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// Splits "{schemeID}/{word}"
+func splitSchemeAndWord(path string) (string, string, error) {
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", &httpError{http.StatusBadRequest, errors.New("Path should be {schemeID}/{word}")}
+	}
+	return parts[0], parts[1], nil
+}
+
+func (handler *Handler) transliterate(r *http.Request, path string) (interface{}, error) {
+	schemeID, word, err := splitSchemeAndWord(path)
+	if err != nil {
+		return nil, err
+	}
+
+	varnam, release, err := handler.getInstance(r, schemeID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	query := r.URL.Query()
+	opts := govarnam.TransliterateOptions{
+		Domains: query["domain"],
+		Quick:   query.Get("quick") == "1" || query.Get("quick") == "true",
+	}
+
+	start := time.Now()
+	result := varnam.TransliterateAdvancedWithOptions(r.Context(), word, opts)
+	if r.Context().Err() != nil {
+		return nil, r.Context().Err()
+	}
+
+	if handler.Metrics {
+		handler.metrics.observeTransliteration(schemeID, time.Since(start), result)
+	}
+	return result, nil
+}
+
+func (handler *Handler) reverseTransliterate(r *http.Request, path string) (interface{}, error) {
+	schemeID, word, err := splitSchemeAndWord(path)
+	if err != nil {
+		return nil, err
+	}
+
+	varnam, release, err := handler.getInstance(r, schemeID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	sugs, err := varnam.ReverseTransliterate(word)
+	if err != nil {
+		return nil, &httpError{http.StatusBadRequest, err}
+	}
+	return sugs, nil
+}
+
+func (handler *Handler) learn(r *http.Request, action string) (interface{}, error) {
+	var (
+		schemeID string
+		do       func(varnam *govarnam.Varnam) error
+	)
+
+	body := http.MaxBytesReader(nil, r.Body, maxBodySize)
+	decoder := json.NewDecoder(body)
+
+	switch action {
+	case "learn":
+		var req LearnRequest
+		if err := decoder.Decode(&req); err != nil {
+			return nil, &httpError{http.StatusBadRequest, err}
+		}
+		schemeID = req.Scheme
+		do = func(varnam *govarnam.Varnam) error {
+			return varnam.LearnContext(r.Context(), req.Word, req.Weight)
+		}
+	case "train":
+		var req TrainRequest
+		if err := decoder.Decode(&req); err != nil {
+			return nil, &httpError{http.StatusBadRequest, err}
+		}
+		schemeID = req.Scheme
+		do = func(varnam *govarnam.Varnam) error {
+			return varnam.TrainContext(r.Context(), req.Pattern, req.Word)
+		}
+	case "unlearn":
+		var req UnlearnRequest
+		if err := decoder.Decode(&req); err != nil {
+			return nil, &httpError{http.StatusBadRequest, err}
+		}
+		schemeID = req.Scheme
+		do = func(varnam *govarnam.Varnam) error {
+			return varnam.UnlearnContext(r.Context(), req.Word)
+		}
+	}
+
+	varnam, release, err := handler.getInstance(r, schemeID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	err = do(varnam)
+	if handler.Metrics {
+		handler.metrics.countLearn(schemeID, action, err)
+	}
+	if err != nil {
+		return nil, &httpError{http.StatusBadRequest, err}
+	}
+	return successResponse{true}, nil
+}
+
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeError(w, &httpError{http.StatusMethodNotAllowed, fmt.Errorf("Method %s not allowed", r.Method)})
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	var hErr *httpError
+	if errors.As(err, &hErr) {
+		status = hErr.status
+	}
+
+	var rlErr *rateLimitError
+	if errors.As(err, &rlErr) {
+		status = http.StatusTooManyRequests
+		w.Header().Set("Retry-After", strconv.Itoa(rlErr.retryAfterSeconds()))
+	}
+
+	writeJSON(w, status, errorResponse{err.Error()})
+}
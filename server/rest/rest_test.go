@@ -0,0 +1,177 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/testutil"
+)
+
+// Handler with instances of testutil.Init
+func makeHandler(t *testing.T) *Handler {
+	handler := NewHandler()
+	handler.Init = testutil.Init(t)
+	t.Cleanup(func() {
+		handler.Close()
+	})
+
+	return handler
+}
+
+func request(t *testing.T, handler http.Handler, method string, path string, body string, response interface{}) int {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if response != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), response); err != nil {
+			t.Fatalf("%s %s: %s", method, path, err)
+		}
+	}
+	return rec.Code
+}
+
+func TestTransliterate(t *testing.T) {
+	handler := makeHandler(t)
+
+	var result govarnam.TransliterationResult
+	status := request(t, handler, http.MethodGet, "/tl/hi/namaste", "", &result)
+	if status != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", status)
+	}
+	if result.GreedyTokenized[0].Word != "नमस्ते" {
+		t.Errorf("Expected नमस्ते, got %s", result.GreedyTokenized[0].Word)
+	}
+
+	var sugs []govarnam.Suggestion
+	status = request(t, handler, http.MethodGet, "/rtl/hi/नमस्ते", "", &sugs)
+	if status != http.StatusOK || len(sugs) == 0 {
+		t.Errorf("Expected reverse transliterations, got %d %v", status, sugs)
+	}
+}
+
+func TestLearn(t *testing.T) {
+	handler := makeHandler(t)
+
+	var success successResponse
+	status := request(t, handler, http.MethodPost, "/learn", `{"scheme": "hi", "word": "नमस्कार"}`, &success)
+	if status != http.StatusOK || !success.Success {
+		t.Fatalf("Learn failed with %d", status)
+	}
+
+	var result govarnam.TransliterationResult
+	request(t, handler, http.MethodGet, "/tl/hi/namas", "", &result)
+	if len(result.DictionarySuggestions) == 0 || result.DictionarySuggestions[0].Word != "नमस्कार" {
+		t.Errorf("Expected learnt word in suggestions, got %v", result.DictionarySuggestions)
+	}
+
+	status = request(t, handler, http.MethodPost, "/unlearn", `{"scheme": "hi", "word": "नमस्कार"}`, &success)
+	if status != http.StatusOK {
+		t.Fatalf("Unlearn failed with %d", status)
+	}
+
+	result = govarnam.TransliterationResult{}
+	request(t, handler, http.MethodGet, "/tl/hi/namas", "", &result)
+	if len(result.DictionarySuggestions) != 0 {
+		t.Errorf("Expected no suggestions after unlearn, got %v", result.DictionarySuggestions)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	handler := makeHandler(t)
+
+	var errResp errorResponse
+
+	status := request(t, handler, http.MethodGet, "/tl/../hi/namaste", "", &errResp)
+	if status != http.StatusBadRequest || errResp.Error == "" {
+		t.Errorf("Expected 400 for bad scheme ID, got %d", status)
+	}
+
+	status = request(t, handler, http.MethodGet, "/tl/xx/namaste", "", &errResp)
+	if status != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown scheme, got %d", status)
+	}
+
+	status = request(t, handler, http.MethodGet, "/learn", "", &errResp)
+	if status != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", status)
+	}
+
+	status = request(t, handler, http.MethodPost, "/learn", "{", &errResp)
+	if status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for bad body, got %d", status)
+	}
+
+	handler.ReadOnly = true
+	status = request(t, handler, http.MethodPost, "/learn", `{"scheme": "hi", "word": "नमस्कार"}`, &errResp)
+	if status != http.StatusForbidden {
+		t.Errorf("Expected 403 when read only, got %d", status)
+	}
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	handler := makeHandler(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), "openapi:") {
+		t.Errorf("Expected OpenAPI spec, got %d", rec.Code)
+	}
+}
+
+type spanNameKey struct{}
+
+// Records "parent > name" of spans
+type testTracer struct {
+	mutex sync.Mutex
+	spans []string
+}
+
+type testSpan struct{}
+
+func (testSpan) SetAttribute(string, interface{}) {}
+func (testSpan) End(error)                        {}
+
+func (tracer *testTracer) Start(ctx context.Context, name string) (context.Context, govarnam.TraceSpan) {
+	parent, _ := ctx.Value(spanNameKey{}).(string)
+
+	tracer.mutex.Lock()
+	tracer.spans = append(tracer.spans, parent+" > "+name)
+	tracer.mutex.Unlock()
+
+	return context.WithValue(ctx, spanNameKey{}, name), testSpan{}
+}
+
+func TestTracer(t *testing.T) {
+	handler := makeHandler(t)
+	tracer := &testTracer{}
+	handler.Tracer = tracer
+
+	serve := func(method string, path string, body string) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), spanNameKey{}, "http"))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve(http.MethodGet, "/tl/hi/namaste", "")
+	serve(http.MethodPost, "/learn", `{"scheme": "hi", "word": "नमस्कार"}`)
+
+	spans := strings.Join(tracer.spans, "\n")
+	for _, expected := range []string{
+		"http > " + govarnam.TraceTransliterate,
+		govarnam.TraceTransliterate + " > " + govarnam.TraceTokenize,
+		"http > " + govarnam.TraceLearn,
+	} {
+		if !strings.Contains(spans, expected) {
+			t.Errorf("Expected span %q, got:\n%s", expected, spans)
+		}
+	}
+}
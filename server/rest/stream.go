@@ -0,0 +1,247 @@
+package rest
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+// StreamInput body of POST /stream/{schemeID}/{sessionID}
+type StreamInput struct {
+	Input string `json:"input"`
+}
+
+// StreamEvent data of a "result" event
+type StreamEvent struct {
+	// Increases with each input posted to the session
+	Seq   int                           `json:"seq"`
+	Input string                        `json:"input"`
+	Stage govarnam.TransliterationStage `json:"stage"`
+
+	// Everything found so far for Input
+	Result govarnam.TransliterationResult `json:"result"`
+}
+
+// A client's event stream. Every input posted to it cancels
+// transliteration of the previous input
+type streamSession struct {
+	handler  *Handler
+	schemeID string
+	userID   string
+	varnam   *govarnam.Varnam
+
+	// Ends when the client closes the event stream or on CloseStreams
+	ctx    context.Context
+	end    context.CancelFunc
+	events chan StreamEvent
+
+	mutex  sync.Mutex
+	seq    int
+	cancel context.CancelFunc
+}
+
+func newSessionID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func (session *streamSession) currentSeq() int {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return session.seq
+}
+
+func (session *streamSession) transliterate(input string) int {
+	session.mutex.Lock()
+	if session.cancel != nil {
+		session.cancel()
+		session.cancel = nil
+	}
+	session.seq++
+	seq := session.seq
+
+	// Nothing to suggest, only cancel
+	if input == "" {
+		session.mutex.Unlock()
+		return seq
+	}
+
+	ctx, cancel := context.WithCancel(session.ctx)
+	session.cancel = cancel
+	session.mutex.Unlock()
+
+	go func() {
+		defer cancel()
+
+		// Not while Reload changes settings of the instance
+		session.handler.configMutex.RLock()
+		defer session.handler.configMutex.RUnlock()
+
+		session.varnam.TransliterateStaged(ctx, input, func(stage govarnam.TransliterationStage, result govarnam.TransliterationResult) {
+			select {
+			case <-ctx.Done():
+			case session.events <- StreamEvent{seq, input, stage, result}:
+			}
+		})
+	}()
+
+	return seq
+}
+
+// unlock is called when the request doesn't need Handler's settings anymore
+func (handler *Handler) stream(w http.ResponseWriter, r *http.Request, path string, unlock func()) {
+	parts := strings.Split(path, "/")
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		handler.openStream(w, r, parts[0], unlock)
+	case len(parts) == 2 && r.Method == http.MethodPost:
+		handler.streamInput(w, r, parts[0], parts[1])
+	case len(parts) == 1:
+		allowMethod(w, r, http.MethodGet)
+	case len(parts) == 2:
+		allowMethod(w, r, http.MethodPost)
+	default:
+		writeError(w, &httpError{http.StatusNotFound, fmt.Errorf("Unknown path %q", r.URL.Path)})
+	}
+}
+
+// Serves the event stream of a new session. The first event is
+// "session" with the session ID to post inputs to
+func (handler *Handler) openStream(w http.ResponseWriter, r *http.Request, schemeID string, unlock func()) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errors.New("Streaming not supported"))
+		return
+	}
+
+	userID, err := handler.userOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	varnam, release, err := handler.getInstance(r, schemeID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer release()
+
+	id, err := newSessionID()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	session := &streamSession{
+		handler:  handler,
+		schemeID: schemeID,
+		userID:   userID,
+		varnam:   varnam,
+		ctx:      ctx,
+		end:      cancel,
+		events:   make(chan StreamEvent),
+	}
+
+	handler.sessionsMutex.Lock()
+	if handler.sessions == nil {
+		handler.sessions = map[string]*streamSession{}
+	}
+	handler.sessions[id] = session
+	handler.sessionsMutex.Unlock()
+
+	defer func() {
+		handler.sessionsMutex.Lock()
+		delete(handler.sessions, id)
+		handler.sessionsMutex.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	writeEvent(w, "session", map[string]string{"id": id})
+	flusher.Flush()
+
+	// Stream lasts long, Reload shouldn't wait for it
+	unlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-session.events:
+			// Input changed while this was being sent
+			if event.Seq != session.currentSeq() {
+				continue
+			}
+			writeEvent(w, "result", event)
+			flusher.Flush()
+		}
+	}
+}
+
+func (handler *Handler) streamInput(w http.ResponseWriter, r *http.Request, schemeID string, sessionID string) {
+	handler.sessionsMutex.Lock()
+	session, found := handler.sessions[sessionID]
+	handler.sessionsMutex.Unlock()
+
+	userID, err := handler.userOf(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	// Users can only post to their own sessions
+	if !found || session.schemeID != schemeID || session.userID != userID {
+		writeError(w, &httpError{http.StatusNotFound, fmt.Errorf("Unknown session %q", sessionID)})
+		return
+	}
+
+	var input StreamInput
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&input); err != nil {
+		writeError(w, &httpError{http.StatusBadRequest, err})
+		return
+	}
+
+	seq := session.transliterate(input.Input)
+	writeJSON(w, http.StatusAccepted, map[string]int{"seq": seq})
+}
+
+func writeEvent(w http.ResponseWriter, name string, data interface{}) {
+	encoded, _ := json.Marshal(data)
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, encoded)
+}
+
+// CloseStreams end all event streams. Register with
+// http.Server.RegisterOnShutdown, else Shutdown waits for clients
+// to close them
+func (handler *Handler) CloseStreams() {
+	handler.sessionsMutex.Lock()
+	defer handler.sessionsMutex.Unlock()
+
+	for _, session := range handler.sessions {
+		session.end()
+	}
+}
@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+)
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+func readEvents(resp *http.Response) <-chan sseEvent {
+	events := make(chan sseEvent)
+
+	go func() {
+		defer close(events)
+
+		var event sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				event.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			case line == "":
+				events <- event
+				event = sseEvent{}
+			}
+		}
+	}()
+
+	return events
+}
+
+func postInput(t *testing.T, url string, input string) {
+	t.Helper()
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(`{"input": "`+input+`"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d", resp.StatusCode)
+	}
+}
+
+func TestStream(t *testing.T) {
+	server := httptest.NewServer(makeHandler(t))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/stream/hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected event stream, got %s", resp.Header.Get("Content-Type"))
+	}
+
+	events := readEvents(resp)
+
+	event := <-events
+	if event.name != "session" {
+		t.Fatalf("Expected session event first, got %s", event.name)
+	}
+	var session map[string]string
+	json.Unmarshal([]byte(event.data), &session)
+
+	inputURL := server.URL + "/stream/hi/" + session["id"]
+	postInput(t, inputURL, "nam")
+	postInput(t, inputURL, "namaste")
+
+	lastSeq := 0
+	for event := range events {
+		var streamEvent StreamEvent
+		if err := json.Unmarshal([]byte(event.data), &streamEvent); err != nil {
+			t.Fatal(err)
+		}
+
+		if streamEvent.Seq < lastSeq {
+			t.Errorf("Got result of an older input %q", streamEvent.Input)
+		}
+		lastSeq = streamEvent.Seq
+
+		if streamEvent.Input == "namaste" && streamEvent.Stage == govarnam.TransliterationStageComplete {
+			if streamEvent.Result.GreedyTokenized[0].Word != "नमस्ते" {
+				t.Errorf("Expected नमस्ते, got %s", streamEvent.Result.GreedyTokenized[0].Word)
+			}
+			break
+		}
+	}
+	if lastSeq != 2 {
+		t.Errorf("Expected result of second input, last one was %d", lastSeq)
+	}
+
+	resp.Body.Close()
+	for range events {
+	}
+}
+
+func TestStreamUnknownSession(t *testing.T) {
+	handler := makeHandler(t)
+
+	var errResp errorResponse
+	status := request(t, handler, http.MethodPost, "/stream/hi/abcd", `{"input": "nam"}`, &errResp)
+	if status != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", status)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/stream/hi", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", rec.Code)
+	}
+}
+
+func TestStreamShutdown(t *testing.T) {
+	handler := makeHandler(t)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	server.Config.RegisterOnShutdown(handler.CloseStreams)
+
+	resp, err := http.Get(server.URL + "/stream/hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	events := readEvents(resp)
+	if event := <-events; event.name != "session" {
+		t.Fatalf("Expected session event first, got %s", event.name)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := server.Config.Shutdown(ctx); err != nil {
+		t.Fatalf("Expected shutdown to not wait for the stream, got %v", err)
+	}
+
+	// Stream is ended
+	for range events {
+	}
+}
@@ -0,0 +1,129 @@
+package rest
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+)
+
+// DefaultMaxUserInstances used when Handler.MaxUserInstances is 0
+const DefaultMaxUserInstances = instances.DefaultMaxUserInstances
+
+// UserFromHeader identify user by the value of header, like
+// X-Varnam-User. Only for servers behind a proxy that sets the
+// header after authenticating users, anyone can send any header
+func UserFromHeader(header string) func(r *http.Request) (string, error) {
+	return func(r *http.Request) (string, error) {
+		userID := r.Header.Get(header)
+		if userID == "" {
+			return "", &httpError{http.StatusUnauthorized, fmt.Errorf("%s header is required", header)}
+		}
+		if !instances.ValidUserID(userID) {
+			return "", &httpError{http.StatusBadRequest, fmt.Errorf("Invalid user ID %q", userID)}
+		}
+		return userID, nil
+	}
+}
+
+// UserFromToken identify user by the token in "Authorization: Bearer <token>"
+// header. tokens is a map of token to user ID
+func UserFromToken(tokens map[string]string) func(r *http.Request) (string, error) {
+	return func(r *http.Request) (string, error) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") {
+			return "", &httpError{http.StatusUnauthorized, errors.New("Authorization: Bearer token is required")}
+		}
+		given := []byte(strings.TrimPrefix(auth, "Bearer "))
+
+		// Compare with all of them in constant time so that
+		// timing doesn't tell how much of a token matched
+		userID := ""
+		for token, id := range tokens {
+			if subtle.ConstantTimeCompare(given, []byte(token)) == 1 {
+				userID = id
+			}
+		}
+
+		if userID == "" {
+			return "", &httpError{http.StatusUnauthorized, errors.New("Invalid token")}
+		}
+		if !instances.ValidUserID(userID) {
+			return "", fmt.Errorf("Invalid user ID %q for token", userID)
+		}
+		return userID, nil
+	}
+}
+
+// The user making request r. Empty if Handler.User isn't set
+func (handler *Handler) userOf(r *http.Request) (string, error) {
+	if handler.User == nil {
+		return "", nil
+	}
+	return handler.User(r)
+}
+
+func (handler *Handler) initForUser(schemeID string, userID string) (*govarnam.Varnam, error) {
+	if handler.InitForUser != nil {
+		return handler.InitForUser(schemeID, userID)
+	}
+	if handler.UsersDir == "" {
+		return nil, errors.New("UsersDir isn't set")
+	}
+	return govarnam.InitFromIDInLearningsDir(schemeID, filepath.Join(handler.UsersDir, userID))
+}
+
+// Get the instance of userID for schemeID, opening it if needed.
+// release should be called when done with it
+func (handler *Handler) getUserInstance(schemeID string, userID string) (*govarnam.Varnam, func(), error) {
+	varnam, release, err := handler.instances.GetForUser(schemeID, userID, func(schemeID string, userID string) (*govarnam.Varnam, error) {
+		varnam, err := handler.initForUser(schemeID, userID)
+		if err != nil {
+			return nil, err
+		}
+		handler.setUpInstance(varnam)
+
+		return varnam, nil
+	})
+	if err != nil {
+		return nil, nil, &httpError{http.StatusNotFound, err}
+	}
+
+	handler.evictUserInstances()
+
+	return varnam, func() {
+		release()
+		handler.evictUserInstances()
+	}, nil
+}
+
+// Close least recently used instances not in use till there
+// are only MaxUserInstances
+func (handler *Handler) evictUserInstances() {
+	handler.mutex.Lock()
+	max := handler.MaxUserInstances
+	collectMetrics := handler.Metrics
+	handler.mutex.Unlock()
+
+	var closing func(varnam *govarnam.Varnam)
+	if collectMetrics {
+		closing = handler.metrics.instanceClosed
+	}
+	handler.instances.Evict(max, closing)
+}
+
+// OpenUserInstances number of user instances open now
+func (handler *Handler) OpenUserInstances() int {
+	return handler.instances.OpenUserInstances()
+}
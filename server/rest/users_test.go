@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/testutil"
+)
+
+// Handler serving "hi" with learnings of each user in a directory
+func makeUsersHandler(t *testing.T) *Handler {
+	handler := NewHandler()
+	handler.User = UserFromHeader("X-Varnam-User")
+	handler.InitForUser = testutil.InitForUser(t)
+	t.Cleanup(func() {
+		handler.Close()
+	})
+
+	return handler
+}
+
+func requestWithHeader(t *testing.T, handler http.Handler, method string, path string, body string, header string, value string, response interface{}) int {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if response != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), response); err != nil {
+			t.Fatalf("%s %s: %s", method, path, err)
+		}
+	}
+	return rec.Code
+}
+
+func TestUserIsolation(t *testing.T) {
+	handler := makeUsersHandler(t)
+
+	var success successResponse
+	status := requestWithHeader(t, handler, http.MethodPost, "/learn", `{"scheme": "hi", "word": "नमस्कार"}`, "X-Varnam-User", "alice", &success)
+	if status != http.StatusOK || !success.Success {
+		t.Fatalf("Learn failed with %d", status)
+	}
+
+	var result govarnam.TransliterationResult
+	requestWithHeader(t, handler, http.MethodGet, "/tl/hi/namas", "", "X-Varnam-User", "alice", &result)
+	if len(result.DictionarySuggestions) == 0 || result.DictionarySuggestions[0].Word != "नमस्कार" {
+		t.Errorf("Expected learnt word in suggestions of alice, got %v", result.DictionarySuggestions)
+	}
+
+	result = govarnam.TransliterationResult{}
+	requestWithHeader(t, handler, http.MethodGet, "/tl/hi/namas", "", "X-Varnam-User", "bob", &result)
+	if len(result.DictionarySuggestions) != 0 {
+		t.Errorf("Expected no suggestions for bob, got %v", result.DictionarySuggestions)
+	}
+
+	var errResp errorResponse
+	status = requestWithHeader(t, handler, http.MethodGet, "/tl/hi/namas", "", "", "", &errResp)
+	if status != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without user, got %d", status)
+	}
+
+	status = requestWithHeader(t, handler, http.MethodGet, "/tl/hi/namas", "", "X-Varnam-User", "../bob", &errResp)
+	if status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for bad user ID, got %d", status)
+	}
+}
+
+func TestUserInstancesEviction(t *testing.T) {
+	handler := makeUsersHandler(t)
+	handler.MaxUserInstances = 2
+
+	for _, user := range []string{"a", "b", "c"} {
+		status := requestWithHeader(t, handler, http.MethodPost, "/learn", `{"scheme": "hi", "word": "नमस्कार"}`, "X-Varnam-User", user, nil)
+		if status != http.StatusOK {
+			t.Fatalf("Learn failed with %d", status)
+		}
+	}
+
+	if open := handler.OpenUserInstances(); open != 2 {
+		t.Errorf("Expected 2 open instances, got %d", open)
+	}
+
+	// Learnings of "a" are kept after its instance was closed
+	var result govarnam.TransliterationResult
+	requestWithHeader(t, handler, http.MethodGet, "/tl/hi/namas", "", "X-Varnam-User", "a", &result)
+	if len(result.DictionarySuggestions) == 0 {
+		t.Errorf("Expected learnt word after reopening, got %v", result.DictionarySuggestions)
+	}
+}
+
+func TestUserFromToken(t *testing.T) {
+	handler := makeUsersHandler(t)
+	handler.User = UserFromToken(map[string]string{"secret": "alice"})
+
+	status := requestWithHeader(t, handler, http.MethodGet, "/tl/hi/namaste", "", "Authorization", "Bearer secret", nil)
+	if status != http.StatusOK {
+		t.Errorf("Expected 200 with token, got %d", status)
+	}
+
+	status = requestWithHeader(t, handler, http.MethodGet, "/tl/hi/namaste", "", "Authorization", "Bearer wrong", nil)
+	if status != http.StatusUnauthorized {
+		t.Errorf("Expected 401 with wrong token, got %d", status)
+	}
+}
@@ -0,0 +1,416 @@
+package socket
+
+/**
+ * govarnam - An Indian language transliteration library
+ * Copyright Subin Siby <mail at subinsb (.) com>, 2021
+ * Licensed under AGPL-3.0-only. See LICENSE.txt
+ */
+
+/*
+Package socket serves govarnam over a Unix socket (or any
+net.Listener) with newline-delimited JSON, so that local clients
+(editor plugins, IMEs, CLI) share one warm instance & DB connection.
+
+Each line a client writes is a Request, the server writes back a
+Response line with the same ID. Requests of a connection are answered
+in order :
+
+	{"id": 1, "method": "transliterate", "scheme": "ml", "word": "malayalam"}
+	{"id": 1, "result": [{"Word": "മലയാളം", ...}]}
+
+	{"id": 2, "method": "learn", "scheme": "ml", "word": "മലയാളം"}
+	{"id": 2, "result": true}
+
+	{"id": 3, "method": "nope"}
+	{"id": 3, "error": "Unknown method \"nope\""}
+
+Methods :
+
+	transliterate                   word, domains, quick  []Suggestion
+	transliterate_advanced          word, domains, quick  TransliterationResult
+	transliterate_greedy_tokenized  word                  []Suggestion
+	transliterate_sentence          text                  string
+	transliterate_stream            word                  see below
+	reverse_transliterate           word                  []Suggestion
+	learn                           word, weight          true
+	train                           pattern, word         true
+	unlearn                         word                  true
+	schemes                                               []SchemeDetails
+
+All except schemes need "scheme". An instance is made for a scheme on
+its first request and is shared by all clients after that.
+
+transliterate_stream is for showing suggestions as soon as they're
+found. A line is written for each stage of Varnam.TransliterateStaged
+with everything found so far. Greedy tokenized comes first, then
+dictionary & pattern results. The last line has "done" with the
+complete result :
+
+	{"id": 4, "method": "transliterate_stream", "scheme": "ml", "word": "malayalam"}
+	{"id": 4, "stage": 1, "result": {"GreedyTokenized": [...], ...}}
+	{"id": 4, "stage": 2, "result": {"ExactWords": [...], ...}}
+	{"id": 4, "stage": 3, "result": {"PatternDictionarySuggestions": [...], ...}}
+	...
+	{"id": 4, "done": true, "result": {...}}
+
+An error ends the stream too, it's given with "done". Handle gives
+only the complete result.
+
+Server.Shutdown stops the server letting requests being answered finish,
+so that learnings aren't cut off midway.
+*/
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+)
+
+// Longest request line accepted
+const maxLineSize = 1 << 20
+
+// Request a line sent by clients
+type Request struct {
+	// Given back in the response, for clients to match them
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Scheme string          `json:"scheme,omitempty"`
+
+	Word    string   `json:"word,omitempty"`
+	Text    string   `json:"text,omitempty"`
+	Pattern string   `json:"pattern,omitempty"`
+	Weight  int      `json:"weight,omitempty"`
+	Domains []string `json:"domains,omitempty"`
+	Quick   bool     `json:"quick,omitempty"`
+}
+
+// Response a line sent back for a Request
+type Response struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Result interface{}     `json:"result"`
+	Error  string          `json:"error,omitempty"`
+
+	// Of transliterate_stream
+	Stage govarnam.TransliterationStage `json:"stage,omitempty"`
+	Done  bool                          `json:"done,omitempty"`
+}
+
+// Server serves govarnam to socket clients
+type Server struct {
+	// Makes the instance for a scheme ID. govarnam.InitFromID if nil
+	Init func(schemeID string) (*govarnam.Varnam, error)
+
+	// Disables learn, train & unlearn
+	ReadOnly bool
+
+	instances instances.Manager
+
+	// Of Serve & ServeConn, for Shutdown
+	connsMutex   sync.Mutex
+	listeners    map[net.Listener]bool
+	conns        map[io.Closer]bool // Whether a request of it is being handled
+	shuttingDown bool
+	requests     sync.WaitGroup
+}
+
+// NewServer make a Server
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Close closes all instances made
+func (server *Server) Close() error {
+	return server.instances.Close()
+}
+
+func (server *Server) getInstance(schemeID string) (*govarnam.Varnam, error) {
+	if !instances.ValidSchemeID(schemeID) {
+		return nil, fmt.Errorf("Invalid scheme ID %q", schemeID)
+	}
+	return server.instances.Get(schemeID, server.Init)
+}
+
+// Shutdown stop accepting connections & requests, wait for requests
+// being handled (learnings too) to finish and close instances, which
+// checkpoints learnings. Idle connections are closed right away. If
+// ctx ends before requests finish, its error is given and instances
+// are left open
+func (server *Server) Shutdown(ctx context.Context) error {
+	server.connsMutex.Lock()
+	server.shuttingDown = true
+	for listener := range server.listeners {
+		listener.Close()
+	}
+	for conn, busy := range server.conns {
+		if !busy {
+			conn.Close()
+		}
+	}
+	server.connsMutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		server.requests.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+	}
+
+	return server.Close()
+}
+
+// Keep track of a listener or connection. false if shutting down
+func (server *Server) track(listener net.Listener, conn io.Closer) bool {
+	server.connsMutex.Lock()
+	defer server.connsMutex.Unlock()
+
+	if server.shuttingDown {
+		return false
+	}
+
+	if server.listeners == nil {
+		server.listeners = map[net.Listener]bool{}
+		server.conns = map[io.Closer]bool{}
+	}
+	if listener != nil {
+		server.listeners[listener] = true
+	}
+	if conn != nil {
+		server.conns[conn] = false
+	}
+	return true
+}
+
+func (server *Server) untrack(listener net.Listener, conn io.Closer) {
+	server.connsMutex.Lock()
+	defer server.connsMutex.Unlock()
+
+	delete(server.listeners, listener)
+	delete(server.conns, conn)
+}
+
+// Mark a request of conn as being handled. false if shutting down
+func (server *Server) startRequest(conn io.Closer) bool {
+	server.connsMutex.Lock()
+	defer server.connsMutex.Unlock()
+
+	if server.shuttingDown {
+		return false
+	}
+	server.conns[conn] = true
+	server.requests.Add(1)
+	return true
+}
+
+// Mark a request of conn as answered. false if shutting down
+func (server *Server) endRequest(conn io.Closer) bool {
+	server.connsMutex.Lock()
+	defer server.connsMutex.Unlock()
+
+	server.conns[conn] = false
+	server.requests.Done()
+	return !server.shuttingDown
+}
+
+// Serve accept connections on listener & serve each of them.
+// Returns when listener is closed or on Shutdown
+func (server *Server) Serve(listener net.Listener) error {
+	if !server.track(listener, nil) {
+		listener.Close()
+		return nil
+	}
+	defer server.untrack(listener, nil)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go server.ServeConn(context.Background(), conn)
+	}
+}
+
+// ServeConn answer requests of a client till it disconnects.
+// Transliterations are cancelled if ctx is
+func (server *Server) ServeConn(ctx context.Context, conn io.ReadWriteCloser) {
+	defer conn.Close()
+
+	if !server.track(nil, conn) {
+		return
+	}
+	defer server.untrack(nil, conn)
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), maxLineSize)
+
+	writer := bufio.NewWriter(conn)
+	encoder := json.NewEncoder(writer)
+
+	// Encode writes the newline
+	send := func(resp Response) error {
+		if err := encoder.Encode(resp); err != nil {
+			return err
+		}
+		return writer.Flush()
+	}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		// Connection was closed by Shutdown after this was read
+		if !server.startRequest(conn) {
+			return
+		}
+
+		var req Request
+		err := json.Unmarshal(line, &req)
+		if err != nil {
+			err = send(Response{Error: fmt.Sprintf("Invalid request: %s", err)})
+		} else if req.Method == "transliterate_stream" {
+			err = server.Stream(ctx, req, send)
+		} else {
+			err = send(server.Handle(ctx, req))
+		}
+
+		if !server.endRequest(conn) || err != nil {
+			return
+		}
+	}
+}
+
+// Handle answer a request
+func (server *Server) Handle(ctx context.Context, req Request) Response {
+	result, err := server.handle(ctx, req)
+	if err != nil {
+		return Response{ID: req.ID, Error: err.Error()}
+	}
+	return Response{ID: req.ID, Result: result}
+}
+
+// Stream answer a transliterate_stream request with a response for
+// each stage, the last one is done. Transliteration is stopped if
+// send fails, its error is given
+func (server *Server) Stream(ctx context.Context, req Request, send func(Response) error) error {
+	varnam, err := server.getInstance(req.Scheme)
+	if err != nil {
+		return send(Response{ID: req.ID, Error: err.Error(), Done: true})
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		sendErr error
+
+		// Greedy tokenized is sent first, stages that finish before it wait
+		greedySent bool
+		waiting    []govarnam.TransliterationStage
+	)
+
+	sendStage := func(stage govarnam.TransliterationStage, result govarnam.TransliterationResult) {
+		if sendErr != nil {
+			return
+		}
+		if sendErr = send(Response{ID: req.ID, Result: result, Stage: stage}); sendErr != nil {
+			cancel()
+		}
+	}
+
+	// Results of stages include what was found before them,
+	// waiting ones are sent with the latest
+	sendWaiting := func(result govarnam.TransliterationResult) {
+		for _, stage := range waiting {
+			sendStage(stage, result)
+		}
+		waiting = nil
+	}
+
+	result := varnam.TransliterateStaged(ctx, req.Word, func(stage govarnam.TransliterationStage, result govarnam.TransliterationResult) {
+		switch {
+		case stage == govarnam.TransliterationStageComplete:
+			// Sent as done
+			sendWaiting(result)
+		case stage == govarnam.TransliterationStageGreedyTokenized:
+			greedySent = true
+			sendStage(stage, result)
+			sendWaiting(result)
+		case !greedySent:
+			waiting = append(waiting, stage)
+		default:
+			sendStage(stage, result)
+		}
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+
+	return send(Response{ID: req.ID, Result: result, Done: true})
+}
+
+func (server *Server) handle(ctx context.Context, req Request) (interface{}, error) {
+	switch req.Method {
+	case "schemes":
+		return govarnam.GetAllSchemeDetails()
+	case "transliterate", "transliterate_advanced", "transliterate_stream", "transliterate_greedy_tokenized", "transliterate_sentence", "reverse_transliterate":
+	case "learn", "train", "unlearn":
+		if server.ReadOnly {
+			return nil, errors.New("Server is read only")
+		}
+	default:
+		return nil, fmt.Errorf("Unknown method %q", req.Method)
+	}
+
+	varnam, err := server.getInstance(req.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := govarnam.TransliterateOptions{
+		Domains: req.Domains,
+		Quick:   req.Quick,
+	}
+
+	switch req.Method {
+	case "transliterate":
+		return varnam.TransliterateWithOptions(ctx, req.Word, opts), nil
+	case "transliterate_advanced":
+		return varnam.TransliterateAdvancedWithOptions(ctx, req.Word, opts), nil
+	case "transliterate_stream":
+		return varnam.TransliterateStaged(ctx, req.Word, nil), nil
+	case "transliterate_greedy_tokenized":
+		return varnam.TransliterateGreedyTokenized(req.Word), nil
+	case "transliterate_sentence":
+		return varnam.TransliterateSentence(ctx, req.Text)
+	case "reverse_transliterate":
+		return varnam.ReverseTransliterate(req.Word)
+	case "learn":
+		err = varnam.Learn(req.Word, req.Weight)
+	case "train":
+		err = varnam.Train(req.Pattern, req.Word)
+	case "unlearn":
+		err = varnam.Unlearn(req.Word)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return true, nil
+}
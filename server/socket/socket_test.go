@@ -0,0 +1,247 @@
+package socket
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/varnamproject/govarnam/govarnam"
+	"github.com/varnamproject/govarnam/server/internal/instances"
+	"github.com/varnamproject/govarnam/server/internal/testutil"
+)
+
+// Server with instances of testutil.Init
+func makeServer(t *testing.T) *Server {
+	server := NewServer()
+	server.Init = testutil.Init(t)
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return server
+}
+
+type client struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+}
+
+func connect(t *testing.T, server *Server) *client {
+	clientConn, serverConn := net.Pipe()
+	go server.ServeConn(context.Background(), serverConn)
+	t.Cleanup(func() {
+		clientConn.Close()
+	})
+	return &client{clientConn, bufio.NewScanner(clientConn)}
+}
+
+// Send a line & decode the response line into result
+func (c *client) call(t *testing.T, line string, result interface{}) Response {
+	t.Helper()
+
+	if _, err := c.conn.Write([]byte(line + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	if !c.scanner.Scan() {
+		t.Fatal("No response", c.scanner.Err())
+	}
+
+	var resp Response
+	if err := json.Unmarshal(c.scanner.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	if result != nil {
+		raw, _ := json.Marshal(resp.Result)
+		if err := json.Unmarshal(raw, result); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp
+}
+
+func TestSocket(t *testing.T) {
+	server := makeServer(t)
+	c := connect(t, server)
+
+	var sugs []govarnam.Suggestion
+	resp := c.call(t, `{"id": 1, "method": "transliterate_greedy_tokenized", "scheme": "hi", "word": "namaste"}`, &sugs)
+	if string(resp.ID) != "1" || resp.Error != "" {
+		t.Fatalf("Unexpected response %+v", resp)
+	}
+	if len(sugs) == 0 || sugs[0].Word != "नमस्ते" {
+		t.Errorf("Expected नमस्ते, got %v", sugs)
+	}
+
+	resp = c.call(t, `{"id": "a", "method": "learn", "scheme": "hi", "word": "नमस्ते"}`, nil)
+	if string(resp.ID) != `"a"` || resp.Result != true {
+		t.Fatalf("Unexpected response %+v", resp)
+	}
+
+	var sentence string
+	resp = c.call(t, `{"id": 3, "method": "transliterate_sentence", "scheme": "hi", "text": "namaste, dost"}`, &sentence)
+	if resp.Error != "" || !strings.HasPrefix(sentence, "नमस्ते, ") {
+		t.Errorf("Expected sentence starting with नमस्ते, got %q %q", sentence, resp.Error)
+	}
+
+	// Another client sees what the first one learnt
+	c2 := connect(t, server)
+
+	c2.call(t, `{"id": 2, "method": "transliterate", "scheme": "hi", "word": "namaste"}`, &sugs)
+	if len(sugs) == 0 || sugs[0].Word != "नमस्ते" || sugs[0].LearnedOn == 0 {
+		t.Errorf("Expected learnt नमस्ते, got %v", sugs)
+	}
+}
+
+func TestSocketStream(t *testing.T) {
+	server := makeServer(t)
+	c := connect(t, server)
+
+	c.call(t, `{"id": 1, "method": "learn", "scheme": "hi", "word": "नमस्ते"}`, nil)
+
+	if _, err := c.conn.Write([]byte(`{"id": 2, "method": "transliterate_stream", "scheme": "hi", "word": "namaste"}` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	var stages []govarnam.TransliterationStage
+	for {
+		if !c.scanner.Scan() {
+			t.Fatal("Stream ended without done", c.scanner.Err())
+		}
+
+		var resp struct {
+			Response
+			Result govarnam.TransliterationResult `json:"result"`
+		}
+		if err := json.Unmarshal(c.scanner.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if string(resp.ID) != "2" || resp.Error != "" {
+			t.Fatalf("Unexpected response %s", c.scanner.Text())
+		}
+
+		if resp.Done {
+			if len(resp.Result.ExactWords) == 0 || resp.Result.ExactWords[0].Word != "नमस्ते" {
+				t.Errorf("Expected learnt नमस्ते in complete result, got %+v", resp.Result)
+			}
+			break
+		}
+		stages = append(stages, resp.Stage)
+	}
+
+	// Greedy tokenized first, then dictionary results
+	expected := []govarnam.TransliterationStage{
+		govarnam.TransliterationStageGreedyTokenized,
+		govarnam.TransliterationStageDictionary,
+		govarnam.TransliterationStagePatternDictionary,
+	}
+	if len(stages) < len(expected) || stages[0] != expected[0] {
+		t.Fatalf("Expected stages to start with %v, got %v", expected, stages)
+	}
+	for _, stage := range expected[1:] {
+		found := false
+		for _, got := range stages[1:] {
+			found = found || got == stage
+		}
+		if !found {
+			t.Errorf("Expected stage %v after greedy tokenized, got %v", stage, stages)
+		}
+	}
+
+	// Connection is usable after a stream
+	var sugs []govarnam.Suggestion
+	c.call(t, `{"id": 3, "method": "transliterate", "scheme": "hi", "word": "namaste"}`, &sugs)
+	if len(sugs) == 0 || sugs[0].Word != "नमस्ते" {
+		t.Errorf("Expected नमस्ते, got %v", sugs)
+	}
+
+	// Errors end the stream
+	resp := c.call(t, `{"id": 4, "method": "transliterate_stream", "scheme": "ml", "word": "a"}`, nil)
+	if resp.Error == "" || !resp.Done {
+		t.Errorf("Expected done with error, got %+v", resp)
+	}
+}
+
+func TestSocketErrors(t *testing.T) {
+	server := makeServer(t)
+	server.ReadOnly = true
+	c := connect(t, server)
+
+	errorCases := []string{
+		`not json`,
+		`{"id": 1, "method": "nope", "scheme": "hi"}`,
+		`{"id": 1, "method": "transliterate", "scheme": "../hi", "word": "a"}`,
+		`{"id": 1, "method": "transliterate", "scheme": "ml", "word": "a"}`,
+		`{"id": 1, "method": "learn", "scheme": "hi", "word": "नमस्ते"}`,
+	}
+
+	for _, line := range errorCases {
+		resp := c.call(t, line, nil)
+		if resp.Error == "" {
+			t.Errorf("Expected error for %s", line)
+		}
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	server := makeServer(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	served := make(chan error)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	c := connect(t, server)
+
+	var learnt bool
+	resp := c.call(t, `{"id": 1, "method": "learn", "scheme": "hi", "word": "नमस्कार"}`, &learnt)
+	if resp.Error != "" || !learnt {
+		t.Fatalf("Expected learn to work, got %q", resp.Error)
+	}
+
+	varnam, err := server.getInstance("hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dictPath := varnam.DictPath
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-served; err != nil {
+		t.Errorf("Expected Serve to return nil on shutdown, got %v", err)
+	}
+
+	// Idle connection is closed
+	if c.scanner.Scan() {
+		t.Errorf("Expected connection to be closed, got %q", c.scanner.Text())
+	}
+
+	if _, err := net.Dial("tcp", listener.Addr().String()); err == nil {
+		t.Errorf("Expected listener to be closed")
+	}
+
+	// Learnings are checkpointed when instance is closed
+	server.instances.Open(func(open []instances.Instance) {
+		if len(open) != 0 {
+			t.Errorf("Expected instances to be closed, %d open", len(open))
+		}
+	})
+	if info, err := os.Stat(dictPath + "-wal"); err == nil && info.Size() != 0 {
+		t.Errorf("Expected WAL to be checkpointed, is %d bytes", info.Size())
+	}
+}